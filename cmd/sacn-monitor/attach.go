@@ -0,0 +1,35 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"sacn-monitor/internal/mirror"
+)
+
+// defaultMirrorAddr is where attach looks for a session when no address is given
+const defaultMirrorAddr = "127.0.0.1:5569"
+
+// runAttach connects to a running session's mirror server and shows a
+// read-only view of it. It returns the process exit code.
+func runAttach(args []string) int {
+	fs := flag.NewFlagSet("attach", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: sacn-monitor attach [host:port | unix:/path]\n\n")
+		fmt.Fprintf(fs.Output(), "Attaches a read-only view to a session started with -mirror-listen.\n")
+		fmt.Fprintf(fs.Output(), "Defaults to %s.\n", defaultMirrorAddr)
+	}
+	_ = fs.Parse(args)
+
+	addr := defaultMirrorAddr
+	if fs.NArg() > 0 {
+		addr = fs.Arg(0)
+	}
+
+	if err := mirror.Attach(addr); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
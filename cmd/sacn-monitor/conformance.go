@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"sacn-monitor/internal/conformance"
+	"sacn-monitor/internal/sacn"
+)
+
+// runConformance observes a device under test and prints a pass/fail report.
+// It returns the process exit code.
+func runConformance(args []string) int {
+	fs := flag.NewFlagSet("conformance", flag.ExitOnError)
+	duration := fs.Duration("duration", 30*time.Second, "how long to observe the device under test")
+	universeFilter := fs.Uint("universe", 0, "only check this universe (0 = all)")
+	sourceFilter := fs.String("source", "", "only check sources with this name")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: sacn-monitor conformance [flags]\n\n")
+		fmt.Fprintf(fs.Output(), "Observes sACN output and checks it against E1.31 requirements.\n\n")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	ctx, cancel := context.WithTimeout(context.Background(), *duration)
+	defer cancel()
+
+	// Stop early on Ctrl+C but still report what was observed
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigCh
+		cancel()
+	}()
+
+	receiver := sacn.NewReceiver()
+	if err := receiver.Start(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Error starting receiver: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("Observing sACN traffic for %s...\n", *duration)
+	checker := conformance.NewChecker(time.Now())
+
+	wanted := func(name string) bool {
+		return *sourceFilter == "" || name == *sourceFilter
+	}
+
+observe:
+	for {
+		select {
+		case <-ctx.Done():
+			break observe
+		case packet := <-receiver.Packets():
+			if *universeFilter != 0 && uint(packet.Universe) != *universeFilter {
+				continue
+			}
+			if wanted(packet.SourceName) {
+				checker.ObservePacket(packet)
+			}
+		case discovery := <-receiver.Discovery():
+			if wanted(discovery.SourceName) {
+				checker.ObserveDiscovery(discovery)
+			}
+		}
+	}
+
+	report := checker.Report(time.Now())
+	fmt.Println()
+	report.WriteText(os.Stdout)
+
+	if !report.Passed() {
+		return 1
+	}
+	return 0
+}
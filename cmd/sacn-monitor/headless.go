@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"sacn-monitor/internal/export"
+	"sacn-monitor/internal/stats"
+	"sacn-monitor/internal/universe"
+)
+
+// runHeadless prints a plain-text status of every universe to stdout at
+// each interval until the context is cancelled, then once more
+func runHeadless(ctx context.Context, manager *universe.Manager, tracker *stats.Tracker, interval time.Duration) {
+	fmt.Printf("Monitoring sACN on UDP port 5568 without the UI, status every %s\n", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if err := export.WriteStatus(os.Stdout, manager, tracker, time.Now()); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			}
+			return
+		case now := <-ticker.C:
+			if err := export.WriteStatus(os.Stdout, manager, tracker, now); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			}
+		}
+	}
+}
@@ -2,13 +2,31 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"io/fs"
+	"net/url"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strings"
 	"syscall"
+	"time"
 
+	"sacn-monitor/internal/api"
+	"sacn-monitor/internal/artnet"
+	"sacn-monitor/internal/config"
+	"sacn-monitor/internal/dmxout"
+	"sacn-monitor/internal/events"
+	"sacn-monitor/internal/export"
+	"sacn-monitor/internal/history"
+	"sacn-monitor/internal/mirror"
+	"sacn-monitor/internal/osc"
+	"sacn-monitor/internal/patch"
 	"sacn-monitor/internal/sacn"
 	"sacn-monitor/internal/stats"
+	"sacn-monitor/internal/store"
 	"sacn-monitor/internal/tui"
 	"sacn-monitor/internal/universe"
 
@@ -16,10 +34,182 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "conformance":
+			os.Exit(runConformance(os.Args[2:]))
+		case "attach":
+			os.Exit(runAttach(os.Args[2:]))
+		}
+	}
+
+	previzTarget := flag.String("previz", "", "stream channel values to a previz tool (udp://host:port or tcp://host:port)")
+	previzGroup := flag.String("previz-group", "", "only stream universes in this configured universe group to the previz tool")
+	previzLabels := flag.Bool("previz-labels", false, "add patch labels to the previz stream as a fourth field")
+	patchFile := flag.String("patch", "", "load a fixture patch file (JSON or CSV) for channel labels and footprint checks")
+	baselineFile := flag.String("baseline", "", "compare live output against a reference look saved as a snapshot file")
+	baselineTolerance := flag.Uint("baseline-tolerance", 2, "accepted deviation from the baseline look, in levels (0-255)")
+	loadSnapshot := flag.String("load-snapshot", "", "load snapshots saved with B, O or /sacn-monitor/snapshot/save to diff live output against (d)")
+	mirrorAddr := flag.String("mirror-listen", "", "serve read-only mirrored sessions on host:port or unix:/path (attach with 'sacn-monitor attach')")
+	maxUniverses := flag.Int("max-universes", 1024, "maximum universes tracked at once; the least recently active is evicted beyond it (0 = unlimited)")
+	gapThreshold := flag.Duration("gap-threshold", 500*time.Millisecond, "log inter-packet gaps longer than this per source")
+	expectedRate := flag.Float64("expected-rate", 0, "expected packets per second of each universe, e.g. 44 (0 = learn it from the traffic)")
+	rollupLog := flag.String("rollup-log", "", "append hourly and daily per-universe rollups to this CSV file")
+	captureFile := flag.String("capture", "", "record every received sACN datagram to this pcapng file, for Wireshark, sACNView and other tools")
+	dmxOutPort := flag.String("dmx-out", "", "render a universe to a physical DMX port on this serial device (e.g. /dev/ttyUSB0, COM3)")
+	dmxOutDriver := flag.String("dmx-out-driver", string(dmxout.EnttecPro), "-dmx-out interface: pro (Enttec DMX USB Pro) or open (Enttec Open DMX / plain FTDI)")
+	dmxOutUniverse := flag.Uint("dmx-out-universe", 1, "universe rendered to -dmx-out")
+	dmxOutRate := flag.Int("dmx-out-rate", dmxout.DefaultRate, "frames per second sent to -dmx-out (max 44, 40 for open)")
+	artnetTarget := flag.String("artnet", "", "re-transmit received universes as Art-Net to this node or broadcast address (host or host:port, e.g. 2.255.255.255)")
+	artnetMap := flag.String("artnet-map", "", "universes bridged to -artnet: N or A-B send to port-address N-1, N=P or A-B=P from port-address P (default all)")
+	syslogTarget := flag.String("syslog", "", "forward alerts and events as RFC 5424 syslog to udp://host[:514] or tcp://host[:601]")
+	syslogFacility := flag.String("syslog-facility", export.DefaultSyslogFacility, "facility of -syslog messages (daemon, local0-local7, ...)")
+	changeLogFile := flag.String("change-log", "", "log every channel value change (universe, channel, old, new, source, time) to this CSV file")
+	changeLogSize := flag.Int("change-log-size", export.DefaultChangeLogSize>>20, "MB at which -change-log rotates; 5 old files are kept")
+	reportFile := flag.String("report", "", "write a post-show report (per universe and source, worst gaps, offline periods, alerts) to this .html or .md file on exit")
+	summaryFile := flag.String("summary", "", "write a JSON session summary per universe and source to this file on exit (- = stdout)")
+	statsFile := flag.String("stats-file", "", "save statistics to this file periodically and restore them from it on startup")
+	sequenceTimeline := flag.Int("sequence-timeline", 0, "keep the last N packets' sequence numbers per source for the sequence strip chart (0 = off)")
+	statsSaveInterval := flag.Duration("stats-save-interval", 30*time.Second, "how often to save statistics to -stats-file")
+	pruneAfter := flag.Duration("prune-after", 0, "remove universes silent for this long, with their statistics, automatically (0 = only with the P key)")
+	refresh := flag.Duration("refresh", 0, "how often the UI redraws, e.g. 250ms over slow links (default 100ms, or refresh_interval from the config)")
+	configFile := flag.String("config", "", "config file for universe names and other settings (default: user config dir)")
+	noTUI := flag.Bool("no-tui", false, "run without the UI, printing a plain-text status of every universe to stdout periodically (for systemd or containers)")
+	printChanges := flag.Bool("print-changes", false, "run without the UI, writing one line per channel change (time universe channel old new cid) to stdout for shell pipelines")
+	printChangesFilter := flag.String("print-changes-filter", "", "only print changes of these universes and channels, e.g. 1,2:1-16 (default all)")
+	statusInterval := flag.Duration("status-interval", 10*time.Second, "how often -no-tui prints the status")
+	ndjsonFile := flag.String("ndjson", "", "append observations (packet summaries, source and loss events, channel changes) as JSON Lines to this file (- = stdout, with -no-tui)")
+	ndjsonThreshold := flag.Int("ndjson-threshold", export.DefaultChangeThreshold, "level change a channel must make to be written to -ndjson again")
+	mqttBroker := flag.String("mqtt", "", "publish universe summaries and source states to this MQTT broker (tcp://[user:pass@]host:1883, ssl:// or ws://)")
+	mqttPrefix := flag.String("mqtt-prefix", export.DefaultMQTTPrefix, "topic prefix for -mqtt")
+	mqttChannels := flag.Bool("mqtt-channels", false, "also publish channel values to -mqtt when they change")
+	mqttInterval := flag.Duration("mqtt-interval", export.DefaultMQTTInterval, "how often to publish to -mqtt")
+	influxTarget := flag.String("influx", "", "write per-universe and per-source metrics in Influx line protocol to this write URL (http[s]://...) or udp://host:port; token from $INFLUX_TOKEN")
+	influxInterval := flag.Duration("influx-interval", export.DefaultInfluxInterval, "how often to write metrics to -influx")
+	remoteWriteTarget := flag.String("remote-write", "", "push metrics to a Prometheus remote-write URL (http[s]://[user:pass@]host/api/v1/write); bearer token from $REMOTE_WRITE_TOKEN")
+	remoteWriteInterval := flag.Duration("remote-write-interval", export.DefaultRemoteWriteInterval, "how often to push metrics to -remote-write")
+	remoteWriteLabels := flag.String("remote-write-labels", "", "extra labels on every -remote-write series, e.g. site=arena,rig=A")
+	metricsTarget := flag.String("metrics", "", "emit rate, loss and drop metrics to statsd://host:8125 or graphite://host:2003")
+	metricsPrefix := flag.String("metrics-prefix", export.DefaultMetricsPrefix, "prefix of every -metrics name")
+	metricsInterval := flag.Duration("metrics-interval", export.DefaultMetricsInterval, "how often to emit -metrics")
+	storeFile := flag.String("store", "", "keep downsampled stats, loss events and source history in this SQLite database for post-show queries")
+	storeInterval := flag.Duration("store-interval", store.DefaultInterval, "width of each -store sample")
+	storeRetention := flag.Duration("store-retention", store.DefaultRetention, "how long -store keeps rows (0 = forever)")
+	oscDir := flag.String("osc-dir", ".", "directory OSC record/start and snapshot/save write to and snapshot/load reads from; remote commands only name files in it")
+	oscAddr := flag.String("osc-listen", "", "accept OSC remote control on this UDP host:port, e.g. :8000 (/sacn-monitor/universe, reset, record/start, record/stop, snapshot, snapshot/save, snapshot/load)")
+	grpcAddr := flag.String("grpc-listen", "", "serve the gRPC API (proto/sacnmonitor/v1/monitor.proto) on host:port")
+	wsAddr := flag.String("ws-listen", "", "stream channel changes and stats to WebSocket clients on host:port, e.g. for a browser visualizer")
+	stateFile := flag.String("state-file", "", "save where you were in the UI on exit and restore it on startup (default: user config dir, off = don't)")
+	flag.Parse()
+
 	// Create components
 	universeManager := universe.NewManager()
+	universeManager.SetMaxUniverses(*maxUniverses)
 	statsTracker := stats.NewTracker()
+	statsTracker.SetGapThreshold(*gapThreshold)
+	statsTracker.SetDefaultExpectedRate(*expectedRate)
+	statsTracker.SetSequenceTimeline(*sequenceTimeline)
+	eventLog := events.NewLog(0)
 	receiver := sacn.NewReceiver()
+	receiver.SetDropHandler(func(packet *sacn.Packet) {
+		statsTracker.RecordReceiverDrop(packet.Universe, packet.CID, packet.Sequence)
+	})
+
+	cfg, err := loadConfig(*configFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+	universeManager.SetNames(cfg.UniverseNames)
+	if *refresh == 0 && cfg.RefreshInterval != "" {
+		*refresh, err = time.ParseDuration(cfg.RefreshInterval)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error in config refresh_interval: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if *refresh < 0 {
+		fmt.Fprintf(os.Stderr, "Error: refresh interval must be positive\n")
+		os.Exit(1)
+	}
+	if *noTUI && *statusInterval <= 0 {
+		fmt.Fprintf(os.Stderr, "Error: status interval must be positive\n")
+		os.Exit(1)
+	}
+	if *statsFile != "" && *statsSaveInterval <= 0 {
+		fmt.Fprintf(os.Stderr, "Error: stats save interval must be positive\n")
+		os.Exit(1)
+	}
+	if *printChanges && *ndjsonFile == "-" {
+		fmt.Fprintf(os.Stderr, "Error: -print-changes and -ndjson - both need stdout\n")
+		os.Exit(1)
+	}
+	if *printChanges {
+		*noTUI = true
+	}
+	if *ndjsonFile == "-" && !*noTUI {
+		fmt.Fprintf(os.Stderr, "Error: -ndjson - needs -no-tui, the UI owns stdout\n")
+		os.Exit(1)
+	}
+	if ext := strings.ToLower(filepath.Ext(*reportFile)); *reportFile != "" && ext != ".html" && ext != ".htm" && ext != ".md" && ext != ".markdown" {
+		fmt.Fprintf(os.Stderr, "Error: -report must end in .html or .md\n")
+		os.Exit(1)
+	}
+	for id, hz := range cfg.ExpectedRates {
+		statsTracker.SetExpectedRate(id, hz)
+	}
+	groups := make([]universe.Group, len(cfg.UniverseGroups))
+	for i, g := range cfg.UniverseGroups {
+		groups[i] = universe.Group{Name: g.Name, Universes: g.Universes}
+	}
+	universeManager.SetGroups(groups)
+	for id, ranges := range cfg.ChannelMasks {
+		for _, spec := range ranges {
+			r, err := universe.ParseChannelRange(spec)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error in config channel mask for universe %d: %v\n", id, err)
+				os.Exit(1)
+			}
+			universeManager.MaskChannels(id, r)
+		}
+	}
+
+	if *patchFile != "" {
+		p, err := patch.Load(*patchFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading patch: %v\n", err)
+			os.Exit(1)
+		}
+		universeManager.SetPatch(p)
+	}
+
+	if *baselineFile != "" {
+		look, err := universe.ReadSnapshotFile(*baselineFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading baseline: %v\n", err)
+			os.Exit(1)
+		}
+		universeManager.SetBaseline(look, uint8(min(*baselineTolerance, 255)))
+	}
+
+	if *loadSnapshot != "" {
+		snapshots, err := universe.ReadSnapshotFile(*loadSnapshot)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading snapshots: %v\n", err)
+			os.Exit(1)
+		}
+		universeManager.ImportSnapshots(snapshots)
+	}
+
+	if *statsFile != "" {
+		state, err := stats.ReadStateFile(*statsFile)
+		if err == nil {
+			statsTracker.Restore(state)
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			fmt.Fprintf(os.Stderr, "Error loading stats: %v\n", err)
+			os.Exit(1)
+		}
+	}
 
 	// Context for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
@@ -33,41 +223,611 @@ func main() {
 		cancel()
 	}()
 
+	// Serve the gRPC API if requested; it sees every packet, so it starts
+	// before packet processing
+	var apiServer *api.Server
+	if *grpcAddr != "" {
+		apiServer, err = api.Listen(*grpcAddr, universeManager, statsTracker, eventLog)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error starting gRPC server: %v\n", err)
+			os.Exit(1)
+		}
+		defer apiServer.Close()
+		go func() {
+			if err := apiServer.Serve(ctx); err != nil {
+				eventLog.Add(events.Event{Time: time.Now(), Kind: events.ExportError, Message: err.Error()})
+			}
+		}()
+	}
+
+	// Log channel changes if requested; every DMX packet is compared, so
+	// it opens before packet processing
+	var changeLog *export.ChangeLog
+	if *changeLogFile != "" {
+		changeLog, err = export.OpenChangeLog(*changeLogFile, int64(*changeLogSize)<<20)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error starting change log: %v\n", err)
+			os.Exit(1)
+		}
+		defer changeLog.Close()
+		changeLog.SetLabels(universeManager.ChannelLabel)
+		go changeLog.Run(ctx, func(err error) {
+			eventLog.Add(events.Event{Time: time.Now(), Kind: events.ExportError, Message: err.Error()})
+		})
+	}
+
+	// Print channel changes to stdout if requested; the monitor stops once
+	// the reading end of the pipeline goes away
+	var changePrinter *export.ChangePrinter
+	if *printChanges {
+		filter, err := export.ParseChangeFilter(*printChangesFilter)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		changePrinter = export.NewChangePrinter(os.Stdout, filter)
+	}
+
+	// Record a packet capture if requested; on exit it waits for queued
+	// packets to be written before closing the file
+	if *captureFile != "" {
+		capture, err := export.CreateCapture(*captureFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error starting capture: %v\n", err)
+			os.Exit(1)
+		}
+		receiver.SetCaptureHandler(capture.Record)
+		captured := make(chan struct{})
+		defer func() {
+			cancel()
+			<-captured
+			if err := capture.Close(); err != nil {
+				fmt.Fprintf(os.Stderr, "Error closing capture: %v\n", err)
+			}
+			if n := capture.Dropped(); n > 0 {
+				fmt.Fprintf(os.Stderr, "Capture dropped %d packets it couldn't write in time\n", n)
+			}
+		}()
+		go func() {
+			defer close(captured)
+			capture.Run(ctx, func(err error) {
+				eventLog.Add(events.Event{Time: time.Now(), Kind: events.ExportError, Message: err.Error()})
+			})
+		}()
+	}
+
+	// Record significant events with a snapshot of the universe state
+	monitor := events.NewMonitor(universeManager, statsTracker, eventLog)
+	go monitor.Run(ctx)
+
+	// Keep downsampled history for trends and reports; on exit the periods
+	// in progress go to the rollup log
+	historyRecorder := history.NewRecorder(universeManager, statsTracker)
+	if *rollupLog != "" {
+		historyRecorder.OnRollup(func(r history.Rollup) {
+			if err := export.AppendRollups(*rollupLog, []history.Rollup{r}); err != nil {
+				eventLog.Add(events.Event{Time: time.Now(), Kind: events.ExportError, Message: err.Error()})
+			}
+		})
+	}
+	recorded := make(chan struct{})
+	defer func() {
+		cancel()
+		<-recorded
+		historyRecorder.Flush()
+	}()
+	go func() {
+		defer close(recorded)
+		historyRecorder.Run(ctx)
+	}()
+
+	// Evicted and pruned universes take their statistics, history and
+	// event state with them; registered before packets can create any
+	universeManager.OnRemove(func(id uint16) {
+		statsTracker.RemoveUniverse(id)
+		historyRecorder.Forget(id)
+		monitor.Forget(id)
+	})
+
 	// Start the receiver
 	if err := receiver.Start(ctx); err != nil {
 		fmt.Fprintf(os.Stderr, "Error starting receiver: %v\n", err)
 		os.Exit(1)
 	}
 
-	// Process incoming packets
+	// Process incoming packets. Data and sync packets share one loop so a
+	// sync always releases the data that arrived before it.
 	go func() {
-		for packet := range receiver.Packets() {
-			// Update universe state
-			u := universeManager.GetOrCreate(packet.Universe)
-			u.Update(
-				packet.ChannelData,
-				packet.SourceName,
-				packet.CID,
-				packet.Priority,
-				packet.Sequence,
-			)
-
-			// Update stats
-			statsTracker.RecordPacket(
-				packet.Universe,
-				packet.CID,
-				packet.SourceName,
-				packet.Sequence,
-			)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case syncPacket := <-receiver.Syncs():
+				universeManager.ReleaseSync(syncPacket.SyncAddress)
+			case packet := <-receiver.Packets():
+				// Update stats, skipping late packets and second copies of
+				// the same packet (e.g. sent both unicast and multicast)
+				statsTracker.RecordSourceAddress(packet.CID, packet.SourceIP())
+				statsTracker.RecordSourceTransport(packet.CID, packet.Transport())
+				arrival := statsTracker.RecordPacket(
+					packet.Universe,
+					packet.CID,
+					packet.SourceName,
+					packet.Sequence,
+				)
+				statsTracker.RecordBytes(packet.Universe, packet.CID, packet.Length)
+				if apiServer != nil {
+					apiServer.PublishPacket(packet, arrival)
+				}
+				if arrival != stats.InOrder {
+					continue
+				}
+				statsTracker.RecordPriority(
+					packet.Universe,
+					packet.CID,
+					packet.Priority,
+					packet.StartCode == sacn.StartCodePerAddressPriority,
+				)
+
+				// Only DMX data carries levels; per-address priorities are
+				// kept apart and other start codes aren't applied
+				u := universeManager.GetOrCreate(packet.Universe)
+				u.RecordStartCode(packet.StartCode, packet.SourceName)
+				switch packet.StartCode {
+				case sacn.StartCodeDMX:
+					statsTracker.RecordData(packet.Universe, packet.CID, packet.ChannelData)
+					u.RecordFlags(packet.PreviewData(), packet.StreamTerminated())
+					if changeLog != nil {
+						changeLog.Record(packet.Universe, packet.ChannelData, packet.SourceName, packet.CID, packet.ReceivedAt)
+					}
+					if changePrinter != nil {
+						if err := changePrinter.Record(packet.Universe, packet.ChannelData, packet.CID, packet.ReceivedAt); err != nil {
+							cancel()
+						}
+					}
+				case sacn.StartCodePerAddressPriority:
+					u.UpdatePriorities(packet.ChannelData, packet.CID)
+					continue
+				default:
+					continue
+				}
+
+				// Update universe state; synchronized data waits for its
+				// sync packet
+				if packet.SyncAddress != 0 {
+					u.Hold(
+						packet.ChannelData,
+						packet.SourceName,
+						packet.CID,
+						packet.Priority,
+						packet.Sequence,
+						packet.SyncAddress,
+					)
+					continue
+				}
+				u.Update(
+					packet.ChannelData,
+					packet.SourceName,
+					packet.CID,
+					packet.Priority,
+					packet.Sequence,
+				)
+			}
 		}
 	}()
 
+	// Feed a previsualization tool if requested
+	if *previzTarget != "" {
+		previz, err := export.DialPreviz(*previzTarget, universeManager)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error starting previz stream: %v\n", err)
+			os.Exit(1)
+		}
+		defer previz.Close()
+		if *previzGroup != "" {
+			group, ok := universeManager.Group(*previzGroup)
+			if !ok {
+				fmt.Fprintf(os.Stderr, "Error: unknown universe group %q\n", *previzGroup)
+				os.Exit(1)
+			}
+			previz.SetFilter(group.Contains)
+		}
+		previz.SetLabels(*previzLabels)
+		go func() {
+			if err := previz.Run(ctx); err != nil {
+				eventLog.Add(events.Event{Time: time.Now(), Kind: events.ExportError, Message: err.Error()})
+			}
+		}()
+	}
+
+	// Stream observations as JSON Lines if requested
+	if *ndjsonFile != "" {
+		out := os.Stdout
+		if *ndjsonFile != "-" {
+			out, err = os.OpenFile(*ndjsonFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error opening event stream: %v\n", err)
+				os.Exit(1)
+			}
+			defer out.Close()
+		}
+		stream := export.NewEventStream(out, universeManager, statsTracker, eventLog)
+		stream.SetThreshold(*ndjsonThreshold)
+		go func() {
+			if err := stream.Run(ctx); err != nil {
+				eventLog.Add(events.Event{Time: time.Now(), Kind: events.ExportError, Message: err.Error()})
+			}
+		}()
+	}
+
+	// Stream to WebSocket clients if requested
+	if *wsAddr != "" {
+		live, err := export.ListenLive(*wsAddr, universeManager, statsTracker, eventLog)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error starting WebSocket server: %v\n", err)
+			os.Exit(1)
+		}
+		defer live.Close()
+		go func() {
+			if err := live.Serve(ctx); err != nil {
+				eventLog.Add(events.Event{Time: time.Now(), Kind: events.ExportError, Message: err.Error()})
+			}
+		}()
+	}
+
+	// Publish to an MQTT broker if requested
+	if *mqttBroker != "" {
+		publisher, err := export.DialMQTT(*mqttBroker, *mqttPrefix, universeManager, statsTracker)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error starting MQTT publishing: %v\n", err)
+			os.Exit(1)
+		}
+		defer publisher.Close()
+		publisher.SetInterval(*mqttInterval)
+		publisher.PublishChannels(*mqttChannels)
+		go publisher.Run(ctx, func(err error) {
+			eventLog.Add(events.Event{Time: time.Now(), Kind: events.ExportError, Message: err.Error()})
+		})
+	}
+
+	// Write metrics to InfluxDB or Telegraf if requested
+	if *influxTarget != "" {
+		influx, err := export.DialInflux(*influxTarget, universeManager, statsTracker)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error starting Influx export: %v\n", err)
+			os.Exit(1)
+		}
+		defer influx.Close()
+		influx.SetInterval(*influxInterval)
+		go influx.Run(ctx, func(err error) {
+			eventLog.Add(events.Event{Time: time.Now(), Kind: events.ExportError, Message: err.Error()})
+		})
+	}
+
+	// Push metrics to a Prometheus remote-write endpoint if requested
+	if *remoteWriteTarget != "" {
+		writer, err := export.DialRemoteWrite(*remoteWriteTarget, universeManager, statsTracker)
+		if err == nil {
+			err = writer.SetLabels(*remoteWriteLabels)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error starting remote write: %v\n", err)
+			os.Exit(1)
+		}
+		writer.SetInterval(*remoteWriteInterval)
+		go writer.Run(ctx, func(err error) {
+			eventLog.Add(events.Event{Time: time.Now(), Kind: events.ExportError, Message: err.Error()})
+		})
+	}
+
+	// Emit StatsD or Graphite metrics if requested
+	if *metricsTarget != "" {
+		emitter, err := export.DialMetrics(*metricsTarget, statsTracker)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error starting metrics export: %v\n", err)
+			os.Exit(1)
+		}
+		defer emitter.Close()
+		emitter.SetPrefix(*metricsPrefix)
+		emitter.SetInterval(*metricsInterval)
+		go emitter.Run(ctx, func(err error) {
+			eventLog.Add(events.Event{Time: time.Now(), Kind: events.ExportError, Message: err.Error()})
+		})
+	}
+
+	// Forward events to syslog if requested; on exit the last ones are sent
+	// before closing
+	if *syslogTarget != "" {
+		forwarder, err := export.DialSyslog(*syslogTarget, eventLog)
+		if err == nil {
+			err = forwarder.SetFacility(*syslogFacility)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error starting syslog forwarding: %v\n", err)
+			os.Exit(1)
+		}
+		forwarded := make(chan struct{})
+		defer func() {
+			cancel()
+			<-forwarded
+			forwarder.Close()
+		}()
+		go func() {
+			defer close(forwarded)
+			forwarder.Run(ctx, func(err error) {
+				eventLog.Add(events.Event{Time: time.Now(), Kind: events.ExportError, Message: err.Error()})
+			})
+		}()
+	}
+
+	// Keep history in SQLite if requested; on exit it waits for the last
+	// events to be written before closing the database
+	if *storeFile != "" {
+		db, err := store.Open(*storeFile, universeManager, statsTracker)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error opening store: %v\n", err)
+			os.Exit(1)
+		}
+		db.SetInterval(*storeInterval)
+		db.SetRetention(*storeRetention)
+		stored := make(chan struct{})
+		defer func() {
+			cancel()
+			<-stored
+			db.Close()
+		}()
+		go func() {
+			defer close(stored)
+			db.Run(ctx, func(err error) {
+				eventLog.Add(events.Event{Time: time.Now(), Kind: events.ExportError, Message: err.Error()})
+			})
+		}()
+	}
+
+	// Drive a USB DMX interface if requested; on exit the port is blacked
+	// out before closing
+	if *dmxOutPort != "" {
+		driver, err := dmxout.ParseDriver(*dmxOutDriver)
+		if err == nil && (*dmxOutUniverse < sacn.E131MinUniverse || *dmxOutUniverse > sacn.E131MaxUniverse) {
+			err = fmt.Errorf("-dmx-out-universe must be %d-%d", sacn.E131MinUniverse, sacn.E131MaxUniverse)
+		}
+		var output *dmxout.Output
+		if err == nil {
+			output, err = dmxout.Open(*dmxOutPort, driver, universeManager, uint16(*dmxOutUniverse))
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error starting DMX output: %v\n", err)
+			os.Exit(1)
+		}
+		output.SetRate(*dmxOutRate)
+		rendered := make(chan struct{})
+		defer func() {
+			cancel()
+			<-rendered
+			output.Close()
+		}()
+		go func() {
+			defer close(rendered)
+			output.Run(ctx, func(err error) {
+				eventLog.Add(events.Event{Time: time.Now(), Kind: events.ExportError, Message: err.Error()})
+			})
+		}()
+	}
+
+	// Bridge universes to Art-Net if requested
+	if *artnetTarget != "" {
+		mapping, err := artnet.ParseMapping(*artnetMap)
+		var bridge *artnet.Bridge
+		if err == nil {
+			bridge, err = artnet.Dial(*artnetTarget, universeManager, mapping)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error starting Art-Net bridge: %v\n", err)
+			os.Exit(1)
+		}
+		defer bridge.Close()
+		go bridge.Run(ctx, func(err error) {
+			eventLog.Add(events.Event{Time: time.Now(), Kind: events.ExportError, Message: err.Error()})
+		})
+	}
+
+	// Accept OSC remote control if requested; it starts once the UI (if
+	// any) can receive its commands
+	remote := &remoteControl{ctx: ctx, manager: universeManager, tracker: statsTracker, log: eventLog, dir: *oscDir}
+	defer remote.stopRecording()
+	var oscServer *osc.Server
+	if *oscAddr != "" {
+		oscServer, err = osc.Listen(*oscAddr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error starting OSC remote control: %v\n", err)
+			os.Exit(1)
+		}
+		defer oscServer.Close()
+	}
+	startRemote := func(notify func(tea.Msg)) {
+		if oscServer == nil {
+			return
+		}
+		remote.notify = notify
+		go oscServer.Serve(ctx, remote.handle, func(err error) {
+			eventLog.Add(events.Event{Time: time.Now(), Kind: events.ExportError, Message: err.Error()})
+		})
+	}
+
+	// Serve mirrored sessions if requested
+	if *mirrorAddr != "" {
+		server, err := mirror.Listen(*mirrorAddr, func() tui.Model {
+			return tui.NewModel(universeManager, statsTracker).WithEventLog(eventLog).WithRefresh(*refresh)
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error starting mirror server: %v\n", err)
+			os.Exit(1)
+		}
+		defer server.Close()
+		go server.Serve(ctx)
+	}
+
+	// Drop universes from earlier experiments once they fall silent
+	if *pruneAfter > 0 {
+		go universeManager.RunPruning(ctx, *pruneAfter)
+	}
+
+	// Save statistics periodically, and once more on exit
+	if *statsFile != "" {
+		persister := stats.NewPersister(statsTracker, *statsFile, *statsSaveInterval, func(err error) {
+			eventLog.Add(events.Event{Time: time.Now(), Kind: events.ExportError, Message: err.Error()})
+		})
+		go persister.Run(ctx)
+		defer func() {
+			if err := persister.Save(); err != nil {
+				fmt.Fprintf(os.Stderr, "Error saving stats: %v\n", err)
+			}
+		}()
+	}
+
+	// Report the session on exit
+	if *summaryFile != "" {
+		defer func() {
+			if err := export.WriteSummaryFile(*summaryFile, statsTracker.Summary()); err != nil {
+				fmt.Fprintf(os.Stderr, "Error writing summary: %v\n", err)
+			}
+		}()
+	}
+	if *reportFile != "" {
+		defer func() {
+			if err := export.WriteReportFile(*reportFile, export.NewReport(universeManager, statsTracker, eventLog)); err != nil {
+				fmt.Fprintf(os.Stderr, "Error writing report: %v\n", err)
+			}
+		}()
+	}
+
+	// Where this session is being recorded, for the status bar
+	var outputs []string
+	if *statsFile != "" {
+		outputs = append(outputs, "stats → "+*statsFile)
+	}
+	if *rollupLog != "" {
+		outputs = append(outputs, "rollups → "+*rollupLog)
+	}
+	if *captureFile != "" {
+		outputs = append(outputs, "capture → "+*captureFile)
+	}
+	if *changeLogFile != "" {
+		outputs = append(outputs, "changes → "+*changeLogFile)
+	}
+	if *previzTarget != "" {
+		outputs = append(outputs, "previz → "+*previzTarget)
+	}
+	if *ndjsonFile != "" {
+		outputs = append(outputs, "events → "+*ndjsonFile)
+	}
+	if *mirrorAddr != "" {
+		outputs = append(outputs, "mirror on "+*mirrorAddr)
+	}
+	if *wsAddr != "" {
+		outputs = append(outputs, "websocket on "+*wsAddr)
+	}
+	if *grpcAddr != "" {
+		outputs = append(outputs, "gRPC on "+*grpcAddr)
+	}
+	if *mqttBroker != "" {
+		outputs = append(outputs, "mqtt → "+*mqttPrefix)
+	}
+	if *oscAddr != "" {
+		outputs = append(outputs, "osc on "+*oscAddr)
+	}
+	if *influxTarget != "" {
+		outputs = append(outputs, "influx → "+*influxTarget)
+	}
+	if *remoteWriteTarget != "" {
+		// Credentials in the URL aren't for the screen
+		target, _ := url.Parse(*remoteWriteTarget)
+		outputs = append(outputs, "remote write → "+target.Redacted())
+	}
+	if *metricsTarget != "" {
+		outputs = append(outputs, "metrics → "+*metricsTarget)
+	}
+	if *syslogTarget != "" {
+		outputs = append(outputs, "syslog → "+*syslogTarget)
+	}
+	if *dmxOutPort != "" {
+		outputs = append(outputs, fmt.Sprintf("dmx %d → %s", *dmxOutUniverse, *dmxOutPort))
+	}
+	if *artnetTarget != "" {
+		mapping, _ := artnet.ParseMapping(*artnetMap)
+		outputs = append(outputs, fmt.Sprintf("artnet %s → %s", mapping, *artnetTarget))
+	}
+	if *storeFile != "" {
+		outputs = append(outputs, "store → "+*storeFile)
+	}
+
+	// Without a terminal, report to stdout until stopped
+	if *noTUI {
+		startRemote(nil)
+		if *ndjsonFile == "-" || *printChanges {
+			// The event stream or change lines have stdout to themselves
+			<-ctx.Done()
+			return
+		}
+		runHeadless(ctx, universeManager, statsTracker, *statusInterval)
+		return
+	}
+
 	// Create and run TUI
-	model := tui.NewModel(universeManager, statsTracker)
+	model := tui.NewModel(universeManager, statsTracker).WithEventLog(eventLog).WithConfig(cfg).WithHistory(historyRecorder).WithOutputs(outputs...).WithRefresh(*refresh).WithPruneTimeout(*pruneAfter)
+
+	// Put the operator back where they were
+	statePath, err := resolveStatePath(*stateFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error locating UI state: %v (not saved this run)\n", err)
+	}
+	if statePath != "" {
+		state, err := config.LoadState(statePath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading UI state: %v (starting fresh)\n", err)
+		}
+		model = model.WithState(state)
+	}
+
 	p := tea.NewProgram(model, tea.WithAltScreen())
+	startRemote(p.Send)
 
-	if _, err := p.Run(); err != nil {
+	final, err := p.Run()
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error running TUI: %v\n", err)
 		os.Exit(1)
 	}
+	if m, ok := final.(tui.Model); ok && statePath != "" {
+		if err := config.SaveState(statePath, m.State()); err != nil {
+			fmt.Fprintf(os.Stderr, "Error saving UI state: %v\n", err)
+		}
+	}
+}
+
+// resolveStatePath returns the UI state file for the -state-file flag:
+// the default location when empty, or "" when turned off
+func resolveStatePath(flagValue string) (string, error) {
+	switch flagValue {
+	case "off":
+		return "", nil
+	case "":
+		return config.DefaultStatePath()
+	default:
+		return flagValue, nil
+	}
+}
+
+// loadConfig loads the config file at path, or from the default location
+// when path is empty. Without a default location (no home directory under
+// systemd or in a container) settings are kept for this run only.
+func loadConfig(path string) (*config.Config, error) {
+	if path == "" {
+		defaultPath, err := config.DefaultPath()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error locating config: %v (settings not saved this run, use -config)\n", err)
+			return config.New(), nil
+		}
+		path = defaultPath
+	}
+	return config.Load(path)
 }
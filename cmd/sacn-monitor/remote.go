@@ -0,0 +1,254 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"sacn-monitor/internal/events"
+	"sacn-monitor/internal/export"
+	"sacn-monitor/internal/osc"
+	"sacn-monitor/internal/stats"
+	"sacn-monitor/internal/tui"
+	"sacn-monitor/internal/universe"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// oscPrefix is the OSC address space the monitor answers to
+const oscPrefix = "/sacn-monitor/"
+
+// remoteControl carries out OSC commands from a show-control system:
+//
+//	/sacn-monitor/universe <id>         select a universe in the UI
+//	/sacn-monitor/reset [id]            reset statistics of one or all universes
+//	/sacn-monitor/record/start [file]   record the JSON Lines event stream to a file
+//	/sacn-monitor/record/stop           stop recording
+//	/sacn-monitor/snapshot [id] [name]  snapshot one or all universes
+//	/sacn-monitor/snapshot/save [id] [file]  save one or all universes to a JSON file
+//	/sacn-monitor/snapshot/load <file>  load snapshots from a JSON file to diff against
+//
+// OSC is unauthenticated, so files are plain names in dir: senders can't
+// reach other directories or overwrite existing files.
+type remoteControl struct {
+	ctx     context.Context
+	manager *universe.Manager
+	tracker *stats.Tracker
+	log     *events.Log
+	dir     string        // Where remote commands read and write files
+	notify  func(tea.Msg) // Sends to the UI, nil without one
+
+	mu         sync.Mutex
+	stopRecord context.CancelFunc // Ends the current recording, nil if none
+	recorded   chan struct{}      // Closed once the recording file is closed
+}
+
+// handle carries out one OSC message
+func (r *remoteControl) handle(msg osc.Message) {
+	command, ok := strings.CutPrefix(msg.Address, oscPrefix)
+	if !ok {
+		return
+	}
+
+	switch command {
+	case "universe":
+		id, ok := msg.Int(0)
+		if !ok || id < 0 || id > 0xFFFF {
+			r.report("Remote: /sacn-monitor/universe needs a universe number")
+			return
+		}
+		if r.notify != nil {
+			r.notify(tui.SelectUniverseMsg(id))
+		}
+	case "reset":
+		if id, ok := msg.Int(0); ok && id >= 0 && id <= 0xFFFF {
+			r.tracker.ResetUniverseStats(uint16(id))
+			r.report("Remote: statistics reset for " + r.manager.Describe(uint16(id)))
+			return
+		}
+		r.tracker.ResetAllStats()
+		r.report("Remote: statistics reset for all universes")
+	case "record/start":
+		name, _ := msg.String(0)
+		if name == "" {
+			name = "recording-" + time.Now().Format("20060102-150405")
+		}
+		path, err := r.file(name, ".jsonl")
+		if err != nil {
+			r.report(fmt.Sprintf("Remote: recording failed: %v", err))
+			return
+		}
+		r.startRecording(path)
+	case "record/stop":
+		r.stopRecording()
+	case "snapshot":
+		r.snapshot(msg)
+	case "snapshot/save":
+		r.saveSnapshot(msg)
+	case "snapshot/load":
+		name, ok := msg.String(0)
+		if !ok || name == "" {
+			r.report("Remote: /sacn-monitor/snapshot/load needs a file name")
+			return
+		}
+		path, err := r.file(name, ".json")
+		if err != nil {
+			r.report(fmt.Sprintf("Remote: %v", err))
+			return
+		}
+		snapshots, err := universe.ReadSnapshotFile(path)
+		if err != nil {
+			r.report(fmt.Sprintf("Remote: %v", err))
+			return
+		}
+		r.manager.ImportSnapshots(snapshots)
+		r.report(fmt.Sprintf("Remote: loaded %d snapshots from %s", len(snapshots), path))
+	default:
+		r.report("Remote: unknown command " + msg.Address)
+	}
+}
+
+// file returns the path of a file named by a remote command: a plain name
+// in the output directory, given ext if it has another extension
+func (r *remoteControl) file(name, ext string) (string, error) {
+	if strings.ContainsAny(name, `/\:`) || strings.Contains(name, "..") || strings.HasPrefix(name, ".") {
+		return "", fmt.Errorf("invalid file name %q: a plain name without directories is needed", name)
+	}
+	if filepath.Ext(name) != ext {
+		name += ext
+	}
+	return filepath.Join(r.dir, name), nil
+}
+
+// startRecording writes the event stream to a new file at path until
+// stopped, ending any recording already running
+func (r *remoteControl) startRecording(path string) {
+	r.stopRecording()
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		r.report(fmt.Sprintf("Remote: recording failed: %v", err))
+		return
+	}
+
+	ctx, cancel := context.WithCancel(r.ctx)
+	done := make(chan struct{})
+	r.mu.Lock()
+	r.stopRecord, r.recorded = cancel, done
+	r.mu.Unlock()
+
+	go func() {
+		defer close(done)
+		stream := export.NewEventStream(f, r.manager, r.tracker, r.log)
+		if err := stream.Run(ctx); err != nil {
+			r.log.Add(events.Event{Time: time.Now(), Kind: events.ExportError, Message: err.Error()})
+		}
+		if err := f.Close(); err != nil {
+			r.log.Add(events.Event{Time: time.Now(), Kind: events.ExportError, Message: err.Error()})
+		}
+	}()
+	r.report("Remote: recording to " + path)
+}
+
+// stopRecording ends the current recording, if any, once its file is closed
+func (r *remoteControl) stopRecording() {
+	r.mu.Lock()
+	stop, done := r.stopRecord, r.recorded
+	r.stopRecord, r.recorded = nil, nil
+	r.mu.Unlock()
+
+	if stop == nil {
+		return
+	}
+	stop()
+	<-done
+	r.report("Remote: recording stopped")
+}
+
+// snapshot captures a snapshot of one universe, or of every universe when
+// none is given, named after the capture time unless a name is given
+func (r *remoteControl) snapshot(msg osc.Message) {
+	name := time.Now().Format("15:04:05")
+	id, hasID := msg.Int(0)
+	if s, ok := msg.String(0); ok {
+		name, hasID = s, false
+	} else if s, ok := msg.String(1); ok {
+		name = s
+	}
+
+	if hasID {
+		if id < 0 || id > 0xFFFF {
+			r.report(fmt.Sprintf("Remote: invalid universe %d", id))
+			return
+		}
+		if _, ok := r.manager.CaptureSnapshot(uint16(id), name); !ok {
+			r.report(fmt.Sprintf("Remote: no data for universe %d", id))
+			return
+		}
+		r.report("Remote: snapshot " + name + " of " + r.manager.Describe(uint16(id)))
+		return
+	}
+
+	count := 0
+	for _, u := range r.manager.GetAll() {
+		if _, ok := r.manager.CaptureSnapshot(u.ID, name); ok {
+			count++
+		}
+	}
+	r.report(fmt.Sprintf("Remote: snapshot %s of %d universes", name, count))
+}
+
+// saveSnapshot writes one universe, or every universe when none is given,
+// to a new JSON file named after the capture time unless a name is given
+func (r *remoteControl) saveSnapshot(msg osc.Message) {
+	now := time.Now()
+	name := now.Format("20060102-150405")
+	file := "snapshot-" + name
+	var ids []uint16
+	id, hasID := msg.Int(0)
+	if s, ok := msg.String(0); ok {
+		file, hasID = s, false
+	} else if s, ok := msg.String(1); ok {
+		file = s
+	}
+	path, err := r.file(file, ".json")
+	if err != nil {
+		r.report(fmt.Sprintf("Remote: %v", err))
+		return
+	}
+	if _, err := os.Lstat(path); err == nil {
+		r.report(fmt.Sprintf("Remote: %s already exists", path))
+		return
+	}
+	if hasID {
+		if id < 0 || id > 0xFFFF {
+			r.report(fmt.Sprintf("Remote: invalid universe %d", id))
+			return
+		}
+		ids = append(ids, uint16(id))
+	}
+
+	snapshots := export.CaptureSnapshots(r.manager, r.tracker, name, ids...)
+	if len(snapshots) == 0 {
+		r.report("Remote: no universe data to save")
+		return
+	}
+	if err := universe.WriteSnapshotFile(path, snapshots); err != nil {
+		r.report(fmt.Sprintf("Remote: %v", err))
+		return
+	}
+	r.report(fmt.Sprintf("Remote: saved %d universes to %s", len(snapshots), path))
+}
+
+// report shows the outcome of a command in the UI, or on stderr without one
+func (r *remoteControl) report(text string) {
+	if r.notify != nil {
+		r.notify(tui.NoticeMsg(text))
+		return
+	}
+	fmt.Fprintln(os.Stderr, text)
+}
@@ -0,0 +1,1358 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: sacnmonitor/v1/monitor.proto
+
+package monitorpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Packet_Arrival int32
+
+const (
+	Packet_ARRIVAL_UNSPECIFIED  Packet_Arrival = 0
+	Packet_ARRIVAL_IN_ORDER     Packet_Arrival = 1
+	Packet_ARRIVAL_OUT_OF_ORDER Packet_Arrival = 2
+	Packet_ARRIVAL_DUPLICATE    Packet_Arrival = 3
+)
+
+// Enum value maps for Packet_Arrival.
+var (
+	Packet_Arrival_name = map[int32]string{
+		0: "ARRIVAL_UNSPECIFIED",
+		1: "ARRIVAL_IN_ORDER",
+		2: "ARRIVAL_OUT_OF_ORDER",
+		3: "ARRIVAL_DUPLICATE",
+	}
+	Packet_Arrival_value = map[string]int32{
+		"ARRIVAL_UNSPECIFIED":  0,
+		"ARRIVAL_IN_ORDER":     1,
+		"ARRIVAL_OUT_OF_ORDER": 2,
+		"ARRIVAL_DUPLICATE":    3,
+	}
+)
+
+func (x Packet_Arrival) Enum() *Packet_Arrival {
+	p := new(Packet_Arrival)
+	*p = x
+	return p
+}
+
+func (x Packet_Arrival) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Packet_Arrival) Descriptor() protoreflect.EnumDescriptor {
+	return file_sacnmonitor_v1_monitor_proto_enumTypes[0].Descriptor()
+}
+
+func (Packet_Arrival) Type() protoreflect.EnumType {
+	return &file_sacnmonitor_v1_monitor_proto_enumTypes[0]
+}
+
+func (x Packet_Arrival) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Packet_Arrival.Descriptor instead.
+func (Packet_Arrival) EnumDescriptor() ([]byte, []int) {
+	return file_sacnmonitor_v1_monitor_proto_rawDescGZIP(), []int{12, 0}
+}
+
+type ListUniversesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUniversesRequest) Reset() {
+	*x = ListUniversesRequest{}
+	mi := &file_sacnmonitor_v1_monitor_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUniversesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUniversesRequest) ProtoMessage() {}
+
+func (x *ListUniversesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sacnmonitor_v1_monitor_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUniversesRequest.ProtoReflect.Descriptor instead.
+func (*ListUniversesRequest) Descriptor() ([]byte, []int) {
+	return file_sacnmonitor_v1_monitor_proto_rawDescGZIP(), []int{0}
+}
+
+type ListUniversesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Universes     []*Universe            `protobuf:"bytes,1,rep,name=universes,proto3" json:"universes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUniversesResponse) Reset() {
+	*x = ListUniversesResponse{}
+	mi := &file_sacnmonitor_v1_monitor_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUniversesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUniversesResponse) ProtoMessage() {}
+
+func (x *ListUniversesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sacnmonitor_v1_monitor_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUniversesResponse.ProtoReflect.Descriptor instead.
+func (*ListUniversesResponse) Descriptor() ([]byte, []int) {
+	return file_sacnmonitor_v1_monitor_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListUniversesResponse) GetUniverses() []*Universe {
+	if x != nil {
+		return x.Universes
+	}
+	return nil
+}
+
+type GetUniverseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Universe      uint32                 `protobuf:"varint,1,opt,name=universe,proto3" json:"universe,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUniverseRequest) Reset() {
+	*x = GetUniverseRequest{}
+	mi := &file_sacnmonitor_v1_monitor_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUniverseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUniverseRequest) ProtoMessage() {}
+
+func (x *GetUniverseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sacnmonitor_v1_monitor_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUniverseRequest.ProtoReflect.Descriptor instead.
+func (*GetUniverseRequest) Descriptor() ([]byte, []int) {
+	return file_sacnmonitor_v1_monitor_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetUniverseRequest) GetUniverse() uint32 {
+	if x != nil {
+		return x.Universe
+	}
+	return 0
+}
+
+type GetStatsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Universes to include; empty for all
+	Universes     []uint32 `protobuf:"varint,1,rep,packed,name=universes,proto3" json:"universes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatsRequest) Reset() {
+	*x = GetStatsRequest{}
+	mi := &file_sacnmonitor_v1_monitor_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsRequest) ProtoMessage() {}
+
+func (x *GetStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sacnmonitor_v1_monitor_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetStatsRequest) Descriptor() ([]byte, []int) {
+	return file_sacnmonitor_v1_monitor_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetStatsRequest) GetUniverses() []uint32 {
+	if x != nil {
+		return x.Universes
+	}
+	return nil
+}
+
+type SubscribePacketsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Universes to include; empty for all
+	Universes     []uint32 `protobuf:"varint,1,rep,packed,name=universes,proto3" json:"universes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribePacketsRequest) Reset() {
+	*x = SubscribePacketsRequest{}
+	mi := &file_sacnmonitor_v1_monitor_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribePacketsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribePacketsRequest) ProtoMessage() {}
+
+func (x *SubscribePacketsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sacnmonitor_v1_monitor_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribePacketsRequest.ProtoReflect.Descriptor instead.
+func (*SubscribePacketsRequest) Descriptor() ([]byte, []int) {
+	return file_sacnmonitor_v1_monitor_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SubscribePacketsRequest) GetUniverses() []uint32 {
+	if x != nil {
+		return x.Universes
+	}
+	return nil
+}
+
+type SubscribeChannelsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Universes to include; empty for all
+	Universes []uint32 `protobuf:"varint,1,rep,packed,name=universes,proto3" json:"universes,omitempty"`
+	// Level change a channel must make since its last update to be sent
+	// again; 0 sends every change
+	Threshold     uint32 `protobuf:"varint,2,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeChannelsRequest) Reset() {
+	*x = SubscribeChannelsRequest{}
+	mi := &file_sacnmonitor_v1_monitor_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeChannelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeChannelsRequest) ProtoMessage() {}
+
+func (x *SubscribeChannelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sacnmonitor_v1_monitor_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeChannelsRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeChannelsRequest) Descriptor() ([]byte, []int) {
+	return file_sacnmonitor_v1_monitor_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SubscribeChannelsRequest) GetUniverses() []uint32 {
+	if x != nil {
+		return x.Universes
+	}
+	return nil
+}
+
+func (x *SubscribeChannelsRequest) GetThreshold() uint32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+type SubscribeStatsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Universes to include; empty for all
+	Universes []uint32 `protobuf:"varint,1,rep,packed,name=universes,proto3" json:"universes,omitempty"`
+	// Milliseconds between updates; 0 for one second
+	IntervalMs    uint32 `protobuf:"varint,2,opt,name=interval_ms,json=intervalMs,proto3" json:"interval_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeStatsRequest) Reset() {
+	*x = SubscribeStatsRequest{}
+	mi := &file_sacnmonitor_v1_monitor_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeStatsRequest) ProtoMessage() {}
+
+func (x *SubscribeStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sacnmonitor_v1_monitor_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeStatsRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeStatsRequest) Descriptor() ([]byte, []int) {
+	return file_sacnmonitor_v1_monitor_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SubscribeStatsRequest) GetUniverses() []uint32 {
+	if x != nil {
+		return x.Universes
+	}
+	return nil
+}
+
+func (x *SubscribeStatsRequest) GetIntervalMs() uint32 {
+	if x != nil {
+		return x.IntervalMs
+	}
+	return 0
+}
+
+type SubscribeEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeEventsRequest) Reset() {
+	*x = SubscribeEventsRequest{}
+	mi := &file_sacnmonitor_v1_monitor_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeEventsRequest) ProtoMessage() {}
+
+func (x *SubscribeEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sacnmonitor_v1_monitor_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeEventsRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeEventsRequest) Descriptor() ([]byte, []int) {
+	return file_sacnmonitor_v1_monitor_proto_rawDescGZIP(), []int{7}
+}
+
+// Universe is the merged output of one universe
+type Universe struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Source of the latest data
+	SourceName     string                 `protobuf:"bytes,3,opt,name=source_name,json=sourceName,proto3" json:"source_name,omitempty"`
+	SourceCid      string                 `protobuf:"bytes,4,opt,name=source_cid,json=sourceCid,proto3" json:"source_cid,omitempty"`
+	Priority       uint32                 `protobuf:"varint,5,opt,name=priority,proto3" json:"priority,omitempty"`
+	LastPacket     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_packet,json=lastPacket,proto3" json:"last_packet,omitempty"`
+	Stale          bool                   `protobuf:"varint,7,opt,name=stale,proto3" json:"stale,omitempty"`
+	ActiveChannels uint32                 `protobuf:"varint,8,opt,name=active_channels,json=activeChannels,proto3" json:"active_channels,omitempty"`
+	// Values of channels 1-512, -1 if not received; only set by GetUniverse
+	Channels      []int32 `protobuf:"varint,9,rep,packed,name=channels,proto3" json:"channels,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Universe) Reset() {
+	*x = Universe{}
+	mi := &file_sacnmonitor_v1_monitor_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Universe) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Universe) ProtoMessage() {}
+
+func (x *Universe) ProtoReflect() protoreflect.Message {
+	mi := &file_sacnmonitor_v1_monitor_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Universe.ProtoReflect.Descriptor instead.
+func (*Universe) Descriptor() ([]byte, []int) {
+	return file_sacnmonitor_v1_monitor_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Universe) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Universe) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Universe) GetSourceName() string {
+	if x != nil {
+		return x.SourceName
+	}
+	return ""
+}
+
+func (x *Universe) GetSourceCid() string {
+	if x != nil {
+		return x.SourceCid
+	}
+	return ""
+}
+
+func (x *Universe) GetPriority() uint32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *Universe) GetLastPacket() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastPacket
+	}
+	return nil
+}
+
+func (x *Universe) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
+
+func (x *Universe) GetActiveChannels() uint32 {
+	if x != nil {
+		return x.ActiveChannels
+	}
+	return 0
+}
+
+func (x *Universe) GetChannels() []int32 {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
+// Source is one sender on a universe
+type Source struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cid           string                 `protobuf:"bytes,1,opt,name=cid,proto3" json:"cid,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Address       string                 `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	Transport     string                 `protobuf:"bytes,4,opt,name=transport,proto3" json:"transport,omitempty"`
+	Priority      uint32                 `protobuf:"varint,5,opt,name=priority,proto3" json:"priority,omitempty"`
+	Packets       uint64                 `protobuf:"varint,6,opt,name=packets,proto3" json:"packets,omitempty"`
+	LostPackets   uint64                 `protobuf:"varint,7,opt,name=lost_packets,json=lostPackets,proto3" json:"lost_packets,omitempty"`
+	LastSeen      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Source) Reset() {
+	*x = Source{}
+	mi := &file_sacnmonitor_v1_monitor_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Source) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Source) ProtoMessage() {}
+
+func (x *Source) ProtoReflect() protoreflect.Message {
+	mi := &file_sacnmonitor_v1_monitor_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Source.ProtoReflect.Descriptor instead.
+func (*Source) Descriptor() ([]byte, []int) {
+	return file_sacnmonitor_v1_monitor_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Source) GetCid() string {
+	if x != nil {
+		return x.Cid
+	}
+	return ""
+}
+
+func (x *Source) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Source) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Source) GetTransport() string {
+	if x != nil {
+		return x.Transport
+	}
+	return ""
+}
+
+func (x *Source) GetPriority() uint32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *Source) GetPackets() uint64 {
+	if x != nil {
+		return x.Packets
+	}
+	return 0
+}
+
+func (x *Source) GetLostPackets() uint64 {
+	if x != nil {
+		return x.LostPackets
+	}
+	return 0
+}
+
+func (x *Source) GetLastSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeen
+	}
+	return nil
+}
+
+// UniverseStats are the packet statistics of one universe
+type UniverseStats struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Universe             uint32                 `protobuf:"varint,1,opt,name=universe,proto3" json:"universe,omitempty"`
+	PacketRate           float64                `protobuf:"fixed64,2,opt,name=packet_rate,json=packetRate,proto3" json:"packet_rate,omitempty"`
+	ByteRate             float64                `protobuf:"fixed64,3,opt,name=byte_rate,json=byteRate,proto3" json:"byte_rate,omitempty"`
+	LossPercentage       float64                `protobuf:"fixed64,4,opt,name=loss_percentage,json=lossPercentage,proto3" json:"loss_percentage,omitempty"`
+	RecentLossPercentage float64                `protobuf:"fixed64,5,opt,name=recent_loss_percentage,json=recentLossPercentage,proto3" json:"recent_loss_percentage,omitempty"`
+	Health               uint32                 `protobuf:"varint,6,opt,name=health,proto3" json:"health,omitempty"`
+	Sources              []*Source              `protobuf:"bytes,7,rep,name=sources,proto3" json:"sources,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *UniverseStats) Reset() {
+	*x = UniverseStats{}
+	mi := &file_sacnmonitor_v1_monitor_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UniverseStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UniverseStats) ProtoMessage() {}
+
+func (x *UniverseStats) ProtoReflect() protoreflect.Message {
+	mi := &file_sacnmonitor_v1_monitor_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UniverseStats.ProtoReflect.Descriptor instead.
+func (*UniverseStats) Descriptor() ([]byte, []int) {
+	return file_sacnmonitor_v1_monitor_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UniverseStats) GetUniverse() uint32 {
+	if x != nil {
+		return x.Universe
+	}
+	return 0
+}
+
+func (x *UniverseStats) GetPacketRate() float64 {
+	if x != nil {
+		return x.PacketRate
+	}
+	return 0
+}
+
+func (x *UniverseStats) GetByteRate() float64 {
+	if x != nil {
+		return x.ByteRate
+	}
+	return 0
+}
+
+func (x *UniverseStats) GetLossPercentage() float64 {
+	if x != nil {
+		return x.LossPercentage
+	}
+	return 0
+}
+
+func (x *UniverseStats) GetRecentLossPercentage() float64 {
+	if x != nil {
+		return x.RecentLossPercentage
+	}
+	return 0
+}
+
+func (x *UniverseStats) GetHealth() uint32 {
+	if x != nil {
+		return x.Health
+	}
+	return 0
+}
+
+func (x *UniverseStats) GetSources() []*Source {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+// Stats are network totals and per-universe statistics
+type Stats struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Time                 *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Universes            uint32                 `protobuf:"varint,2,opt,name=universes,proto3" json:"universes,omitempty"`
+	Sources              uint32                 `protobuf:"varint,3,opt,name=sources,proto3" json:"sources,omitempty"`
+	PacketRate           float64                `protobuf:"fixed64,4,opt,name=packet_rate,json=packetRate,proto3" json:"packet_rate,omitempty"`
+	ByteRate             float64                `protobuf:"fixed64,5,opt,name=byte_rate,json=byteRate,proto3" json:"byte_rate,omitempty"`
+	Packets              uint64                 `protobuf:"varint,6,opt,name=packets,proto3" json:"packets,omitempty"`
+	LostPackets          uint64                 `protobuf:"varint,7,opt,name=lost_packets,json=lostPackets,proto3" json:"lost_packets,omitempty"`
+	RecentLossPercentage float64                `protobuf:"fixed64,8,opt,name=recent_loss_percentage,json=recentLossPercentage,proto3" json:"recent_loss_percentage,omitempty"`
+	ReceiverDrops        uint64                 `protobuf:"varint,9,opt,name=receiver_drops,json=receiverDrops,proto3" json:"receiver_drops,omitempty"`
+	UniverseStats        []*UniverseStats       `protobuf:"bytes,10,rep,name=universe_stats,json=universeStats,proto3" json:"universe_stats,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *Stats) Reset() {
+	*x = Stats{}
+	mi := &file_sacnmonitor_v1_monitor_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Stats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Stats) ProtoMessage() {}
+
+func (x *Stats) ProtoReflect() protoreflect.Message {
+	mi := &file_sacnmonitor_v1_monitor_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Stats.ProtoReflect.Descriptor instead.
+func (*Stats) Descriptor() ([]byte, []int) {
+	return file_sacnmonitor_v1_monitor_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Stats) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *Stats) GetUniverses() uint32 {
+	if x != nil {
+		return x.Universes
+	}
+	return 0
+}
+
+func (x *Stats) GetSources() uint32 {
+	if x != nil {
+		return x.Sources
+	}
+	return 0
+}
+
+func (x *Stats) GetPacketRate() float64 {
+	if x != nil {
+		return x.PacketRate
+	}
+	return 0
+}
+
+func (x *Stats) GetByteRate() float64 {
+	if x != nil {
+		return x.ByteRate
+	}
+	return 0
+}
+
+func (x *Stats) GetPackets() uint64 {
+	if x != nil {
+		return x.Packets
+	}
+	return 0
+}
+
+func (x *Stats) GetLostPackets() uint64 {
+	if x != nil {
+		return x.LostPackets
+	}
+	return 0
+}
+
+func (x *Stats) GetRecentLossPercentage() float64 {
+	if x != nil {
+		return x.RecentLossPercentage
+	}
+	return 0
+}
+
+func (x *Stats) GetReceiverDrops() uint64 {
+	if x != nil {
+		return x.ReceiverDrops
+	}
+	return 0
+}
+
+func (x *Stats) GetUniverseStats() []*UniverseStats {
+	if x != nil {
+		return x.UniverseStats
+	}
+	return nil
+}
+
+// Packet is the header of a received data packet
+type Packet struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Universe      uint32                 `protobuf:"varint,2,opt,name=universe,proto3" json:"universe,omitempty"`
+	SourceCid     string                 `protobuf:"bytes,3,opt,name=source_cid,json=sourceCid,proto3" json:"source_cid,omitempty"`
+	SourceName    string                 `protobuf:"bytes,4,opt,name=source_name,json=sourceName,proto3" json:"source_name,omitempty"`
+	SourceAddress string                 `protobuf:"bytes,5,opt,name=source_address,json=sourceAddress,proto3" json:"source_address,omitempty"`
+	Transport     string                 `protobuf:"bytes,6,opt,name=transport,proto3" json:"transport,omitempty"`
+	Sequence      uint32                 `protobuf:"varint,7,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Priority      uint32                 `protobuf:"varint,8,opt,name=priority,proto3" json:"priority,omitempty"`
+	StartCode     uint32                 `protobuf:"varint,9,opt,name=start_code,json=startCode,proto3" json:"start_code,omitempty"`
+	Slots         uint32                 `protobuf:"varint,10,opt,name=slots,proto3" json:"slots,omitempty"`
+	Arrival       Packet_Arrival         `protobuf:"varint,11,opt,name=arrival,proto3,enum=sacnmonitor.v1.Packet_Arrival" json:"arrival,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Packet) Reset() {
+	*x = Packet{}
+	mi := &file_sacnmonitor_v1_monitor_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Packet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Packet) ProtoMessage() {}
+
+func (x *Packet) ProtoReflect() protoreflect.Message {
+	mi := &file_sacnmonitor_v1_monitor_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Packet.ProtoReflect.Descriptor instead.
+func (*Packet) Descriptor() ([]byte, []int) {
+	return file_sacnmonitor_v1_monitor_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Packet) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *Packet) GetUniverse() uint32 {
+	if x != nil {
+		return x.Universe
+	}
+	return 0
+}
+
+func (x *Packet) GetSourceCid() string {
+	if x != nil {
+		return x.SourceCid
+	}
+	return ""
+}
+
+func (x *Packet) GetSourceName() string {
+	if x != nil {
+		return x.SourceName
+	}
+	return ""
+}
+
+func (x *Packet) GetSourceAddress() string {
+	if x != nil {
+		return x.SourceAddress
+	}
+	return ""
+}
+
+func (x *Packet) GetTransport() string {
+	if x != nil {
+		return x.Transport
+	}
+	return ""
+}
+
+func (x *Packet) GetSequence() uint32 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *Packet) GetPriority() uint32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *Packet) GetStartCode() uint32 {
+	if x != nil {
+		return x.StartCode
+	}
+	return 0
+}
+
+func (x *Packet) GetSlots() uint32 {
+	if x != nil {
+		return x.Slots
+	}
+	return 0
+}
+
+func (x *Packet) GetArrival() Packet_Arrival {
+	if x != nil {
+		return x.Arrival
+	}
+	return Packet_ARRIVAL_UNSPECIFIED
+}
+
+// ChannelUpdate is a channel's value, sent on subscribing and when it changes
+type ChannelUpdate struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Time     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Universe uint32                 `protobuf:"varint,2,opt,name=universe,proto3" json:"universe,omitempty"`
+	// 1-based channel number
+	Channel uint32 `protobuf:"varint,3,opt,name=channel,proto3" json:"channel,omitempty"`
+	Value   uint32 `protobuf:"varint,4,opt,name=value,proto3" json:"value,omitempty"`
+	// Value of the previous update; unset on the first update
+	Previous      *uint32 `protobuf:"varint,5,opt,name=previous,proto3,oneof" json:"previous,omitempty"`
+	SourceCid     string  `protobuf:"bytes,6,opt,name=source_cid,json=sourceCid,proto3" json:"source_cid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChannelUpdate) Reset() {
+	*x = ChannelUpdate{}
+	mi := &file_sacnmonitor_v1_monitor_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChannelUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelUpdate) ProtoMessage() {}
+
+func (x *ChannelUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_sacnmonitor_v1_monitor_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelUpdate.ProtoReflect.Descriptor instead.
+func (*ChannelUpdate) Descriptor() ([]byte, []int) {
+	return file_sacnmonitor_v1_monitor_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ChannelUpdate) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *ChannelUpdate) GetUniverse() uint32 {
+	if x != nil {
+		return x.Universe
+	}
+	return 0
+}
+
+func (x *ChannelUpdate) GetChannel() uint32 {
+	if x != nil {
+		return x.Channel
+	}
+	return 0
+}
+
+func (x *ChannelUpdate) GetValue() uint32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *ChannelUpdate) GetPrevious() uint32 {
+	if x != nil && x.Previous != nil {
+		return *x.Previous
+	}
+	return 0
+}
+
+func (x *ChannelUpdate) GetSourceCid() string {
+	if x != nil {
+		return x.SourceCid
+	}
+	return ""
+}
+
+// Event is a significant occurrence: a monitor event such as a source
+// going online or offline, or a detected sequence gap (kind "loss")
+type Event struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Time     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Kind     string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Universe uint32                 `protobuf:"varint,3,opt,name=universe,proto3" json:"universe,omitempty"`
+	Source   string                 `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	Message  string                 `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	// Packets missing, for losses
+	Lost          uint32 `protobuf:"varint,6,opt,name=lost,proto3" json:"lost,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_sacnmonitor_v1_monitor_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_sacnmonitor_v1_monitor_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_sacnmonitor_v1_monitor_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Event) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *Event) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Event) GetUniverse() uint32 {
+	if x != nil {
+		return x.Universe
+	}
+	return 0
+}
+
+func (x *Event) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Event) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Event) GetLost() uint32 {
+	if x != nil {
+		return x.Lost
+	}
+	return 0
+}
+
+var File_sacnmonitor_v1_monitor_proto protoreflect.FileDescriptor
+
+const file_sacnmonitor_v1_monitor_proto_rawDesc = "" +
+	"\n" +
+	"\x1csacnmonitor/v1/monitor.proto\x12\x0esacnmonitor.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x16\n" +
+	"\x14ListUniversesRequest\"O\n" +
+	"\x15ListUniversesResponse\x126\n" +
+	"\tuniverses\x18\x01 \x03(\v2\x18.sacnmonitor.v1.UniverseR\tuniverses\"0\n" +
+	"\x12GetUniverseRequest\x12\x1a\n" +
+	"\buniverse\x18\x01 \x01(\rR\buniverse\"/\n" +
+	"\x0fGetStatsRequest\x12\x1c\n" +
+	"\tuniverses\x18\x01 \x03(\rR\tuniverses\"7\n" +
+	"\x17SubscribePacketsRequest\x12\x1c\n" +
+	"\tuniverses\x18\x01 \x03(\rR\tuniverses\"V\n" +
+	"\x18SubscribeChannelsRequest\x12\x1c\n" +
+	"\tuniverses\x18\x01 \x03(\rR\tuniverses\x12\x1c\n" +
+	"\tthreshold\x18\x02 \x01(\rR\tthreshold\"V\n" +
+	"\x15SubscribeStatsRequest\x12\x1c\n" +
+	"\tuniverses\x18\x01 \x03(\rR\tuniverses\x12\x1f\n" +
+	"\vinterval_ms\x18\x02 \x01(\rR\n" +
+	"intervalMs\"\x18\n" +
+	"\x16SubscribeEventsRequest\"\xa2\x02\n" +
+	"\bUniverse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1f\n" +
+	"\vsource_name\x18\x03 \x01(\tR\n" +
+	"sourceName\x12\x1d\n" +
+	"\n" +
+	"source_cid\x18\x04 \x01(\tR\tsourceCid\x12\x1a\n" +
+	"\bpriority\x18\x05 \x01(\rR\bpriority\x12;\n" +
+	"\vlast_packet\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastPacket\x12\x14\n" +
+	"\x05stale\x18\a \x01(\bR\x05stale\x12'\n" +
+	"\x0factive_channels\x18\b \x01(\rR\x0eactiveChannels\x12\x1a\n" +
+	"\bchannels\x18\t \x03(\x05R\bchannels\"\xf8\x01\n" +
+	"\x06Source\x12\x10\n" +
+	"\x03cid\x18\x01 \x01(\tR\x03cid\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\aaddress\x18\x03 \x01(\tR\aaddress\x12\x1c\n" +
+	"\ttransport\x18\x04 \x01(\tR\ttransport\x12\x1a\n" +
+	"\bpriority\x18\x05 \x01(\rR\bpriority\x12\x18\n" +
+	"\apackets\x18\x06 \x01(\x04R\apackets\x12!\n" +
+	"\flost_packets\x18\a \x01(\x04R\vlostPackets\x127\n" +
+	"\tlast_seen\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\blastSeen\"\x92\x02\n" +
+	"\rUniverseStats\x12\x1a\n" +
+	"\buniverse\x18\x01 \x01(\rR\buniverse\x12\x1f\n" +
+	"\vpacket_rate\x18\x02 \x01(\x01R\n" +
+	"packetRate\x12\x1b\n" +
+	"\tbyte_rate\x18\x03 \x01(\x01R\bbyteRate\x12'\n" +
+	"\x0floss_percentage\x18\x04 \x01(\x01R\x0elossPercentage\x124\n" +
+	"\x16recent_loss_percentage\x18\x05 \x01(\x01R\x14recentLossPercentage\x12\x16\n" +
+	"\x06health\x18\x06 \x01(\rR\x06health\x120\n" +
+	"\asources\x18\a \x03(\v2\x16.sacnmonitor.v1.SourceR\asources\"\x8d\x03\n" +
+	"\x05Stats\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x1c\n" +
+	"\tuniverses\x18\x02 \x01(\rR\tuniverses\x12\x18\n" +
+	"\asources\x18\x03 \x01(\rR\asources\x12\x1f\n" +
+	"\vpacket_rate\x18\x04 \x01(\x01R\n" +
+	"packetRate\x12\x1b\n" +
+	"\tbyte_rate\x18\x05 \x01(\x01R\bbyteRate\x12\x18\n" +
+	"\apackets\x18\x06 \x01(\x04R\apackets\x12!\n" +
+	"\flost_packets\x18\a \x01(\x04R\vlostPackets\x124\n" +
+	"\x16recent_loss_percentage\x18\b \x01(\x01R\x14recentLossPercentage\x12%\n" +
+	"\x0ereceiver_drops\x18\t \x01(\x04R\rreceiverDrops\x12D\n" +
+	"\x0euniverse_stats\x18\n" +
+	" \x03(\v2\x1d.sacnmonitor.v1.UniverseStatsR\runiverseStats\"\xeb\x03\n" +
+	"\x06Packet\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x1a\n" +
+	"\buniverse\x18\x02 \x01(\rR\buniverse\x12\x1d\n" +
+	"\n" +
+	"source_cid\x18\x03 \x01(\tR\tsourceCid\x12\x1f\n" +
+	"\vsource_name\x18\x04 \x01(\tR\n" +
+	"sourceName\x12%\n" +
+	"\x0esource_address\x18\x05 \x01(\tR\rsourceAddress\x12\x1c\n" +
+	"\ttransport\x18\x06 \x01(\tR\ttransport\x12\x1a\n" +
+	"\bsequence\x18\a \x01(\rR\bsequence\x12\x1a\n" +
+	"\bpriority\x18\b \x01(\rR\bpriority\x12\x1d\n" +
+	"\n" +
+	"start_code\x18\t \x01(\rR\tstartCode\x12\x14\n" +
+	"\x05slots\x18\n" +
+	" \x01(\rR\x05slots\x128\n" +
+	"\aarrival\x18\v \x01(\x0e2\x1e.sacnmonitor.v1.Packet.ArrivalR\aarrival\"i\n" +
+	"\aArrival\x12\x17\n" +
+	"\x13ARRIVAL_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10ARRIVAL_IN_ORDER\x10\x01\x12\x18\n" +
+	"\x14ARRIVAL_OUT_OF_ORDER\x10\x02\x12\x15\n" +
+	"\x11ARRIVAL_DUPLICATE\x10\x03\"\xd8\x01\n" +
+	"\rChannelUpdate\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x1a\n" +
+	"\buniverse\x18\x02 \x01(\rR\buniverse\x12\x18\n" +
+	"\achannel\x18\x03 \x01(\rR\achannel\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\rR\x05value\x12\x1f\n" +
+	"\bprevious\x18\x05 \x01(\rH\x00R\bprevious\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"source_cid\x18\x06 \x01(\tR\tsourceCidB\v\n" +
+	"\t_previous\"\xad\x01\n" +
+	"\x05Event\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x1a\n" +
+	"\buniverse\x18\x03 \x01(\rR\buniverse\x12\x16\n" +
+	"\x06source\x18\x04 \x01(\tR\x06source\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\x12\x12\n" +
+	"\x04lost\x18\x06 \x01(\rR\x04lost2\xd5\x04\n" +
+	"\aMonitor\x12\\\n" +
+	"\rListUniverses\x12$.sacnmonitor.v1.ListUniversesRequest\x1a%.sacnmonitor.v1.ListUniversesResponse\x12K\n" +
+	"\vGetUniverse\x12\".sacnmonitor.v1.GetUniverseRequest\x1a\x18.sacnmonitor.v1.Universe\x12B\n" +
+	"\bGetStats\x12\x1f.sacnmonitor.v1.GetStatsRequest\x1a\x15.sacnmonitor.v1.Stats\x12U\n" +
+	"\x10SubscribePackets\x12'.sacnmonitor.v1.SubscribePacketsRequest\x1a\x16.sacnmonitor.v1.Packet0\x01\x12^\n" +
+	"\x11SubscribeChannels\x12(.sacnmonitor.v1.SubscribeChannelsRequest\x1a\x1d.sacnmonitor.v1.ChannelUpdate0\x01\x12P\n" +
+	"\x0eSubscribeStats\x12%.sacnmonitor.v1.SubscribeStatsRequest\x1a\x15.sacnmonitor.v1.Stats0\x01\x12R\n" +
+	"\x0fSubscribeEvents\x12&.sacnmonitor.v1.SubscribeEventsRequest\x1a\x15.sacnmonitor.v1.Event0\x01B%Z#sacn-monitor/internal/api/monitorpbb\x06proto3"
+
+var (
+	file_sacnmonitor_v1_monitor_proto_rawDescOnce sync.Once
+	file_sacnmonitor_v1_monitor_proto_rawDescData []byte
+)
+
+func file_sacnmonitor_v1_monitor_proto_rawDescGZIP() []byte {
+	file_sacnmonitor_v1_monitor_proto_rawDescOnce.Do(func() {
+		file_sacnmonitor_v1_monitor_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_sacnmonitor_v1_monitor_proto_rawDesc), len(file_sacnmonitor_v1_monitor_proto_rawDesc)))
+	})
+	return file_sacnmonitor_v1_monitor_proto_rawDescData
+}
+
+var file_sacnmonitor_v1_monitor_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_sacnmonitor_v1_monitor_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_sacnmonitor_v1_monitor_proto_goTypes = []any{
+	(Packet_Arrival)(0),              // 0: sacnmonitor.v1.Packet.Arrival
+	(*ListUniversesRequest)(nil),     // 1: sacnmonitor.v1.ListUniversesRequest
+	(*ListUniversesResponse)(nil),    // 2: sacnmonitor.v1.ListUniversesResponse
+	(*GetUniverseRequest)(nil),       // 3: sacnmonitor.v1.GetUniverseRequest
+	(*GetStatsRequest)(nil),          // 4: sacnmonitor.v1.GetStatsRequest
+	(*SubscribePacketsRequest)(nil),  // 5: sacnmonitor.v1.SubscribePacketsRequest
+	(*SubscribeChannelsRequest)(nil), // 6: sacnmonitor.v1.SubscribeChannelsRequest
+	(*SubscribeStatsRequest)(nil),    // 7: sacnmonitor.v1.SubscribeStatsRequest
+	(*SubscribeEventsRequest)(nil),   // 8: sacnmonitor.v1.SubscribeEventsRequest
+	(*Universe)(nil),                 // 9: sacnmonitor.v1.Universe
+	(*Source)(nil),                   // 10: sacnmonitor.v1.Source
+	(*UniverseStats)(nil),            // 11: sacnmonitor.v1.UniverseStats
+	(*Stats)(nil),                    // 12: sacnmonitor.v1.Stats
+	(*Packet)(nil),                   // 13: sacnmonitor.v1.Packet
+	(*ChannelUpdate)(nil),            // 14: sacnmonitor.v1.ChannelUpdate
+	(*Event)(nil),                    // 15: sacnmonitor.v1.Event
+	(*timestamppb.Timestamp)(nil),    // 16: google.protobuf.Timestamp
+}
+var file_sacnmonitor_v1_monitor_proto_depIdxs = []int32{
+	9,  // 0: sacnmonitor.v1.ListUniversesResponse.universes:type_name -> sacnmonitor.v1.Universe
+	16, // 1: sacnmonitor.v1.Universe.last_packet:type_name -> google.protobuf.Timestamp
+	16, // 2: sacnmonitor.v1.Source.last_seen:type_name -> google.protobuf.Timestamp
+	10, // 3: sacnmonitor.v1.UniverseStats.sources:type_name -> sacnmonitor.v1.Source
+	16, // 4: sacnmonitor.v1.Stats.time:type_name -> google.protobuf.Timestamp
+	11, // 5: sacnmonitor.v1.Stats.universe_stats:type_name -> sacnmonitor.v1.UniverseStats
+	16, // 6: sacnmonitor.v1.Packet.time:type_name -> google.protobuf.Timestamp
+	0,  // 7: sacnmonitor.v1.Packet.arrival:type_name -> sacnmonitor.v1.Packet.Arrival
+	16, // 8: sacnmonitor.v1.ChannelUpdate.time:type_name -> google.protobuf.Timestamp
+	16, // 9: sacnmonitor.v1.Event.time:type_name -> google.protobuf.Timestamp
+	1,  // 10: sacnmonitor.v1.Monitor.ListUniverses:input_type -> sacnmonitor.v1.ListUniversesRequest
+	3,  // 11: sacnmonitor.v1.Monitor.GetUniverse:input_type -> sacnmonitor.v1.GetUniverseRequest
+	4,  // 12: sacnmonitor.v1.Monitor.GetStats:input_type -> sacnmonitor.v1.GetStatsRequest
+	5,  // 13: sacnmonitor.v1.Monitor.SubscribePackets:input_type -> sacnmonitor.v1.SubscribePacketsRequest
+	6,  // 14: sacnmonitor.v1.Monitor.SubscribeChannels:input_type -> sacnmonitor.v1.SubscribeChannelsRequest
+	7,  // 15: sacnmonitor.v1.Monitor.SubscribeStats:input_type -> sacnmonitor.v1.SubscribeStatsRequest
+	8,  // 16: sacnmonitor.v1.Monitor.SubscribeEvents:input_type -> sacnmonitor.v1.SubscribeEventsRequest
+	2,  // 17: sacnmonitor.v1.Monitor.ListUniverses:output_type -> sacnmonitor.v1.ListUniversesResponse
+	9,  // 18: sacnmonitor.v1.Monitor.GetUniverse:output_type -> sacnmonitor.v1.Universe
+	12, // 19: sacnmonitor.v1.Monitor.GetStats:output_type -> sacnmonitor.v1.Stats
+	13, // 20: sacnmonitor.v1.Monitor.SubscribePackets:output_type -> sacnmonitor.v1.Packet
+	14, // 21: sacnmonitor.v1.Monitor.SubscribeChannels:output_type -> sacnmonitor.v1.ChannelUpdate
+	12, // 22: sacnmonitor.v1.Monitor.SubscribeStats:output_type -> sacnmonitor.v1.Stats
+	15, // 23: sacnmonitor.v1.Monitor.SubscribeEvents:output_type -> sacnmonitor.v1.Event
+	17, // [17:24] is the sub-list for method output_type
+	10, // [10:17] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_sacnmonitor_v1_monitor_proto_init() }
+func file_sacnmonitor_v1_monitor_proto_init() {
+	if File_sacnmonitor_v1_monitor_proto != nil {
+		return
+	}
+	file_sacnmonitor_v1_monitor_proto_msgTypes[13].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_sacnmonitor_v1_monitor_proto_rawDesc), len(file_sacnmonitor_v1_monitor_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_sacnmonitor_v1_monitor_proto_goTypes,
+		DependencyIndexes: file_sacnmonitor_v1_monitor_proto_depIdxs,
+		EnumInfos:         file_sacnmonitor_v1_monitor_proto_enumTypes,
+		MessageInfos:      file_sacnmonitor_v1_monitor_proto_msgTypes,
+	}.Build()
+	File_sacnmonitor_v1_monitor_proto = out.File
+	file_sacnmonitor_v1_monitor_proto_goTypes = nil
+	file_sacnmonitor_v1_monitor_proto_depIdxs = nil
+}
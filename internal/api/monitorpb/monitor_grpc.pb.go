@@ -0,0 +1,384 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: sacnmonitor/v1/monitor.proto
+
+package monitorpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Monitor_ListUniverses_FullMethodName     = "/sacnmonitor.v1.Monitor/ListUniverses"
+	Monitor_GetUniverse_FullMethodName       = "/sacnmonitor.v1.Monitor/GetUniverse"
+	Monitor_GetStats_FullMethodName          = "/sacnmonitor.v1.Monitor/GetStats"
+	Monitor_SubscribePackets_FullMethodName  = "/sacnmonitor.v1.Monitor/SubscribePackets"
+	Monitor_SubscribeChannels_FullMethodName = "/sacnmonitor.v1.Monitor/SubscribeChannels"
+	Monitor_SubscribeStats_FullMethodName    = "/sacnmonitor.v1.Monitor/SubscribeStats"
+	Monitor_SubscribeEvents_FullMethodName   = "/sacnmonitor.v1.Monitor/SubscribeEvents"
+)
+
+// MonitorClient is the client API for Monitor service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Monitor exposes what sacn-monitor observes on the network: the state of
+// every universe, packet and loss statistics, and live streams of packets,
+// channel changes, statistics and events.
+type MonitorClient interface {
+	// ListUniverses returns every tracked universe, without channel values
+	ListUniverses(ctx context.Context, in *ListUniversesRequest, opts ...grpc.CallOption) (*ListUniversesResponse, error)
+	// GetUniverse returns one universe with its channel values
+	GetUniverse(ctx context.Context, in *GetUniverseRequest, opts ...grpc.CallOption) (*Universe, error)
+	// GetStats returns network totals and per-universe statistics
+	GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*Stats, error)
+	// SubscribePackets streams every received data packet's header
+	SubscribePackets(ctx context.Context, in *SubscribePacketsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Packet], error)
+	// SubscribeChannels streams each channel's current value, then its changes
+	SubscribeChannels(ctx context.Context, in *SubscribeChannelsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ChannelUpdate], error)
+	// SubscribeStats streams statistics at a fixed interval
+	SubscribeStats(ctx context.Context, in *SubscribeStatsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Stats], error)
+	// SubscribeEvents streams monitor events and detected sequence gaps
+	SubscribeEvents(ctx context.Context, in *SubscribeEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error)
+}
+
+type monitorClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMonitorClient(cc grpc.ClientConnInterface) MonitorClient {
+	return &monitorClient{cc}
+}
+
+func (c *monitorClient) ListUniverses(ctx context.Context, in *ListUniversesRequest, opts ...grpc.CallOption) (*ListUniversesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUniversesResponse)
+	err := c.cc.Invoke(ctx, Monitor_ListUniverses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *monitorClient) GetUniverse(ctx context.Context, in *GetUniverseRequest, opts ...grpc.CallOption) (*Universe, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Universe)
+	err := c.cc.Invoke(ctx, Monitor_GetUniverse_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *monitorClient) GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*Stats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Stats)
+	err := c.cc.Invoke(ctx, Monitor_GetStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *monitorClient) SubscribePackets(ctx context.Context, in *SubscribePacketsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Packet], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Monitor_ServiceDesc.Streams[0], Monitor_SubscribePackets_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribePacketsRequest, Packet]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Monitor_SubscribePacketsClient = grpc.ServerStreamingClient[Packet]
+
+func (c *monitorClient) SubscribeChannels(ctx context.Context, in *SubscribeChannelsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ChannelUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Monitor_ServiceDesc.Streams[1], Monitor_SubscribeChannels_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeChannelsRequest, ChannelUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Monitor_SubscribeChannelsClient = grpc.ServerStreamingClient[ChannelUpdate]
+
+func (c *monitorClient) SubscribeStats(ctx context.Context, in *SubscribeStatsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Stats], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Monitor_ServiceDesc.Streams[2], Monitor_SubscribeStats_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeStatsRequest, Stats]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Monitor_SubscribeStatsClient = grpc.ServerStreamingClient[Stats]
+
+func (c *monitorClient) SubscribeEvents(ctx context.Context, in *SubscribeEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Monitor_ServiceDesc.Streams[3], Monitor_SubscribeEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeEventsRequest, Event]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Monitor_SubscribeEventsClient = grpc.ServerStreamingClient[Event]
+
+// MonitorServer is the server API for Monitor service.
+// All implementations must embed UnimplementedMonitorServer
+// for forward compatibility.
+//
+// Monitor exposes what sacn-monitor observes on the network: the state of
+// every universe, packet and loss statistics, and live streams of packets,
+// channel changes, statistics and events.
+type MonitorServer interface {
+	// ListUniverses returns every tracked universe, without channel values
+	ListUniverses(context.Context, *ListUniversesRequest) (*ListUniversesResponse, error)
+	// GetUniverse returns one universe with its channel values
+	GetUniverse(context.Context, *GetUniverseRequest) (*Universe, error)
+	// GetStats returns network totals and per-universe statistics
+	GetStats(context.Context, *GetStatsRequest) (*Stats, error)
+	// SubscribePackets streams every received data packet's header
+	SubscribePackets(*SubscribePacketsRequest, grpc.ServerStreamingServer[Packet]) error
+	// SubscribeChannels streams each channel's current value, then its changes
+	SubscribeChannels(*SubscribeChannelsRequest, grpc.ServerStreamingServer[ChannelUpdate]) error
+	// SubscribeStats streams statistics at a fixed interval
+	SubscribeStats(*SubscribeStatsRequest, grpc.ServerStreamingServer[Stats]) error
+	// SubscribeEvents streams monitor events and detected sequence gaps
+	SubscribeEvents(*SubscribeEventsRequest, grpc.ServerStreamingServer[Event]) error
+	mustEmbedUnimplementedMonitorServer()
+}
+
+// UnimplementedMonitorServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMonitorServer struct{}
+
+func (UnimplementedMonitorServer) ListUniverses(context.Context, *ListUniversesRequest) (*ListUniversesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUniverses not implemented")
+}
+func (UnimplementedMonitorServer) GetUniverse(context.Context, *GetUniverseRequest) (*Universe, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUniverse not implemented")
+}
+func (UnimplementedMonitorServer) GetStats(context.Context, *GetStatsRequest) (*Stats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
+}
+func (UnimplementedMonitorServer) SubscribePackets(*SubscribePacketsRequest, grpc.ServerStreamingServer[Packet]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribePackets not implemented")
+}
+func (UnimplementedMonitorServer) SubscribeChannels(*SubscribeChannelsRequest, grpc.ServerStreamingServer[ChannelUpdate]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeChannels not implemented")
+}
+func (UnimplementedMonitorServer) SubscribeStats(*SubscribeStatsRequest, grpc.ServerStreamingServer[Stats]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeStats not implemented")
+}
+func (UnimplementedMonitorServer) SubscribeEvents(*SubscribeEventsRequest, grpc.ServerStreamingServer[Event]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeEvents not implemented")
+}
+func (UnimplementedMonitorServer) mustEmbedUnimplementedMonitorServer() {}
+func (UnimplementedMonitorServer) testEmbeddedByValue()                 {}
+
+// UnsafeMonitorServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MonitorServer will
+// result in compilation errors.
+type UnsafeMonitorServer interface {
+	mustEmbedUnimplementedMonitorServer()
+}
+
+func RegisterMonitorServer(s grpc.ServiceRegistrar, srv MonitorServer) {
+	// If the following call pancis, it indicates UnimplementedMonitorServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Monitor_ServiceDesc, srv)
+}
+
+func _Monitor_ListUniverses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUniversesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MonitorServer).ListUniverses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Monitor_ListUniverses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MonitorServer).ListUniverses(ctx, req.(*ListUniversesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Monitor_GetUniverse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUniverseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MonitorServer).GetUniverse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Monitor_GetUniverse_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MonitorServer).GetUniverse(ctx, req.(*GetUniverseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Monitor_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MonitorServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Monitor_GetStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MonitorServer).GetStats(ctx, req.(*GetStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Monitor_SubscribePackets_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribePacketsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MonitorServer).SubscribePackets(m, &grpc.GenericServerStream[SubscribePacketsRequest, Packet]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Monitor_SubscribePacketsServer = grpc.ServerStreamingServer[Packet]
+
+func _Monitor_SubscribeChannels_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeChannelsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MonitorServer).SubscribeChannels(m, &grpc.GenericServerStream[SubscribeChannelsRequest, ChannelUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Monitor_SubscribeChannelsServer = grpc.ServerStreamingServer[ChannelUpdate]
+
+func _Monitor_SubscribeStats_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeStatsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MonitorServer).SubscribeStats(m, &grpc.GenericServerStream[SubscribeStatsRequest, Stats]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Monitor_SubscribeStatsServer = grpc.ServerStreamingServer[Stats]
+
+func _Monitor_SubscribeEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MonitorServer).SubscribeEvents(m, &grpc.GenericServerStream[SubscribeEventsRequest, Event]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Monitor_SubscribeEventsServer = grpc.ServerStreamingServer[Event]
+
+// Monitor_ServiceDesc is the grpc.ServiceDesc for Monitor service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Monitor_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "sacnmonitor.v1.Monitor",
+	HandlerType: (*MonitorServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListUniverses",
+			Handler:    _Monitor_ListUniverses_Handler,
+		},
+		{
+			MethodName: "GetUniverse",
+			Handler:    _Monitor_GetUniverse_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _Monitor_GetStats_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribePackets",
+			Handler:       _Monitor_SubscribePackets_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeChannels",
+			Handler:       _Monitor_SubscribeChannels_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeStats",
+			Handler:       _Monitor_SubscribeStats_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeEvents",
+			Handler:       _Monitor_SubscribeEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "sacnmonitor/v1/monitor.proto",
+}
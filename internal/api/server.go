@@ -0,0 +1,206 @@
+// Package api serves the monitor's observations over gRPC, described by
+// proto/sacnmonitor/v1/monitor.proto, so tools in other languages can
+// query universes and statistics and subscribe to live streams
+package api
+
+//go:generate protoc -I ../../proto --go_out=../.. --go_opt=module=sacn-monitor --go-grpc_out=../.. --go-grpc_opt=module=sacn-monitor sacnmonitor/v1/monitor.proto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"sort"
+	"time"
+
+	"sacn-monitor/internal/api/monitorpb"
+	"sacn-monitor/internal/events"
+	"sacn-monitor/internal/stats"
+	"sacn-monitor/internal/universe"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// staleTimeout is how long a universe may be silent before it is reported
+// as stale
+const staleTimeout = time.Second
+
+// Server implements the Monitor gRPC service over the shared universe
+// manager and stats tracker
+type Server struct {
+	monitorpb.UnimplementedMonitorServer
+
+	manager  *universe.Manager
+	tracker  *stats.Tracker
+	log      *events.Log
+	listener net.Listener
+	grpc     *grpc.Server
+
+	packets packetSubscribers
+}
+
+// Listen starts accepting gRPC clients on addr (host:port). log may be nil
+// to leave monitor events out of the event stream.
+func Listen(addr string, manager *universe.Manager, tracker *stats.Tracker, log *events.Log) (*Server, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for gRPC clients on %s: %w", addr, err)
+	}
+
+	s := &Server{
+		manager:  manager,
+		tracker:  tracker,
+		log:      log,
+		listener: listener,
+		grpc:     grpc.NewServer(),
+	}
+	monitorpb.RegisterMonitorServer(s.grpc, s)
+	return s, nil
+}
+
+// Addr returns the address the server is listening on
+func (s *Server) Addr() net.Addr {
+	return s.listener.Addr()
+}
+
+// Serve accepts clients until the context is cancelled, which also ends
+// every subscription
+func (s *Server) Serve(ctx context.Context) error {
+	go func() {
+		<-ctx.Done()
+		s.grpc.Stop()
+	}()
+
+	if err := s.grpc.Serve(s.listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return fmt.Errorf("gRPC server failed: %w", err)
+	}
+	return nil
+}
+
+// Close stops the server and disconnects every client
+func (s *Server) Close() error {
+	s.grpc.Stop()
+	return nil
+}
+
+// ListUniverses returns every tracked universe, without channel values
+func (s *Server) ListUniverses(ctx context.Context, req *monitorpb.ListUniversesRequest) (*monitorpb.ListUniversesResponse, error) {
+	all := s.manager.GetAll()
+	sort.Slice(all, func(i, j int) bool { return all[i].ID < all[j].ID })
+
+	resp := &monitorpb.ListUniversesResponse{}
+	for _, u := range all {
+		resp.Universes = append(resp.Universes, s.universe(u, false))
+	}
+	return resp, nil
+}
+
+// GetUniverse returns one universe with its channel values
+func (s *Server) GetUniverse(ctx context.Context, req *monitorpb.GetUniverseRequest) (*monitorpb.Universe, error) {
+	if req.GetUniverse() > 0xFFFF {
+		return nil, status.Errorf(codes.InvalidArgument, "universe %d out of range", req.GetUniverse())
+	}
+	u := s.manager.Get(uint16(req.GetUniverse()))
+	if u == nil {
+		return nil, status.Errorf(codes.NotFound, "universe %d not seen", req.GetUniverse())
+	}
+	return s.universe(u, true), nil
+}
+
+// GetStats returns network totals and per-universe statistics
+func (s *Server) GetStats(ctx context.Context, req *monitorpb.GetStatsRequest) (*monitorpb.Stats, error) {
+	return s.stats(time.Now(), universeFilter(req.GetUniverses())), nil
+}
+
+// universe converts a universe, with its channel values if requested
+func (s *Server) universe(u *universe.Universe, withChannels bool) *monitorpb.Universe {
+	info := u.GetInfo()
+	pb := &monitorpb.Universe{
+		Id:             uint32(info.ID),
+		Name:           s.manager.Name(info.ID),
+		SourceName:     info.SourceName,
+		SourceCid:      fmt.Sprintf("%x", info.SourceCID),
+		Priority:       uint32(info.Priority),
+		Stale:          u.IsStale(staleTimeout),
+		ActiveChannels: uint32(u.ActiveChannelCount()),
+	}
+	if !info.LastPacket.IsZero() {
+		pb.LastPacket = timestamppb.New(info.LastPacket)
+	}
+	if withChannels {
+		channels := u.GetAllChannels()
+		pb.Channels = make([]int32, len(channels))
+		for i, ch := range channels {
+			pb.Channels[i] = -1
+			if ch.Active {
+				pb.Channels[i] = int32(ch.Value)
+			}
+		}
+	}
+	return pb
+}
+
+// stats builds the statistics of the universes keep accepts
+func (s *Server) stats(now time.Time, keep func(uint16) bool) *monitorpb.Stats {
+	var ids []uint16
+	for _, id := range s.tracker.GetAllUniverseIDs() {
+		if keep(id) {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+
+	agg := s.tracker.Aggregate(ids)
+	pb := &monitorpb.Stats{
+		Time:                 timestamppb.New(now),
+		Universes:            uint32(agg.Universes),
+		Sources:              uint32(agg.Sources),
+		PacketRate:           agg.PacketRate,
+		ByteRate:             agg.ByteRate,
+		Packets:              agg.PacketCount,
+		LostPackets:          agg.LostPackets,
+		RecentLossPercentage: agg.RecentLossPercentage(),
+		ReceiverDrops:        agg.ReceiverDrops,
+	}
+	for _, id := range ids {
+		us := &monitorpb.UniverseStats{
+			Universe:             uint32(id),
+			PacketRate:           s.tracker.GetPacketRate(id),
+			ByteRate:             s.tracker.GetByteRate(id),
+			LossPercentage:       s.tracker.GetLossPercentage(id),
+			RecentLossPercentage: s.tracker.GetRecentLossPercentage(id),
+			Health:               uint32(s.tracker.GetHealth(id).Score),
+		}
+		sources := s.tracker.GetSources(id)
+		sort.Slice(sources, func(i, j int) bool { return sources[i].Name < sources[j].Name })
+		for _, src := range sources {
+			us.Sources = append(us.Sources, &monitorpb.Source{
+				Cid:         fmt.Sprintf("%x", src.CID),
+				Name:        src.Name,
+				Address:     src.Address,
+				Transport:   src.Transport,
+				Priority:    uint32(src.Priority),
+				Packets:     src.PacketCount,
+				LostPackets: src.LostPackets,
+				LastSeen:    timestamppb.New(src.LastSeen),
+			})
+		}
+		pb.UniverseStats = append(pb.UniverseStats, us)
+	}
+	return pb
+}
+
+// universeFilter returns a filter accepting the listed universes, or all
+// universes if none are listed
+func universeFilter(universes []uint32) func(uint16) bool {
+	if len(universes) == 0 {
+		return func(uint16) bool { return true }
+	}
+	return func(id uint16) bool {
+		return slices.Contains(universes, uint32(id))
+	}
+}
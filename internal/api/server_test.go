@@ -0,0 +1,143 @@
+package api
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"sacn-monitor/internal/api/monitorpb"
+	"sacn-monitor/internal/sacn"
+	"sacn-monitor/internal/stats"
+	"sacn-monitor/internal/universe"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// startServer serves the manager and tracker and returns a client for it
+func startServer(t *testing.T, manager *universe.Manager, tracker *stats.Tracker) (*Server, monitorpb.MonitorClient, context.Context) {
+	t.Helper()
+	server, err := Listen("127.0.0.1:0", manager, tracker, nil)
+	if err != nil {
+		t.Fatalf("Listen() returned error: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	go server.Serve(ctx)
+
+	conn, err := grpc.NewClient(server.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("NewClient() returned error: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return server, monitorpb.NewMonitorClient(conn), ctx
+}
+
+func TestServer_Queries(t *testing.T) {
+	manager := universe.NewManager()
+	manager.SetName(1, "FOH")
+	manager.GetOrCreate(1).Update([]byte{10, 20}, "console", [16]byte{1}, 100, 0)
+	tracker := stats.NewTracker()
+	tracker.RecordPacket(1, [16]byte{1}, "console", 0)
+	_, client, ctx := startServer(t, manager, tracker)
+
+	list, err := client.ListUniverses(ctx, &monitorpb.ListUniversesRequest{})
+	if err != nil {
+		t.Fatalf("ListUniverses() returned error: %v", err)
+	}
+	if len(list.GetUniverses()) != 1 || list.GetUniverses()[0].GetName() != "FOH" || len(list.GetUniverses()[0].GetChannels()) != 0 {
+		t.Errorf("ListUniverses() = %v, want FOH without channels", list)
+	}
+
+	u, err := client.GetUniverse(ctx, &monitorpb.GetUniverseRequest{Universe: 1})
+	if err != nil {
+		t.Fatalf("GetUniverse() returned error: %v", err)
+	}
+	if ch := u.GetChannels(); len(ch) != 512 || ch[0] != 10 || ch[1] != 20 || ch[2] != -1 {
+		t.Errorf("GetUniverse() channels start %v, want [10 20 -1]", ch[:3])
+	}
+
+	if _, err := client.GetUniverse(ctx, &monitorpb.GetUniverseRequest{Universe: 2}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetUniverse(2) error = %v, want NotFound", err)
+	}
+
+	st, err := client.GetStats(ctx, &monitorpb.GetStatsRequest{})
+	if err != nil {
+		t.Fatalf("GetStats() returned error: %v", err)
+	}
+	if st.GetPackets() != 1 || len(st.GetUniverseStats()) != 1 || st.GetUniverseStats()[0].GetSources()[0].GetName() != "console" {
+		t.Errorf("GetStats() = %v, want 1 packet from console on one universe", st)
+	}
+}
+
+func TestServer_SubscribeChannels(t *testing.T) {
+	manager := universe.NewManager()
+	u := manager.GetOrCreate(1)
+	u.Update([]byte{10}, "console", [16]byte{1}, 100, 0)
+	_, client, ctx := startServer(t, manager, stats.NewTracker())
+
+	stream, err := client.SubscribeChannels(ctx, &monitorpb.SubscribeChannelsRequest{})
+	if err != nil {
+		t.Fatalf("SubscribeChannels() returned error: %v", err)
+	}
+	first, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Recv() returned error: %v", err)
+	}
+	if first.GetChannel() != 1 || first.GetValue() != 10 || first.Previous != nil {
+		t.Errorf("first update = %v, want channel 1 at 10 without previous", first)
+	}
+
+	u.Update([]byte{30}, "console", [16]byte{1}, 100, 1)
+	next, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Recv() returned error: %v", err)
+	}
+	if next.GetValue() != 30 || next.GetPrevious() != 10 {
+		t.Errorf("next update = %v, want 10→30", next)
+	}
+}
+
+func TestServer_SubscribePackets(t *testing.T) {
+	server, client, ctx := startServer(t, universe.NewManager(), stats.NewTracker())
+
+	stream, err := client.SubscribePackets(ctx, &monitorpb.SubscribePacketsRequest{Universes: []uint32{2}})
+	if err != nil {
+		t.Fatalf("SubscribePackets() returned error: %v", err)
+	}
+
+	// Publish until the subscription is registered and the packet arrives
+	received := make(chan *monitorpb.Packet, 1)
+	go func() {
+		p, err := stream.Recv()
+		if err == nil {
+			received <- p
+		}
+	}()
+	packet := &sacn.Packet{
+		Universe:   2,
+		SourceName: "console",
+		Sequence:   7,
+		SourceAddr: &net.UDPAddr{IP: net.IPv4(10, 0, 0, 1)},
+		ReceivedAt: time.Now(),
+	}
+	other := *packet
+	other.Universe = 1
+	for {
+		server.PublishPacket(&other, stats.InOrder)
+		server.PublishPacket(packet, stats.Duplicate)
+		select {
+		case p := <-received:
+			if p.GetUniverse() != 2 || p.GetSequence() != 7 || p.GetSourceAddress() != "10.0.0.1" || p.GetArrival() != monitorpb.Packet_ARRIVAL_DUPLICATE {
+				t.Errorf("packet = %v, want duplicate sequence 7 on universe 2 from 10.0.0.1", p)
+			}
+			return
+		case <-ctx.Done():
+			t.Fatal("no packet received")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
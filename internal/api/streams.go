@@ -0,0 +1,260 @@
+package api
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"sacn-monitor/internal/api/monitorpb"
+	"sacn-monitor/internal/events"
+	"sacn-monitor/internal/sacn"
+	"sacn-monitor/internal/stats"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Subscription defaults
+const (
+	// packetBuffer is how many packets a subscriber may fall behind before
+	// packets are dropped for it
+	packetBuffer = 1024
+	// channelInterval is how often channel changes are sent, fast enough to
+	// follow fades
+	channelInterval = 40 * time.Millisecond
+	// eventInterval is how often the event log is checked for new events
+	eventInterval = 250 * time.Millisecond
+	// defaultStatsInterval is the stats interval when a client asks for none
+	defaultStatsInterval = time.Second
+	// minStatsInterval keeps a client from asking for stats continuously
+	minStatsInterval = 100 * time.Millisecond
+)
+
+// packetSubscribers fans published packets out to SubscribePackets streams
+type packetSubscribers struct {
+	chans   map[chan *monitorpb.Packet]struct{}
+	dropped atomic.Uint64 // Packets dropped because a subscriber was full
+	mu      sync.RWMutex
+}
+
+// PublishPacket passes a received packet to the packet subscribers without
+// blocking packet processing: a subscriber that falls behind misses packets
+func (s *Server) PublishPacket(p *sacn.Packet, arrival stats.Arrival) {
+	s.packets.mu.RLock()
+	defer s.packets.mu.RUnlock()
+	if len(s.packets.chans) == 0 {
+		return
+	}
+
+	pb := &monitorpb.Packet{
+		Time:          timestamppb.New(p.ReceivedAt),
+		Universe:      uint32(p.Universe),
+		SourceCid:     fmt.Sprintf("%x", p.CID),
+		SourceName:    p.SourceName,
+		SourceAddress: p.SourceIP(),
+		Transport:     p.Transport(),
+		Sequence:      uint32(p.Sequence),
+		Priority:      uint32(p.Priority),
+		StartCode:     uint32(p.StartCode),
+		Slots:         uint32(p.ChannelCount()),
+		Arrival:       arrivalPB(arrival),
+	}
+	for ch := range s.packets.chans {
+		select {
+		case ch <- pb:
+		default:
+			s.packets.dropped.Add(1)
+		}
+	}
+}
+
+// DroppedPackets returns how many packets were dropped because a packet
+// subscriber was full
+func (s *Server) DroppedPackets() uint64 {
+	return s.packets.dropped.Load()
+}
+
+// SubscribePackets streams every received data packet's header
+func (s *Server) SubscribePackets(req *monitorpb.SubscribePacketsRequest, stream grpc.ServerStreamingServer[monitorpb.Packet]) error {
+	ch := make(chan *monitorpb.Packet, packetBuffer)
+	s.packets.mu.Lock()
+	if s.packets.chans == nil {
+		s.packets.chans = make(map[chan *monitorpb.Packet]struct{})
+	}
+	s.packets.chans[ch] = struct{}{}
+	s.packets.mu.Unlock()
+	defer func() {
+		s.packets.mu.Lock()
+		delete(s.packets.chans, ch)
+		s.packets.mu.Unlock()
+	}()
+
+	keep := universeFilter(req.GetUniverses())
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case p := <-ch:
+			if !keep(uint16(p.GetUniverse())) {
+				continue
+			}
+			if err := stream.Send(p); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// SubscribeChannels streams each channel's current value, then every change
+// of at least the requested threshold
+func (s *Server) SubscribeChannels(req *monitorpb.SubscribeChannelsRequest, stream grpc.ServerStreamingServer[monitorpb.ChannelUpdate]) error {
+	keep := universeFilter(req.GetUniverses())
+	threshold := int(max(req.GetThreshold(), 1))
+	sent := make(map[uint16]*[512]int16) // Last value sent per channel, -1 = none
+
+	ticker := time.NewTicker(channelInterval)
+	defer ticker.Stop()
+	for now := time.Now(); ; {
+		for _, u := range s.manager.GetAll() {
+			if !keep(u.ID) {
+				continue
+			}
+			last, exists := sent[u.ID]
+			if !exists {
+				last = new([512]int16)
+				for i := range last {
+					last[i] = -1
+				}
+				sent[u.ID] = last
+			}
+			for i, ch := range u.GetAllChannels() {
+				value, previous := int(ch.Value), int(last[i])
+				if !ch.Active || (previous >= 0 && abs(value-previous) < threshold) {
+					continue
+				}
+				last[i] = int16(value)
+				update := &monitorpb.ChannelUpdate{
+					Time:      timestamppb.New(now),
+					Universe:  uint32(u.ID),
+					Channel:   uint32(i + 1),
+					Value:     uint32(value),
+					SourceCid: fmt.Sprintf("%x", ch.SourceCID),
+				}
+				if previous >= 0 {
+					update.Previous = proto.Uint32(uint32(previous))
+				}
+				if err := stream.Send(update); err != nil {
+					return err
+				}
+			}
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case now = <-ticker.C:
+		}
+	}
+}
+
+// SubscribeStats streams statistics at the requested interval, starting
+// immediately
+func (s *Server) SubscribeStats(req *monitorpb.SubscribeStatsRequest, stream grpc.ServerStreamingServer[monitorpb.Stats]) error {
+	keep := universeFilter(req.GetUniverses())
+	interval := defaultStatsInterval
+	if req.GetIntervalMs() > 0 {
+		interval = max(time.Duration(req.GetIntervalMs())*time.Millisecond, minStatsInterval)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for now := time.Now(); ; {
+		if err := stream.Send(s.stats(now, keep)); err != nil {
+			return err
+		}
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case now = <-ticker.C:
+		}
+	}
+}
+
+// SubscribeEvents streams monitor events recorded after subscribing, and
+// each detected sequence gap as a "loss" event
+func (s *Server) SubscribeEvents(req *monitorpb.SubscribeEventsRequest, stream grpc.ServerStreamingServer[monitorpb.Event]) error {
+	notifications, unsubscribe := s.tracker.Subscribe(packetBuffer)
+	defer unsubscribe()
+
+	// Only events from now on; asking past the end returns the count
+	var seen uint64
+	if s.log != nil {
+		_, seen = s.log.Since(^uint64(0))
+	}
+
+	ticker := time.NewTicker(eventInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case n := <-notifications:
+			if n.Kind != stats.LossDetected {
+				continue
+			}
+			err := stream.Send(&monitorpb.Event{
+				Time:     timestamppb.New(n.Time),
+				Kind:     "loss",
+				Universe: uint32(n.Universe),
+				Source:   n.Source,
+				Message:  fmt.Sprintf("%d packets lost (sequence %d-%d)", n.Loss.Lost, n.Loss.FirstMissing, n.Loss.LastMissing),
+				Lost:     uint32(n.Loss.Lost),
+			})
+			if err != nil {
+				return err
+			}
+		case <-ticker.C:
+			if s.log == nil {
+				continue
+			}
+			var fresh []events.Event
+			fresh, seen = s.log.Since(seen)
+			for _, e := range fresh {
+				err := stream.Send(&monitorpb.Event{
+					Time:     timestamppb.New(e.Time),
+					Kind:     string(e.Kind),
+					Universe: uint32(e.Universe),
+					Source:   e.Source,
+					Message:  e.Message,
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// arrivalPB converts a packet's arrival classification
+func arrivalPB(a stats.Arrival) monitorpb.Packet_Arrival {
+	switch a {
+	case stats.InOrder:
+		return monitorpb.Packet_ARRIVAL_IN_ORDER
+	case stats.OutOfOrder:
+		return monitorpb.Packet_ARRIVAL_OUT_OF_ORDER
+	case stats.Duplicate:
+		return monitorpb.Packet_ARRIVAL_DUPLICATE
+	default:
+		return monitorpb.Packet_ARRIVAL_UNSPECIFIED
+	}
+}
+
+// abs returns the absolute value of n
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,230 @@
+package artnet
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"sacn-monitor/internal/universe"
+)
+
+// Bridge defaults
+const (
+	// bridgeInterval is how often universes are checked for changes,
+	// within Art-Net's 44 packets per second per universe
+	bridgeInterval = 25 * time.Millisecond
+	// bridgeKeepAlive resends unchanged universes; Art-Net nodes expect
+	// data at least every 4 s
+	bridgeKeepAlive = time.Second
+)
+
+// Mapping assigns sACN universes to Art-Net port-addresses
+type Mapping struct {
+	ports map[uint16]uint16 // sACN universe -> port-address, nil = default for all
+}
+
+// ParseMapping parses a comma-separated list of sACN universes to bridge:
+// "N" or "A-B" send to port-address universe-1 (sACN 1 is Art-Net 0), and
+// "N=P" or "A-B=P" to consecutive port-addresses from P. An empty spec
+// bridges every universe with the default offset.
+func ParseMapping(spec string) (Mapping, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return Mapping{}, nil
+	}
+
+	ports := make(map[uint16]uint16)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		universes, target, explicit := strings.Cut(entry, "=")
+
+		first, last, err := parseRange(universes)
+		if err != nil {
+			return Mapping{}, fmt.Errorf("invalid Art-Net mapping %q: %w", entry, err)
+		}
+		start := int(first) - 1
+		if explicit {
+			p, err := strconv.ParseUint(strings.TrimSpace(target), 10, 16)
+			if err != nil {
+				return Mapping{}, fmt.Errorf("invalid Art-Net mapping %q: bad port-address %q", entry, target)
+			}
+			start = int(p)
+		}
+		if start < 0 || start+int(last-first) > MaxPortAddress {
+			return Mapping{}, fmt.Errorf("invalid Art-Net mapping %q: port-addresses must be 0-%d", entry, MaxPortAddress)
+		}
+		for u := int(first); u <= int(last); u++ {
+			ports[uint16(u)] = uint16(start + u - int(first))
+		}
+	}
+	return Mapping{ports: ports}, nil
+}
+
+// parseRange parses "N" or "A-B" as sACN universes
+func parseRange(s string) (uint16, uint16, error) {
+	from, to, isRange := strings.Cut(strings.TrimSpace(s), "-")
+	first, err := strconv.ParseUint(strings.TrimSpace(from), 10, 16)
+	if err != nil || first == 0 {
+		return 0, 0, fmt.Errorf("bad universe %q", from)
+	}
+	last := first
+	if isRange {
+		last, err = strconv.ParseUint(strings.TrimSpace(to), 10, 16)
+		if err != nil || last < first {
+			return 0, 0, fmt.Errorf("bad universe range %q", s)
+		}
+	}
+	return uint16(first), uint16(last), nil
+}
+
+// PortAddress returns the Art-Net port-address a universe is bridged to,
+// and false if it isn't bridged
+func (m Mapping) PortAddress(universeID uint16) (uint16, bool) {
+	if m.ports == nil {
+		if universeID == 0 || universeID-1 > MaxPortAddress {
+			return 0, false
+		}
+		return universeID - 1, true
+	}
+	p, ok := m.ports[universeID]
+	return p, ok
+}
+
+// String describes the mapping, e.g. "1-4→0-3" or "all"
+func (m Mapping) String() string {
+	if m.ports == nil {
+		return "all"
+	}
+	ids := make([]uint16, 0, len(m.ports))
+	for id := range m.ports {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	// Collapse runs of consecutive universes on consecutive ports
+	var parts []string
+	for i := 0; i < len(ids); {
+		j := i
+		for j+1 < len(ids) && ids[j+1] == ids[j]+1 && m.ports[ids[j+1]] == m.ports[ids[j]]+1 {
+			j++
+		}
+		if i == j {
+			parts = append(parts, fmt.Sprintf("%d→%d", ids[i], m.ports[ids[i]]))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d-%d→%d-%d", ids[i], ids[j], m.ports[ids[i]], m.ports[ids[j]]))
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ",")
+}
+
+// sent is what was last sent for a universe
+type sent struct {
+	levels   [512]byte
+	at       time.Time
+	sequence uint8
+}
+
+// Bridge re-transmits the current levels of mapped universes, as the
+// monitor holds them after sync and merging, as ArtDmx to a node or a
+// broadcast address. A universe is sent when it changes and at least once
+// a second; universes not seen yet aren't sent.
+type Bridge struct {
+	manager *universe.Manager
+	mapping Mapping
+	conn    net.Conn
+	last    map[uint16]*sent
+}
+
+// Dial prepares a bridge sending to target, a host or host:port (default
+// port 6454), e.g. 2.255.255.255 to broadcast on the primary Art-Net range
+func Dial(target string, manager *universe.Manager, mapping Mapping) (*Bridge, error) {
+	if _, _, err := net.SplitHostPort(target); err != nil {
+		target = net.JoinHostPort(target, strconv.Itoa(Port))
+	}
+	conn, err := net.Dial("udp4", target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open Art-Net output to %s: %w", target, err)
+	}
+	return NewBridge(conn, manager, mapping), nil
+}
+
+// NewBridge creates a bridge writing to an established connection
+func NewBridge(conn net.Conn, manager *universe.Manager, mapping Mapping) *Bridge {
+	return &Bridge{
+		manager: manager,
+		mapping: mapping,
+		conn:    conn,
+		last:    make(map[uint16]*sent),
+	}
+}
+
+// Run sends until the context is cancelled. Failed sends are reported to
+// onError once until sending works again.
+func (b *Bridge) Run(ctx context.Context, onError func(error)) {
+	ticker := time.NewTicker(bridgeInterval)
+	defer ticker.Stop()
+
+	var failed error
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			err := b.Send(now)
+			if err != nil && failed == nil && onError != nil {
+				onError(err)
+			}
+			failed = err
+		}
+	}
+}
+
+// Send transmits every mapped universe that changed since it was last
+// sent or is due for a keepalive
+func (b *Bridge) Send(now time.Time) error {
+	var firstErr error
+	for _, u := range b.manager.GetAll() {
+		port, ok := b.mapping.PortAddress(u.ID)
+		if !ok {
+			continue
+		}
+
+		var levels [512]byte
+		for i, ch := range u.GetAllChannels() {
+			levels[i] = ch.Value
+		}
+		s := b.last[u.ID]
+		if s == nil {
+			s = &sent{}
+			b.last[u.ID] = s
+		} else if bytes.Equal(levels[:], s.levels[:]) && now.Sub(s.at) < bridgeKeepAlive {
+			continue
+		}
+
+		// Sequence runs 1-255; 0 would disable reordering at the node
+		s.sequence = s.sequence%255 + 1
+		packet, err := ArtDmx(port, s.sequence, levels[:])
+		if err == nil {
+			_, err = b.conn.Write(packet)
+		}
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("Art-Net send failed: %w", err)
+			}
+			continue
+		}
+		s.levels, s.at = levels, now
+	}
+	return firstErr
+}
+
+// Close closes the connection
+func (b *Bridge) Close() error {
+	return b.conn.Close()
+}
@@ -0,0 +1,89 @@
+package artnet
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"sacn-monitor/internal/universe"
+)
+
+func TestParseMapping(t *testing.T) {
+	m, err := ParseMapping("1-3, 10=100, 20-21=0")
+	if err != nil {
+		t.Fatalf("ParseMapping() returned error: %v", err)
+	}
+	for universeID, want := range map[uint16]uint16{1: 0, 3: 2, 10: 100, 20: 0, 21: 1} {
+		if got, ok := m.PortAddress(universeID); !ok || got != want {
+			t.Errorf("PortAddress(%d) = %d, %t, want %d", universeID, got, ok, want)
+		}
+	}
+	if _, ok := m.PortAddress(4); ok {
+		t.Error("PortAddress(4) is bridged, want only the listed universes")
+	}
+	if s := m.String(); s != "1-3→0-2,10→100,20-21→0-1" {
+		t.Errorf("String() = %q", s)
+	}
+
+	all, _ := ParseMapping("")
+	if got, ok := all.PortAddress(5); !ok || got != 4 {
+		t.Errorf("default PortAddress(5) = %d, %t, want 4", got, ok)
+	}
+
+	for _, bad := range []string{"0", "5-3", "x", "1=99999", "32769"} {
+		if _, err := ParseMapping(bad); err == nil {
+			t.Errorf("ParseMapping(%q) expected error, got nil", bad)
+		}
+	}
+}
+
+func TestBridge_Send(t *testing.T) {
+	manager := universe.NewManager()
+	u := manager.GetOrCreate(2)
+	u.Update([]byte{50}, "console", [16]byte{1}, 100, 0)
+	manager.GetOrCreate(9).Update([]byte{1}, "console", [16]byte{1}, 100, 0) // Not mapped
+
+	mapping, _ := ParseMapping("1-4")
+	client, server := net.Pipe()
+	defer server.Close()
+	b := NewBridge(client, manager, mapping)
+
+	received := make(chan []byte, 10)
+	go func() {
+		buf := make([]byte, 1024)
+		for {
+			n, err := server.Read(buf)
+			if err != nil {
+				return
+			}
+			received <- append([]byte(nil), buf[:n]...)
+		}
+	}()
+
+	now := time.Now()
+	if err := b.Send(now); err != nil {
+		t.Fatalf("Send() returned error: %v", err)
+	}
+	first := <-received
+	if first[12] != 1 || first[14] != 1 || first[18] != 50 {
+		t.Errorf("first packet: sequence %d, port-address %d, level %d; want 1, 1, 50", first[12], first[14], first[18])
+	}
+
+	// Unchanged within the keepalive: nothing sent
+	if err := b.Send(now.Add(100 * time.Millisecond)); err != nil {
+		t.Fatalf("Send() returned error: %v", err)
+	}
+	u.Update([]byte{60}, "console", [16]byte{1}, 100, 1)
+	if err := b.Send(now.Add(200 * time.Millisecond)); err != nil {
+		t.Fatalf("Send() returned error: %v", err)
+	}
+	second := <-received
+	if second[12] != 2 || second[18] != 60 {
+		t.Errorf("second packet: sequence %d, level %d; want 2, 60", second[12], second[18])
+	}
+	select {
+	case extra := <-received:
+		t.Errorf("unexpected packet for port-address %d", extra[14])
+	default:
+	}
+}
@@ -0,0 +1,47 @@
+// Package artnet re-transmits received sACN universes as Art-Net, for
+// mixed-protocol rigs and legacy fixtures
+package artnet
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Art-Net protocol constants
+const (
+	// Port is the UDP port Art-Net nodes listen on
+	Port = 6454
+	// MaxPortAddress is the highest 15-bit Art-Net port-address
+	MaxPortAddress = 0x7FFF
+
+	opDmx           = 0x5000
+	protocolVersion = 14
+	dmxHeaderSize   = 18
+)
+
+// packetID starts every Art-Net packet
+var packetID = []byte("Art-Net\x00")
+
+// ArtDmx builds an ArtDmx packet carrying levels to a port-address
+// (net << 8 | sub-net << 4 | universe). Sequence 0 disables reordering at
+// the receiver; 1-255 otherwise. An odd length is padded with a zero, as
+// the length must be even.
+func ArtDmx(portAddress uint16, sequence uint8, levels []byte) ([]byte, error) {
+	if portAddress > MaxPortAddress {
+		return nil, fmt.Errorf("Art-Net port-address %d out of range 0-%d", portAddress, MaxPortAddress)
+	}
+	n := min(len(levels), 512)
+	length := max(n+n%2, 2)
+
+	p := make([]byte, dmxHeaderSize+length)
+	copy(p, packetID)
+	binary.LittleEndian.PutUint16(p[8:], opDmx)
+	binary.BigEndian.PutUint16(p[10:], protocolVersion)
+	p[12] = sequence
+	p[13] = 0                      // Physical input port, informational only
+	p[14] = byte(portAddress)      // SubUni: sub-net and universe
+	p[15] = byte(portAddress >> 8) // Net
+	binary.BigEndian.PutUint16(p[16:], uint16(length))
+	copy(p[dmxHeaderSize:], levels[:n])
+	return p, nil
+}
@@ -0,0 +1,29 @@
+package artnet
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestArtDmx(t *testing.T) {
+	p, err := ArtDmx(0x1234, 7, []byte{1, 2, 3})
+	if err != nil {
+		t.Fatalf("ArtDmx() returned error: %v", err)
+	}
+	want := []byte{
+		'A', 'r', 't', '-', 'N', 'e', 't', 0,
+		0x00, 0x50, // OpDmx, little endian
+		0, 14, // Protocol version
+		7, 0, // Sequence, physical
+		0x34, 0x12, // SubUni, Net
+		0, 4, // Length padded to even
+		1, 2, 3, 0,
+	}
+	if !bytes.Equal(p, want) {
+		t.Errorf("ArtDmx() = % x, want % x", p, want)
+	}
+
+	if _, err := ArtDmx(MaxPortAddress+1, 1, nil); err == nil {
+		t.Error("ArtDmx(0x8000) expected error, got nil")
+	}
+}
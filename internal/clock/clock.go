@@ -0,0 +1,54 @@
+// Package clock abstracts the current time, so statistics can be driven by
+// capture timestamps when replaying and tests can control time
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells the current time
+type Clock interface {
+	Now() time.Time
+}
+
+// Real is the wall clock
+type Real struct{}
+
+// Now returns the current wall-clock time
+func (Real) Now() time.Time {
+	return time.Now()
+}
+
+// Manual is a clock that only moves when set or advanced, e.g. to the
+// timestamps of replayed packets. It is safe for concurrent use.
+type Manual struct {
+	now time.Time
+	mu  sync.Mutex
+}
+
+// NewManual creates a manual clock reading t
+func NewManual(t time.Time) *Manual {
+	return &Manual{now: t}
+}
+
+// Now returns the clock's current time
+func (c *Manual) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Set moves the clock to t
+func (c *Manual) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+}
+
+// Advance moves the clock forward by d
+func (c *Manual) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
@@ -0,0 +1,31 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestManual(t *testing.T) {
+	start := time.Date(2026, 3, 14, 20, 0, 0, 0, time.UTC)
+	c := NewManual(start)
+
+	if got := c.Now(); !got.Equal(start) {
+		t.Errorf("Now() = %v, want %v", got, start)
+	}
+	c.Advance(250 * time.Millisecond)
+	if got := c.Now(); !got.Equal(start.Add(250 * time.Millisecond)) {
+		t.Errorf("Now() after Advance = %v, want +250ms", got)
+	}
+	c.Set(start)
+	if got := c.Now(); !got.Equal(start) {
+		t.Errorf("Now() after Set = %v, want %v", got, start)
+	}
+}
+
+func TestReal(t *testing.T) {
+	before := time.Now()
+	got := Real{}.Now()
+	if got.Before(before) || got.After(time.Now()) {
+		t.Errorf("Real.Now() = %v, not the wall-clock time", got)
+	}
+}
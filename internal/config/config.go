@@ -0,0 +1,154 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Config holds settings persisted across runs
+type Config struct {
+	// UniverseNames maps universe IDs to human-readable names
+	UniverseNames map[uint16]string `json:"universe_names,omitempty"`
+
+	// ChannelMasks lists channel ranges to ignore per universe, as "N" or "N-M"
+	ChannelMasks map[uint16][]string `json:"channel_masks,omitempty"`
+
+	// UniverseGroups are named sets of universes, in display order
+	UniverseGroups []UniverseGroup `json:"universe_groups,omitempty"`
+
+	// ExpectedRates sets the expected packet rate per universe in Hz
+	ExpectedRates map[uint16]float64 `json:"expected_rates,omitempty"`
+
+	// RefreshInterval is how often the UI redraws, e.g. "250ms"
+	RefreshInterval string `json:"refresh_interval,omitempty"`
+
+	// Watch lists channels shown in the watch panel on every screen, in order
+	Watch []WatchEntry `json:"watch,omitempty"`
+
+	path string
+	mu   sync.Mutex
+}
+
+// UniverseGroup is a named set of universes, e.g. "Stage" or "Pixel tape"
+type UniverseGroup struct {
+	Name      string   `json:"name"`
+	Universes []uint16 `json:"universes"`
+}
+
+// WatchEntry is a channel pinned to the watch panel
+type WatchEntry struct {
+	Universe uint16 `json:"universe"`
+	Channel  int    `json:"channel"` // 1-based
+}
+
+// DefaultPath returns the default config file location in the user's
+// config directory
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate config directory: %w", err)
+	}
+	return filepath.Join(dir, "sacn-monitor", "config.json"), nil
+}
+
+// New returns an empty config with no file, kept for this run only
+func New() *Config {
+	cfg := &Config{}
+	cfg.init()
+	return cfg
+}
+
+// Load reads the config file at path. A missing file yields an empty
+// config that will be created on the first Save.
+func Load(path string) (*Config, error) {
+	cfg := &Config{path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		cfg.init()
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	cfg.init()
+	return cfg, nil
+}
+
+// init makes sure maps are usable after loading
+func (c *Config) init() {
+	if c.UniverseNames == nil {
+		c.UniverseNames = make(map[uint16]string)
+	}
+}
+
+// Path returns the file the config is saved to
+func (c *Config) Path() string {
+	return c.path
+}
+
+// SetUniverseName names a universe, or removes its name when name is empty
+func (c *Config) SetUniverseName(id uint16, name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if name == "" {
+		delete(c.UniverseNames, id)
+	} else {
+		c.UniverseNames[id] = name
+	}
+}
+
+// ToggleWatch adds a channel to the end of the watch list, or removes it
+// if already there, and reports whether it is watched now
+func (c *Config) ToggleWatch(universe uint16, channel int) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, w := range c.Watch {
+		if w.Universe == universe && w.Channel == channel {
+			c.Watch = append(c.Watch[:i], c.Watch[i+1:]...)
+			return false
+		}
+	}
+	c.Watch = append(c.Watch, WatchEntry{Universe: universe, Channel: channel})
+	return true
+}
+
+// WatchList returns a copy of the watch list
+func (c *Config) WatchList() []WatchEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]WatchEntry(nil), c.Watch...)
+}
+
+// Save writes the config back to its file, creating the directory if needed
+func (c *Config) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.path == "" {
+		return errors.New("config has no file path")
+	}
+
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(c.path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoad_MissingFile(t *testing.T) {
+	cfg, err := Load(filepath.Join(t.TempDir(), "config.json"))
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	if cfg.UniverseNames == nil || len(cfg.UniverseNames) != 0 {
+		t.Errorf("UniverseNames = %v, want empty map", cfg.UniverseNames)
+	}
+}
+
+func TestConfig_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "config.json")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.SetUniverseName(1, "FOH rig")
+	cfg.SetUniverseName(2, "LED wall left")
+	cfg.SetUniverseName(2, "")
+
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	if loaded.UniverseNames[1] != "FOH rig" {
+		t.Errorf("UniverseNames[1] = %q, want %q", loaded.UniverseNames[1], "FOH rig")
+	}
+
+	if _, exists := loaded.UniverseNames[2]; exists {
+		t.Error("UniverseNames[2] exists after clearing")
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Load(path); err == nil {
+		t.Error("Load() expected error for invalid JSON, got nil")
+	}
+}
+
+func TestLoad_ChannelMasks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"channel_masks": {"2": ["1-10", "512"]}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := cfg.ChannelMasks[2]; len(got) != 2 || got[0] != "1-10" || got[1] != "512" {
+		t.Errorf("ChannelMasks[2] = %v, want [1-10 512]", got)
+	}
+}
+
+func TestConfig_ToggleWatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !cfg.ToggleWatch(1, 17) || !cfg.ToggleWatch(2, 5) || !cfg.ToggleWatch(1, 1) {
+		t.Fatal("ToggleWatch() = false adding new channels, want true")
+	}
+	if cfg.ToggleWatch(2, 5) {
+		t.Error("ToggleWatch() = true removing a watched channel, want false")
+	}
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []WatchEntry{{Universe: 1, Channel: 17}, {Universe: 1, Channel: 1}}
+	got := loaded.WatchList()
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("WatchList() = %v, want %v", got, want)
+	}
+}
+
+func TestNew_NotSaved(t *testing.T) {
+	cfg := New()
+	cfg.SetUniverseName(1, "FOH rig")
+	if cfg.Path() != "" || cfg.UniverseNames[1] != "FOH rig" {
+		t.Errorf("New() = path %q, names %v, want an in-memory config", cfg.Path(), cfg.UniverseNames)
+	}
+	if err := cfg.Save(); err == nil {
+		t.Error("Save() of an in-memory config expected error, got nil")
+	}
+}
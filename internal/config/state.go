@@ -0,0 +1,81 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// UIState is where the operator was in the UI, saved on exit and restored
+// on the next start
+type UIState struct {
+	SelectedUniverse uint16      `json:"selected_universe,omitempty"`
+	SelectedChannel  int         `json:"selected_channel,omitempty"` // 0-based
+	Scroll           int         `json:"scroll,omitempty"`
+	View             string      `json:"view,omitempty"`
+	ChannelFilter    string      `json:"channel_filter,omitempty"`
+	Threshold        string      `json:"threshold,omitempty"` // e.g. ">200"
+	TabOrder         string      `json:"tab_order,omitempty"`
+	ManualOrder      []uint16    `json:"manual_order,omitempty"`
+	Pinned           []uint16    `json:"pinned,omitempty"`
+	Group            string      `json:"group,omitempty"`
+	ValueFormat      string      `json:"value_format,omitempty"`
+	Density          string      `json:"density,omitempty"`
+	LossMode         string      `json:"loss_mode,omitempty"`
+	Layout           string      `json:"layout,omitempty"`
+	PaneShare        int         `json:"pane_share,omitempty"` // Percent of the screen for the layout's second pane
+	Panes            []PaneState `json:"panes,omitempty"`
+}
+
+// PaneState is a universe shown in the split view and its scroll position
+type PaneState struct {
+	Universe uint16 `json:"universe"`
+	Scroll   int    `json:"scroll,omitempty"`
+}
+
+// DefaultStatePath returns the default UI state file location, next to the
+// default config file
+func DefaultStatePath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate config directory: %w", err)
+	}
+	return filepath.Join(dir, "sacn-monitor", "state.json"), nil
+}
+
+// LoadState reads the UI state file at path. A missing file yields an
+// empty state.
+func LoadState(path string) (UIState, error) {
+	var state UIState
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return state, fmt.Errorf("failed to read state file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &state); err != nil {
+		return UIState{}, fmt.Errorf("failed to parse state file %s: %w", path, err)
+	}
+	return state, nil
+}
+
+// SaveState writes the UI state to path, creating the directory if needed
+func SaveState(path string, state UIState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	return nil
+}
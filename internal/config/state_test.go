@@ -0,0 +1,54 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadState_MissingFile(t *testing.T) {
+	state, err := LoadState(filepath.Join(t.TempDir(), "state.json"))
+	if err != nil {
+		t.Fatalf("LoadState() returned error: %v", err)
+	}
+	if !reflect.DeepEqual(state, UIState{}) {
+		t.Errorf("LoadState() = %+v, want empty state", state)
+	}
+}
+
+func TestState_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "state.json")
+	want := UIState{
+		SelectedUniverse: 3,
+		SelectedChannel:  17,
+		Scroll:           16,
+		View:             "heatmap",
+		Threshold:        ">200",
+		TabOrder:         "manual",
+		ManualOrder:      []uint16{3, 1, 2},
+		Panes:            []PaneState{{Universe: 1}, {Universe: 3, Scroll: 32}},
+	}
+
+	if err := SaveState(path, want); err != nil {
+		t.Fatalf("SaveState() returned error: %v", err)
+	}
+	got, err := LoadState(path)
+	if err != nil {
+		t.Fatalf("LoadState() returned error: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadState() = %+v, want %+v", got, want)
+	}
+}
+
+func TestLoadState_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadState(path); err == nil {
+		t.Error("LoadState() expected error for invalid JSON, got nil")
+	}
+}
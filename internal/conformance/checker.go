@@ -0,0 +1,423 @@
+package conformance
+
+import (
+	"fmt"
+	"sort"
+	"time"
+	"unicode/utf8"
+
+	"sacn-monitor/internal/sacn"
+)
+
+// Constants for conformance checks
+const (
+	// terminationPacketCount is how many Stream_Terminated packets a source
+	// must send when it stops transmitting a universe (E1.31 section 6.2.6)
+	terminationPacketCount = 3
+	// discoveryTolerance is the slack allowed on top of the discovery interval
+	discoveryTolerance = 2 * time.Second
+	// outOfOrderWindow is the sequence window treated as out of order rather
+	// than loss (E1.31 section 6.7.2)
+	outOfOrderWindow = 20
+)
+
+// Status is the outcome of a single check
+type Status int
+
+const (
+	Pass Status = iota
+	Fail
+	Skip
+)
+
+func (s Status) String() string {
+	switch s {
+	case Pass:
+		return "PASS"
+	case Fail:
+		return "FAIL"
+	default:
+		return "SKIP"
+	}
+}
+
+// Result is the outcome of one check against one source
+type Result struct {
+	Check  string
+	Status Status
+	Detail string
+}
+
+// universeObservation records a source's traffic on a single universe
+type universeObservation struct {
+	packets      uint64
+	first        time.Time
+	last         time.Time
+	maxGap       time.Duration
+	lastSequence uint8
+	lost         uint64
+	outOfOrder   uint64
+	terminated   int   // Stream_Terminated packets seen in the current run
+	terminations []int // Length of each completed Stream_Terminated run
+}
+
+// sourceObservation records everything seen from a single CID
+type sourceObservation struct {
+	cid           [16]byte
+	name          string
+	badNames      int
+	badPriorities int
+	badUniverses  map[uint16]bool
+	universes     map[uint16]*universeObservation
+
+	discoveryPackets int
+	discovered       map[uint16]bool
+	unsortedPages    int
+	lastDiscovery    time.Time
+	maxDiscoveryGap  time.Duration
+}
+
+// Checker observes a device under test and evaluates its output against
+// E1.31 requirements
+type Checker struct {
+	sources map[[16]byte]*sourceObservation
+	start   time.Time
+	end     time.Time
+}
+
+// NewChecker creates a checker for an observation starting at start
+func NewChecker(start time.Time) *Checker {
+	return &Checker{
+		sources: make(map[[16]byte]*sourceObservation),
+		start:   start,
+		end:     start,
+	}
+}
+
+// source returns the observation for a CID, creating it if needed
+func (c *Checker) source(cid [16]byte, name string) *sourceObservation {
+	src, exists := c.sources[cid]
+	if !exists {
+		src = &sourceObservation{
+			cid:          cid,
+			badUniverses: make(map[uint16]bool),
+			universes:    make(map[uint16]*universeObservation),
+			discovered:   make(map[uint16]bool),
+		}
+		c.sources[cid] = src
+	}
+	src.name = name
+	return src
+}
+
+// ObservePacket records a data packet
+func (c *Checker) ObservePacket(p *sacn.Packet) {
+	c.advance(p.ReceivedAt)
+	src := c.source(p.CID, p.SourceName)
+
+	if p.SourceName == "" || !utf8.ValidString(p.SourceName) {
+		src.badNames++
+	}
+	if p.Priority > sacn.E131MaxPriority {
+		src.badPriorities++
+	}
+	if p.Universe < sacn.E131MinUniverse || p.Universe > sacn.E131MaxUniverse {
+		src.badUniverses[p.Universe] = true
+	}
+
+	obs, exists := src.universes[p.Universe]
+	if !exists {
+		obs = &universeObservation{first: p.ReceivedAt, lastSequence: p.Sequence - 1}
+		src.universes[p.Universe] = obs
+	}
+
+	if gap := p.ReceivedAt.Sub(obs.last); exists && gap > obs.maxGap {
+		obs.maxGap = gap
+	}
+	obs.packets++
+	obs.last = p.ReceivedAt
+
+	// Sequence numbers are compared as a signed 8-bit difference; receivers
+	// discard out of order packets, so they don't advance the sequence
+	diff := int8(p.Sequence - obs.lastSequence)
+	if diff <= 0 && diff > -outOfOrderWindow {
+		obs.outOfOrder++
+		return
+	}
+	if diff > 1 {
+		obs.lost += uint64(diff - 1)
+	}
+	obs.lastSequence = p.Sequence
+
+	if p.StreamTerminated() {
+		obs.terminated++
+	} else if obs.terminated > 0 {
+		obs.terminations = append(obs.terminations, obs.terminated)
+		obs.terminated = 0
+	}
+}
+
+// ObserveDiscovery records a universe discovery packet
+func (c *Checker) ObserveDiscovery(d *sacn.DiscoveryPacket) {
+	c.advance(d.ReceivedAt)
+	src := c.source(d.CID, d.SourceName)
+
+	// Pages of one announcement arrive back to back, so only the first page
+	// counts towards the announcement interval
+	if d.Page == 0 {
+		if !src.lastDiscovery.IsZero() {
+			if gap := d.ReceivedAt.Sub(src.lastDiscovery); gap > src.maxDiscoveryGap {
+				src.maxDiscoveryGap = gap
+			}
+		}
+		src.lastDiscovery = d.ReceivedAt
+	}
+
+	src.discoveryPackets++
+	if !sort.SliceIsSorted(d.Universes, func(i, j int) bool { return d.Universes[i] < d.Universes[j] }) {
+		src.unsortedPages++
+	}
+	for _, u := range d.Universes {
+		src.discovered[u] = true
+	}
+}
+
+// advance moves the end of the observation period forward
+func (c *Checker) advance(t time.Time) {
+	if t.After(c.end) {
+		c.end = t
+	}
+}
+
+// Report evaluates all checks for an observation that ended at end
+func (c *Checker) Report(end time.Time) Report {
+	c.advance(end)
+	report := Report{
+		Duration: c.end.Sub(c.start),
+	}
+
+	for _, src := range c.sources {
+		report.Sources = append(report.Sources, SourceReport{
+			CID:     src.cid,
+			Name:    src.name,
+			Results: c.evaluate(src, c.end),
+		})
+	}
+
+	sort.Slice(report.Sources, func(i, j int) bool {
+		return report.Sources[i].Name < report.Sources[j].Name
+	})
+
+	return report
+}
+
+// evaluate runs every check against a single source
+func (c *Checker) evaluate(src *sourceObservation, end time.Time) []Result {
+	universes := src.sortedUniverses()
+
+	return []Result{
+		checkSourceName(src),
+		checkPriority(src),
+		checkUniverseRange(src),
+		checkRefresh(src, universes, end),
+		checkSequence(src, universes),
+		checkTermination(src, universes),
+		checkDiscovery(src, universes, end.Sub(c.start)),
+	}
+}
+
+// sortedUniverses returns the data universes seen from a source
+func (src *sourceObservation) sortedUniverses() []uint16 {
+	ids := make([]uint16, 0, len(src.universes))
+	for id := range src.universes {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+func checkSourceName(src *sourceObservation) Result {
+	result := Result{Check: "source name", Status: Pass, Detail: fmt.Sprintf("%q", src.name)}
+	if len(src.universes) == 0 {
+		result.Status = Skip
+		result.Detail = "no data packets"
+	} else if src.badNames > 0 {
+		result.Status = Fail
+		result.Detail = fmt.Sprintf("%d packets with an empty or invalid UTF-8 name", src.badNames)
+	}
+	return result
+}
+
+func checkPriority(src *sourceObservation) Result {
+	result := Result{Check: "priority range", Status: Pass, Detail: fmt.Sprintf("all within 0-%d", sacn.E131MaxPriority)}
+	if len(src.universes) == 0 {
+		result.Status = Skip
+		result.Detail = "no data packets"
+	} else if src.badPriorities > 0 {
+		result.Status = Fail
+		result.Detail = fmt.Sprintf("%d packets above %d", src.badPriorities, sacn.E131MaxPriority)
+	}
+	return result
+}
+
+func checkUniverseRange(src *sourceObservation) Result {
+	result := Result{Check: "universe range", Status: Pass, Detail: fmt.Sprintf("%d universes", len(src.universes))}
+	if len(src.universes) == 0 {
+		result.Status = Skip
+		result.Detail = "no data packets"
+	} else if len(src.badUniverses) > 0 {
+		result.Status = Fail
+		result.Detail = fmt.Sprintf("%d universes outside %d-%d", len(src.badUniverses), sacn.E131MinUniverse, sacn.E131MaxUniverse)
+	}
+	return result
+}
+
+func checkRefresh(src *sourceObservation, universes []uint16, end time.Time) Result {
+	result := Result{Check: "refresh / keep-alive", Status: Skip, Detail: "no data packets"}
+
+	var worstGap time.Duration
+	var worstUniverse uint16
+	var rates []string
+	for _, id := range universes {
+		obs := src.universes[id]
+		gap := obs.maxGap
+		// A stream that went silent without terminating counts as a gap too
+		if obs.terminated == 0 && len(obs.terminations) == 0 {
+			if trailing := end.Sub(obs.last); trailing > gap {
+				gap = trailing
+			}
+		}
+		if gap > worstGap {
+			worstGap = gap
+			worstUniverse = id
+		}
+		if span := obs.last.Sub(obs.first).Seconds(); span > 0 {
+			rates = append(rates, fmt.Sprintf("u%d %.1f pps", id, float64(obs.packets-1)/span))
+		}
+	}
+
+	if len(universes) == 0 {
+		return result
+	}
+
+	result.Status = Pass
+	result.Detail = fmt.Sprintf("max gap %s", worstGap.Round(time.Millisecond))
+	if len(rates) > 0 {
+		result.Detail += fmt.Sprintf(" (%s)", joinLimited(rates, 4))
+	}
+	if worstGap >= sacn.E131NetworkDataLossTimeout {
+		result.Status = Fail
+		result.Detail = fmt.Sprintf("universe %d silent for %s, exceeds %s data loss timeout",
+			worstUniverse, worstGap.Round(time.Millisecond), sacn.E131NetworkDataLossTimeout)
+	}
+	return result
+}
+
+func checkSequence(src *sourceObservation, universes []uint16) Result {
+	result := Result{Check: "sequence continuity", Status: Skip, Detail: "no data packets"}
+	if len(universes) == 0 {
+		return result
+	}
+
+	var packets, lost, outOfOrder uint64
+	for _, id := range universes {
+		obs := src.universes[id]
+		packets += obs.packets
+		lost += obs.lost
+		outOfOrder += obs.outOfOrder
+	}
+
+	result.Status = Pass
+	result.Detail = fmt.Sprintf("%d packets in order", packets)
+	if lost > 0 || outOfOrder > 0 {
+		result.Status = Fail
+		result.Detail = fmt.Sprintf("%d missing, %d out of order in %d packets", lost, outOfOrder, packets)
+	}
+	return result
+}
+
+func checkTermination(src *sourceObservation, universes []uint16) Result {
+	result := Result{Check: "stream termination", Status: Skip, Detail: "no termination observed"}
+
+	var terminations, short int
+	for _, id := range universes {
+		obs := src.universes[id]
+		runs := obs.terminations
+		if obs.terminated > 0 {
+			runs = append(runs, obs.terminated)
+		}
+		for _, run := range runs {
+			terminations++
+			if run < terminationPacketCount {
+				short++
+			}
+		}
+	}
+
+	if terminations == 0 {
+		return result
+	}
+
+	result.Status = Pass
+	result.Detail = fmt.Sprintf("%d terminations with %d+ packets", terminations, terminationPacketCount)
+	if short > 0 {
+		result.Status = Fail
+		result.Detail = fmt.Sprintf("%d of %d terminations sent fewer than %d packets", short, terminations, terminationPacketCount)
+	}
+	return result
+}
+
+func checkDiscovery(src *sourceObservation, universes []uint16, observed time.Duration) Result {
+	result := Result{Check: "universe discovery", Status: Pass}
+	limit := sacn.E131DiscoveryInterval + discoveryTolerance
+
+	if src.discoveryPackets == 0 {
+		if observed < limit {
+			result.Status = Skip
+			result.Detail = fmt.Sprintf("observe at least %s to check", limit)
+		} else {
+			result.Status = Fail
+			result.Detail = fmt.Sprintf("no discovery packets in %s", observed.Round(time.Second))
+		}
+		return result
+	}
+
+	var missing []string
+	for _, id := range universes {
+		if !src.discovered[id] {
+			missing = append(missing, fmt.Sprintf("%d", id))
+		}
+	}
+
+	switch {
+	case len(missing) > 0:
+		result.Status = Fail
+		result.Detail = fmt.Sprintf("universes not announced: %s", joinLimited(missing, 8))
+	case src.unsortedPages > 0:
+		result.Status = Fail
+		result.Detail = fmt.Sprintf("%d pages with unsorted universe lists", src.unsortedPages)
+	case src.maxDiscoveryGap > limit:
+		result.Status = Fail
+		result.Detail = fmt.Sprintf("announcements %s apart, expected every %s",
+			src.maxDiscoveryGap.Round(time.Millisecond), sacn.E131DiscoveryInterval)
+	default:
+		result.Detail = fmt.Sprintf("%d universes announced", len(src.discovered))
+	}
+	return result
+}
+
+// joinLimited joins items with commas, eliding all but the first limit
+func joinLimited(items []string, limit int) string {
+	s := ""
+	for i, item := range items {
+		if i == limit {
+			s += fmt.Sprintf(", +%d more", len(items)-limit)
+			break
+		}
+		if i > 0 {
+			s += ", "
+		}
+		s += item
+	}
+	return s
+}
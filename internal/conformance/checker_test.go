@@ -0,0 +1,197 @@
+package conformance
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"sacn-monitor/internal/sacn"
+)
+
+var testCID = [16]byte{1, 2, 3, 4}
+
+// feed sends count packets at the given interval, returning the time after the last one
+func feed(c *Checker, start time.Time, universe uint16, count int, interval time.Duration, firstSeq uint8) time.Time {
+	at := start
+	for i := 0; i < count; i++ {
+		c.ObservePacket(&sacn.Packet{
+			CID:        testCID,
+			SourceName: "dut",
+			Priority:   100,
+			Universe:   universe,
+			Sequence:   firstSeq + uint8(i),
+			ReceivedAt: at,
+		})
+		at = at.Add(interval)
+	}
+	return at
+}
+
+// result finds a check result by name in the first source report
+func result(t *testing.T, report Report, check string) Result {
+	t.Helper()
+	if len(report.Sources) != 1 {
+		t.Fatalf("len(Sources) = %d, want 1", len(report.Sources))
+	}
+	for _, r := range report.Sources[0].Results {
+		if r.Check == check {
+			return r
+		}
+	}
+	t.Fatalf("check %q not found", check)
+	return Result{}
+}
+
+func TestChecker_ConformingSource(t *testing.T) {
+	start := time.Now()
+	c := NewChecker(start)
+
+	end := feed(c, start, 1, 100, 25*time.Millisecond, 0)
+	c.ObserveDiscovery(&sacn.DiscoveryPacket{CID: testCID, SourceName: "dut", Universes: []uint16{1}, ReceivedAt: start})
+
+	report := c.Report(end)
+	if !report.Passed() {
+		var sb strings.Builder
+		report.WriteText(&sb)
+		t.Errorf("Passed() = false, want true:\n%s", sb.String())
+	}
+}
+
+func TestChecker_NoSources(t *testing.T) {
+	report := NewChecker(time.Now()).Report(time.Now())
+	if report.Passed() {
+		t.Error("Passed() = true with no sources, want false")
+	}
+}
+
+func TestChecker_SequenceGap(t *testing.T) {
+	start := time.Now()
+	c := NewChecker(start)
+
+	at := feed(c, start, 1, 5, 25*time.Millisecond, 0)
+	feed(c, at, 1, 5, 25*time.Millisecond, 10) // 5-9 missing
+
+	r := result(t, c.Report(at), "sequence continuity")
+	if r.Status != Fail {
+		t.Errorf("Status = %v, want FAIL", r.Status)
+	}
+	if !strings.Contains(r.Detail, "5 missing") {
+		t.Errorf("Detail = %q, want it to mention 5 missing", r.Detail)
+	}
+}
+
+func TestChecker_OutOfOrder(t *testing.T) {
+	start := time.Now()
+	c := NewChecker(start)
+
+	at := feed(c, start, 1, 5, 25*time.Millisecond, 0)
+	feed(c, at, 1, 1, 0, 2) // late packet
+	feed(c, at, 1, 3, 25*time.Millisecond, 5)
+
+	r := result(t, c.Report(at), "sequence continuity")
+	if !strings.Contains(r.Detail, "0 missing, 1 out of order") {
+		t.Errorf("Detail = %q, want 0 missing, 1 out of order", r.Detail)
+	}
+}
+
+func TestChecker_KeepAliveGap(t *testing.T) {
+	start := time.Now()
+	c := NewChecker(start)
+
+	at := feed(c, start, 1, 3, 25*time.Millisecond, 0)
+	feed(c, at.Add(3*time.Second), 1, 3, 25*time.Millisecond, 3)
+
+	r := result(t, c.Report(at), "refresh / keep-alive")
+	if r.Status != Fail {
+		t.Errorf("Status = %v, want FAIL", r.Status)
+	}
+}
+
+func TestChecker_Termination(t *testing.T) {
+	tests := []struct {
+		name       string
+		terminated int
+		want       Status
+	}{
+		{"none", 0, Skip},
+		{"three packets", 3, Pass},
+		{"single packet", 1, Fail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Now()
+			c := NewChecker(start)
+
+			at := feed(c, start, 1, 10, 25*time.Millisecond, 0)
+			for i := 0; i < tt.terminated; i++ {
+				c.ObservePacket(&sacn.Packet{
+					CID:        testCID,
+					SourceName: "dut",
+					Universe:   1,
+					Sequence:   uint8(10 + i),
+					Options:    sacn.OptionStreamTerminated,
+					ReceivedAt: at,
+				})
+			}
+
+			if r := result(t, c.Report(at), "stream termination"); r.Status != tt.want {
+				t.Errorf("Status = %v, want %v (%s)", r.Status, tt.want, r.Detail)
+			}
+		})
+	}
+}
+
+func TestChecker_Discovery(t *testing.T) {
+	start := time.Now()
+
+	t.Run("short observation skipped", func(t *testing.T) {
+		c := NewChecker(start)
+		end := feed(c, start, 1, 10, 100*time.Millisecond, 0)
+		if r := result(t, c.Report(end), "universe discovery"); r.Status != Skip {
+			t.Errorf("Status = %v, want SKIP", r.Status)
+		}
+	})
+
+	t.Run("missing announcements", func(t *testing.T) {
+		c := NewChecker(start)
+		end := feed(c, start, 1, 150, 100*time.Millisecond, 0)
+		if r := result(t, c.Report(end), "universe discovery"); r.Status != Fail {
+			t.Errorf("Status = %v, want FAIL", r.Status)
+		}
+	})
+
+	t.Run("universe not announced", func(t *testing.T) {
+		c := NewChecker(start)
+		end := feed(c, start, 1, 10, 100*time.Millisecond, 0)
+		feed(c, start, 2, 10, 100*time.Millisecond, 0)
+		c.ObserveDiscovery(&sacn.DiscoveryPacket{CID: testCID, SourceName: "dut", Universes: []uint16{1}, ReceivedAt: start})
+
+		r := result(t, c.Report(end), "universe discovery")
+		if r.Status != Fail || !strings.Contains(r.Detail, "2") {
+			t.Errorf("got %v %q, want FAIL naming universe 2", r.Status, r.Detail)
+		}
+	})
+
+	t.Run("unsorted list", func(t *testing.T) {
+		c := NewChecker(start)
+		end := feed(c, start, 1, 10, 100*time.Millisecond, 0)
+		c.ObserveDiscovery(&sacn.DiscoveryPacket{CID: testCID, SourceName: "dut", Universes: []uint16{5, 1}, ReceivedAt: start})
+
+		if r := result(t, c.Report(end), "universe discovery"); r.Status != Fail {
+			t.Errorf("Status = %v, want FAIL", r.Status)
+		}
+	})
+}
+
+func TestChecker_InvalidFields(t *testing.T) {
+	c := NewChecker(time.Now())
+	c.ObservePacket(&sacn.Packet{CID: testCID, Priority: 250, Universe: 64000, ReceivedAt: time.Now()})
+
+	report := c.Report(time.Now())
+	for _, check := range []string{"source name", "priority range", "universe range"} {
+		if r := result(t, report, check); r.Status != Fail {
+			t.Errorf("%s: Status = %v, want FAIL", check, r.Status)
+		}
+	}
+}
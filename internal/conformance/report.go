@@ -0,0 +1,67 @@
+package conformance
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// Report is the outcome of a conformance run
+type Report struct {
+	Duration time.Duration
+	Sources  []SourceReport
+}
+
+// SourceReport holds check results for a single source
+type SourceReport struct {
+	CID     [16]byte
+	Name    string
+	Results []Result
+}
+
+// Passed reports whether a source passed every check that could be run
+func (s SourceReport) Passed() bool {
+	for _, r := range s.Results {
+		if r.Status == Fail {
+			return false
+		}
+	}
+	return true
+}
+
+// Passed reports whether any source was observed and none failed a check
+func (r Report) Passed() bool {
+	if len(r.Sources) == 0 {
+		return false
+	}
+	for _, s := range r.Sources {
+		if !s.Passed() {
+			return false
+		}
+	}
+	return true
+}
+
+// WriteText writes a human readable report
+func (r Report) WriteText(w io.Writer) {
+	fmt.Fprintf(w, "E1.31 conformance report (%s observed)\n", r.Duration.Round(time.Second))
+
+	if len(r.Sources) == 0 {
+		fmt.Fprintln(w, "\nNo sources observed.")
+		fmt.Fprintln(w, "\nResult: FAIL")
+		return
+	}
+
+	for _, s := range r.Sources {
+		fmt.Fprintf(w, "\nSource %q (CID %x)\n", s.Name, s.CID)
+		for _, res := range s.Results {
+			fmt.Fprintf(w, "  [%s] %-22s %s\n", res.Status, res.Check, res.Detail)
+		}
+	}
+
+	result := "PASS"
+	if !r.Passed() {
+		result = "FAIL"
+	}
+	fmt.Fprintf(w, "\nResult: %s\n", result)
+}
@@ -0,0 +1,184 @@
+// Package dmxout renders a universe to a physical DMX port through an
+// Enttec USB Pro or Open DMX interface, so the monitor doubles as a
+// network-to-wire test adapter
+package dmxout
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"sacn-monitor/internal/universe"
+
+	"go.bug.st/serial"
+)
+
+// Output defaults
+const (
+	// DefaultRate is how many frames are sent per second
+	DefaultRate = 40
+	// MaxRate is the fastest a full 512-slot universe goes over the wire
+	MaxRate = 44
+	// MaxOpenDMXRate leaves Open DMX room for the host-timed break: a frame
+	// takes 22.6 ms at 250 kbaud, so at 44 Hz the next break would cut it off
+	MaxOpenDMXRate = 40
+	// dmxBreak is the break before each Open DMX frame; the spec's minimum
+	// is 88 µs but sleeps are coarser, and receivers accept up to 1 s
+	dmxBreak = time.Millisecond
+)
+
+// Enttec USB Pro message framing: start, label, length (LSB first), data, end
+const (
+	proStart     = 0x7E
+	proEnd       = 0xE7
+	proLabelSend = 6 // Output Only Send DMX Packet Request
+)
+
+// Driver selects the USB interface protocol
+type Driver string
+
+// Supported interfaces
+const (
+	// EnttecPro is an Enttec DMX USB Pro or compatible, which takes whole
+	// frames in a framed message and generates the DMX timing itself
+	EnttecPro Driver = "pro"
+	// OpenDMX is an Enttec Open DMX USB or other plain FTDI interface,
+	// driven at 250 kbaud with the break generated by the host
+	OpenDMX Driver = "open"
+)
+
+// ParseDriver returns the driver named by s
+func ParseDriver(s string) (Driver, error) {
+	switch d := Driver(s); d {
+	case EnttecPro, OpenDMX:
+		return d, nil
+	default:
+		return "", fmt.Errorf("unknown DMX output driver %q (want pro or open)", s)
+	}
+}
+
+// Port is a serial port an output writes to; serial.Port satisfies it
+type Port interface {
+	io.WriteCloser
+	Drain() error
+	Break(time.Duration) error
+}
+
+// Output sends one universe's current levels, as the monitor holds them
+// after merging, to a DMX port at a fixed rate. Until the universe is seen
+// it sends zeros; when its sources stop it holds the last look, as a node
+// would.
+type Output struct {
+	port       Port
+	driver     Driver
+	manager    *universe.Manager
+	universeID uint16
+	rate       int
+}
+
+// Open opens the serial device at path for the given driver
+func Open(path string, driver Driver, manager *universe.Manager, universeID uint16) (*Output, error) {
+	mode := &serial.Mode{BaudRate: 57600, DataBits: 8, Parity: serial.NoParity, StopBits: serial.OneStopBit}
+	if driver == OpenDMX {
+		mode = &serial.Mode{BaudRate: 250000, DataBits: 8, Parity: serial.NoParity, StopBits: serial.TwoStopBits}
+	}
+	port, err := serial.Open(path, mode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open DMX output %s: %w", path, err)
+	}
+	return NewOutput(port, driver, manager, universeID), nil
+}
+
+// NewOutput creates an output writing to an open port
+func NewOutput(port Port, driver Driver, manager *universe.Manager, universeID uint16) *Output {
+	return &Output{
+		port:       port,
+		driver:     driver,
+		manager:    manager,
+		universeID: universeID,
+		rate:       DefaultRate,
+	}
+}
+
+// SetRate sets the frames sent per second, up to MaxRate (MaxOpenDMXRate
+// for Open DMX)
+func (o *Output) SetRate(rate int) {
+	if rate <= 0 {
+		return
+	}
+	if o.driver == OpenDMX {
+		o.rate = min(rate, MaxOpenDMXRate)
+	} else {
+		o.rate = min(rate, MaxRate)
+	}
+}
+
+// Run sends frames until the context is cancelled, then blacks the port
+// out. Failed writes are reported to onError once until writing works
+// again, e.g. after the interface is unplugged.
+func (o *Output) Run(ctx context.Context, onError func(error)) {
+	ticker := time.NewTicker(time.Second / time.Duration(o.rate))
+	defer ticker.Stop()
+
+	var failed error
+	for {
+		select {
+		case <-ctx.Done():
+			_ = o.WriteFrame(make([]byte, 512))
+			return
+		case <-ticker.C:
+			err := o.WriteFrame(o.levels())
+			if err != nil && failed == nil && onError != nil {
+				onError(err)
+			}
+			failed = err
+		}
+	}
+}
+
+// WriteFrame sends one frame of levels with the null start code
+func (o *Output) WriteFrame(levels []byte) error {
+	var err error
+	switch o.driver {
+	case OpenDMX:
+		// The previous frame must be on the wire before the break
+		if err = o.port.Drain(); err == nil {
+			err = o.port.Break(dmxBreak)
+		}
+		if err == nil {
+			_, err = o.port.Write(append([]byte{0}, levels...))
+		}
+	default:
+		_, err = o.port.Write(proFrame(levels))
+	}
+	if err != nil {
+		return fmt.Errorf("DMX output write failed: %w", err)
+	}
+	return nil
+}
+
+// Close closes the port
+func (o *Output) Close() error {
+	return o.port.Close()
+}
+
+// levels returns the universe's current levels, zeros if it isn't known
+func (o *Output) levels() []byte {
+	levels := make([]byte, 512)
+	if u := o.manager.Get(o.universeID); u != nil {
+		for i, ch := range u.GetAllChannels() {
+			levels[i] = ch.Value
+		}
+	}
+	return levels
+}
+
+// proFrame wraps levels in an Enttec USB Pro Send DMX message
+func proFrame(levels []byte) []byte {
+	n := len(levels) + 1 // Start code included
+	frame := make([]byte, 0, n+5)
+	frame = append(frame, proStart, proLabelSend, byte(n), byte(n>>8), 0)
+	frame = append(frame, levels...)
+	return append(frame, proEnd)
+}
@@ -0,0 +1,115 @@
+package dmxout
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"sacn-monitor/internal/universe"
+)
+
+// fakePort records writes, drains and breaks in order
+type fakePort struct {
+	writes [][]byte
+	breaks int
+	ops    []string
+	err    error
+}
+
+func (p *fakePort) Write(b []byte) (int, error) {
+	if p.err != nil {
+		return 0, p.err
+	}
+	p.writes = append(p.writes, append([]byte(nil), b...))
+	p.ops = append(p.ops, "write")
+	return len(b), nil
+}
+
+func (p *fakePort) Drain() error {
+	p.ops = append(p.ops, "drain")
+	return p.err
+}
+
+func (p *fakePort) Break(time.Duration) error {
+	p.breaks++
+	p.ops = append(p.ops, "break")
+	return p.err
+}
+
+func (p *fakePort) Close() error { return nil }
+
+func TestOutput_EnttecPro(t *testing.T) {
+	manager := universe.NewManager()
+	manager.GetOrCreate(3).Update([]byte{255, 128}, "console", [16]byte{1}, 100, 0)
+	port := &fakePort{}
+	o := NewOutput(port, EnttecPro, manager, 3)
+
+	if err := o.WriteFrame(o.levels()); err != nil {
+		t.Fatalf("WriteFrame() returned error: %v", err)
+	}
+	frame := port.writes[0]
+	if len(frame) != 518 {
+		t.Fatalf("frame length = %d, want 518 (header, start code, 512 slots, end)", len(frame))
+	}
+	if !bytes.Equal(frame[:7], []byte{0x7E, 6, 0x01, 0x02, 0, 255, 128}) || frame[517] != 0xE7 {
+		t.Errorf("frame = % x ... % x, want a label 6 message of 513 bytes", frame[:7], frame[517:])
+	}
+	if port.breaks != 0 {
+		t.Errorf("%d breaks sent, the Pro times DMX itself", port.breaks)
+	}
+}
+
+func TestOutput_OpenDMX(t *testing.T) {
+	port := &fakePort{}
+	o := NewOutput(port, OpenDMX, universe.NewManager(), 1)
+
+	// An unknown universe goes out as zeros
+	if err := o.WriteFrame(o.levels()); err != nil {
+		t.Fatalf("WriteFrame() returned error: %v", err)
+	}
+	if port.breaks != 1 || len(port.writes) != 1 || len(port.writes[0]) != 513 {
+		t.Fatalf("breaks = %d, writes = %d, want a break then start code and 512 slots", port.breaks, len(port.writes))
+	}
+	if !bytes.Equal(port.writes[0], make([]byte, 513)) {
+		t.Error("frame for an unknown universe isn't all zeros")
+	}
+
+	// Each break waits for the previous frame to leave the port
+	if err := o.WriteFrame(o.levels()); err != nil {
+		t.Fatalf("WriteFrame() returned error: %v", err)
+	}
+	if got := strings.Join(port.ops, ","); got != "drain,break,write,drain,break,write" {
+		t.Errorf("port operations = %s, want a drain before each break", got)
+	}
+
+	o.SetRate(MaxRate)
+	if o.rate != MaxOpenDMXRate {
+		t.Errorf("Open DMX rate = %d, want it capped at %d", o.rate, MaxOpenDMXRate)
+	}
+}
+
+func TestOutput_RunReportsOnce(t *testing.T) {
+	port := &fakePort{err: errors.New("unplugged")}
+	o := NewOutput(port, EnttecPro, universe.NewManager(), 1)
+	o.SetRate(MaxRate)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 150*time.Millisecond)
+	defer cancel()
+	reported := 0
+	o.Run(ctx, func(error) { reported++ })
+	if reported != 1 {
+		t.Errorf("%d errors reported, want 1 while the port keeps failing", reported)
+	}
+}
+
+func TestParseDriver(t *testing.T) {
+	if d, err := ParseDriver("open"); err != nil || d != OpenDMX {
+		t.Errorf("ParseDriver(open) = %q, %v", d, err)
+	}
+	if _, err := ParseDriver("artnet"); err == nil {
+		t.Error("ParseDriver(artnet) expected error, got nil")
+	}
+}
@@ -0,0 +1,114 @@
+package events
+
+import (
+	"sync"
+	"time"
+
+	"sacn-monitor/internal/universe"
+)
+
+// defaultLogSize is the number of events kept by default
+const defaultLogSize = 500
+
+// Kind identifies the type of an event
+type Kind string
+
+// Event kinds
+const (
+	SourceOnline   Kind = "source_online"
+	SourceLost     Kind = "source_lost"
+	LossSpike      Kind = "loss_spike"
+	DuplicateCID   Kind = "duplicate_cid"
+	Blackout       Kind = "blackout"
+	BlackoutEnd    Kind = "blackout_end"
+	RateDeviation  Kind = "rate_deviation"
+	RateRestored   Kind = "rate_restored"
+	ExportError    Kind = "export_error"
+	AddressChange  Kind = "address_change"
+	Burst          Kind = "burst"
+	Flapping       Kind = "flapping"
+	PriorityChange Kind = "priority_change"
+	// PriorityConflict is several online sources sharing a universe's
+	// highest priority, so receivers merge or flicker between them
+	PriorityConflict Kind = "priority_conflict"
+)
+
+// Event is a significant occurrence on the network
+type Event struct {
+	Time     time.Time
+	Kind     Kind
+	Universe uint16
+	Source   string // Source name, if the event concerns a single source
+	Message  string
+
+	// Snapshot is the universe's channel state when the event fired, if captured
+	Snapshot *universe.Snapshot
+}
+
+// Log is a bounded, thread-safe event log. When full, the oldest events
+// are discarded.
+type Log struct {
+	events []Event
+	max    int
+	added  uint64 // Events ever added, including discarded ones
+	mu     sync.RWMutex
+}
+
+// NewLog creates an event log holding up to max events (0 = default size)
+func NewLog(max int) *Log {
+	if max <= 0 {
+		max = defaultLogSize
+	}
+	return &Log{max: max}
+}
+
+// Add appends an event, discarding the oldest one if the log is full
+func (l *Log) Add(e Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.events) >= l.max {
+		copy(l.events, l.events[1:])
+		l.events = l.events[:len(l.events)-1]
+	}
+	l.events = append(l.events, e)
+	l.added++
+}
+
+// Since returns the events added after the first seen events, oldest
+// first, and the number of events added so far to pass to the next call.
+// Events discarded in between are skipped.
+func (l *Log) Since(seen uint64) ([]Event, uint64) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	n := len(l.events)
+	if fresh := l.added - min(seen, l.added); fresh < uint64(n) {
+		n = int(fresh)
+	}
+	result := make([]Event, n)
+	copy(result, l.events[len(l.events)-n:])
+	return result, l.added
+}
+
+// Recent returns up to n of the most recent events, newest first
+func (l *Log) Recent(n int) []Event {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if n <= 0 || n > len(l.events) {
+		n = len(l.events)
+	}
+	result := make([]Event, n)
+	for i := 0; i < n; i++ {
+		result[i] = l.events[len(l.events)-1-i]
+	}
+	return result
+}
+
+// Len returns the number of events in the log
+func (l *Log) Len() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return len(l.events)
+}
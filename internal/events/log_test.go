@@ -0,0 +1,59 @@
+package events
+
+import (
+	"testing"
+)
+
+func TestLog_RecentNewestFirst(t *testing.T) {
+	log := NewLog(10)
+	log.Add(Event{Message: "first"})
+	log.Add(Event{Message: "second"})
+	log.Add(Event{Message: "third"})
+
+	recent := log.Recent(2)
+	if len(recent) != 2 || recent[0].Message != "third" || recent[1].Message != "second" {
+		t.Errorf("Recent(2) = %v, want [third second]", recent)
+	}
+
+	if all := log.Recent(0); len(all) != 3 {
+		t.Errorf("len(Recent(0)) = %d, want 3", len(all))
+	}
+}
+
+func TestLog_Bounded(t *testing.T) {
+	log := NewLog(2)
+	log.Add(Event{Message: "first"})
+	log.Add(Event{Message: "second"})
+	log.Add(Event{Message: "third"})
+
+	if log.Len() != 2 {
+		t.Fatalf("Len() = %d, want 2", log.Len())
+	}
+
+	if recent := log.Recent(0); recent[1].Message != "second" {
+		t.Errorf("oldest event = %q, want second", recent[1].Message)
+	}
+}
+
+func TestLog_Since(t *testing.T) {
+	log := NewLog(2)
+	log.Add(Event{Message: "first"})
+
+	got, seen := log.Since(0)
+	if len(got) != 1 || got[0].Message != "first" || seen != 1 {
+		t.Fatalf("Since(0) = %v, %d, want [first], 1", got, seen)
+	}
+
+	log.Add(Event{Message: "second"})
+	log.Add(Event{Message: "third"})
+	log.Add(Event{Message: "fourth"})
+
+	// "second" was discarded before it could be read
+	got, seen = log.Since(seen)
+	if len(got) != 2 || got[0].Message != "third" || got[1].Message != "fourth" || seen != 4 {
+		t.Errorf("Since(1) = %v, %d, want [third fourth], 4", got, seen)
+	}
+	if got, _ := log.Since(seen); len(got) != 0 {
+		t.Errorf("Since(4) = %v, want none", got)
+	}
+}
@@ -0,0 +1,332 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"sacn-monitor/internal/stats"
+	"sacn-monitor/internal/universe"
+)
+
+// Monitor defaults
+const (
+	// checkInterval is how often the monitor looks for new events
+	checkInterval = 250 * time.Millisecond
+	// sourceTimeout is how long a source may be silent before it is lost
+	// (E1.31 network data loss timeout)
+	sourceTimeout = 2500 * time.Millisecond
+	// defaultLossSpikeThreshold is the recent loss percentage that counts as a spike
+	defaultLossSpikeThreshold = 1.0
+)
+
+// sourceKey identifies a source on a universe
+type sourceKey struct {
+	universe uint16
+	cid      [16]byte
+}
+
+// Monitor watches the universe manager and stats tracker and records
+// significant events, attaching a snapshot of the universe state to each
+type Monitor struct {
+	manager            *universe.Manager
+	tracker            *stats.Tracker
+	log                *Log
+	lossSpikeThreshold float64
+
+	online     map[sourceKey]bool   // Sources currently considered online
+	spiking    map[uint16]bool      // Universes currently above the loss threshold
+	duplicated map[[16]byte]bool    // CIDs currently arriving from several addresses
+	blackout   map[uint16]time.Time // Universes currently blacked out, and since when
+	offRate    map[uint16]bool      // Universes currently off their expected rate
+	bursts     map[uint16]uint64    // Bursts already recorded per universe
+	flapping   map[sourceKey]bool   // Sources currently flapping
+	conflicts  map[uint16]bool      // Universes with several sources tied at the highest priority
+
+	lastAddressChange  time.Time // Newest address change already recorded
+	lastPriorityChange time.Time // Newest priority change already recorded
+
+	// Held by Check and Forget, which run on different goroutines
+	mu sync.Mutex
+}
+
+// NewMonitor creates a monitor recording events into log
+func NewMonitor(manager *universe.Manager, tracker *stats.Tracker, log *Log) *Monitor {
+	return &Monitor{
+		manager:            manager,
+		tracker:            tracker,
+		log:                log,
+		lossSpikeThreshold: defaultLossSpikeThreshold,
+		online:             make(map[sourceKey]bool),
+		spiking:            make(map[uint16]bool),
+		duplicated:         make(map[[16]byte]bool),
+		blackout:           make(map[uint16]time.Time),
+		offRate:            make(map[uint16]bool),
+		bursts:             make(map[uint16]uint64),
+		flapping:           make(map[sourceKey]bool),
+		conflicts:          make(map[uint16]bool),
+	}
+}
+
+// Run checks for events periodically until the context is cancelled
+func (m *Monitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			m.Check(now)
+		}
+	}
+}
+
+// Check compares the current state against the last check and records
+// any source online/lost transitions, flapping sources, loss spikes,
+// duplicated CIDs, source address changes, priority changes and
+// conflicts, blackouts, refresh rate deviations and bursts
+func (m *Monitor) Check(now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.checkDuplicateCIDs(now)
+	m.checkAddressChanges(now)
+	m.checkPriorityChanges(now)
+	m.checkPriorityConflicts(now)
+	m.checkBlackouts(now)
+	m.checkRates(now)
+
+	for _, id := range m.tracker.GetAllUniverseIDs() {
+		for _, src := range m.tracker.GetSources(id) {
+			key := sourceKey{universe: id, cid: src.CID}
+
+			// Flapping re-arms once the transitions age out of its window
+			if flap := src.Flapping(now); flap.Flapping != m.flapping[key] {
+				m.flapping[key] = flap.Flapping
+				if flap.Flapping {
+					m.record(now, Flapping, id, src.Name, fmt.Sprintf("Source %q flapping on %s: %d dropouts in %s", src.Name, m.manager.Describe(id), flap.Recent, now.Sub(flap.Since).Round(time.Second)), false)
+				}
+			}
+
+			alive := now.Sub(src.LastSeen) <= sourceTimeout
+			if alive == m.online[key] {
+				continue
+			}
+			m.online[key] = alive
+
+			if alive {
+				m.record(now, SourceOnline, id, src.Name, fmt.Sprintf("Source %q online on %s", src.Name, m.manager.Describe(id)), false)
+			} else {
+				m.record(now, SourceLost, id, src.Name, fmt.Sprintf("Source %q lost on %s", src.Name, m.manager.Describe(id)), true)
+			}
+		}
+
+		// Loss spikes re-arm once loss falls back under half the threshold
+		loss := m.tracker.GetRecentLossPercentage(id)
+		if !m.spiking[id] && loss > m.lossSpikeThreshold {
+			m.spiking[id] = true
+			m.record(now, LossSpike, id, "", fmt.Sprintf("Loss spike on %s: %.1f%%", m.manager.Describe(id), loss), true)
+		} else if m.spiking[id] && loss < m.lossSpikeThreshold/2 {
+			m.spiking[id] = false
+		}
+	}
+}
+
+// Forget drops the state kept for a universe, for universes the manager no
+// longer holds. A universe coming back starts over, so its sources are
+// reported online again.
+func (m *Monitor) Forget(universeID uint16) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key := range m.online {
+		if key.universe == universeID {
+			delete(m.online, key)
+		}
+	}
+	for key := range m.flapping {
+		if key.universe == universeID {
+			delete(m.flapping, key)
+		}
+	}
+	delete(m.spiking, universeID)
+	delete(m.blackout, universeID)
+	delete(m.offRate, universeID)
+	delete(m.bursts, universeID)
+	delete(m.conflicts, universeID)
+}
+
+// checkDuplicateCIDs records an event when a CID starts arriving from more
+// than one address
+func (m *Monitor) checkDuplicateCIDs(now time.Time) {
+	duplicates := m.tracker.GetDuplicateCIDs()
+	for cid := range m.duplicated {
+		if _, still := duplicates[cid]; !still {
+			delete(m.duplicated, cid)
+		}
+	}
+
+	for _, id := range m.tracker.GetAllUniverseIDs() {
+		for _, src := range m.tracker.GetSources(id) {
+			addrs, dup := duplicates[src.CID]
+			if !dup || m.duplicated[src.CID] {
+				continue
+			}
+			m.duplicated[src.CID] = true
+			m.record(now, DuplicateCID, id, src.Name, fmt.Sprintf("Source %q CID seen from multiple addresses: %s", src.Name, strings.Join(addrs, ", ")), false)
+		}
+	}
+}
+
+// checkAddressChanges records sources that started arriving from a new
+// address since the last check
+func (m *Monitor) checkAddressChanges(now time.Time) {
+	changes := m.tracker.GetAddressChanges(0)
+
+	// Oldest first, skipping those already recorded
+	for i := len(changes) - 1; i >= 0; i-- {
+		c := changes[i]
+		if !c.Time.After(m.lastAddressChange) {
+			continue
+		}
+		m.lastAddressChange = c.Time
+
+		id, name := m.findSource(c.CID)
+		m.record(now, AddressChange, id, name, fmt.Sprintf("Source %q moved from %s to %s", name, c.Previous, c.Current), false)
+	}
+}
+
+// checkPriorityChanges records sources that changed their priority or
+// per-address priority since the last check
+func (m *Monitor) checkPriorityChanges(now time.Time) {
+	changes := m.tracker.GetPriorityChanges(0)
+
+	// Oldest first, skipping those already recorded
+	for i := len(changes) - 1; i >= 0; i-- {
+		c := changes[i]
+		if !c.Time.After(m.lastPriorityChange) {
+			continue
+		}
+		m.lastPriorityChange = c.Time
+
+		var msg string
+		switch {
+		case c.Previous != c.Current:
+			msg = fmt.Sprintf("Source %q priority %d → %d on %s", c.Source, c.Previous, c.Current, m.manager.Describe(c.Universe))
+		case c.PerAddress:
+			msg = fmt.Sprintf("Source %q started per-address priority on %s", c.Source, m.manager.Describe(c.Universe))
+		default:
+			msg = fmt.Sprintf("Source %q stopped per-address priority on %s", c.Source, m.manager.Describe(c.Universe))
+		}
+		m.record(now, PriorityChange, c.Universe, c.Source, msg, false)
+	}
+}
+
+// checkPriorityConflicts records when several online sources start
+// sharing a universe's highest priority; it re-arms once one remains
+func (m *Monitor) checkPriorityConflicts(now time.Time) {
+	for _, id := range m.tracker.GetAllUniverseIDs() {
+		var active []string
+		var priority uint8
+		for _, src := range m.tracker.CompareSources(id) {
+			if src.Active {
+				active = append(active, fmt.Sprintf("%q", src.Name))
+				priority = src.Priority
+			}
+		}
+
+		conflict := len(active) > 1
+		if conflict == m.conflicts[id] {
+			continue
+		}
+		m.conflicts[id] = conflict
+		if conflict {
+			m.record(now, PriorityConflict, id, "", fmt.Sprintf("Priority conflict on %s: %s all at priority %d", m.manager.Describe(id), strings.Join(active, ", "), priority), true)
+		}
+	}
+}
+
+// findSource returns the first universe a CID sends to and its name there
+func (m *Monitor) findSource(cid [16]byte) (uint16, string) {
+	for _, id := range m.tracker.GetAllUniverseIDs() {
+		for _, src := range m.tracker.GetSources(id) {
+			if src.CID == cid {
+				return id, src.Name
+			}
+		}
+	}
+	return 0, ""
+}
+
+// checkBlackouts records when a universe's output goes all-zero and when it
+// comes back
+func (m *Monitor) checkBlackouts(now time.Time) {
+	for _, u := range m.manager.GetAll() {
+		since, wasBlack := m.blackout[u.ID]
+		isBlack := u.IsBlackout()
+		if isBlack == wasBlack {
+			continue
+		}
+
+		source := u.GetInfo().SourceName
+		if isBlack {
+			m.blackout[u.ID] = now
+			m.record(now, Blackout, u.ID, source, fmt.Sprintf("Blackout on %s: all channels from %q at zero", m.manager.Describe(u.ID), source), false)
+		} else {
+			delete(m.blackout, u.ID)
+			m.record(now, BlackoutEnd, u.ID, source, fmt.Sprintf("Output restored on %s after %s blackout", m.manager.Describe(u.ID), now.Sub(since).Round(100*time.Millisecond)), true)
+		}
+	}
+}
+
+// checkRates records when a universe's packet rate starts and stops
+// deviating from its expected rate, and bursts far above it
+func (m *Monitor) checkRates(now time.Time) {
+	for _, id := range m.tracker.GetAllUniverseIDs() {
+		status := m.tracker.GetRateStatus(id)
+
+		// Bursts can be over between checks, so count them instead of
+		// watching the flag
+		if status.Bursts > m.bursts[id] {
+			m.bursts[id] = status.Bursts
+			m.record(now, Burst, id, "", fmt.Sprintf("Burst on %s: up to %.0f pps, expected %.1f", m.manager.Describe(id), status.LastBurst.PeakRate, status.Expected), false)
+		} else if status.Bursts < m.bursts[id] {
+			m.bursts[id] = status.Bursts // Stats were reset
+		}
+
+		if status.Deviating == m.offRate[id] {
+			continue
+		}
+		m.offRate[id] = status.Deviating
+
+		if status.Deviating {
+			m.record(now, RateDeviation, id, "", fmt.Sprintf("Refresh rate on %s at %.1f pps, expected %.1f", m.manager.Describe(id), status.Current, status.Expected), false)
+		} else {
+			m.record(now, RateRestored, id, "", fmt.Sprintf("Refresh rate on %s back to %.1f pps", m.manager.Describe(id), status.Current), false)
+		}
+	}
+}
+
+// record adds an event, capturing the universe state if requested
+func (m *Monitor) record(now time.Time, kind Kind, universeID uint16, source, message string, capture bool) {
+	e := Event{
+		Time:     now,
+		Kind:     kind,
+		Universe: universeID,
+		Source:   source,
+		Message:  message,
+	}
+
+	if capture {
+		if u := m.manager.Get(universeID); u != nil {
+			snap := u.Snapshot(string(kind))
+			e.Snapshot = &snap
+		}
+	}
+
+	m.log.Add(e)
+}
@@ -0,0 +1,263 @@
+package events
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"sacn-monitor/internal/stats"
+	"sacn-monitor/internal/universe"
+)
+
+func TestMonitor_SourceOnlineAndLost(t *testing.T) {
+	manager := universe.NewManager()
+	tracker := stats.NewTracker()
+	log := NewLog(0)
+	monitor := NewMonitor(manager, tracker, log)
+
+	cid := [16]byte{1}
+	manager.GetOrCreate(1).Update([]byte{42, 0, 7}, "console", cid, 100, 0)
+	tracker.RecordPacket(1, cid, "console", 0)
+
+	now := time.Now()
+	monitor.Check(now)
+	if log.Len() != 1 || log.Recent(1)[0].Kind != SourceOnline {
+		t.Fatalf("after first packet: events = %v, want one source_online", log.Recent(0))
+	}
+
+	// Nothing changes while the source keeps sending
+	monitor.Check(now)
+	if log.Len() != 1 {
+		t.Errorf("Len() = %d after repeated check, want 1", log.Len())
+	}
+
+	monitor.Check(now.Add(3 * time.Second))
+	lost := log.Recent(1)[0]
+	if lost.Kind != SourceLost || lost.Universe != 1 || lost.Source != "console" {
+		t.Fatalf("latest event = %+v, want source_lost for console on universe 1", lost)
+	}
+
+	if lost.Snapshot == nil {
+		t.Fatal("source_lost event has no snapshot")
+	}
+	if lost.Snapshot.Values[0] != 42 || lost.Snapshot.Values[2] != 7 {
+		t.Errorf("snapshot values = %v, want 42,0,7", lost.Snapshot.Values[:3])
+	}
+}
+
+func TestMonitor_Forget(t *testing.T) {
+	manager := universe.NewManager()
+	tracker := stats.NewTracker()
+	log := NewLog(0)
+	monitor := NewMonitor(manager, tracker, log)
+
+	cid := [16]byte{1}
+	manager.GetOrCreate(1).Update([]byte{42}, "console", cid, 100, 0)
+	tracker.RecordPacket(1, cid, "console", 0)
+	now := time.Now()
+	monitor.Check(now)
+
+	// A universe pruned and coming back reports its source online again
+	monitor.Forget(1)
+	monitor.Check(now)
+	if log.Len() != 2 || log.Recent(1)[0].Kind != SourceOnline {
+		t.Errorf("events after Forget = %v, want a second source_online", log.Recent(0))
+	}
+}
+
+func TestMonitor_LossSpike(t *testing.T) {
+	manager := universe.NewManager()
+	tracker := stats.NewTracker()
+	log := NewLog(0)
+	monitor := NewMonitor(manager, tracker, log)
+
+	cid := [16]byte{1}
+	manager.GetOrCreate(1).Update([]byte{255}, "console", cid, 100, 0)
+	tracker.RecordPacket(1, cid, "console", 0)
+	tracker.RecordPacket(1, cid, "console", 10) // 9 lost
+
+	monitor.Check(time.Now())
+
+	var spikes int
+	for _, e := range log.Recent(0) {
+		if e.Kind == LossSpike {
+			spikes++
+			if e.Snapshot == nil || e.Snapshot.Values[0] != 255 {
+				t.Errorf("loss spike snapshot = %v, want captured state", e.Snapshot)
+			}
+		}
+	}
+	if spikes != 1 {
+		t.Errorf("loss spikes = %d, want 1", spikes)
+	}
+
+	// Still above the threshold, so no new spike is recorded
+	monitor.Check(time.Now())
+	if got := len(log.Recent(0)); got != 2 {
+		t.Errorf("events = %d after repeated check, want 2", got)
+	}
+}
+
+func TestMonitor_DuplicateCID(t *testing.T) {
+	manager := universe.NewManager()
+	tracker := stats.NewTracker()
+	log := NewLog(0)
+	monitor := NewMonitor(manager, tracker, log)
+
+	cid := [16]byte{1}
+	tracker.RecordPacket(1, cid, "console", 0)
+	tracker.RecordSourceAddress(cid, "10.0.0.1")
+	tracker.RecordSourceAddress(cid, "10.0.0.2")
+
+	count := func() int {
+		n := 0
+		for _, e := range log.Recent(0) {
+			if e.Kind == DuplicateCID {
+				n++
+			}
+		}
+		return n
+	}
+
+	monitor.Check(time.Now())
+	if got := count(); got != 1 {
+		t.Fatalf("duplicate_cid events = %d, want 1", got)
+	}
+
+	// Reported once while the conflict persists
+	monitor.Check(time.Now())
+	if got := count(); got != 1 {
+		t.Errorf("duplicate_cid events = %d after repeated check, want 1", got)
+	}
+}
+
+func TestMonitor_Blackout(t *testing.T) {
+	manager := universe.NewManager()
+	log := NewLog(0)
+	monitor := NewMonitor(manager, stats.NewTracker(), log)
+
+	u := manager.GetOrCreate(1)
+	u.Update([]byte{255, 128}, "console", [16]byte{1}, 100, 0)
+
+	now := time.Now()
+	monitor.Check(now)
+	if log.Len() != 0 {
+		t.Fatalf("events = %v with output up, want none", log.Recent(0))
+	}
+
+	u.Update([]byte{0, 0}, "console", [16]byte{1}, 100, 1)
+	monitor.Check(now.Add(time.Second))
+	monitor.Check(now.Add(2 * time.Second))
+	if log.Len() != 1 || log.Recent(1)[0].Kind != Blackout {
+		t.Fatalf("events = %v, want one blackout", log.Recent(0))
+	}
+
+	u.Update([]byte{0, 10}, "console", [16]byte{1}, 100, 2)
+	monitor.Check(now.Add(4 * time.Second))
+	end := log.Recent(1)[0]
+	if end.Kind != BlackoutEnd || end.Snapshot == nil || end.Snapshot.Values[1] != 10 {
+		t.Errorf("latest event = %+v, want blackout_end with a snapshot", end)
+	}
+	if !strings.Contains(end.Message, "3s") {
+		t.Errorf("Message = %q, want the blackout duration", end.Message)
+	}
+}
+
+func TestMonitor_AddressChange(t *testing.T) {
+	manager := universe.NewManager()
+	tracker := stats.NewTracker()
+	log := NewLog(0)
+	monitor := NewMonitor(manager, tracker, log)
+
+	cid := [16]byte{1}
+	tracker.RecordPacket(3, cid, "console", 0)
+	tracker.RecordSourceAddress(cid, "10.0.0.1")
+	tracker.RecordSourceAddress(cid, "10.0.0.2")
+
+	now := time.Now()
+	monitor.Check(now)
+	monitor.Check(now)
+
+	var changes []Event
+	for _, e := range log.Recent(0) {
+		if e.Kind == AddressChange {
+			changes = append(changes, e)
+		}
+	}
+	if len(changes) != 1 {
+		t.Fatalf("address change events = %+v, want exactly one", changes)
+	}
+	if e := changes[0]; e.Universe != 3 || e.Source != "console" || !strings.Contains(e.Message, "10.0.0.1 to 10.0.0.2") {
+		t.Errorf("event = %+v, want console on universe 3 moving to 10.0.0.2", e)
+	}
+}
+
+func TestMonitor_PriorityChange(t *testing.T) {
+	manager := universe.NewManager()
+	tracker := stats.NewTracker()
+	log := NewLog(0)
+	monitor := NewMonitor(manager, tracker, log)
+
+	cid := [16]byte{1}
+	tracker.RecordPacket(3, cid, "backup", 0)
+	tracker.RecordPriority(3, cid, 100, false)
+	tracker.RecordPacket(3, cid, "backup", 1)
+	tracker.RecordPriority(3, cid, 150, false)
+
+	now := time.Now()
+	monitor.Check(now)
+	monitor.Check(now)
+
+	var changes []Event
+	for _, e := range log.Recent(0) {
+		if e.Kind == PriorityChange {
+			changes = append(changes, e)
+		}
+	}
+	if len(changes) != 1 {
+		t.Fatalf("priority change events = %+v, want exactly one", changes)
+	}
+	if e := changes[0]; e.Universe != 3 || e.Source != "backup" || !strings.Contains(e.Message, "100 → 150") {
+		t.Errorf("event = %+v, want backup on universe 3 going 100 → 150", e)
+	}
+}
+
+func TestMonitor_PriorityConflict(t *testing.T) {
+	manager := universe.NewManager()
+	tracker := stats.NewTracker()
+	log := NewLog(0)
+	monitor := NewMonitor(manager, tracker, log)
+
+	main, backup := [16]byte{1}, [16]byte{2}
+	tracker.RecordPacket(1, main, "main", 0)
+	tracker.RecordPriority(1, main, 100, false)
+	tracker.RecordPacket(1, backup, "backup", 0)
+	tracker.RecordPriority(1, backup, 90, false)
+
+	countConflicts := func() int {
+		n := 0
+		for _, e := range log.Recent(0) {
+			if e.Kind == PriorityConflict {
+				n++
+			}
+		}
+		return n
+	}
+
+	monitor.Check(time.Now())
+	if n := countConflicts(); n != 0 {
+		t.Fatalf("conflicts with distinct priorities = %d, want 0", n)
+	}
+
+	// The backup raising its priority to the main's ties them
+	tracker.RecordPacket(1, backup, "backup", 1)
+	tracker.RecordPriority(1, backup, 100, false)
+	monitor.Check(time.Now())
+	monitor.Check(time.Now())
+	if n := countConflicts(); n != 1 {
+		t.Fatalf("conflicts after tie = %d, want 1", n)
+	}
+	if e := log.Recent(1)[0]; e.Kind != PriorityConflict || !strings.Contains(e.Message, "priority 100") {
+		t.Errorf("latest event = %+v, want the conflict at priority 100", e)
+	}
+}
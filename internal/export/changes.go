@@ -0,0 +1,235 @@
+package export
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"sacn-monitor/internal/patch"
+)
+
+// Channel change log defaults
+const (
+	// DefaultChangeLogSize is the size at which the log rotates, in bytes
+	DefaultChangeLogSize = 10 << 20
+	// changeLogKeep is how many rotated files are kept (path.1 is the newest)
+	changeLogKeep = 5
+	// changeLogFlush is how often buffered rows are written out
+	changeLogFlush = time.Second
+)
+
+// changeLogHeader is the first row of every change log file
+var changeLogHeader = []string{"time", "universe", "channel", "old", "new", "source", "cid", "label"}
+
+// ChangeLog writes every channel value change to a rotating CSV file, as
+// simple evidence of what happened during a fault. It compares each packet
+// with the previous values of its universe, so changes shorter than the
+// UI's refresh are still caught. A universe's first packet only sets the
+// starting values. Rows carry the channel's patch label, if labels are
+// set.
+type ChangeLog struct {
+	path    string
+	maxSize int64
+	labels  func(universeID uint16, channel int) (patch.Label, bool)
+
+	file    *os.File
+	csv     *csv.Writer            // Buffers rows until flushed
+	size    int64                  // Bytes in the current file, buffered rows included
+	last    map[uint16]*[512]int16 // Previous value per channel, -1 = not received
+	lastErr error                  // First write error since the last flush
+	mu      sync.Mutex
+}
+
+// OpenChangeLog opens or creates the change log at path, rotating it once
+// it grows past maxSize bytes (0 = default size)
+func OpenChangeLog(path string, maxSize int64) (*ChangeLog, error) {
+	if maxSize <= 0 {
+		maxSize = DefaultChangeLogSize
+	}
+	l := &ChangeLog{path: path, maxSize: maxSize, last: make(map[uint16]*[512]int16)}
+	if err := l.open(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// SetLabels makes rows carry channel labels looked up with labels, e.g. a
+// manager's ChannelLabel
+func (l *ChangeLog) SetLabels(labels func(universeID uint16, channel int) (patch.Label, bool)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.labels = labels
+}
+
+// Record logs the channels of a packet whose value differs from the
+// universe's previous packet
+func (l *ChangeLog) Record(universeID uint16, data []byte, source string, cid [16]byte, at time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	last, seen := l.last[universeID]
+	if !seen {
+		last = newSentValues()
+		l.last[universeID] = last
+	}
+
+	var stamp, cidText string
+	for i := 0; i < len(data) && i < 512; i++ {
+		old := last[i]
+		last[i] = int16(data[i])
+		if old < 0 || old == int16(data[i]) {
+			continue
+		}
+		if stamp == "" {
+			stamp = at.Format(time.RFC3339Nano)
+			cidText = fmt.Sprintf("%x", cid)
+		}
+		var label string
+		if l.labels != nil {
+			if patched, ok := l.labels(universeID, i+1); ok {
+				label = patched.String()
+			}
+		}
+		l.write([]string{
+			stamp,
+			strconv.Itoa(int(universeID)),
+			strconv.Itoa(i + 1),
+			strconv.Itoa(int(old)),
+			strconv.Itoa(int(data[i])),
+			source,
+			cidText,
+			label,
+		})
+	}
+}
+
+// Run writes buffered rows out every second until the context is
+// cancelled. Write errors are reported to onError.
+func (l *ChangeLog) Run(ctx context.Context, onError func(error)) {
+	ticker := time.NewTicker(changeLogFlush)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := l.Flush(); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+// Flush writes buffered rows to the file and returns the first error
+// since the previous flush
+func (l *ChangeLog) Flush() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.flush()
+}
+
+// Close flushes and closes the file
+func (l *ChangeLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	err := l.flush()
+	if cerr := l.file.Close(); err == nil && cerr != nil {
+		err = fmt.Errorf("failed to close change log: %w", cerr)
+	}
+	return err
+}
+
+// write appends a row, rotating first if the file is full. Caller must
+// hold l.mu.
+func (l *ChangeLog) write(row []string) {
+	if l.size >= l.maxSize {
+		if err := l.rotate(); err != nil {
+			l.fail(err)
+			return
+		}
+	}
+
+	n := len(row) // Separators and newline
+	for _, field := range row {
+		n += len(field)
+	}
+	if err := l.csv.Write(row); err != nil {
+		l.fail(fmt.Errorf("failed to write change log: %w", err))
+		return
+	}
+	l.size += int64(n)
+}
+
+// open opens the log file for appending, writing the header to a new
+// file. Caller must hold l.mu or own l.
+func (l *ChangeLog) open() error {
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open change log: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("failed to open change log: %w", err)
+	}
+
+	l.file = f
+	l.csv = csv.NewWriter(f)
+	l.size = info.Size()
+	if l.size == 0 {
+		l.write(changeLogHeader)
+	}
+	return nil
+}
+
+// rotate closes the current file, shifts path.1 … path.N-1 up by one
+// (dropping the oldest), renames the current file to path.1 and starts a
+// new one. Caller must hold l.mu.
+func (l *ChangeLog) rotate() error {
+	if err := l.flush(); err != nil {
+		return err
+	}
+	if err := l.file.Close(); err != nil {
+		return fmt.Errorf("failed to rotate change log: %w", err)
+	}
+
+	for i := changeLogKeep - 1; i >= 1; i-- {
+		from := l.path + "." + strconv.Itoa(i)
+		to := l.path + "." + strconv.Itoa(i+1)
+		if err := os.Rename(from, to); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to rotate change log: %w", err)
+		}
+	}
+	if err := os.Rename(l.path, l.path+".1"); err != nil {
+		return fmt.Errorf("failed to rotate change log: %w", err)
+	}
+	return l.open()
+}
+
+// flush writes buffered rows out and returns, then clears, the first
+// error since the previous flush. Caller must hold l.mu.
+func (l *ChangeLog) flush() error {
+	l.csv.Flush()
+	if err := l.csv.Error(); err != nil {
+		l.fail(fmt.Errorf("failed to write change log: %w", err))
+	}
+
+	err := l.lastErr
+	l.lastErr = nil
+	return err
+}
+
+// fail keeps the first error until the next flush reports it
+func (l *ChangeLog) fail(err error) {
+	if l.lastErr == nil {
+		l.lastErr = err
+	}
+}
@@ -0,0 +1,83 @@
+package export
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"sacn-monitor/internal/patch"
+)
+
+// readCSV returns the rows of a CSV file
+func readCSV(t *testing.T, path string) [][]string {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", path, err)
+	}
+	defer f.Close()
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", path, err)
+	}
+	return rows
+}
+
+func TestChangeLog_Record(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "changes.csv")
+	l, err := OpenChangeLog(path, 0)
+	if err != nil {
+		t.Fatalf("OpenChangeLog() returned error: %v", err)
+	}
+	l.SetLabels(func(universeID uint16, channel int) (patch.Label, bool) {
+		return patch.Label{Fixture: "Spot 1", Parameter: "Dim"}, channel == 2
+	})
+
+	at := time.Date(2026, 3, 14, 19, 0, 0, 0, time.UTC)
+	l.Record(1, []byte{0, 10, 20}, "console", [16]byte{1}, at)                  // Starting values
+	l.Record(1, []byte{0, 15, 20}, "console", [16]byte{1}, at.Add(time.Second)) // Channel 2 changes
+	l.Record(1, []byte{0, 15, 20}, "console", [16]byte{1}, at.Add(2*time.Second))
+	if err := l.Close(); err != nil {
+		t.Fatalf("Close() returned error: %v", err)
+	}
+
+	rows := readCSV(t, path)
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want header and one change: %v", len(rows), rows)
+	}
+	want := []string{"2026-03-14T19:00:01Z", "1", "2", "10", "15", "console", "01000000000000000000000000000000", "Spot 1 Dim"}
+	for i := range want {
+		if rows[1][i] != want[i] {
+			t.Errorf("row = %v, want %v", rows[1], want)
+			break
+		}
+	}
+}
+
+func TestChangeLog_Rotates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "changes.csv")
+	l, err := OpenChangeLog(path, 200)
+	if err != nil {
+		t.Fatalf("OpenChangeLog() returned error: %v", err)
+	}
+
+	at := time.Now()
+	l.Record(1, []byte{0}, "console", [16]byte{1}, at)
+	for i := 1; i <= 20; i++ {
+		l.Record(1, []byte{byte(i)}, "console", [16]byte{1}, at)
+	}
+	if err := l.Close(); err != nil {
+		t.Fatalf("Close() returned error: %v", err)
+	}
+
+	for _, name := range []string{path, path + ".1", path + ".5"} {
+		if rows := readCSV(t, name); rows[0][0] != "time" {
+			t.Errorf("%s starts with %v, want the header", name, rows[0])
+		}
+	}
+	if _, err := os.Stat(path + ".6"); err == nil {
+		t.Error("more rotated files kept than the limit")
+	}
+}
@@ -0,0 +1,229 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"sacn-monitor/internal/stats"
+	"sacn-monitor/internal/universe"
+)
+
+// Influx export defaults
+const (
+	// DefaultInfluxInterval is how often metrics are written
+	DefaultInfluxInterval = 10 * time.Second
+	// influxTimeout bounds each HTTP write
+	influxTimeout = 10 * time.Second
+	// influxTokenEnv names the environment variable holding an API token
+	influxTokenEnv = "INFLUX_TOKEN"
+)
+
+// influxEscaper escapes tag keys and values in line protocol
+var influxEscaper = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `)
+
+// InfluxExporter writes per-universe and per-source metrics in InfluxDB
+// line protocol, for venues standardized on InfluxDB or Telegraf:
+//
+//	sacn_universe,universe=1,name=FOH packet_rate=44,loss_pct=0,... <ns>
+//	sacn_source,universe=1,source=console,cid=... packets=1234i,online=true,... <ns>
+type InfluxExporter struct {
+	manager  *universe.Manager
+	tracker  *stats.Tracker
+	send     func(lines []string) error
+	close    func() error
+	interval time.Duration
+}
+
+// DialInflux prepares writes to target: an http:// or https:// write URL
+// (e.g. http://host:8086/api/v2/write?org=venue&bucket=sacn, or
+// /write?db=sacn for 1.x), or udp://host:port for a UDP listener. HTTP
+// writes send the token in $INFLUX_TOKEN, if set.
+func DialInflux(target string, manager *universe.Manager, tracker *stats.Tracker) (*InfluxExporter, error) {
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Influx target %q: %w", target, err)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid Influx target %q: missing host:port", target)
+	}
+
+	switch u.Scheme {
+	case "http", "https":
+		e := NewInfluxExporter(manager, tracker, httpInfluxWriter(target, os.Getenv(influxTokenEnv)))
+		return e, nil
+	case "udp":
+		conn, err := net.Dial("udp", u.Host)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to Influx target %s: %w", target, err)
+		}
+		e := NewInfluxExporter(manager, tracker, udpInfluxWriter(conn))
+		e.close = conn.Close
+		return e, nil
+	default:
+		return nil, fmt.Errorf("invalid Influx target %q: scheme must be http, https or udp", target)
+	}
+}
+
+// NewInfluxExporter creates an exporter handing its lines to send
+func NewInfluxExporter(manager *universe.Manager, tracker *stats.Tracker, send func(lines []string) error) *InfluxExporter {
+	return &InfluxExporter{
+		manager:  manager,
+		tracker:  tracker,
+		send:     send,
+		interval: DefaultInfluxInterval,
+	}
+}
+
+// SetInterval sets how often metrics are written
+func (e *InfluxExporter) SetInterval(d time.Duration) {
+	if d > 0 {
+		e.interval = d
+	}
+}
+
+// Run writes metrics at each interval until the context is cancelled.
+// Failed writes are reported to onError; their points are dropped.
+func (e *InfluxExporter) Run(ctx context.Context, onError func(error)) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := e.send(e.Lines(now)); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+// Close releases the connection of a UDP exporter
+func (e *InfluxExporter) Close() error {
+	if e.close != nil {
+		return e.close()
+	}
+	return nil
+}
+
+// Lines returns the metrics of every universe and its sources as line
+// protocol, stamped with now
+func (e *InfluxExporter) Lines(now time.Time) []string {
+	ts := strconv.FormatInt(now.UnixNano(), 10)
+	ids := e.tracker.GetAllUniverseIDs()
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var lines []string
+	for _, id := range ids {
+		universeTag := "universe=" + strconv.Itoa(int(id))
+		tags := universeTag
+		if name := e.manager.Name(id); name != "" {
+			tags += ",name=" + influxEscaper.Replace(name)
+		}
+
+		sources := e.tracker.GetSources(id)
+		var packets, lost uint64
+		for _, s := range sources {
+			packets += s.PacketCount
+			lost += s.LostPackets
+		}
+		active := 0
+		if u := e.manager.Get(id); u != nil {
+			active = u.ActiveChannelCount()
+		}
+		lines = append(lines, fmt.Sprintf("sacn_universe,%s packet_rate=%g,byte_rate=%g,loss_pct=%g,recent_loss_pct=%g,jitter_ms=%g,health=%di,packets=%di,lost=%di,sources=%di,active_channels=%di %s",
+			tags,
+			e.tracker.GetPacketRate(id),
+			e.tracker.GetByteRate(id),
+			e.tracker.GetLossPercentage(id),
+			e.tracker.GetRecentLossPercentage(id),
+			float64(e.tracker.GetJitter(id).StdDev)/float64(time.Millisecond),
+			e.tracker.GetHealth(id).Score,
+			packets, lost, len(sources), active, ts))
+
+		sort.Slice(sources, func(i, j int) bool { return sources[i].Name < sources[j].Name })
+		for _, s := range sources {
+			online := now.Sub(s.LastSeen) <= sourceOfflineAfter
+			lines = append(lines, fmt.Sprintf("sacn_source,%s,source=%s,cid=%x byte_rate=%g,loss_pct=%g,priority=%di,packets=%di,lost=%di,online=%t %s",
+				universeTag,
+				influxEscaper.Replace(sourceTag(s.Name)),
+				s.CID,
+				e.tracker.GetSourceByteRate(id, s.CID),
+				e.tracker.GetSourceLossPercentage(id, s.CID),
+				s.Priority, s.PacketCount, s.LostPackets, online, ts))
+		}
+	}
+	return lines
+}
+
+// sourceTag returns a source name usable as a tag value, which can't be empty
+func sourceTag(name string) string {
+	if name == "" {
+		return "unnamed"
+	}
+	return name
+}
+
+// httpInfluxWriter posts lines to an InfluxDB write endpoint
+func httpInfluxWriter(target, token string) func([]string) error {
+	client := &http.Client{Timeout: influxTimeout}
+	return func(lines []string) error {
+		if len(lines) == 0 {
+			return nil
+		}
+		req, err := http.NewRequest(http.MethodPost, target, strings.NewReader(strings.Join(lines, "\n")+"\n"))
+		if err != nil {
+			return fmt.Errorf("influx write failed: %w", err)
+		}
+		req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+		if token != "" {
+			req.Header.Set("Authorization", "Token "+token)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return fmt.Errorf("influx write failed: %w", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode/100 != 2 {
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+			return fmt.Errorf("influx write failed: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+		}
+		return nil
+	}
+}
+
+// udpInfluxWriter sends lines over UDP, packed into MTU-sized datagrams
+func udpInfluxWriter(conn net.Conn) func([]string) error {
+	return func(lines []string) error {
+		var buf bytes.Buffer
+		for _, line := range lines {
+			if buf.Len() > 0 && buf.Len()+len(line)+1 > previzMaxDatagram {
+				if _, err := conn.Write(buf.Bytes()); err != nil {
+					return fmt.Errorf("influx write failed: %w", err)
+				}
+				buf.Reset()
+			}
+			buf.WriteString(line)
+			buf.WriteByte('\n')
+		}
+		if buf.Len() == 0 {
+			return nil
+		}
+		if _, err := conn.Write(buf.Bytes()); err != nil {
+			return fmt.Errorf("influx write failed: %w", err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,64 @@
+package export
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"sacn-monitor/internal/stats"
+	"sacn-monitor/internal/universe"
+)
+
+func TestInfluxExporter_Lines(t *testing.T) {
+	manager := universe.NewManager()
+	manager.SetName(1, "FOH rig")
+	manager.GetOrCreate(1).Update([]byte{255}, "console", [16]byte{1}, 100, 0)
+	tracker := stats.NewTracker()
+	tracker.RecordPacket(1, [16]byte{1}, "Main, desk", 0)
+
+	now := time.Unix(1700000000, 0)
+	lines := NewInfluxExporter(manager, tracker, nil).Lines(now)
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2:\n%s", len(lines), strings.Join(lines, "\n"))
+	}
+
+	for _, want := range []string{`sacn_universe,universe=1,name=FOH\ rig `, "packets=1i", "sources=1i", "active_channels=1i", " 1700000000000000000"} {
+		if !strings.Contains(lines[0], want) {
+			t.Errorf("universe line %q missing %q", lines[0], want)
+		}
+	}
+	for _, want := range []string{`sacn_source,universe=1,source=Main\,\ desk,cid=01000000`, "online=true", "packets=1i"} {
+		if !strings.Contains(lines[1], want) {
+			t.Errorf("source line %q missing %q", lines[1], want)
+		}
+	}
+}
+
+func TestHTTPInfluxWriter(t *testing.T) {
+	var body, auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		body, auth = string(b), r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	write := httpInfluxWriter(server.URL+"/api/v2/write?bucket=sacn", "secret")
+	if err := write([]string{"a x=1i", "b x=2i"}); err != nil {
+		t.Fatalf("write returned error: %v", err)
+	}
+	if body != "a x=1i\nb x=2i\n" || auth != "Token secret" {
+		t.Errorf("posted %q with %q, want two lines with the token", body, auth)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bucket not found", http.StatusNotFound)
+	}))
+	defer failing.Close()
+	if err := httpInfluxWriter(failing.URL, "")([]string{"a x=1i"}); err == nil || !strings.Contains(err.Error(), "bucket not found") {
+		t.Errorf("write error = %v, want the server's message", err)
+	}
+}
@@ -0,0 +1,66 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"sacn-monitor/internal/stats"
+)
+
+// lossLogHeader is the first row of an exported loss log
+var lossLogHeader = []string{"time", "universe", "source", "cid", "lost", "first_missing", "last_missing", "received_after", "received_next"}
+
+// WriteLossLog writes loss events as CSV, one row per event in the order given
+func WriteLossLog(w io.Writer, events []stats.LossEvent) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(lossLogHeader); err != nil {
+		return fmt.Errorf("failed to write loss log: %w", err)
+	}
+
+	for _, e := range events {
+		row := []string{
+			e.Time.Format(time.RFC3339Nano),
+			strconv.Itoa(int(e.Universe)),
+			e.Source,
+			fmt.Sprintf("%x", e.CID),
+			strconv.Itoa(e.Lost),
+			strconv.Itoa(int(e.FirstMissing)),
+			strconv.Itoa(int(e.LastMissing)),
+			strconv.Itoa(int(e.ReceivedAfter)),
+			strconv.Itoa(int(e.ReceivedNext)),
+		}
+		if err := cw.Write(row); err != nil {
+			return fmt.Errorf("failed to write loss log: %w", err)
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write loss log: %w", err)
+	}
+	return nil
+}
+
+// WriteLossLogFile writes loss events as CSV to a file, oldest first
+func WriteLossLogFile(path string, events []stats.LossEvent) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create loss log: %w", err)
+	}
+
+	// Callers hold events newest first, as returned by the tracker
+	ordered := make([]stats.LossEvent, len(events))
+	for i, e := range events {
+		ordered[len(events)-1-i] = e
+	}
+
+	if err := WriteLossLog(f, ordered); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,58 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"sacn-monitor/internal/stats"
+)
+
+func TestWriteLossLog(t *testing.T) {
+	at := time.Date(2026, 3, 14, 21, 10, 5, 0, time.UTC)
+	events := []stats.LossEvent{{
+		Time:          at,
+		Universe:      3,
+		CID:           [16]byte{0xab},
+		Source:        "Main, Console",
+		Lost:          2,
+		FirstMissing:  12,
+		LastMissing:   13,
+		ReceivedAfter: 11,
+		ReceivedNext:  14,
+	}}
+
+	var sb strings.Builder
+	if err := WriteLossLog(&sb, events); err != nil {
+		t.Fatalf("WriteLossLog() returned error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(sb.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want header and one row:\n%s", len(lines), sb.String())
+	}
+	want := `2026-03-14T21:10:05Z,3,"Main, Console",ab000000000000000000000000000000,2,12,13,11,14`
+	if lines[1] != want {
+		t.Errorf("row = %q, want %q", lines[1], want)
+	}
+}
+
+func TestWriteLossLogFile_OldestFirst(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "losses.csv")
+	newestFirst := []stats.LossEvent{{Universe: 2}, {Universe: 1}}
+
+	if err := WriteLossLogFile(path, newestFirst); err != nil {
+		t.Fatalf("WriteLossLogFile() returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 3 || !strings.Contains(lines[1], ",1,") || !strings.Contains(lines[2], ",2,") {
+		t.Errorf("file = %q, want universe 1 then 2", data)
+	}
+}
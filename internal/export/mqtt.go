@@ -0,0 +1,294 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"sacn-monitor/internal/stats"
+	"sacn-monitor/internal/universe"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+// MQTT publishing defaults
+const (
+	// DefaultMQTTPrefix is the topic prefix when none is configured
+	DefaultMQTTPrefix = "sacn-monitor"
+	// DefaultMQTTInterval is how often summaries are published
+	DefaultMQTTInterval = 5 * time.Second
+	// mqttTimeout bounds connecting and each publish
+	mqttTimeout = 10 * time.Second
+	// sourceOfflineAfter is the silence after which a source is published
+	// as offline (E1.31 network data loss timeout)
+	sourceOfflineAfter = 2500 * time.Millisecond
+)
+
+// PublishFunc publishes a payload to an MQTT topic
+type PublishFunc func(topic string, retained bool, payload []byte) error
+
+// MQTTSummary is the payload of a universe summary topic
+type MQTTSummary struct {
+	Time                 time.Time `json:"time"`
+	Universe             uint16    `json:"universe"`
+	Name                 string    `json:"name,omitempty"`
+	PacketRate           float64   `json:"packet_rate"`
+	LossPercentage       float64   `json:"loss_percentage"`
+	RecentLossPercentage float64   `json:"recent_loss_percentage"`
+	Health               int       `json:"health"`
+	Sources              int       `json:"sources"`
+	ActiveChannels       int       `json:"active_channels"`
+	Stale                bool      `json:"stale"`
+}
+
+// MQTTSource is the payload of a source state topic
+type MQTTSource struct {
+	Time        time.Time `json:"time"`
+	Universe    uint16    `json:"universe"`
+	CID         string    `json:"cid"`
+	Name        string    `json:"name"`
+	Address     string    `json:"address,omitempty"`
+	Priority    uint8     `json:"priority"`
+	State       string    `json:"state"` // "online" or "offline"
+	LastSeen    time.Time `json:"last_seen"`
+	Packets     uint64    `json:"packets"`
+	LostPackets uint64    `json:"lost_packets"`
+}
+
+// MQTTPublisher publishes universe summaries, source states and optionally
+// channel values to an MQTT broker, for building automation and remote
+// monitoring. Under the topic prefix:
+//
+//	<prefix>/status                            "online", or "offline" as the will
+//	<prefix>/universe/<id>/summary             MQTTSummary
+//	<prefix>/universe/<id>/source/<cid>        MQTTSource (retained)
+//	<prefix>/universe/<id>/channels            512 values, -1 if not received
+type MQTTPublisher struct {
+	publish  PublishFunc
+	close    func()
+	manager  *universe.Manager
+	tracker  *stats.Tracker
+	prefix   string
+	interval time.Duration
+	channels bool
+
+	sent map[uint16][]int // Channel values last published per universe
+}
+
+// DialMQTT connects to the broker at target (tcp://, ssl:// or ws://, with
+// optional user:password@), announcing the monitor under prefix with a
+// retained status that the broker turns to "offline" if we vanish
+func DialMQTT(target, prefix string, manager *universe.Manager, tracker *stats.Tracker) (*MQTTPublisher, error) {
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MQTT broker %q: %w", target, err)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid MQTT broker %q: missing host:port", target)
+	}
+	if prefix == "" {
+		prefix = DefaultMQTTPrefix
+	}
+
+	opts := mqtt.NewClientOptions().
+		AddBroker(target).
+		SetClientID(mqttClientID()).
+		SetWill(prefix+"/status", "offline", 1, true).
+		SetAutoReconnect(true).
+		SetConnectTimeout(mqttTimeout)
+	if u.User != nil {
+		opts.SetUsername(u.User.Username())
+		if password, ok := u.User.Password(); ok {
+			opts.SetPassword(password)
+		}
+	}
+	// Re-announce after reconnecting, since the will fired meanwhile
+	opts.SetOnConnectHandler(func(c mqtt.Client) {
+		c.Publish(prefix+"/status", 1, true, "online")
+	})
+
+	client := mqtt.NewClient(opts)
+	token := client.Connect()
+	if !token.WaitTimeout(mqttTimeout) {
+		return nil, fmt.Errorf("failed to connect to MQTT broker %s: timed out", target)
+	}
+	if err := token.Error(); err != nil {
+		return nil, fmt.Errorf("failed to connect to MQTT broker %s: %w", target, err)
+	}
+
+	publish := func(topic string, retained bool, payload []byte) error {
+		token := client.Publish(topic, 0, retained, payload)
+		if !token.WaitTimeout(mqttTimeout) {
+			return fmt.Errorf("MQTT publish to %s timed out", topic)
+		}
+		if err := token.Error(); err != nil {
+			return fmt.Errorf("MQTT publish to %s failed: %w", topic, err)
+		}
+		return nil
+	}
+	p := NewMQTTPublisher(publish, prefix, manager, tracker)
+	p.close = func() {
+		publish(prefix+"/status", true, []byte("offline"))
+		client.Disconnect(250)
+	}
+	return p, nil
+}
+
+// NewMQTTPublisher creates a publisher sending through publish
+func NewMQTTPublisher(publish PublishFunc, prefix string, manager *universe.Manager, tracker *stats.Tracker) *MQTTPublisher {
+	if prefix == "" {
+		prefix = DefaultMQTTPrefix
+	}
+	return &MQTTPublisher{
+		publish:  publish,
+		manager:  manager,
+		tracker:  tracker,
+		prefix:   prefix,
+		interval: DefaultMQTTInterval,
+		sent:     make(map[uint16][]int),
+	}
+}
+
+// SetInterval sets how often summaries are published
+func (p *MQTTPublisher) SetInterval(d time.Duration) {
+	if d > 0 {
+		p.interval = d
+	}
+}
+
+// PublishChannels turns on publishing channel values, sent when they changed
+func (p *MQTTPublisher) PublishChannels(enabled bool) {
+	p.channels = enabled
+}
+
+// Run publishes at each interval until the context is cancelled. Failed
+// publishes are reported to onError and retried at the next interval.
+func (p *MQTTPublisher) Run(ctx context.Context, onError func(error)) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := p.Publish(now); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+// Publish sends the summary and source states of every universe, and the
+// channel values that changed since they were last sent
+func (p *MQTTPublisher) Publish(now time.Time) error {
+	ids := p.tracker.GetAllUniverseIDs()
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, id := range ids {
+		base := p.prefix + "/universe/" + strconv.Itoa(int(id))
+		u := p.manager.Get(id)
+		sources := p.tracker.GetSources(id)
+
+		summary := MQTTSummary{
+			Time:                 now,
+			Universe:             id,
+			Name:                 p.manager.Name(id),
+			PacketRate:           p.tracker.GetPacketRate(id),
+			LossPercentage:       p.tracker.GetLossPercentage(id),
+			RecentLossPercentage: p.tracker.GetRecentLossPercentage(id),
+			Health:               p.tracker.GetHealth(id).Score,
+			Sources:              len(sources),
+			Stale:                u == nil || u.IsStale(time.Second),
+		}
+		if u != nil {
+			summary.ActiveChannels = u.ActiveChannelCount()
+		}
+		if err := p.publishJSON(base+"/summary", false, summary); err != nil {
+			return err
+		}
+
+		for _, s := range sources {
+			cid := fmt.Sprintf("%x", s.CID)
+			state := "online"
+			if now.Sub(s.LastSeen) > sourceOfflineAfter {
+				state = "offline"
+			}
+			source := MQTTSource{
+				Time:        now,
+				Universe:    id,
+				CID:         cid,
+				Name:        s.Name,
+				Address:     s.Address,
+				Priority:    s.Priority,
+				State:       state,
+				LastSeen:    s.LastSeen,
+				Packets:     s.PacketCount,
+				LostPackets: s.LostPackets,
+			}
+			if err := p.publishJSON(base+"/source/"+cid, true, source); err != nil {
+				return err
+			}
+		}
+
+		if p.channels && u != nil {
+			if err := p.publishChannels(base+"/channels", u); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Close announces the monitor going offline and disconnects
+func (p *MQTTPublisher) Close() {
+	if p.close != nil {
+		p.close()
+	}
+}
+
+// publishChannels sends a universe's channel values if they changed
+func (p *MQTTPublisher) publishChannels(topic string, u *universe.Universe) error {
+	channels := u.GetAllChannels()
+	values := make([]int, len(channels))
+	for i, ch := range channels {
+		values[i] = -1
+		if ch.Active {
+			values[i] = int(ch.Value)
+		}
+	}
+
+	if sent, ok := p.sent[u.ID]; ok && slices.Equal(sent, values) {
+		return nil
+	}
+	if err := p.publishJSON(topic, false, values); err != nil {
+		return err
+	}
+	p.sent[u.ID] = values
+	return nil
+}
+
+// publishJSON publishes v encoded as JSON
+func (p *MQTTPublisher) publishJSON(topic string, retained bool, v any) error {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode MQTT payload for %s: %w", topic, err)
+	}
+	return p.publish(topic, retained, payload)
+}
+
+// mqttClientID returns a client ID unique to this host and process, so
+// several monitors can share a broker
+func mqttClientID() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("sacn-monitor-%s-%d", host, os.Getpid())
+}
@@ -0,0 +1,69 @@
+package export
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"sacn-monitor/internal/stats"
+	"sacn-monitor/internal/universe"
+)
+
+// published is a message captured by a fake MQTT publish function
+type published struct {
+	retained bool
+	payload  []byte
+}
+
+func TestMQTTPublisher_Publish(t *testing.T) {
+	manager := universe.NewManager()
+	manager.SetName(1, "FOH")
+	manager.GetOrCreate(1).Update([]byte{255}, "console", [16]byte{1}, 100, 0)
+	tracker := stats.NewTracker()
+	tracker.RecordPacket(1, [16]byte{1}, "console", 0)
+
+	messages := make(map[string]published)
+	p := NewMQTTPublisher(func(topic string, retained bool, payload []byte) error {
+		messages[topic] = published{retained, payload}
+		return nil
+	}, "venue", manager, tracker)
+	p.PublishChannels(true)
+
+	if err := p.Publish(time.Now()); err != nil {
+		t.Fatalf("Publish() returned error: %v", err)
+	}
+
+	var summary MQTTSummary
+	if err := json.Unmarshal(messages["venue/universe/1/summary"].payload, &summary); err != nil {
+		t.Fatalf("summary not published as JSON: %v (topics %v)", err, messages)
+	}
+	if summary.Name != "FOH" || summary.Sources != 1 || summary.ActiveChannels != 1 {
+		t.Errorf("summary = %+v, want FOH with 1 source and 1 active channel", summary)
+	}
+
+	msg := messages["venue/universe/1/source/01000000000000000000000000000000"]
+	var source MQTTSource
+	if err := json.Unmarshal(msg.payload, &source); err != nil {
+		t.Fatalf("source state not published as JSON: %v", err)
+	}
+	if !msg.retained || source.Name != "console" || source.State != "online" {
+		t.Errorf("source state = %+v (retained %v), want console online, retained", source, msg.retained)
+	}
+
+	var channels []int
+	if err := json.Unmarshal(messages["venue/universe/1/channels"].payload, &channels); err != nil {
+		t.Fatalf("channels not published as JSON: %v", err)
+	}
+	if len(channels) != 512 || channels[0] != 255 || channels[1] != -1 {
+		t.Errorf("channels start %v, want [255 -1]", channels[:2])
+	}
+
+	// Unchanged channel values aren't sent again
+	delete(messages, "venue/universe/1/channels")
+	if err := p.Publish(time.Now()); err != nil {
+		t.Fatalf("Publish() returned error: %v", err)
+	}
+	if _, ok := messages["venue/universe/1/channels"]; ok {
+		t.Error("unchanged channel values published again")
+	}
+}
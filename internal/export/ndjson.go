@@ -0,0 +1,291 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"sacn-monitor/internal/events"
+	"sacn-monitor/internal/stats"
+	"sacn-monitor/internal/universe"
+)
+
+// Event stream defaults
+const (
+	// streamInterval is how often monitor events and channel changes are
+	// written by default
+	streamInterval = time.Second
+	// summaryInterval is how often packet summaries are written
+	summaryInterval = time.Second
+	// streamBuffer is how many loss notifications may queue between writes
+	streamBuffer = 256
+	// DefaultChangeThreshold is the level change a channel must make since
+	// its last reported value to be reported again
+	DefaultChangeThreshold = 5
+)
+
+// Record types of the event stream besides the monitor's event kinds
+const (
+	RecordPackets       = "packets"
+	RecordLoss          = "loss"
+	RecordChannelChange = "channel_change"
+)
+
+// StreamRecord is one line of the event stream. Type is a Record* constant
+// or a monitor event kind (source_online, source_lost, loss_spike, ...);
+// only the fields belonging to the type are set.
+type StreamRecord struct {
+	Time     time.Time `json:"time"`
+	Type     string    `json:"type"`
+	Universe uint16    `json:"universe"`
+	Source   string    `json:"source,omitempty"`
+	CID      string    `json:"cid,omitempty"`
+	Message  string    `json:"message,omitempty"`
+
+	// Packet summaries: packets since the previous summary, current rate
+	// and last minute's loss
+	Packets uint64  `json:"packets,omitempty"`
+	Rate    float64 `json:"rate,omitempty"`
+	Loss    float64 `json:"loss_pct,omitempty"`
+	Sources int     `json:"sources,omitempty"`
+
+	// Losses: missing packets and their sequence numbers
+	Lost         int    `json:"lost,omitempty"`
+	FirstMissing *uint8 `json:"first_missing,omitempty"`
+	LastMissing  *uint8 `json:"last_missing,omitempty"`
+
+	// Channel changes: 1-based channel, its patch label, new and last
+	// reported value
+	Channel  int    `json:"channel,omitempty"`
+	Label    string `json:"label,omitempty"`
+	Value    *int   `json:"value,omitempty"`
+	Previous *int   `json:"previous,omitempty"`
+}
+
+// EventStream writes the monitor's observations as JSON Lines (NDJSON),
+// one record per line, for jq or a log pipeline: packet summaries per
+// universe, monitor events such as sources going online or offline, each
+// detected sequence gap, and channel changes above a threshold.
+type EventStream struct {
+	enc       *json.Encoder
+	manager   *universe.Manager
+	tracker   *stats.Tracker
+	log       *events.Log
+	interval  time.Duration
+	threshold int
+	initial   bool // Report each channel's first value as a change
+
+	packets     map[uint16]uint64      // Packet count at the previous summary
+	lastSummary time.Time              // When packet summaries were last written
+	reported    map[uint16]*[512]int16 // Last reported value per channel, -1 = none
+	eventsSeen  uint64                 // Monitor events already written
+}
+
+// NewEventStream creates a stream writing to w. log may be nil to leave out
+// monitor events.
+func NewEventStream(w io.Writer, manager *universe.Manager, tracker *stats.Tracker, log *events.Log) *EventStream {
+	return &EventStream{
+		enc:       json.NewEncoder(w),
+		manager:   manager,
+		tracker:   tracker,
+		log:       log,
+		interval:  streamInterval,
+		threshold: DefaultChangeThreshold,
+		packets:   make(map[uint16]uint64),
+		reported:  make(map[uint16]*[512]int16),
+	}
+}
+
+// SetThreshold sets the level change reported as a channel change
+func (s *EventStream) SetThreshold(levels int) {
+	s.threshold = max(levels, 1)
+}
+
+// SetInterval sets how often monitor events and channel changes are
+// written; packet summaries stay at one per second
+func (s *EventStream) SetInterval(d time.Duration) {
+	if d > 0 {
+		s.interval = d
+	}
+}
+
+// ReportInitial makes each channel's first value a change record (without
+// a previous value), so a consumer starting from nothing gets the full
+// state rather than only what moves afterwards
+func (s *EventStream) ReportInitial() {
+	s.initial = true
+}
+
+// Run writes records until the context is cancelled or a write fails
+func (s *EventStream) Run(ctx context.Context) error {
+	notifications, unsubscribe := s.tracker.Subscribe(streamBuffer)
+	defer unsubscribe()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case n := <-notifications:
+			if n.Kind != stats.LossDetected {
+				continue
+			}
+			if err := s.WriteLoss(n.Loss); err != nil {
+				return err
+			}
+		case now := <-ticker.C:
+			if err := s.Flush(now); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// WriteLoss writes a record for a detected sequence gap
+func (s *EventStream) WriteLoss(e stats.LossEvent) error {
+	first, last := e.FirstMissing, e.LastMissing
+	return s.write(StreamRecord{
+		Time:         e.Time,
+		Type:         RecordLoss,
+		Universe:     e.Universe,
+		Source:       e.Source,
+		CID:          fmt.Sprintf("%x", e.CID),
+		Lost:         e.Lost,
+		FirstMissing: &first,
+		LastMissing:  &last,
+	})
+}
+
+// Flush writes the monitor events recorded since the previous flush, a
+// packet summary of every universe that received packets (once per
+// second), and the channels that moved by at least the threshold since
+// last reported
+func (s *EventStream) Flush(now time.Time) error {
+	if s.log != nil {
+		var fresh []events.Event
+		fresh, s.eventsSeen = s.log.Since(s.eventsSeen)
+		for _, e := range fresh {
+			err := s.write(StreamRecord{
+				Time:     e.Time,
+				Type:     string(e.Kind),
+				Universe: e.Universe,
+				Source:   e.Source,
+				Message:  e.Message,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := s.writeSummaries(now); err != nil {
+		return err
+	}
+
+	for _, u := range s.manager.GetAll() {
+		reported, exists := s.reported[u.ID]
+		if !exists {
+			reported = newSentValues()
+			s.reported[u.ID] = reported
+		}
+		if err := s.writeChanges(now, u.ID, u.GetAllChannels(), reported); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeSummaries writes a packet summary of every universe that received
+// packets since the previous summaries, unless those are too recent
+func (s *EventStream) writeSummaries(now time.Time) error {
+	if now.Sub(s.lastSummary) < summaryInterval {
+		return nil
+	}
+	s.lastSummary = now
+
+	for _, id := range s.tracker.GetAllUniverseIDs() {
+		sources := s.tracker.GetSources(id)
+		var total uint64
+		for _, src := range sources {
+			total += src.PacketCount
+		}
+		previous := s.packets[id]
+		s.packets[id] = total
+		if total <= previous {
+			continue
+		}
+		err := s.write(StreamRecord{
+			Time:     now,
+			Type:     RecordPackets,
+			Universe: id,
+			Packets:  total - previous,
+			Rate:     s.tracker.GetPacketRate(id),
+			Loss:     s.tracker.GetRecentLossPercentage(id),
+			Sources:  len(sources),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeChanges writes a record for every active channel that moved by at
+// least the threshold since its last reported value. A channel's first
+// value is taken as its starting point, with a record only if initial
+// values are reported.
+func (s *EventStream) writeChanges(now time.Time, id uint16, channels [512]universe.Channel, reported *[512]int16) error {
+	for i, ch := range channels {
+		if !ch.Active {
+			continue
+		}
+		value, previous := int(ch.Value), int(reported[i])
+		first := previous < 0
+		if first && !s.initial {
+			reported[i] = int16(value)
+			continue
+		}
+		if !first && abs(value-previous) < s.threshold {
+			continue
+		}
+		reported[i] = int16(value)
+		r := StreamRecord{
+			Time:     now,
+			Type:     RecordChannelChange,
+			Universe: id,
+			CID:      fmt.Sprintf("%x", ch.SourceCID),
+			Channel:  i + 1,
+			Value:    &value,
+		}
+		if label, ok := s.manager.ChannelLabel(id, i+1); ok {
+			r.Label = label.String()
+		}
+		if !first {
+			r.Previous = &previous
+		}
+		if err := s.write(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// write encodes one record as a line
+func (s *EventStream) write(r StreamRecord) error {
+	if err := s.enc.Encode(r); err != nil {
+		return fmt.Errorf("event stream write failed: %w", err)
+	}
+	return nil
+}
+
+// abs returns the absolute value of n
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,93 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"sacn-monitor/internal/events"
+	"sacn-monitor/internal/patch"
+	"sacn-monitor/internal/stats"
+	"sacn-monitor/internal/universe"
+)
+
+func TestEventStream_Flush(t *testing.T) {
+	manager := universe.NewManager()
+	tracker := stats.NewTracker()
+	log := events.NewLog(0)
+	cid := [16]byte{1}
+	p, err := patch.New([]patch.Fixture{{Name: "Spot 1", Universe: 1, Address: 1, Footprint: 1, Params: []string{"Dim"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	manager.SetPatch(p)
+
+	var sb strings.Builder
+	stream := NewEventStream(&sb, manager, tracker, log)
+	stream.SetThreshold(10)
+	now := time.Date(2026, 3, 14, 19, 0, 0, 0, time.UTC)
+
+	u := manager.GetOrCreate(1)
+	u.Update([]byte{0, 100}, "console", cid, 100, 0)
+	tracker.RecordPacket(1, cid, "console", 0)
+	log.Add(events.Event{Time: now, Kind: events.SourceOnline, Universe: 1, Source: "console"})
+	if err := stream.Flush(now); err != nil {
+		t.Fatalf("Flush() returned error: %v", err)
+	}
+
+	// Channel 1 moves past the threshold, channel 2 stays under it
+	u.Update([]byte{50, 105}, "console", cid, 100, 1)
+	tracker.RecordPacket(1, cid, "console", 1)
+	if err := stream.Flush(now.Add(time.Second)); err != nil {
+		t.Fatalf("Flush() returned error: %v", err)
+	}
+
+	var records []StreamRecord
+	scanner := bufio.NewScanner(strings.NewReader(sb.String()))
+	for scanner.Scan() {
+		var r StreamRecord
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			t.Fatalf("line %q is not JSON: %v", scanner.Text(), err)
+		}
+		records = append(records, r)
+	}
+
+	types := make([]string, len(records))
+	for i, r := range records {
+		types[i] = r.Type
+	}
+	want := []string{"source_online", RecordPackets, RecordPackets, RecordChannelChange}
+	if strings.Join(types, ",") != strings.Join(want, ",") {
+		t.Fatalf("record types = %v, want %v", types, want)
+	}
+
+	if records[1].Packets != 1 || records[2].Packets != 1 || records[2].Sources != 1 {
+		t.Errorf("packet summaries = %+v, %+v, want 1 packet from 1 source each", records[1], records[2])
+	}
+	change := records[3]
+	if change.Channel != 1 || *change.Value != 50 || *change.Previous != 0 {
+		t.Errorf("channel change = channel %d %d→%d, want channel 1 0→50", change.Channel, *change.Previous, *change.Value)
+	}
+	if change.Label != "Spot 1 Dim" {
+		t.Errorf("channel change label = %q, want Spot 1 Dim", change.Label)
+	}
+}
+
+func TestEventStream_WriteLoss(t *testing.T) {
+	var sb strings.Builder
+	stream := NewEventStream(&sb, universe.NewManager(), stats.NewTracker(), nil)
+
+	err := stream.WriteLoss(stats.LossEvent{Universe: 2, Source: "console", Lost: 2, FirstMissing: 0, LastMissing: 1})
+	if err != nil {
+		t.Fatalf("WriteLoss() returned error: %v", err)
+	}
+
+	line := sb.String()
+	for _, want := range []string{`"type":"loss"`, `"universe":2`, `"lost":2`, `"first_missing":0`, `"last_missing":1`} {
+		if !strings.Contains(line, want) {
+			t.Errorf("loss record %s missing %s", line, want)
+		}
+	}
+}
@@ -0,0 +1,319 @@
+package export
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"sacn-monitor/internal/sacn"
+)
+
+// Capture defaults
+const (
+	// captureBuffer is how many datagrams wait for the writer before new
+	// ones are dropped
+	captureBuffer = 4096
+	// captureFlush is how often buffered blocks are written out
+	captureFlush = time.Second
+)
+
+// pcapng block types and fields, per draft-ietf-opsawg-pcapng
+const (
+	pcapngSectionHeader  = 0x0A0D0D0A
+	pcapngInterfaceDesc  = 0x00000001
+	pcapngEnhancedPacket = 0x00000006
+	pcapngByteOrderMagic = 0x1A2B3C4D
+	pcapngLinkEthernet   = 1
+	pcapngSnapLen        = 65535
+	pcapngOptEnd         = 0
+	pcapngOptIfName      = 2
+)
+
+// Synthetic link and network headers around each captured datagram
+const (
+	ethernetHeaderLen = 14
+	ipv4HeaderLen     = 20
+	udpHeaderLen      = 8
+	etherTypeIPv4     = 0x0800
+	ipProtocolUDP     = 17
+	ipDefaultTTL      = 64
+)
+
+// captureSourceMAC is the locally administered MAC put on every frame; the
+// real one isn't visible to a UDP socket
+var captureSourceMAC = [6]byte{0x02, 0x00, 0x00, 0x00, 0x00, 0x01}
+
+// PcapngWriter writes UDP datagrams as a pcapng capture. Each datagram is
+// wrapped in Ethernet, IPv4 and UDP headers with valid checksums, so tools
+// such as Wireshark and sACNView read it like a capture from the wire.
+// Timestamps have microsecond resolution.
+type PcapngWriter struct {
+	w io.Writer
+}
+
+// NewPcapngWriter writes the section header and the one Ethernet
+// interface every packet is recorded on
+func NewPcapngWriter(w io.Writer) (*PcapngWriter, error) {
+	// Section header: byte order magic, version 1.0, unknown section length
+	shb := make([]byte, 16)
+	binary.LittleEndian.PutUint32(shb[0:], pcapngByteOrderMagic)
+	binary.LittleEndian.PutUint16(shb[4:], 1)
+	binary.LittleEndian.PutUint16(shb[6:], 0)
+	binary.LittleEndian.PutUint64(shb[8:], ^uint64(0))
+	if err := writeBlock(w, pcapngSectionHeader, shb); err != nil {
+		return nil, err
+	}
+
+	idb := make([]byte, 8)
+	binary.LittleEndian.PutUint16(idb[0:], pcapngLinkEthernet)
+	binary.LittleEndian.PutUint32(idb[4:], pcapngSnapLen)
+	idb = appendOption(idb, pcapngOptIfName, []byte("sacn-monitor"))
+	idb = appendOption(idb, pcapngOptEnd, nil)
+	if err := writeBlock(w, pcapngInterfaceDesc, idb); err != nil {
+		return nil, err
+	}
+	return &PcapngWriter{w: w}, nil
+}
+
+// WritePacket writes one datagram received at the given time. An unknown
+// destination is taken to be the multicast group of the packet's universe.
+func (p *PcapngWriter) WritePacket(at time.Time, src *net.UDPAddr, dst net.IP, payload []byte) error {
+	frame := udpFrame(src, captureDestination(dst, payload), payload)
+
+	ts := uint64(at.UnixMicro())
+	epb := make([]byte, 20, 20+len(frame)+3)
+	binary.LittleEndian.PutUint32(epb[0:], 0) // Interface ID
+	binary.LittleEndian.PutUint32(epb[4:], uint32(ts>>32))
+	binary.LittleEndian.PutUint32(epb[8:], uint32(ts))
+	binary.LittleEndian.PutUint32(epb[12:], uint32(len(frame)))
+	binary.LittleEndian.PutUint32(epb[16:], uint32(len(frame)))
+	epb = append(epb, frame...)
+	epb = append(epb, make([]byte, pad4(len(frame)))...)
+	return writeBlock(p.w, pcapngEnhancedPacket, epb)
+}
+
+// writeBlock writes a pcapng block: type, total length, body, total length
+func writeBlock(w io.Writer, blockType uint32, body []byte) error {
+	total := uint32(12 + len(body))
+	block := make([]byte, 0, total)
+	block = binary.LittleEndian.AppendUint32(block, blockType)
+	block = binary.LittleEndian.AppendUint32(block, total)
+	block = append(block, body...)
+	block = binary.LittleEndian.AppendUint32(block, total)
+	if _, err := w.Write(block); err != nil {
+		return fmt.Errorf("capture write failed: %w", err)
+	}
+	return nil
+}
+
+// appendOption appends a pcapng option, padded to 32 bits
+func appendOption(b []byte, code uint16, value []byte) []byte {
+	b = binary.LittleEndian.AppendUint16(b, code)
+	b = binary.LittleEndian.AppendUint16(b, uint16(len(value)))
+	b = append(b, value...)
+	return append(b, make([]byte, pad4(len(value)))...)
+}
+
+// pad4 returns the padding that brings n to a multiple of 4
+func pad4(n int) int {
+	return (4 - n%4) % 4
+}
+
+// captureDestination returns the destination to record: the real one if
+// known, else the universe's multicast group, else broadcast
+func captureDestination(dst net.IP, payload []byte) net.IP {
+	if ip := dst.To4(); ip != nil {
+		return ip
+	}
+	if len(payload) >= sacn.E131HeaderSize && !sacn.IsExtended(payload) {
+		return net.IPv4(239, 255, payload[113], payload[114]).To4()
+	}
+	return net.IPv4bcast.To4()
+}
+
+// udpFrame wraps a payload in Ethernet, IPv4 and UDP headers
+func udpFrame(src *net.UDPAddr, dst net.IP, payload []byte) []byte {
+	srcIP := net.IPv4zero.To4()
+	srcPort := sacn.E131Port
+	if src != nil {
+		if ip := src.IP.To4(); ip != nil {
+			srcIP = ip
+		}
+		srcPort = src.Port
+	}
+
+	frame := make([]byte, ethernetHeaderLen+ipv4HeaderLen+udpHeaderLen+len(payload))
+
+	// Ethernet, to the group's MAC for multicast (RFC 1112 section 6.4)
+	eth := frame[:ethernetHeaderLen]
+	switch {
+	case dst.IsMulticast():
+		copy(eth[0:], []byte{0x01, 0x00, 0x5e, dst[1] & 0x7f, dst[2], dst[3]})
+	case dst.Equal(net.IPv4bcast):
+		copy(eth[0:], []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+	default:
+		copy(eth[0:], []byte{0x02, 0x00, 0x00, 0x00, 0x00, 0x02})
+	}
+	copy(eth[6:], captureSourceMAC[:])
+	binary.BigEndian.PutUint16(eth[12:], etherTypeIPv4)
+
+	ip := frame[ethernetHeaderLen : ethernetHeaderLen+ipv4HeaderLen]
+	ip[0] = 0x45 // Version 4, 5 words
+	binary.BigEndian.PutUint16(ip[2:], uint16(ipv4HeaderLen+udpHeaderLen+len(payload)))
+	ip[6] = 0x40 // Don't fragment
+	ip[8] = ipDefaultTTL
+	ip[9] = ipProtocolUDP
+	copy(ip[12:], srcIP)
+	copy(ip[16:], dst)
+	binary.BigEndian.PutUint16(ip[10:], checksum(ip, 0))
+
+	udp := frame[ethernetHeaderLen+ipv4HeaderLen:]
+	binary.BigEndian.PutUint16(udp[0:], uint16(srcPort))
+	binary.BigEndian.PutUint16(udp[2:], sacn.E131Port)
+	binary.BigEndian.PutUint16(udp[4:], uint16(udpHeaderLen+len(payload)))
+	copy(udp[udpHeaderLen:], payload)
+
+	// The UDP checksum covers a pseudo-header of addresses, protocol and
+	// length; a computed 0 is sent as all ones
+	pseudo := uint32(ipProtocolUDP) + uint32(len(udp))
+	for i := 0; i < 4; i += 2 {
+		pseudo += uint32(binary.BigEndian.Uint16(srcIP[i:])) + uint32(binary.BigEndian.Uint16(dst[i:]))
+	}
+	sum := checksum(udp, pseudo)
+	if sum == 0 {
+		sum = 0xffff
+	}
+	binary.BigEndian.PutUint16(udp[6:], sum)
+	return frame
+}
+
+// checksum returns the Internet checksum (RFC 1071) of b, starting from an
+// initial sum
+func checksum(b []byte, initial uint32) uint16 {
+	sum := initial
+	for i := 0; i+1 < len(b); i += 2 {
+		sum += uint32(binary.BigEndian.Uint16(b[i:]))
+	}
+	if len(b)%2 == 1 {
+		sum += uint32(b[len(b)-1]) << 8
+	}
+	for sum > 0xffff {
+		sum = sum>>16 + sum&0xffff
+	}
+	return ^uint16(sum)
+}
+
+// capturedPacket is a datagram waiting to be written
+type capturedPacket struct {
+	at      time.Time
+	src     *net.UDPAddr
+	dst     net.IP
+	payload []byte
+}
+
+// Capture records every received datagram to a pcapng file, to share a
+// session with colleagues using Wireshark, sACNView or other tools.
+// Datagrams are handed over without blocking the receiver; when the writer
+// falls behind they are dropped and counted.
+type Capture struct {
+	file    *os.File
+	buf     *bufio.Writer
+	writer  *PcapngWriter
+	packets chan capturedPacket
+	dropped atomic.Uint64
+}
+
+// CreateCapture creates (or truncates) a pcapng file at path
+func CreateCapture(path string) (*Capture, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create capture: %w", err)
+	}
+	buf := bufio.NewWriterSize(f, 64<<10)
+	writer, err := NewPcapngWriter(buf)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &Capture{
+		file:    f,
+		buf:     buf,
+		writer:  writer,
+		packets: make(chan capturedPacket, captureBuffer),
+	}, nil
+}
+
+// Record queues a datagram for writing. It copies data, so the caller may
+// reuse its buffer, and never blocks.
+func (c *Capture) Record(data []byte, src net.Addr, dst net.IP, at time.Time) {
+	p := capturedPacket{at: at, dst: dst, payload: append([]byte(nil), data...)}
+	p.src, _ = src.(*net.UDPAddr)
+	select {
+	case c.packets <- p:
+	default:
+		c.dropped.Add(1)
+	}
+}
+
+// Dropped returns how many datagrams were dropped because the writer fell
+// behind
+func (c *Capture) Dropped() uint64 {
+	return c.dropped.Load()
+}
+
+// Run writes queued datagrams until the context is cancelled, then writes
+// out what's left. Write errors are reported to onError.
+func (c *Capture) Run(ctx context.Context, onError func(error)) {
+	ticker := time.NewTicker(captureFlush)
+	defer ticker.Stop()
+
+	var failed error // Reported once, until writing works again
+	report := func(err error) {
+		if err != nil && failed == nil && onError != nil {
+			onError(err)
+		}
+		failed = err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			for {
+				select {
+				case p := <-c.packets:
+					report(c.writer.WritePacket(p.at, p.src, p.dst, p.payload))
+				default:
+					report(c.flush())
+					return
+				}
+			}
+		case p := <-c.packets:
+			report(c.writer.WritePacket(p.at, p.src, p.dst, p.payload))
+		case <-ticker.C:
+			report(c.flush())
+		}
+	}
+}
+
+// Close writes out buffered blocks and closes the file. Call it once Run
+// has returned.
+func (c *Capture) Close() error {
+	err := c.flush()
+	if cerr := c.file.Close(); err == nil && cerr != nil {
+		err = fmt.Errorf("failed to close capture: %w", cerr)
+	}
+	return err
+}
+
+// flush writes buffered blocks to the file
+func (c *Capture) flush() error {
+	if err := c.buf.Flush(); err != nil {
+		return fmt.Errorf("capture write failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// readBlocks splits a pcapng file into block types and bodies
+func readBlocks(t *testing.T, data []byte) ([]uint32, [][]byte) {
+	t.Helper()
+	var types []uint32
+	var bodies [][]byte
+	for len(data) > 0 {
+		if len(data) < 12 {
+			t.Fatalf("truncated block: %d bytes left", len(data))
+		}
+		total := binary.LittleEndian.Uint32(data[4:])
+		if total%4 != 0 || int(total) > len(data) || binary.LittleEndian.Uint32(data[total-4:]) != total {
+			t.Fatalf("block of %d bytes has an invalid length", total)
+		}
+		types = append(types, binary.LittleEndian.Uint32(data))
+		bodies = append(bodies, data[8:total-4])
+		data = data[total:]
+	}
+	return types, bodies
+}
+
+func TestPcapngWriter_WritePacket(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewPcapngWriter(&buf)
+	if err != nil {
+		t.Fatalf("NewPcapngWriter() returned error: %v", err)
+	}
+
+	payload := make([]byte, 127) // Data packet for universe 0x0102, odd length
+	payload[113], payload[114] = 0x01, 0x02
+	at := time.Date(2026, 3, 14, 19, 0, 0, 123456000, time.UTC)
+	src := &net.UDPAddr{IP: net.IPv4(10, 0, 0, 5), Port: 49152}
+	if err := w.WritePacket(at, src, nil, payload); err != nil {
+		t.Fatalf("WritePacket() returned error: %v", err)
+	}
+
+	types, bodies := readBlocks(t, buf.Bytes())
+	if len(types) != 3 || types[0] != pcapngSectionHeader || types[1] != pcapngInterfaceDesc || types[2] != pcapngEnhancedPacket {
+		t.Fatalf("block types = %x, want section header, interface, packet", types)
+	}
+	if link := binary.LittleEndian.Uint16(bodies[1]); link != pcapngLinkEthernet {
+		t.Errorf("link type = %d, want Ethernet", link)
+	}
+
+	epb := bodies[2]
+	ts := uint64(binary.LittleEndian.Uint32(epb[4:]))<<32 | uint64(binary.LittleEndian.Uint32(epb[8:]))
+	if ts != uint64(at.UnixMicro()) {
+		t.Errorf("timestamp = %d, want %d", ts, at.UnixMicro())
+	}
+	length := binary.LittleEndian.Uint32(epb[12:])
+	frame := epb[20 : 20+length]
+	if int(length) != 14+20+8+len(payload) {
+		t.Fatalf("frame length = %d, want %d", length, 14+20+8+len(payload))
+	}
+
+	// Multicast group of the universe, on its multicast MAC
+	if !bytes.Equal(frame[:6], []byte{0x01, 0x00, 0x5e, 0x7f, 0x01, 0x02}) {
+		t.Errorf("destination MAC = % x, want 01 00 5e 7f 01 02", frame[:6])
+	}
+	ip := frame[14:34]
+	if !net.IP(ip[12:16]).Equal(net.IPv4(10, 0, 0, 5)) || !net.IP(ip[16:20]).Equal(net.IPv4(239, 255, 1, 2)) {
+		t.Errorf("addresses = %v -> %v, want 10.0.0.5 -> 239.255.1.2", net.IP(ip[12:16]), net.IP(ip[16:20]))
+	}
+	if checksum(ip, 0) != 0 {
+		t.Error("IPv4 header checksum doesn't verify")
+	}
+
+	udp := frame[34:]
+	if binary.BigEndian.Uint16(udp[0:]) != 49152 || binary.BigEndian.Uint16(udp[2:]) != 5568 {
+		t.Errorf("ports = %d -> %d, want 49152 -> 5568", binary.BigEndian.Uint16(udp[0:]), binary.BigEndian.Uint16(udp[2:]))
+	}
+	pseudo := uint32(ipProtocolUDP) + uint32(len(udp))
+	for i := 12; i < 20; i += 2 {
+		pseudo += uint32(binary.BigEndian.Uint16(ip[i:]))
+	}
+	if checksum(udp, pseudo) != 0 {
+		t.Error("UDP checksum doesn't verify")
+	}
+	if !bytes.Equal(udp[8:], payload) {
+		t.Error("payload doesn't match")
+	}
+}
+
+func TestCapture_Run(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.pcapng")
+	c, err := CreateCapture(path)
+	if err != nil {
+		t.Fatalf("CreateCapture() returned error: %v", err)
+	}
+
+	data := []byte("not even sACN")
+	c.Record(data, &net.UDPAddr{IP: net.IPv4(10, 0, 0, 5), Port: 5568}, net.IPv4(10, 0, 0, 1), time.Now())
+	data[0] = 'X' // The capture keeps its own copy
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	c.Run(ctx, func(err error) { t.Errorf("Run() reported %v", err) })
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close() returned error: %v", err)
+	}
+
+	written, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read capture: %v", err)
+	}
+	types, bodies := readBlocks(t, written)
+	if len(types) != 3 {
+		t.Fatalf("%d blocks written, want 3", len(types))
+	}
+	if !bytes.Contains(bodies[2], []byte("not even sACN")) {
+		t.Error("recorded datagram is missing or was changed")
+	}
+}
@@ -0,0 +1,201 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"sacn-monitor/internal/universe"
+)
+
+// Previz stream defaults
+const (
+	// previzInterval is how often channel deltas are flushed to the visualizer
+	previzInterval = 25 * time.Millisecond
+	// previzResyncInterval is how often every active channel is resent so a
+	// visualizer that (re)started after us catches up on UDP
+	previzResyncInterval = 5 * time.Second
+	// previzMaxDatagram keeps UDP datagrams below a typical Ethernet MTU
+	previzMaxDatagram = 1400
+)
+
+// PrevizStream feeds channel values to a lighting previsualization tool as
+// plain-text "universe,channel,value" lines, one per changed channel.
+// Channel numbers are 1-based, matching what consoles and previz tools show.
+// With labels on, patched channels get their patch label as a fourth field.
+type PrevizStream struct {
+	manager  *universe.Manager
+	conn     net.Conn
+	network  string
+	interval time.Duration
+	resync   time.Duration
+
+	sent     map[uint16]*[512]int16 // last value sent per channel, -1 = never sent
+	lastSync time.Time
+	filter   func(uint16) bool // Universes to send, nil = all
+	labels   bool
+}
+
+// DialPreviz connects to a visualizer at target, given as udp://host:port or
+// tcp://host:port
+func DialPreviz(target string, manager *universe.Manager) (*PrevizStream, error) {
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid previz target %q: %w", target, err)
+	}
+	if u.Scheme != "udp" && u.Scheme != "tcp" {
+		return nil, fmt.Errorf("invalid previz target %q: scheme must be udp or tcp", target)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid previz target %q: missing host:port", target)
+	}
+
+	conn, err := net.Dial(u.Scheme, u.Host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to previz target %s: %w", target, err)
+	}
+
+	return NewPrevizStream(conn, u.Scheme, manager), nil
+}
+
+// NewPrevizStream creates a stream writing to an already established connection
+func NewPrevizStream(conn net.Conn, network string, manager *universe.Manager) *PrevizStream {
+	return &PrevizStream{
+		manager:  manager,
+		conn:     conn,
+		network:  network,
+		interval: previzInterval,
+		resync:   previzResyncInterval,
+		sent:     make(map[uint16]*[512]int16),
+	}
+}
+
+// SetFilter limits the stream to universes for which keep returns true
+func (p *PrevizStream) SetFilter(keep func(id uint16) bool) {
+	p.filter = keep
+}
+
+// SetLabels adds patch labels to the lines of patched channels
+func (p *PrevizStream) SetLabels(on bool) {
+	p.labels = on
+}
+
+// Run flushes channel deltas until the context is cancelled or the
+// connection fails
+func (p *PrevizStream) Run(ctx context.Context) error {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			if err := p.Flush(now); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Flush sends all channel values that changed since the previous flush.
+// Every resync interval the full state of all active channels is sent instead.
+func (p *PrevizStream) Flush(now time.Time) error {
+	full := now.Sub(p.lastSync) >= p.resync
+	if full {
+		p.lastSync = now
+	}
+
+	var lines []string
+	for _, u := range p.manager.GetAll() {
+		if p.filter != nil && !p.filter(u.ID) {
+			continue
+		}
+		sent, exists := p.sent[u.ID]
+		if !exists {
+			sent = newSentValues()
+			p.sent[u.ID] = sent
+		}
+		var label func(int) string
+		if p.labels {
+			id := u.ID
+			label = func(channel int) string {
+				patched, _ := p.manager.ChannelLabel(id, channel)
+				return patched.String()
+			}
+		}
+		lines = appendDeltas(lines, u.ID, u.GetAllChannels(), sent, full, label)
+	}
+
+	return p.write(lines)
+}
+
+// Close closes the underlying connection
+func (p *PrevizStream) Close() error {
+	return p.conn.Close()
+}
+
+// write sends lines, packing them into MTU-sized datagrams on UDP
+func (p *PrevizStream) write(lines []string) error {
+	if len(lines) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	for _, line := range lines {
+		if p.network == "udp" && buf.Len()+len(line)+1 > previzMaxDatagram {
+			if _, err := p.conn.Write(buf.Bytes()); err != nil {
+				return fmt.Errorf("previz write failed: %w", err)
+			}
+			buf.Reset()
+		}
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+
+	if _, err := p.conn.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("previz write failed: %w", err)
+	}
+	return nil
+}
+
+// previzLabel keeps labels from splitting fields or lines
+var previzLabel = strings.NewReplacer(",", " ", "\n", " ", "\r", " ")
+
+// newSentValues returns a per-channel record with nothing sent yet
+func newSentValues() *[512]int16 {
+	var sent [512]int16
+	for i := range sent {
+		sent[i] = -1
+	}
+	return &sent
+}
+
+// appendDeltas appends a line for every active channel whose value differs
+// from what was last sent (or every active channel when full is set) and
+// records the new values as sent. A non-empty label for a 1-based channel,
+// if label is set, is added as a fourth field.
+func appendDeltas(lines []string, universeID uint16, channels [512]universe.Channel, sent *[512]int16, full bool, label func(channel int) string) []string {
+	for i, ch := range channels {
+		if !ch.Active {
+			continue
+		}
+		if !full && sent[i] == int16(ch.Value) {
+			continue
+		}
+		sent[i] = int16(ch.Value)
+		line := strconv.Itoa(int(universeID)) + "," + strconv.Itoa(i+1) + "," + strconv.Itoa(int(ch.Value))
+		if label != nil {
+			if text := previzLabel.Replace(label(i + 1)); text != "" {
+				line += "," + text
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
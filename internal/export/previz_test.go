@@ -0,0 +1,138 @@
+package export
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"sacn-monitor/internal/universe"
+)
+
+func TestAppendDeltas_OnlyChanged(t *testing.T) {
+	u := universe.NewUniverse(3)
+	u.Update([]byte{10, 20, 30}, "test", [16]byte{}, 100, 0)
+	sent := newSentValues()
+
+	lines := appendDeltas(nil, 3, u.GetAllChannels(), sent, false, nil)
+	want := []string{"3,1,10", "3,2,20", "3,3,30"}
+	if strings.Join(lines, " ") != strings.Join(want, " ") {
+		t.Errorf("first flush = %v, want %v", lines, want)
+	}
+
+	u.Update([]byte{10, 25, 30}, "test", [16]byte{}, 100, 1)
+	lines = appendDeltas(nil, 3, u.GetAllChannels(), sent, false, nil)
+	if len(lines) != 1 || lines[0] != "3,2,25" {
+		t.Errorf("second flush = %v, want [3,2,25]", lines)
+	}
+
+	lines = appendDeltas(nil, 3, u.GetAllChannels(), sent, false, nil)
+	if len(lines) != 0 {
+		t.Errorf("unchanged flush = %v, want empty", lines)
+	}
+}
+
+func TestAppendDeltas_FullResync(t *testing.T) {
+	u := universe.NewUniverse(1)
+	u.Update([]byte{1, 2}, "test", [16]byte{}, 100, 0)
+	sent := newSentValues()
+
+	appendDeltas(nil, 1, u.GetAllChannels(), sent, false, nil)
+	lines := appendDeltas(nil, 1, u.GetAllChannels(), sent, true, nil)
+	if len(lines) != 2 {
+		t.Errorf("full flush = %v, want 2 lines", lines)
+	}
+}
+
+func TestAppendDeltas_Labels(t *testing.T) {
+	u := universe.NewUniverse(1)
+	u.Update([]byte{1, 2}, "test", [16]byte{}, 100, 0)
+	label := func(channel int) string {
+		if channel == 1 {
+			return "Spot 1, Dim"
+		}
+		return ""
+	}
+
+	lines := appendDeltas(nil, 1, u.GetAllChannels(), newSentValues(), false, label)
+	want := []string{"1,1,1,Spot 1  Dim", "1,2,2"}
+	if strings.Join(lines, "|") != strings.Join(want, "|") {
+		t.Errorf("lines = %q, want %q", lines, want)
+	}
+}
+
+func TestPrevizStream_FlushUDP(t *testing.T) {
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("ListenPacket() returned error: %v", err)
+	}
+	defer listener.Close()
+
+	manager := universe.NewManager()
+	manager.GetOrCreate(7).Update([]byte{255, 0, 128}, "test", [16]byte{}, 100, 0)
+
+	stream, err := DialPreviz("udp://"+listener.LocalAddr().String(), manager)
+	if err != nil {
+		t.Fatalf("DialPreviz() returned error: %v", err)
+	}
+	defer stream.Close()
+
+	if err := stream.Flush(time.Now()); err != nil {
+		t.Fatalf("Flush() returned error: %v", err)
+	}
+
+	buf := make([]byte, 2048)
+	_ = listener.SetReadDeadline(time.Now().Add(time.Second))
+	n, _, err := listener.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("ReadFrom() returned error: %v", err)
+	}
+
+	want := "7,1,255\n7,2,0\n7,3,128\n"
+	if string(buf[:n]) != want {
+		t.Errorf("datagram = %q, want %q", buf[:n], want)
+	}
+}
+
+func TestPrevizStream_Filter(t *testing.T) {
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("ListenPacket() returned error: %v", err)
+	}
+	defer listener.Close()
+
+	manager := universe.NewManager()
+	manager.GetOrCreate(1).Update([]byte{10}, "test", [16]byte{}, 100, 0)
+	manager.GetOrCreate(2).Update([]byte{20}, "test", [16]byte{}, 100, 0)
+
+	stream, err := DialPreviz("udp://"+listener.LocalAddr().String(), manager)
+	if err != nil {
+		t.Fatalf("DialPreviz() returned error: %v", err)
+	}
+	defer stream.Close()
+	stream.SetFilter(func(id uint16) bool { return id == 2 })
+
+	if err := stream.Flush(time.Now()); err != nil {
+		t.Fatalf("Flush() returned error: %v", err)
+	}
+
+	buf := make([]byte, 2048)
+	_ = listener.SetReadDeadline(time.Now().Add(time.Second))
+	n, _, err := listener.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("ReadFrom() returned error: %v", err)
+	}
+
+	if want := "2,1,20\n"; string(buf[:n]) != want {
+		t.Errorf("datagram = %q, want %q", buf[:n], want)
+	}
+}
+
+func TestDialPreviz_InvalidTarget(t *testing.T) {
+	manager := universe.NewManager()
+	for _, target := range []string{"http://localhost:80", "udp://", "not a url\x00"} {
+		if _, err := DialPreviz(target, manager); err == nil {
+			t.Errorf("DialPreviz(%q) expected error, got nil", target)
+		}
+	}
+}
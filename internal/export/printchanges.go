@@ -0,0 +1,149 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"sacn-monitor/internal/universe"
+)
+
+// changeLineTime is the fixed-width timestamp of printed changes
+const changeLineTime = "2006-01-02T15:04:05.000000Z07:00"
+
+// ChangeFilter selects the universes and channels whose changes are
+// printed; the zero value selects everything
+type ChangeFilter struct {
+	universes map[uint16][]universe.ChannelRange // nil ranges = every channel
+}
+
+// ParseChangeFilter parses a comma-separated list of universes, each
+// optionally limited to a channel or range: "1,2:1-16,3:100". An empty
+// spec selects everything.
+func ParseChangeFilter(spec string) (ChangeFilter, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return ChangeFilter{}, nil
+	}
+
+	f := ChangeFilter{universes: make(map[uint16][]universe.ChannelRange)}
+	whole := make(map[uint16]bool)
+	for _, entry := range strings.Split(spec, ",") {
+		id, channels, limited := strings.Cut(strings.TrimSpace(entry), ":")
+		n, err := strconv.ParseUint(strings.TrimSpace(id), 10, 16)
+		if err != nil || n == 0 {
+			return ChangeFilter{}, fmt.Errorf("invalid change filter %q: bad universe %q", entry, id)
+		}
+		universeID := uint16(n)
+
+		if !limited {
+			whole[universeID] = true
+			f.universes[universeID] = nil
+			continue
+		}
+		r, err := universe.ParseChannelRange(channels)
+		if err != nil {
+			return ChangeFilter{}, fmt.Errorf("invalid change filter %q: %w", entry, err)
+		}
+		if !whole[universeID] {
+			f.universes[universeID] = append(f.universes[universeID], r)
+		}
+	}
+	return f, nil
+}
+
+// Matches reports whether a 1-based channel of a universe is selected
+func (f ChangeFilter) Matches(universeID uint16, channel int) bool {
+	if f.universes == nil {
+		return true
+	}
+	ranges, ok := f.universes[universeID]
+	if !ok {
+		return false
+	}
+	if ranges == nil {
+		return true
+	}
+	for _, r := range ranges {
+		if channel >= r.First && channel <= r.Last {
+			return true
+		}
+	}
+	return false
+}
+
+// hasUniverse reports whether any channel of a universe is selected
+func (f ChangeFilter) hasUniverse(universeID uint16) bool {
+	if f.universes == nil {
+		return true
+	}
+	_, ok := f.universes[universeID]
+	return ok
+}
+
+// ChangePrinter writes one line per channel change for shell pipelines:
+//
+//	<time> <universe> <channel> <old> <new> <cid>
+//
+// separated by single spaces, with a microsecond RFC 3339 time, a 1-based
+// channel and the sending source's CID in hex. Like ChangeLog it compares
+// each packet with the universe's previous one, so a universe's first
+// packet only sets the starting values. Each packet's lines are written at
+// once, so a reading script reacts without delay.
+type ChangePrinter struct {
+	w      *bufio.Writer
+	filter ChangeFilter
+	last   map[uint16]*[512]int16 // Previous value per channel, -1 = not received
+	mu     sync.Mutex
+}
+
+// NewChangePrinter creates a printer writing changes selected by filter
+// to w
+func NewChangePrinter(w io.Writer, filter ChangeFilter) *ChangePrinter {
+	return &ChangePrinter{
+		w:      bufio.NewWriter(w),
+		filter: filter,
+		last:   make(map[uint16]*[512]int16),
+	}
+}
+
+// Record prints the selected channels of a packet whose value differs from
+// the universe's previous packet
+func (p *ChangePrinter) Record(universeID uint16, data []byte, cid [16]byte, at time.Time) error {
+	if !p.filter.hasUniverse(universeID) {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	last, seen := p.last[universeID]
+	if !seen {
+		last = newSentValues()
+		p.last[universeID] = last
+	}
+
+	var suffix string // " <cid>\n", once a change is found
+	for i := 0; i < len(data) && i < 512; i++ {
+		old := last[i]
+		last[i] = int16(data[i])
+		if old < 0 || old == int16(data[i]) || !p.filter.Matches(universeID, i+1) {
+			continue
+		}
+		if suffix == "" {
+			suffix = fmt.Sprintf(" %x\n", cid)
+		}
+		fmt.Fprintf(p.w, "%s %d %d %d %d%s", at.Format(changeLineTime), universeID, i+1, old, data[i], suffix)
+	}
+	if suffix == "" {
+		return nil
+	}
+	if err := p.w.Flush(); err != nil {
+		return fmt.Errorf("failed to print changes: %w", err)
+	}
+	return nil
+}
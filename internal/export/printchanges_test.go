@@ -0,0 +1,72 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseChangeFilter(t *testing.T) {
+	f, err := ParseChangeFilter("1, 2:1-16,2:100, 3:5, 3")
+	if err != nil {
+		t.Fatalf("ParseChangeFilter() returned error: %v", err)
+	}
+	tests := []struct {
+		universe uint16
+		channel  int
+		want     bool
+	}{
+		{1, 512, true},
+		{2, 16, true},
+		{2, 17, false},
+		{2, 100, true},
+		{3, 400, true}, // The whole universe wins over a channel
+		{4, 1, false},
+	}
+	for _, tt := range tests {
+		if got := f.Matches(tt.universe, tt.channel); got != tt.want {
+			t.Errorf("Matches(%d, %d) = %t, want %t", tt.universe, tt.channel, got, tt.want)
+		}
+	}
+
+	all, _ := ParseChangeFilter("")
+	if !all.Matches(9, 1) {
+		t.Error("empty filter should match everything")
+	}
+	for _, bad := range []string{"0", "x", "1:0", "1:5-600"} {
+		if _, err := ParseChangeFilter(bad); err == nil {
+			t.Errorf("ParseChangeFilter(%q) expected error, got nil", bad)
+		}
+	}
+}
+
+func TestChangePrinter_Record(t *testing.T) {
+	var out bytes.Buffer
+	filter, _ := ParseChangeFilter("1:1-2")
+	p := NewChangePrinter(&out, filter)
+	at := time.Date(2024, 5, 1, 20, 15, 0, 0, time.UTC)
+	cid := [16]byte{0xab}
+
+	// The first packet only sets the starting values
+	if err := p.Record(1, []byte{0, 0, 0}, cid, at); err != nil {
+		t.Fatalf("Record() returned error: %v", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("first packet printed %q", out.String())
+	}
+
+	if err := p.Record(1, []byte{255, 0, 10}, cid, at); err != nil {
+		t.Fatalf("Record() returned error: %v", err)
+	}
+	if err := p.Record(2, []byte{1}, cid, at); err != nil {
+		t.Fatalf("Record() returned error: %v", err)
+	}
+	want := "2024-05-01T20:15:00.000000Z 1 1 0 255 ab000000000000000000000000000000\n"
+	if out.String() != want {
+		t.Errorf("printed %q, want %q (channel 3 and universe 2 filtered out)", out.String(), want)
+	}
+	if n := strings.Count(out.String(), "\n"); n != 1 {
+		t.Errorf("printed %d lines, want 1", n)
+	}
+}
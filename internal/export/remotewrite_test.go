@@ -0,0 +1,166 @@
+package export
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"sacn-monitor/internal/stats"
+	"sacn-monitor/internal/universe"
+
+	"github.com/golang/snappy"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// decodeWriteRequest returns each series of a WriteRequest as its labels
+// joined into name=value pairs, and the sample value
+func decodeWriteRequest(t *testing.T, b []byte) map[string]float64 {
+	t.Helper()
+	fields := func(b []byte, each func(num protowire.Number, typ protowire.Type, b []byte) int) {
+		for len(b) > 0 {
+			num, typ, n := protowire.ConsumeTag(b)
+			if n < 0 {
+				t.Fatalf("bad tag: %v", protowire.ParseError(n))
+			}
+			b = b[n:]
+			n = each(num, typ, b)
+			if n < 0 {
+				t.Fatalf("bad field %d: %v", num, protowire.ParseError(n))
+			}
+			b = b[n:]
+		}
+	}
+
+	series := make(map[string]float64)
+	fields(b, func(_ protowire.Number, _ protowire.Type, b []byte) int {
+		ts, n := protowire.ConsumeBytes(b)
+		var labels []string
+		var value float64
+		fields(ts, func(num protowire.Number, _ protowire.Type, b []byte) int {
+			msg, n := protowire.ConsumeBytes(b)
+			var pair [2]string
+			fields(msg, func(field protowire.Number, typ protowire.Type, b []byte) int {
+				if typ == protowire.Fixed64Type {
+					v, n := protowire.ConsumeFixed64(b)
+					value = math.Float64frombits(v)
+					return n
+				}
+				if typ == protowire.VarintType {
+					_, n := protowire.ConsumeVarint(b)
+					return n
+				}
+				s, n := protowire.ConsumeString(b)
+				pair[field-1] = s
+				return n
+			})
+			if num == 1 {
+				labels = append(labels, pair[0]+"="+pair[1])
+			}
+			return n
+		})
+		series[strings.Join(labels, ",")] = value
+		return n
+	})
+	return series
+}
+
+func TestRemoteWriter_Push(t *testing.T) {
+	manager := universe.NewManager()
+	manager.SetNames(map[uint16]string{1: "FOH"})
+	tracker := stats.NewTracker()
+	tracker.RecordPacket(1, [16]byte{1}, "console", 0)
+	tracker.RecordPacket(1, [16]byte{1}, "console", 3) // 2 lost
+
+	var mu sync.Mutex
+	var bodies []map[string]float64
+	status := http.StatusServiceUnavailable
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Encoding") != "snappy" || r.Header.Get("Content-Type") != "application/x-protobuf" {
+			t.Errorf("headers %v, want snappy protobuf", r.Header)
+		}
+		if user, pass, ok := r.BasicAuth(); !ok || user != "venue" || pass != "secret" {
+			t.Errorf("basic auth %q %q %t, want venue secret", user, pass, ok)
+		}
+		compressed, _ := io.ReadAll(r.Body)
+		body, err := snappy.Decode(nil, compressed)
+		if err != nil {
+			t.Errorf("body isn't snappy: %v", err)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		bodies = append(bodies, decodeWriteRequest(t, body))
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	w, err := DialRemoteWrite(strings.Replace(server.URL, "http://", "http://venue:secret@", 1)+"/api/v1/write", manager, tracker)
+	if err != nil {
+		t.Fatalf("DialRemoteWrite() returned error: %v", err)
+	}
+	if err := w.SetLabels("site=arena, instance=foh"); err != nil {
+		t.Fatalf("SetLabels() returned error: %v", err)
+	}
+
+	// The endpoint is down: the push waits for the next one
+	now := time.Unix(1700000000, 0)
+	if err := w.Push(context.Background(), now); err == nil {
+		t.Fatal("Push() to a failing endpoint expected error, got nil")
+	}
+	status = http.StatusNoContent
+	if err := w.Push(context.Background(), now.Add(15*time.Second)); err != nil {
+		t.Fatalf("Push() returned error: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(bodies) != 3 {
+		t.Fatalf("got %d requests, want the failed push resent before the new one", len(bodies))
+	}
+	key := "__name__=sacn_universe_lost_packets_total,instance=foh,job=sacn-monitor,name=FOH,site=arena,universe=1"
+	if v, ok := bodies[2][key]; !ok || v != 2 {
+		t.Errorf("series %s = %v, %t, want 2; got %v", key, v, ok, bodies[2])
+	}
+	source := "__name__=sacn_source_online,cid=01000000000000000000000000000000,instance=foh,job=sacn-monitor,site=arena,source=console,universe=1"
+	if _, ok := bodies[2][source]; !ok {
+		t.Errorf("series %s missing", source)
+	}
+}
+
+func TestRemoteWriter_Rejected(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		http.Error(w, "out of order sample", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	w, err := DialRemoteWrite(server.URL, universe.NewManager(), stats.NewTracker())
+	if err != nil {
+		t.Fatalf("DialRemoteWrite() returned error: %v", err)
+	}
+	for range 2 {
+		if err := w.Push(context.Background(), time.Now()); err == nil || !strings.Contains(err.Error(), "out of order") {
+			t.Errorf("Push() error = %v, want the endpoint's message", err)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("got %d requests, want rejected pushes dropped rather than resent", requests)
+	}
+
+	for _, bad := range []string{"udp://host:1234", "http://"} {
+		if _, err := DialRemoteWrite(bad, universe.NewManager(), stats.NewTracker()); err == nil {
+			t.Errorf("DialRemoteWrite(%q) expected error, got nil", bad)
+		}
+	}
+	for _, bad := range []string{"__name__=x", "universe=1", "source=desk", "name=FOH", "cid=00"} {
+		if err := w.SetLabels(bad); err == nil {
+			t.Errorf("SetLabels(%q) expected error, got nil", bad)
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package export
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"sacn-monitor/internal/events"
+	"sacn-monitor/internal/stats"
+	"sacn-monitor/internal/universe"
+)
+
+// reportGaps is how many of the longest gaps a report lists
+const reportGaps = 10
+
+// Report is a post-show report: the session summary with universe names,
+// the longest gaps and the alerts raised, for attaching to show reports
+type Report struct {
+	Summary stats.Summary
+	Names   map[uint16]string // Universe names, where set
+	Gaps    []stats.Gap       // Longest gaps, longest first
+	Alerts  []events.Event    // Oldest first, export errors left out
+}
+
+// NewReport builds a report of the session so far. log may be nil.
+func NewReport(manager *universe.Manager, tracker *stats.Tracker, log *events.Log) Report {
+	r := Report{Summary: tracker.Summary(), Names: make(map[uint16]string)}
+	for _, u := range r.Summary.Universes {
+		if name := manager.Name(u.Universe); name != "" {
+			r.Names[u.Universe] = name
+		}
+	}
+
+	gaps := tracker.GetGaps(0)
+	sort.SliceStable(gaps, func(i, j int) bool { return gaps[i].Duration > gaps[j].Duration })
+	r.Gaps = gaps[:min(len(gaps), reportGaps)]
+
+	if log != nil {
+		recent := log.Recent(0)
+		for i := len(recent) - 1; i >= 0; i-- {
+			if recent[i].Kind != events.ExportError {
+				r.Alerts = append(r.Alerts, recent[i])
+			}
+		}
+	}
+	return r
+}
+
+// reportTotals is the whole network's figures for a report's header
+type reportTotals struct {
+	Packets        uint64
+	Lost           uint64
+	LossPercentage float64
+	Sources        int
+}
+
+// reportFuncs formats values in report templates
+var reportFuncs = map[string]any{
+	"time": func(t time.Time) string {
+		if t.IsZero() {
+			return "-"
+		}
+		return t.Format("2006-01-02 15:04:05")
+	},
+	"clock": func(t time.Time) string {
+		return t.Format("15:04:05")
+	},
+	"dur": func(d time.Duration) string {
+		if d < time.Second {
+			return d.Round(time.Millisecond).String()
+		}
+		return d.Round(time.Second).String()
+	},
+	"pct": func(v float64) string {
+		return fmt.Sprintf("%.2f%%", v)
+	},
+	"totals": func(s stats.Summary) reportTotals {
+		var t reportTotals
+		for _, u := range s.Universes {
+			t.Packets += u.Packets
+			t.Lost += u.Lost
+			t.Sources += len(u.Sources)
+		}
+		if t.Packets+t.Lost > 0 {
+			t.LossPercentage = float64(t.Lost) / float64(t.Packets+t.Lost) * 100
+		}
+		return t
+	},
+	"cid": func(cid [16]byte) string {
+		return fmt.Sprintf("%x", cid)
+	},
+	// md escapes text for a Markdown table cell
+	"md": func(s string) string {
+		return strings.NewReplacer("|", `\|`, "\n", " ").Replace(s)
+	},
+}
+
+var markdownReport = template.Must(template.New("report").Funcs(reportFuncs).Parse(`# sACN session report
+{{$t := totals .Summary}}
+- **Session:** {{time .Summary.Start}} to {{time .Summary.End}} ({{dur .Summary.Duration}})
+- **Universes:** {{len .Summary.Universes}}, **sources:** {{$t.Sources}}
+- **Packets:** {{$t.Packets}}, **lost:** {{$t.Lost}} ({{pct $t.LossPercentage}})
+- **Alerts:** {{len .Alerts}}
+
+## Universes
+
+| Universe | Name | Monitored | Packets | Lost | Loss | Worst gap | Outages | Offline | Availability |
+|---------:|------|----------:|--------:|-----:|-----:|----------:|--------:|--------:|-------------:|
+{{range .Summary.Universes}}| {{.Universe}} | {{md (index $.Names .Universe)}} | {{dur .Monitored}} | {{.Packets}} | {{.Lost}} | {{pct .LossPercentage}} | {{dur .WorstGap}} | {{.Outages}} | {{dur .OfflineTime}} | {{pct .Availability}} |
+{{end}}
+## Sources
+{{range .Summary.Universes}}{{$u := .Universe}}
+### Universe {{.Universe}}{{with index $.Names .Universe}} ({{.}}){{end}}
+
+| Source | CID | Address | Monitored | Packets | Lost | Loss | Worst gap | Outages | Longest outage | Availability |
+|--------|-----|---------|----------:|--------:|-----:|-----:|----------:|--------:|---------------:|-------------:|
+{{range .Sources}}| {{md .Name}} | {{cid .CID}} | {{.Address}} | {{dur .Monitored}} | {{.Packets}} | {{.Lost}} | {{pct .LossPercentage}} | {{dur .WorstGap}} | {{.Outages}} | {{dur .LongestOutage}} | {{pct .Availability}} |
+{{end}}{{range .Sources}}{{if .Offline}}
+Offline periods of {{md .Name}}:
+{{range .Offline}}
+- {{time .Start}} for {{dur .Duration}}{{end}}
+{{end}}{{end}}{{end}}
+## Worst gaps
+{{if .Gaps}}
+| Time | Universe | Source | Length |
+|------|---------:|--------|-------:|
+{{range .Gaps}}| {{time .Start}} | {{.Universe}} | {{md .Source}} | {{dur .Duration}} |
+{{end}}{{else}}
+No gaps over the gap threshold.
+{{end}}
+## Alerts
+{{if .Alerts}}
+| Time | Universe | Kind | Message |
+|------|---------:|------|---------|
+{{range .Alerts}}| {{time .Time}} | {{.Universe}} | {{.Kind}} | {{md .Message}} |
+{{end}}{{else}}
+No alerts.
+{{end}}`))
+
+var htmlReport = htmltemplate.Must(htmltemplate.New("report").Funcs(reportFuncs).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>sACN session report {{time .Summary.Start}}</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2em; color: #222; }
+h1, h2, h3 { font-weight: 600; }
+table { border-collapse: collapse; margin: 0.5em 0 1.5em; font-size: 0.9em; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.6em; text-align: left; }
+th { background: #f0f0f0; }
+td.num { text-align: right; font-variant-numeric: tabular-nums; }
+.bad { color: #b00020; font-weight: 600; }
+code { font-size: 0.85em; }
+</style>
+</head>
+<body>
+<h1>sACN session report</h1>
+{{$t := totals .Summary}}
+<ul>
+<li><strong>Session:</strong> {{time .Summary.Start}} to {{time .Summary.End}} ({{dur .Summary.Duration}})</li>
+<li><strong>Universes:</strong> {{len .Summary.Universes}}, <strong>sources:</strong> {{$t.Sources}}</li>
+<li><strong>Packets:</strong> {{$t.Packets}}, <strong>lost:</strong> <span{{if $t.Lost}} class="bad"{{end}}>{{$t.Lost}} ({{pct $t.LossPercentage}})</span></li>
+<li><strong>Alerts:</strong> {{len .Alerts}}</li>
+</ul>
+
+<h2>Universes</h2>
+<table>
+<tr><th>Universe</th><th>Name</th><th>Monitored</th><th>Packets</th><th>Lost</th><th>Loss</th><th>Worst gap</th><th>Outages</th><th>Offline</th><th>Availability</th></tr>
+{{range .Summary.Universes}}<tr><td class="num">{{.Universe}}</td><td>{{index $.Names .Universe}}</td><td class="num">{{dur .Monitored}}</td><td class="num">{{.Packets}}</td><td class="num{{if .Lost}} bad{{end}}">{{.Lost}}</td><td class="num">{{pct .LossPercentage}}</td><td class="num">{{dur .WorstGap}}</td><td class="num{{if .Outages}} bad{{end}}">{{.Outages}}</td><td class="num">{{dur .OfflineTime}}</td><td class="num">{{pct .Availability}}</td></tr>
+{{end}}</table>
+
+<h2>Sources</h2>
+{{range .Summary.Universes}}
+<h3>Universe {{.Universe}}{{with index $.Names .Universe}} ({{.}}){{end}}</h3>
+<table>
+<tr><th>Source</th><th>CID</th><th>Address</th><th>Monitored</th><th>Packets</th><th>Lost</th><th>Loss</th><th>Worst gap</th><th>Outages</th><th>Longest outage</th><th>Availability</th></tr>
+{{range .Sources}}<tr><td>{{.Name}}</td><td><code>{{cid .CID}}</code></td><td>{{.Address}}</td><td class="num">{{dur .Monitored}}</td><td class="num">{{.Packets}}</td><td class="num{{if .Lost}} bad{{end}}">{{.Lost}}</td><td class="num">{{pct .LossPercentage}}</td><td class="num">{{dur .WorstGap}}</td><td class="num{{if .Outages}} bad{{end}}">{{.Outages}}</td><td class="num">{{dur .LongestOutage}}</td><td class="num">{{pct .Availability}}</td></tr>
+{{end}}</table>
+{{range .Sources}}{{if .Offline}}
+<p>Offline periods of {{.Name}}:</p>
+<ul>
+{{range .Offline}}<li>{{time .Start}} for {{dur .Duration}}</li>
+{{end}}</ul>
+{{end}}{{end}}{{end}}
+<h2>Worst gaps</h2>
+{{if .Gaps}}<table>
+<tr><th>Time</th><th>Universe</th><th>Source</th><th>Length</th></tr>
+{{range .Gaps}}<tr><td>{{time .Start}}</td><td class="num">{{.Universe}}</td><td>{{.Source}}</td><td class="num">{{dur .Duration}}</td></tr>
+{{end}}</table>
+{{else}}<p>No gaps over the gap threshold.</p>
+{{end}}
+<h2>Alerts</h2>
+{{if .Alerts}}<table>
+<tr><th>Time</th><th>Universe</th><th>Kind</th><th>Message</th></tr>
+{{range .Alerts}}<tr><td>{{time .Time}}</td><td class="num">{{.Universe}}</td><td>{{.Kind}}</td><td>{{.Message}}</td></tr>
+{{end}}</table>
+{{else}}<p>No alerts.</p>
+{{end}}</body>
+</html>
+`))
+
+// WriteReportMarkdown writes a report as Markdown
+func WriteReportMarkdown(w io.Writer, r Report) error {
+	if err := markdownReport.Execute(w, r); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+// WriteReportHTML writes a report as a self-contained HTML page
+func WriteReportHTML(w io.Writer, r Report) error {
+	if err := htmlReport.Execute(w, r); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+// WriteReportFile writes a report to a file, as HTML for .html or .htm
+// and as Markdown for .md or .markdown
+func WriteReportFile(path string, r Report) error {
+	var write func(io.Writer, Report) error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".html", ".htm":
+		write = WriteReportHTML
+	case ".md", ".markdown":
+		write = WriteReportMarkdown
+	default:
+		return fmt.Errorf("report %s: extension must be .html, .htm, .md or .markdown", path)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create report: %w", err)
+	}
+	if err := write(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
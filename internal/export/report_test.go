@@ -0,0 +1,80 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"sacn-monitor/internal/events"
+	"sacn-monitor/internal/stats"
+)
+
+// testReport returns a report of one universe with a lossy source
+func testReport() Report {
+	start := time.Date(2026, 3, 14, 19, 0, 0, 0, time.UTC)
+	return Report{
+		Summary: stats.Summary{
+			Start:    start,
+			End:      start.Add(3 * time.Hour),
+			Duration: 3 * time.Hour,
+			Universes: []stats.UniverseSummary{{
+				Universe: 1,
+				Packets:  990,
+				Lost:     10,
+				Sources: []stats.SourceSummary{{
+					Name:    "console <main>",
+					Packets: 990,
+					Lost:    10,
+					Offline: []stats.Gap{{Start: start.Add(time.Hour), Duration: 5 * time.Second}},
+				}},
+			}},
+		},
+		Names:  map[uint16]string{1: "FOH|truss"},
+		Gaps:   []stats.Gap{{Universe: 1, Source: "console <main>", Start: start.Add(time.Hour), Duration: 800 * time.Millisecond}},
+		Alerts: []events.Event{{Time: start.Add(time.Hour), Kind: events.SourceLost, Universe: 1, Message: "console lost"}},
+	}
+}
+
+func TestWriteReportMarkdown(t *testing.T) {
+	var sb strings.Builder
+	if err := WriteReportMarkdown(&sb, testReport()); err != nil {
+		t.Fatalf("WriteReportMarkdown() returned error: %v", err)
+	}
+	out := sb.String()
+
+	for _, want := range []string{
+		"**Packets:** 990, **lost:** 10 (1.00%)",
+		`| 1 | FOH\|truss | `,
+		"### Universe 1 (FOH|truss)",
+		"- 2026-03-14 20:00:00 for 5s",
+		"| 2026-03-14 20:00:00 | 1 | console <main> | 800ms |",
+		"| source_lost | console lost |",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report is missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteReportFile(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "report.html")
+	if err := WriteReportFile(path, testReport()); err != nil {
+		t.Fatalf("WriteReportFile() returned error: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	html := string(data)
+	if !strings.HasPrefix(html, "<!DOCTYPE html>") || !strings.Contains(html, "console &lt;main&gt;") {
+		t.Errorf("report isn't escaped HTML:\n%s", html)
+	}
+
+	if err := WriteReportFile(filepath.Join(dir, "report.txt"), testReport()); err == nil {
+		t.Error("WriteReportFile(.txt) expected error, got nil")
+	}
+}
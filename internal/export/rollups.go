@@ -0,0 +1,85 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"sacn-monitor/internal/history"
+)
+
+// rollupHeader is the first row of a rollup CSV
+var rollupHeader = []string{"start", "period", "universe", "packets", "lost", "loss_percent", "peak_rate", "availability_percent"}
+
+// WriteRollups writes rollups as CSV rows, optionally preceded by the header
+func WriteRollups(w io.Writer, rollups []history.Rollup, header bool) error {
+	cw := csv.NewWriter(w)
+	if header {
+		if err := cw.Write(rollupHeader); err != nil {
+			return fmt.Errorf("failed to write rollups: %w", err)
+		}
+	}
+
+	for _, r := range rollups {
+		period := "hourly"
+		if r.Period == history.Daily {
+			period = "daily"
+		}
+		row := []string{
+			r.Start.Format(time.RFC3339),
+			period,
+			strconv.Itoa(int(r.Universe)),
+			strconv.FormatUint(r.Packets, 10),
+			strconv.FormatUint(r.Lost, 10),
+			strconv.FormatFloat(r.LossPercentage(), 'f', 3, 64),
+			strconv.FormatFloat(r.PeakRate, 'f', 1, 64),
+			strconv.FormatFloat(r.Availability(), 'f', 2, 64),
+		}
+		if err := cw.Write(row); err != nil {
+			return fmt.Errorf("failed to write rollups: %w", err)
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write rollups: %w", err)
+	}
+	return nil
+}
+
+// WriteRollupsFile writes rollups to a new CSV file with a header
+func WriteRollupsFile(path string, rollups []history.Rollup) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create rollups file: %w", err)
+	}
+	if err := WriteRollups(f, rollups, true); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// AppendRollups appends rollups to a CSV file, writing the header if the
+// file is new or empty
+func AppendRollups(path string, rollups []history.Rollup) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open rollup log: %w", err)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("failed to open rollup log: %w", err)
+	}
+
+	if err := WriteRollups(f, rollups, info.Size() == 0); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,49 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"sacn-monitor/internal/history"
+)
+
+func TestAppendRollups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rollups.csv")
+	hour := history.Rollup{
+		Universe:  3,
+		Period:    history.Hourly,
+		Start:     time.Date(2026, 3, 14, 21, 0, 0, 0, time.UTC),
+		Packets:   158399,
+		Lost:      1,
+		PeakRate:  45,
+		Samples:   3600,
+		Available: 3599,
+	}
+	day := hour
+	day.Period = history.Daily
+
+	if err := AppendRollups(path, []history.Rollup{hour}); err != nil {
+		t.Fatalf("AppendRollups() returned error: %v", err)
+	}
+	if err := AppendRollups(path, []history.Rollup{day}); err != nil {
+		t.Fatalf("AppendRollups() returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want the header once and two rows:\n%s", len(lines), data)
+	}
+	if want := "2026-03-14T21:00:00Z,hourly,3,158399,1,0.001,45.0,99.97"; lines[1] != want {
+		t.Errorf("row = %q, want %q", lines[1], want)
+	}
+	if !strings.Contains(lines[2], ",daily,") {
+		t.Errorf("second row = %q, want a daily rollup", lines[2])
+	}
+}
@@ -0,0 +1,68 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"sacn-monitor/internal/stats"
+)
+
+// sequenceHeader is the first row of an exported sequence timeline
+var sequenceHeader = []string{"time", "universe", "source", "cid", "sequence", "arrival", "lost"}
+
+// SourceTimeline is the sequence timeline of one source on a universe
+type SourceTimeline struct {
+	Universe uint16
+	CID      [16]byte
+	Source   string
+	Samples  []stats.SequenceSample
+}
+
+// WriteSequenceTimelines writes sequence timelines as CSV, one row per
+// packet, source by source in the order given
+func WriteSequenceTimelines(w io.Writer, timelines []SourceTimeline) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(sequenceHeader); err != nil {
+		return fmt.Errorf("failed to write sequence timeline: %w", err)
+	}
+
+	for _, tl := range timelines {
+		for _, s := range tl.Samples {
+			row := []string{
+				s.Time.Format(time.RFC3339Nano),
+				strconv.Itoa(int(tl.Universe)),
+				tl.Source,
+				fmt.Sprintf("%x", tl.CID),
+				strconv.Itoa(int(s.Sequence)),
+				s.Arrival.String(),
+				strconv.Itoa(s.Lost),
+			}
+			if err := cw.Write(row); err != nil {
+				return fmt.Errorf("failed to write sequence timeline: %w", err)
+			}
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write sequence timeline: %w", err)
+	}
+	return nil
+}
+
+// WriteSequenceTimelinesFile writes sequence timelines as CSV to a file
+func WriteSequenceTimelinesFile(path string, timelines []SourceTimeline) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create sequence timeline: %w", err)
+	}
+	if err := WriteSequenceTimelines(f, timelines); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
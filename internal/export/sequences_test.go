@@ -0,0 +1,39 @@
+package export
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"sacn-monitor/internal/stats"
+)
+
+func TestWriteSequenceTimelines(t *testing.T) {
+	at := time.Date(2026, 3, 14, 21, 10, 5, 0, time.UTC)
+	timelines := []SourceTimeline{{
+		Universe: 3,
+		CID:      [16]byte{0xab},
+		Source:   "console",
+		Samples: []stats.SequenceSample{
+			{Time: at, Sequence: 10, Arrival: stats.InOrder},
+			{Time: at.Add(25 * time.Millisecond), Sequence: 13, Arrival: stats.InOrder, Lost: 2},
+			{Time: at.Add(30 * time.Millisecond), Sequence: 12, Arrival: stats.OutOfOrder},
+		},
+	}}
+
+	var sb strings.Builder
+	if err := WriteSequenceTimelines(&sb, timelines); err != nil {
+		t.Fatalf("WriteSequenceTimelines() returned error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(sb.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("got %d lines, want header and three rows:\n%s", len(lines), sb.String())
+	}
+	if want := "2026-03-14T21:10:05.025Z,3,console,ab000000000000000000000000000000,13,in_order,2"; lines[2] != want {
+		t.Errorf("row = %q, want %q", lines[2], want)
+	}
+	if !strings.HasSuffix(lines[3], ",12,out_of_order,0") {
+		t.Errorf("row = %q, want a late packet 12", lines[3])
+	}
+}
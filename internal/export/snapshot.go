@@ -0,0 +1,64 @@
+package export
+
+import (
+	"sort"
+
+	"sacn-monitor/internal/stats"
+	"sacn-monitor/internal/universe"
+)
+
+// CaptureSnapshots snapshots the given universes, or every universe if none
+// are given, with every source the tracker has seen sending to each in the
+// last data loss timeout. Unknown universes are skipped.
+func CaptureSnapshots(manager *universe.Manager, tracker *stats.Tracker, name string, ids ...uint16) []universe.Snapshot {
+	if len(ids) == 0 {
+		for _, u := range manager.GetAll() {
+			ids = append(ids, u.ID)
+		}
+	}
+
+	var snapshots []universe.Snapshot
+	for _, id := range ids {
+		u := manager.Get(id)
+		if u == nil {
+			continue
+		}
+		s := u.Snapshot(name)
+		s.Label = manager.Name(id)
+		addSnapshotSources(&s, tracker.GetSources(id))
+		snapshots = append(snapshots, s)
+	}
+	return snapshots
+}
+
+// addSnapshotSources adds the online sources the universe's snapshot
+// doesn't list yet, by priority then name, after the source of its data
+func addSnapshotSources(s *universe.Snapshot, sources []stats.Source) {
+	sort.Slice(sources, func(i, j int) bool {
+		if sources[i].Priority != sources[j].Priority {
+			return sources[i].Priority > sources[j].Priority
+		}
+		return sources[i].Name < sources[j].Name
+	})
+
+	for _, src := range sources {
+		if s.TakenAt.Sub(src.LastSeen) > sourceOfflineAfter {
+			continue
+		}
+		listed := false
+		for i := range s.Sources {
+			if s.Sources[i].CID == src.CID {
+				s.Sources[i].PerAddress = s.Sources[i].PerAddress || src.PerAddressPriority
+				listed = true
+			}
+		}
+		if !listed {
+			s.Sources = append(s.Sources, universe.SnapshotSource{
+				Name:       src.Name,
+				CID:        src.CID,
+				Priority:   src.Priority,
+				PerAddress: src.PerAddressPriority,
+			})
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package export
+
+import (
+	"testing"
+
+	"sacn-monitor/internal/stats"
+	"sacn-monitor/internal/universe"
+)
+
+func TestCaptureSnapshots(t *testing.T) {
+	manager := universe.NewManager()
+	tracker := stats.NewTracker()
+	console, backup := [16]byte{1}, [16]byte{2}
+	manager.GetOrCreate(1).Update([]byte{255}, "console", console, 100, 0)
+	manager.GetOrCreate(2).Update([]byte{0}, "console", console, 100, 0)
+	tracker.RecordPacket(1, console, "console", 0)
+	tracker.RecordPriority(1, console, 100, false)
+	tracker.RecordPacket(1, backup, "backup", 0)
+	tracker.RecordPriority(1, backup, 90, false)
+
+	all := CaptureSnapshots(manager, tracker, "look")
+	if len(all) != 2 {
+		t.Fatalf("len(CaptureSnapshots()) = %d, want every universe", len(all))
+	}
+
+	one := CaptureSnapshots(manager, tracker, "look", 1, 3)
+	if len(one) != 1 {
+		t.Fatalf("len(CaptureSnapshots(1, 3)) = %d, want 1 (3 is unknown)", len(one))
+	}
+	sources := one[0].Sources
+	if len(sources) != 2 || sources[0].Name != "console" || sources[1].Name != "backup" || sources[1].Priority != 90 {
+		t.Errorf("Sources = %+v, want console then backup at 90", sources)
+	}
+}
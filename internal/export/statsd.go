@@ -0,0 +1,206 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"sacn-monitor/internal/stats"
+)
+
+// Metrics emitter defaults
+const (
+	// DefaultMetricsPrefix starts every metric name
+	DefaultMetricsPrefix = "sacn"
+	// DefaultMetricsInterval is how often metrics are emitted
+	DefaultMetricsInterval = 10 * time.Second
+)
+
+// metric is one emitted value. Counters hold running totals: StatsD gets
+// the increase since the last emission, Graphite the total.
+type metric struct {
+	name    string
+	value   float64
+	counter bool
+}
+
+// MetricsEmitter sends rate, loss and drop metrics to StatsD (UDP) or
+// Graphite (plaintext over TCP), for monitoring stacks without Prometheus.
+// Names are <prefix>.total.<metric> and <prefix>.universe.<id>.<metric>.
+type MetricsEmitter struct {
+	tracker  *stats.Tracker
+	conn     net.Conn
+	graphite bool
+	prefix   string
+	interval time.Duration
+
+	counted map[string]float64 // Counter totals at the last StatsD emission
+}
+
+// DialMetrics connects to target, given as statsd://host:port or
+// graphite://host:port
+func DialMetrics(target string, tracker *stats.Tracker) (*MetricsEmitter, error) {
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid metrics target %q: %w", target, err)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid metrics target %q: missing host:port", target)
+	}
+
+	var network string
+	switch u.Scheme {
+	case "statsd":
+		network = "udp"
+	case "graphite":
+		network = "tcp"
+	default:
+		return nil, fmt.Errorf("invalid metrics target %q: scheme must be statsd or graphite", target)
+	}
+	conn, err := net.Dial(network, u.Host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to metrics target %s: %w", target, err)
+	}
+	return NewMetricsEmitter(conn, u.Scheme == "graphite", tracker), nil
+}
+
+// NewMetricsEmitter creates an emitter writing to an established
+// connection, in Graphite's plaintext protocol if graphite is set and as
+// StatsD otherwise
+func NewMetricsEmitter(conn net.Conn, graphite bool, tracker *stats.Tracker) *MetricsEmitter {
+	return &MetricsEmitter{
+		tracker:  tracker,
+		conn:     conn,
+		graphite: graphite,
+		prefix:   DefaultMetricsPrefix,
+		interval: DefaultMetricsInterval,
+		counted:  make(map[string]float64),
+	}
+}
+
+// SetPrefix sets the prefix of every metric name
+func (e *MetricsEmitter) SetPrefix(prefix string) {
+	e.prefix = strings.Trim(prefix, ".")
+}
+
+// SetInterval sets how often metrics are emitted
+func (e *MetricsEmitter) SetInterval(d time.Duration) {
+	if d > 0 {
+		e.interval = d
+	}
+}
+
+// Run emits metrics at each interval until the context is cancelled.
+// Failed writes are reported to onError.
+func (e *MetricsEmitter) Run(ctx context.Context, onError func(error)) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := e.Emit(now); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+// Emit sends the current metrics
+func (e *MetricsEmitter) Emit(now time.Time) error {
+	var lines []string
+	for _, m := range e.metrics() {
+		name := m.name
+		if e.prefix != "" {
+			name = e.prefix + "." + name
+		}
+		value := strconv.FormatFloat(m.value, 'f', -1, 64)
+
+		switch {
+		case e.graphite:
+			lines = append(lines, name+" "+value+" "+strconv.FormatInt(now.Unix(), 10))
+		case m.counter:
+			// Stats reset in between count from zero again
+			delta := m.value - e.counted[name]
+			if delta < 0 {
+				delta = m.value
+			}
+			e.counted[name] = m.value
+			if delta > 0 {
+				lines = append(lines, name+":"+strconv.FormatFloat(delta, 'f', -1, 64)+"|c")
+			}
+		default:
+			lines = append(lines, name+":"+value+"|g")
+		}
+	}
+	return e.write(lines)
+}
+
+// Close closes the connection
+func (e *MetricsEmitter) Close() error {
+	return e.conn.Close()
+}
+
+// metrics collects the network totals and the metrics of every universe
+func (e *MetricsEmitter) metrics() []metric {
+	totals := e.tracker.Totals()
+	metrics := []metric{
+		{"total.packet_rate", totals.PacketRate, false},
+		{"total.byte_rate", totals.ByteRate, false},
+		{"total.recent_loss_pct", totals.RecentLossPercentage(), false},
+		{"total.universes", float64(totals.Universes), false},
+		{"total.sources", float64(totals.Sources), false},
+		{"total.packets", float64(totals.PacketCount), true},
+		{"total.lost", float64(totals.LostPackets), true},
+		{"total.receiver_drops", float64(totals.ReceiverDrops), true},
+	}
+
+	ids := e.tracker.GetAllUniverseIDs()
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for _, id := range ids {
+		agg := e.tracker.Aggregate([]uint16{id})
+		base := "universe." + strconv.Itoa(int(id)) + "."
+		metrics = append(metrics,
+			metric{base + "packet_rate", agg.PacketRate, false},
+			metric{base + "loss_pct", agg.LossPercentage(), false},
+			metric{base + "recent_loss_pct", agg.RecentLossPercentage(), false},
+			metric{base + "health", float64(e.tracker.GetHealth(id).Score), false},
+			metric{base + "sources", float64(agg.Sources), false},
+			metric{base + "packets", float64(agg.PacketCount), true},
+			metric{base + "lost", float64(agg.LostPackets), true},
+			metric{base + "duplicates", float64(agg.Duplicates), true},
+			metric{base + "receiver_drops", float64(agg.ReceiverDrops), true},
+		)
+	}
+	return metrics
+}
+
+// write sends lines, packing them into MTU-sized datagrams for StatsD
+func (e *MetricsEmitter) write(lines []string) error {
+	var buf bytes.Buffer
+	for _, line := range lines {
+		if !e.graphite && buf.Len() > 0 && buf.Len()+len(line)+1 > previzMaxDatagram {
+			if _, err := e.conn.Write(buf.Bytes()); err != nil {
+				return fmt.Errorf("metrics write failed: %w", err)
+			}
+			buf.Reset()
+		}
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+	if buf.Len() == 0 {
+		return nil
+	}
+	if _, err := e.conn.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("metrics write failed: %w", err)
+	}
+	return nil
+}
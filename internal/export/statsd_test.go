@@ -0,0 +1,69 @@
+package export
+
+import (
+	"net"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"sacn-monitor/internal/stats"
+)
+
+// emitLines runs one emission over a pipe and returns the lines written
+func emitLines(t *testing.T, e *MetricsEmitter, server net.Conn) []string {
+	t.Helper()
+	done := make(chan string)
+	go func() {
+		buf := make([]byte, 65536)
+		n, _ := server.Read(buf)
+		done <- string(buf[:n])
+	}()
+	if err := e.Emit(time.Unix(1700000000, 0)); err != nil {
+		t.Fatalf("Emit() returned error: %v", err)
+	}
+	return strings.Split(strings.TrimSpace(<-done), "\n")
+}
+
+func TestMetricsEmitter_StatsD(t *testing.T) {
+	tracker := stats.NewTracker()
+	tracker.RecordPacket(1, [16]byte{1}, "console", 0)
+	tracker.RecordPacket(1, [16]byte{1}, "console", 3) // 2 lost
+
+	client, server := net.Pipe()
+	defer server.Close()
+	e := NewMetricsEmitter(client, false, tracker)
+	e.SetPrefix("venue.sacn.")
+
+	lines := emitLines(t, e, server)
+	for _, want := range []string{"venue.sacn.universe.1.lost:2|c", "venue.sacn.universe.1.packets:2|c", "venue.sacn.total.universes:1|g"} {
+		if !slices.Contains(lines, want) {
+			t.Errorf("metrics missing %q:\n%s", want, strings.Join(lines, "\n"))
+		}
+	}
+
+	// Counters only send their increase
+	tracker.RecordPacket(1, [16]byte{1}, "console", 4)
+	lines = emitLines(t, e, server)
+	if !slices.Contains(lines, "venue.sacn.universe.1.packets:1|c") {
+		t.Errorf("second emission missing a packet increase of 1:\n%s", strings.Join(lines, "\n"))
+	}
+	for _, line := range lines {
+		if strings.HasPrefix(line, "venue.sacn.universe.1.lost:") {
+			t.Errorf("unchanged counter sent again: %s", line)
+		}
+	}
+}
+
+func TestMetricsEmitter_Graphite(t *testing.T) {
+	tracker := stats.NewTracker()
+	tracker.RecordPacket(2, [16]byte{1}, "console", 0)
+
+	client, server := net.Pipe()
+	defer server.Close()
+	lines := emitLines(t, NewMetricsEmitter(client, true, tracker), server)
+
+	if !slices.Contains(lines, "sacn.universe.2.packets 1 1700000000") {
+		t.Errorf("graphite metrics missing the packet total:\n%s", strings.Join(lines, "\n"))
+	}
+}
@@ -0,0 +1,55 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"sacn-monitor/internal/stats"
+	"sacn-monitor/internal/universe"
+)
+
+// WriteStatus writes a plain-text summary of every universe: network
+// totals, then per universe its rate, loss, health and sources. It is the
+// periodic output of headless mode, meant for logs and journald.
+func WriteStatus(w io.Writer, manager *universe.Manager, tracker *stats.Tracker, now time.Time) error {
+	totals := tracker.Totals()
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s  %d universes, %d sources, %.0f pps, loss %.1f%% (1m), %d dropped locally\n",
+		now.Format("2006-01-02 15:04:05"), totals.Universes, totals.Sources, totals.PacketRate,
+		totals.RecentLossPercentage(), totals.ReceiverDrops)
+
+	ids := tracker.GetAllUniverseIDs()
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for _, id := range ids {
+		label := fmt.Sprintf("U%d", id)
+		if name := manager.Name(id); name != "" {
+			label += " " + name
+		}
+		state := ""
+		if u := manager.Get(id); u == nil || u.IsStale(time.Second) {
+			state = " STALE"
+		}
+		fmt.Fprintf(&b, "  %-20s %6.1f pps  loss %5.1f%% (1m) %5.1f%% (total)  health %3d%s\n",
+			label, tracker.GetPacketRate(id), tracker.GetRecentLossPercentage(id),
+			tracker.GetLossPercentage(id), tracker.GetHealth(id).Score, state)
+
+		sources := tracker.GetSources(id)
+		sort.Slice(sources, func(i, j int) bool { return sources[i].Name < sources[j].Name })
+		for _, s := range sources {
+			addr := s.Address
+			if addr == "" {
+				addr = "-"
+			}
+			fmt.Fprintf(&b, "    %-20s %-15s prio %3d  %d packets, %d lost\n",
+				s.Name, addr, s.Priority, s.PacketCount, s.LostPackets)
+		}
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write status: %w", err)
+	}
+	return nil
+}
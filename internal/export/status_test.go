@@ -0,0 +1,36 @@
+package export
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"sacn-monitor/internal/stats"
+	"sacn-monitor/internal/universe"
+)
+
+func TestWriteStatus(t *testing.T) {
+	manager := universe.NewManager()
+	manager.SetName(1, "FOH")
+	manager.GetOrCreate(1).Update([]byte{255}, "console", [16]byte{1}, 100, 0)
+	tracker := stats.NewTracker()
+	tracker.RecordPacket(1, [16]byte{1}, "console", 0)
+	tracker.RecordSourceAddress([16]byte{1}, "10.0.0.1")
+	tracker.RecordPriority(1, [16]byte{1}, 100, false)
+
+	var sb strings.Builder
+	now := time.Date(2026, 3, 14, 19, 0, 0, 0, time.UTC)
+	if err := WriteStatus(&sb, manager, tracker, now); err != nil {
+		t.Fatalf("WriteStatus() returned error: %v", err)
+	}
+
+	out := sb.String()
+	for _, want := range []string{"2026-03-14 19:00:00", "1 universes, 1 sources", "U1 FOH", "console", "10.0.0.1", "prio 100"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("status missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "STALE") {
+		t.Errorf("status flags a live universe as stale:\n%s", out)
+	}
+}
@@ -0,0 +1,38 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"sacn-monitor/internal/stats"
+)
+
+// WriteSummary writes a session summary as indented JSON
+func WriteSummary(w io.Writer, summary stats.Summary) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(summary); err != nil {
+		return fmt.Errorf("failed to write session summary: %w", err)
+	}
+	return nil
+}
+
+// WriteSummaryFile writes a session summary as JSON to a file, or to
+// stdout if path is "-"
+func WriteSummaryFile(path string, summary stats.Summary) error {
+	if path == "-" {
+		return WriteSummary(os.Stdout, summary)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create session summary: %w", err)
+	}
+	if err := WriteSummary(f, summary); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
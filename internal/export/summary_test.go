@@ -0,0 +1,46 @@
+package export
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"sacn-monitor/internal/stats"
+)
+
+func TestWriteSummary(t *testing.T) {
+	start := time.Date(2026, 3, 14, 19, 0, 0, 0, time.UTC)
+	summary := stats.Summary{
+		Start:    start,
+		End:      start.Add(3 * time.Hour),
+		Duration: 3 * time.Hour,
+		Universes: []stats.UniverseSummary{{
+			Universe: 1,
+			Packets:  100,
+			Lost:     1,
+			Sources:  []stats.SourceSummary{{Name: "console", Packets: 100}},
+		}},
+	}
+
+	var sb strings.Builder
+	if err := WriteSummary(&sb, summary); err != nil {
+		t.Fatalf("WriteSummary() returned error: %v", err)
+	}
+
+	var decoded struct {
+		Universes []struct {
+			Universe uint16 `json:"universe"`
+			Lost     uint64 `json:"lost"`
+			Sources  []struct {
+				Name string `json:"name"`
+			} `json:"sources"`
+		} `json:"universes"`
+	}
+	if err := json.Unmarshal([]byte(sb.String()), &decoded); err != nil {
+		t.Fatalf("summary is not valid JSON: %v\n%s", err, sb.String())
+	}
+	if len(decoded.Universes) != 1 || decoded.Universes[0].Lost != 1 || decoded.Universes[0].Sources[0].Name != "console" {
+		t.Errorf("decoded = %+v, want universe 1 with 1 lost from console", decoded)
+	}
+}
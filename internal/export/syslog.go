@@ -0,0 +1,258 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"sacn-monitor/internal/events"
+)
+
+// Syslog forwarder defaults
+const (
+	// syslogInterval is how often new events are forwarded
+	syslogInterval = time.Second
+	// syslogBacklog is how many events wait for a lost TCP connection to
+	// come back before the oldest are dropped
+	syslogBacklog = 500
+	// syslogAppName is the APP-NAME of every message
+	syslogAppName = "sacn-monitor"
+	// syslogEnterprise is the private enterprise number of the structured
+	// data ID; 32473 is reserved for documentation and examples (RFC 5612)
+	syslogEnterprise = 32473
+)
+
+// Syslog severities (RFC 5424 section 6.2.1)
+const (
+	severityError   = 3
+	severityWarning = 4
+	severityNotice  = 5
+	severityInfo    = 6
+)
+
+// syslogFacilities are the facility codes by name
+var syslogFacilities = map[string]int{
+	"kern": 0, "user": 1, "mail": 2, "daemon": 3, "auth": 4, "syslog": 5,
+	"lpr": 6, "news": 7, "uucp": 8, "cron": 9, "authpriv": 10, "ftp": 11,
+	"local0": 16, "local1": 17, "local2": 18, "local3": 19,
+	"local4": 20, "local5": 21, "local6": 22, "local7": 23,
+}
+
+// DefaultSyslogFacility is the facility messages are sent with
+const DefaultSyslogFacility = "local0"
+
+// Syslog forwards monitor events as RFC 5424 messages to a syslog
+// collector over UDP (RFC 5426, one message per datagram) or TCP (RFC 6587
+// octet counting), so venue NOCs see them alongside switch and server
+// logs. The event kind is the MSGID; universe and source go in structured
+// data. A lost TCP connection is redialled at the next forward.
+type Syslog struct {
+	log      *events.Log
+	conn     net.Conn
+	dial     func() (net.Conn, error) // nil = no reconnecting
+	stream   bool                     // Octet-counting framing, for TCP
+	facility int
+	hostname string
+	procID   string
+
+	seen    uint64         // Events already taken from the log
+	pending []events.Event // Taken but not sent yet
+}
+
+// DialSyslog connects to target, given as udp://host[:514] or
+// tcp://host[:601]
+func DialSyslog(target string, log *events.Log) (*Syslog, error) {
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid syslog target %q: %w", target, err)
+	}
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("invalid syslog target %q: missing host", target)
+	}
+
+	var port string
+	switch u.Scheme {
+	case "udp":
+		port = "514"
+	case "tcp":
+		port = "601"
+	default:
+		return nil, fmt.Errorf("invalid syslog target %q: scheme must be udp or tcp", target)
+	}
+	if u.Port() != "" {
+		port = u.Port()
+	}
+	address := net.JoinHostPort(u.Hostname(), port)
+	dial := func() (net.Conn, error) {
+		conn, err := net.DialTimeout(u.Scheme, address, 5*time.Second)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to syslog %s: %w", target, err)
+		}
+		return conn, nil
+	}
+
+	conn, err := dial()
+	if err != nil {
+		return nil, err
+	}
+	s := NewSyslog(conn, u.Scheme == "tcp", log)
+	s.dial = dial
+	return s, nil
+}
+
+// NewSyslog creates a forwarder writing to an established connection, with
+// octet-counting framing if stream is set
+func NewSyslog(conn net.Conn, stream bool, log *events.Log) *Syslog {
+	hostname, _ := os.Hostname()
+	return &Syslog{
+		log:      log,
+		conn:     conn,
+		stream:   stream,
+		facility: syslogFacilities[DefaultSyslogFacility],
+		hostname: hostname,
+		procID:   strconv.Itoa(os.Getpid()),
+	}
+}
+
+// SetFacility sets the facility by name (daemon, local0-local7, ...)
+func (s *Syslog) SetFacility(name string) error {
+	facility, ok := syslogFacilities[strings.ToLower(name)]
+	if !ok {
+		return fmt.Errorf("unknown syslog facility %q", name)
+	}
+	s.facility = facility
+	return nil
+}
+
+// Run forwards events until the context is cancelled, then forwards what's
+// left. Failed sends are reported to onError once until sending works
+// again.
+func (s *Syslog) Run(ctx context.Context, onError func(error)) {
+	ticker := time.NewTicker(syslogInterval)
+	defer ticker.Stop()
+
+	var failed error
+	report := func(err error) {
+		if err != nil && failed == nil && onError != nil {
+			onError(err)
+		}
+		failed = err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			report(s.Flush())
+			return
+		case <-ticker.C:
+			report(s.Flush())
+		}
+	}
+}
+
+// Flush sends the events added to the log since the previous flush, and
+// any still waiting from a failed one
+func (s *Syslog) Flush() error {
+	var fresh []events.Event
+	fresh, s.seen = s.log.Since(s.seen)
+	s.pending = append(s.pending, fresh...)
+	if over := len(s.pending) - syslogBacklog; over > 0 {
+		s.pending = s.pending[over:]
+	}
+
+	for len(s.pending) > 0 {
+		if s.conn == nil {
+			if s.dial == nil {
+				return fmt.Errorf("syslog connection closed")
+			}
+			conn, err := s.dial()
+			if err != nil {
+				return err
+			}
+			s.conn = conn
+		}
+
+		msg := s.Format(s.pending[0])
+		if s.stream {
+			msg = strconv.Itoa(len(msg)) + " " + msg
+		}
+		if _, err := s.conn.Write([]byte(msg)); err != nil {
+			s.conn.Close()
+			s.conn = nil
+			return fmt.Errorf("syslog send failed: %w", err)
+		}
+		s.pending = s.pending[1:]
+	}
+	return nil
+}
+
+// Format renders an event as an RFC 5424 message
+func (s *Syslog) Format(e events.Event) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "<%d>1 %s %s %s %s %s ",
+		s.facility*8+syslogSeverity(e.Kind),
+		e.Time.UTC().Format("2006-01-02T15:04:05.000000Z"),
+		syslogHeaderField(s.hostname, 255),
+		syslogAppName,
+		syslogHeaderField(s.procID, 128),
+		syslogHeaderField(string(e.Kind), 32))
+
+	fmt.Fprintf(&b, "[sacn@%d", syslogEnterprise)
+	if e.Universe != 0 {
+		fmt.Fprintf(&b, ` universe="%d"`, e.Universe)
+	}
+	if e.Source != "" {
+		fmt.Fprintf(&b, ` source="%s"`, syslogParamValue(e.Source))
+	}
+	b.WriteString("] ")
+	b.WriteString(e.Message)
+	return b.String()
+}
+
+// Close closes the connection
+func (s *Syslog) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+	return s.conn.Close()
+}
+
+// syslogSeverity maps an event kind to a severity: failures of the
+// monitor itself are errors, trouble on the network warnings, and things
+// coming back notices
+func syslogSeverity(kind events.Kind) int {
+	switch kind {
+	case events.ExportError:
+		return severityError
+	case events.SourceOnline, events.BlackoutEnd, events.RateRestored:
+		return severityNotice
+	case events.PriorityChange, events.AddressChange:
+		return severityInfo
+	default:
+		return severityWarning
+	}
+}
+
+// syslogHeaderField returns a header field as printable ASCII without
+// spaces, at most max long, or the nil value "-" if empty
+func syslogHeaderField(s string, max int) string {
+	field := strings.Map(func(r rune) rune {
+		if r <= ' ' || r > '~' {
+			return -1
+		}
+		return r
+	}, s)
+	if field == "" {
+		return "-"
+	}
+	return field[:min(len(field), max)]
+}
+
+// syslogParamValue escapes a structured data parameter value
+func syslogParamValue(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(s)
+}
@@ -0,0 +1,76 @@
+package export
+
+import (
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"sacn-monitor/internal/events"
+)
+
+func TestSyslog_Format(t *testing.T) {
+	s := NewSyslog(nil, false, events.NewLog(0))
+	s.hostname, s.procID = "stage left", "42"
+
+	msg := s.Format(events.Event{
+		Time:     time.Date(2024, 5, 1, 20, 15, 0, 250000000, time.UTC),
+		Kind:     events.SourceLost,
+		Universe: 3,
+		Source:   `FOH "main"`,
+		Message:  "Source lost",
+	})
+	want := `<132>1 2024-05-01T20:15:00.250000Z stageleft sacn-monitor 42 source_lost [sacn@32473 universe="3" source="FOH \"main\""] Source lost`
+	if msg != want {
+		t.Errorf("Format() =\n%s\nwant\n%s", msg, want)
+	}
+
+	if err := s.SetFacility("daemon"); err != nil {
+		t.Fatalf("SetFacility() returned error: %v", err)
+	}
+	msg = s.Format(events.Event{Time: time.Now(), Kind: events.ExportError, Message: "disk full"})
+	if !strings.HasPrefix(msg, "<27>1 ") || !strings.Contains(msg, " export_error [sacn@32473] disk full") {
+		t.Errorf("Format() = %s, want daemon.err without universe or source", msg)
+	}
+	if err := s.SetFacility("local9"); err == nil {
+		t.Error("SetFacility(local9) expected error, got nil")
+	}
+}
+
+func TestSyslog_FlushTCP(t *testing.T) {
+	log := events.NewLog(0)
+	log.Add(events.Event{Time: time.Now(), Kind: events.SourceOnline, Universe: 1, Message: "up"})
+	log.Add(events.Event{Time: time.Now(), Kind: events.Blackout, Universe: 1, Message: "dark"})
+
+	client, server := net.Pipe()
+	defer server.Close()
+	s := NewSyslog(client, true, log)
+
+	// net.Pipe hands over one write per read: one framed message each
+	received := make(chan string, 2)
+	go func() {
+		buf := make([]byte, 4096)
+		for range 2 {
+			n, err := server.Read(buf)
+			if err != nil {
+				break
+			}
+			received <- string(buf[:n])
+		}
+	}()
+	if err := s.Flush(); err != nil {
+		t.Fatalf("Flush() returned error: %v", err)
+	}
+	for _, kind := range []string{"source_online", "blackout"} {
+		length, msg, _ := strings.Cut(<-received, " ")
+		if length != strconv.Itoa(len(msg)) || !strings.Contains(msg, " "+kind+" ") {
+			t.Errorf("frame %q with length %s, want a %s message of that length", msg, length, kind)
+		}
+	}
+
+	// Nothing new: nothing sent
+	if err := s.Flush(); err != nil {
+		t.Errorf("Flush() with no events returned error: %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"sacn-monitor/internal/stats"
+)
+
+// UniverseDump is the full state of one universe at a moment, for
+// attaching to a trouble ticket
+type UniverseDump struct {
+	Time                 time.Time              `json:"time"`
+	Universe             uint16                 `json:"universe"`
+	Name                 string                 `json:"name,omitempty"`
+	Source               string                 `json:"source"`
+	Priority             uint8                  `json:"priority"`
+	PacketRate           float64                `json:"packet_rate"`
+	RecentLossPercentage float64                `json:"recent_loss_percentage"`
+	Health               int                    `json:"health"`
+	ActiveChannels       int                    `json:"active_channels"`
+	Channels             []int                  `json:"channels"`         // Values of channels 1-512, -1 if not received
+	Labels               map[int]string         `json:"labels,omitempty"` // Patch labels by channel number
+	Stats                *stats.UniverseSummary `json:"stats,omitempty"`
+}
+
+// WriteUniverseDump writes a universe dump as indented JSON
+func WriteUniverseDump(w io.Writer, dump UniverseDump) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(dump); err != nil {
+		return fmt.Errorf("failed to write universe dump: %w", err)
+	}
+	return nil
+}
+
+// WriteUniverseDumpFile writes a universe dump as JSON to a file
+func WriteUniverseDumpFile(path string, dump UniverseDump) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create universe dump: %w", err)
+	}
+	if err := WriteUniverseDump(f, dump); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
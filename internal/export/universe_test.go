@@ -0,0 +1,55 @@
+package export
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"sacn-monitor/internal/stats"
+)
+
+func TestWriteUniverseDumpFile(t *testing.T) {
+	channels := make([]int, 512)
+	channels[0] = 255
+	channels[511] = -1
+	dump := UniverseDump{
+		Time:     time.Date(2026, 3, 14, 19, 0, 0, 0, time.UTC),
+		Universe: 3,
+		Name:     "FOH",
+		Source:   "console",
+		Channels: channels,
+		Labels:   map[int]string{1: "Spot 1 Dim"},
+		Stats: &stats.UniverseSummary{
+			Universe: 3,
+			Sources:  []stats.SourceSummary{{Name: "console", Address: "10.0.0.1"}},
+		},
+	}
+
+	path := filepath.Join(t.TempDir(), "universe.json")
+	if err := WriteUniverseDumpFile(path, dump); err != nil {
+		t.Fatalf("WriteUniverseDumpFile() returned error: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded UniverseDump
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("dump is not valid JSON: %v", err)
+	}
+	if decoded.Universe != 3 || decoded.Name != "FOH" || len(decoded.Channels) != 512 {
+		t.Errorf("decoded universe %d name %q with %d channels, want 3 FOH 512", decoded.Universe, decoded.Name, len(decoded.Channels))
+	}
+	if decoded.Channels[0] != 255 || decoded.Channels[511] != -1 {
+		t.Errorf("Channels[0], [511] = %d, %d, want 255, -1", decoded.Channels[0], decoded.Channels[511])
+	}
+	if decoded.Labels[1] != "Spot 1 Dim" {
+		t.Errorf("Labels = %v, want channel 1 labeled", decoded.Labels)
+	}
+	if decoded.Stats == nil || len(decoded.Stats.Sources) != 1 || decoded.Stats.Sources[0].Address != "10.0.0.1" {
+		t.Errorf("Stats = %+v, want one source at 10.0.0.1", decoded.Stats)
+	}
+}
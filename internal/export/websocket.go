@@ -0,0 +1,100 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"sacn-monitor/internal/events"
+	"sacn-monitor/internal/stats"
+	"sacn-monitor/internal/universe"
+
+	"github.com/coder/websocket"
+)
+
+// liveInterval is how often channel changes are pushed to WebSocket
+// clients, fast enough for a visualizer to follow fades
+const liveInterval = 40 * time.Millisecond
+
+// LiveServer streams the event stream to WebSocket clients in real time,
+// for browser-based visualizers and wall displays. Each client gets its own
+// stream: every channel's current value on connect, then each change of at
+// least one level, plus the packet summaries, losses and monitor events of
+// the JSON Lines output, one record per text message.
+type LiveServer struct {
+	listener net.Listener
+	server   *http.Server
+	manager  *universe.Manager
+	tracker  *stats.Tracker
+	log      *events.Log
+}
+
+// ListenLive starts accepting WebSocket clients on addr (host:port), at
+// any path. log may be nil to leave out monitor events.
+func ListenLive(addr string, manager *universe.Manager, tracker *stats.Tracker, log *events.Log) (*LiveServer, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for WebSocket clients on %s: %w", addr, err)
+	}
+
+	s := &LiveServer{
+		listener: listener,
+		manager:  manager,
+		tracker:  tracker,
+		log:      log,
+	}
+	s.server = &http.Server{Handler: s, ReadHeaderTimeout: 10 * time.Second}
+	return s, nil
+}
+
+// Addr returns the address the server is listening on
+func (s *LiveServer) Addr() net.Addr {
+	return s.listener.Addr()
+}
+
+// Serve accepts clients until the context is cancelled, which also ends
+// every client's stream
+func (s *LiveServer) Serve(ctx context.Context) error {
+	s.server.BaseContext = func(net.Listener) context.Context { return ctx }
+	go func() {
+		<-ctx.Done()
+		s.server.Close()
+	}()
+
+	if err := s.server.Serve(s.listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("WebSocket server failed: %w", err)
+	}
+	return nil
+}
+
+// Close stops accepting clients and disconnects the connected ones
+func (s *LiveServer) Close() error {
+	return s.server.Close()
+}
+
+// ServeHTTP upgrades a request to a WebSocket and streams to it until the
+// client goes away
+func (s *LiveServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// The stream is read-only monitoring data, so pages served from
+	// anywhere (a file:// visualizer, a display controller) may connect
+	conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{InsecureSkipVerify: true})
+	if err != nil {
+		return
+	}
+	defer conn.CloseNow()
+
+	// Clients don't send anything; reading only handles pings and closes
+	ctx := conn.CloseRead(r.Context())
+
+	stream := NewEventStream(websocket.NetConn(ctx, conn, websocket.MessageText), s.manager, s.tracker, s.log)
+	stream.SetInterval(liveInterval)
+	stream.SetThreshold(1)
+	stream.ReportInitial()
+	if err := stream.Run(ctx); err != nil {
+		return
+	}
+	conn.Close(websocket.StatusGoingAway, "server shutting down")
+}
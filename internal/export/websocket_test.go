@@ -0,0 +1,52 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"sacn-monitor/internal/stats"
+	"sacn-monitor/internal/universe"
+
+	"github.com/coder/websocket"
+)
+
+func TestLiveServer_StreamsChannels(t *testing.T) {
+	manager := universe.NewManager()
+	manager.GetOrCreate(3).Update([]byte{0, 200}, "console", [16]byte{1}, 100, 0)
+
+	server, err := ListenLive("127.0.0.1:0", manager, stats.NewTracker(), nil)
+	if err != nil {
+		t.Fatalf("ListenLive() returned error: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	go server.Serve(ctx)
+
+	conn, _, err := websocket.Dial(ctx, "ws://"+server.Addr().String()+"/", nil)
+	if err != nil {
+		t.Fatalf("Dial() returned error: %v", err)
+	}
+	defer conn.CloseNow()
+
+	// Current values arrive first, without a previous value
+	want := map[int]int{1: 0, 2: 200}
+	for len(want) > 0 {
+		_, data, err := conn.Read(ctx)
+		if err != nil {
+			t.Fatalf("Read() returned error: %v", err)
+		}
+		var r StreamRecord
+		if err := json.Unmarshal(data, &r); err != nil {
+			t.Fatalf("message %q is not JSON: %v", data, err)
+		}
+		if r.Type != RecordChannelChange {
+			continue
+		}
+		if r.Universe != 3 || r.Previous != nil || *r.Value != want[r.Channel] {
+			t.Errorf("record = %+v, want universe 3 channel %d at %d", r, r.Channel, want[r.Channel])
+		}
+		delete(want, r.Channel)
+	}
+}
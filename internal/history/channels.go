@@ -0,0 +1,65 @@
+package history
+
+import "time"
+
+// channelPoints keeps the channel values of the last two minutes
+const channelPoints = 120
+
+// channelRing holds the channel values of a universe at each sample
+type channelRing struct {
+	frames [][512]uint8
+	times  []time.Time
+	next   int
+	filled bool
+}
+
+func newChannelRing(n int) channelRing {
+	return channelRing{frames: make([][512]uint8, n), times: make([]time.Time, n)}
+}
+
+func (c *channelRing) add(now time.Time, values [512]uint8) {
+	c.frames[c.next] = values
+	c.times[c.next] = now
+	c.next = (c.next + 1) % len(c.frames)
+	if c.next == 0 {
+		c.filled = true
+	}
+}
+
+// ChannelPoint is the value of a channel at one sample
+type ChannelPoint struct {
+	Time  time.Time
+	Value uint8
+}
+
+// ChannelHistory returns up to n recent values of a channel (0-511),
+// oldest first, sampled once a second
+func (r *Recorder) ChannelHistory(universeID uint16, channel, n int) []ChannelPoint {
+	if channel < 0 || channel >= 512 {
+		return nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	s, ok := r.series[universeID]
+	if !ok {
+		return nil
+	}
+	c := &s.channels
+	count := c.next
+	if c.filled {
+		count = len(c.frames)
+	}
+	n = min(n, count)
+
+	result := make([]ChannelPoint, 0, n)
+	for i := count - n; i < count; i++ {
+		idx := i
+		if c.filled {
+			idx = (c.next + i) % len(c.frames)
+		}
+		result = append(result, ChannelPoint{Time: c.times[idx], Value: c.frames[idx][channel]})
+	}
+	return result
+}
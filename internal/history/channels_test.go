@@ -0,0 +1,41 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"sacn-monitor/internal/stats"
+	"sacn-monitor/internal/universe"
+)
+
+func TestRecorder_ChannelHistory(t *testing.T) {
+	manager := universe.NewManager()
+	recorder := NewRecorder(manager, stats.NewTracker())
+	u := manager.GetOrCreate(1)
+
+	start := time.Unix(1000, 0)
+	for i := range channelPoints + 5 {
+		u.Update([]byte{0, byte(i)}, "console", [16]byte{1}, 100, uint8(i))
+		recorder.Record(start.Add(time.Duration(i) * time.Second))
+	}
+
+	got := recorder.ChannelHistory(1, 1, 3)
+	if len(got) != 3 {
+		t.Fatalf("ChannelHistory(n=3) = %+v, want 3 points", got)
+	}
+	last := channelPoints + 4
+	for i, p := range got {
+		want := last - 2 + i
+		if p.Value != byte(want) || !p.Time.Equal(start.Add(time.Duration(want)*time.Second)) {
+			t.Errorf("point %d = %+v, want value %d", i, p, byte(want))
+		}
+	}
+
+	// The ring wrapped, so only channelPoints remain
+	if all := recorder.ChannelHistory(1, 1, 1000); len(all) != channelPoints || all[0].Value != 5 {
+		t.Errorf("ChannelHistory(all) = %+v, want %d points from 5", all, channelPoints)
+	}
+	if recorder.ChannelHistory(2, 1, 10) != nil || recorder.ChannelHistory(1, 512, 10) != nil {
+		t.Error("ChannelHistory of unknown universe or channel should be nil")
+	}
+}
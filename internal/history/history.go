@@ -0,0 +1,280 @@
+// Package history keeps downsampled time series of per-universe statistics
+// for trend graphs and post-show reports
+package history
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"sacn-monitor/internal/stats"
+	"sacn-monitor/internal/universe"
+)
+
+// History resolutions. Each tier is a ring of fixed-width points, so memory
+// per universe is bounded regardless of how long the monitor runs.
+const (
+	// sampleInterval is how often the recorder samples every universe
+	sampleInterval = time.Second
+	// secondPoints keeps 1 s points for the last hour
+	secondPoints = 3600
+	// minutePoints keeps 1 min points for the last 24 hours
+	minutePoints = 1440
+)
+
+// Sample is the statistics of a universe over one history interval
+type Sample struct {
+	Time           time.Time // Start of the interval
+	Duration       time.Duration
+	Packets        uint64
+	Lost           uint64
+	PacketRate     float64 // Mean packets per second over the sampled part of the interval
+	ActiveChannels int     // Most channels active at any sample in the interval
+}
+
+// LossPercentage returns the share of expected packets lost in the interval
+func (s Sample) LossPercentage() float64 {
+	total := s.Packets + s.Lost
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Lost) / float64(total) * 100
+}
+
+// point is one slot of a tier, tagged with its slot index so a stale slot
+// left over from an earlier lap of the ring is recognized
+type point struct {
+	slot    int64
+	samples uint16 // Samples summed into the point
+	active  uint16
+	packets uint32
+	lost    uint32
+}
+
+// tier is a ring of points of one width
+type tier struct {
+	width  time.Duration
+	points []point
+}
+
+func newTier(width time.Duration, n int) tier {
+	return tier{width: width, points: make([]point, n)}
+}
+
+// span returns how far back the tier reaches
+func (t *tier) span() time.Duration {
+	return t.width * time.Duration(len(t.points))
+}
+
+// add sums a sample into the point covering now
+func (t *tier) add(now time.Time, packets, lost uint64, active int) {
+	slot := now.UnixNano() / int64(t.width)
+	p := &t.points[ringIndex(slot, len(t.points))]
+	if p.slot != slot {
+		*p = point{slot: slot}
+	}
+	p.samples++
+	p.packets += uint32(packets)
+	p.lost += uint32(lost)
+	p.active = max(p.active, uint16(active))
+}
+
+// ringIndex returns the position of a time slot in a ring of n entries,
+// non-negative for slots before 1970 too
+func ringIndex(slot int64, n int) int {
+	return int((slot%int64(n) + int64(n)) % int64(n))
+}
+
+// between returns the points from from to to, oldest first
+func (t *tier) between(from, to time.Time) []Sample {
+	first := from.UnixNano() / int64(t.width)
+	last := to.UnixNano() / int64(t.width)
+	first = max(first, last-int64(len(t.points))+1)
+
+	var result []Sample
+	for slot := first; slot <= last; slot++ {
+		p := t.points[ringIndex(slot, len(t.points))]
+		if p.slot != slot || p.samples == 0 {
+			continue
+		}
+		result = append(result, Sample{
+			Time:           time.Unix(0, slot*int64(t.width)),
+			Duration:       t.width,
+			Packets:        uint64(p.packets),
+			Lost:           uint64(p.lost),
+			PacketRate:     float64(p.packets) / (float64(p.samples) * sampleInterval.Seconds()),
+			ActiveChannels: int(p.active),
+		})
+	}
+	return result
+}
+
+// series is the history of one universe
+type series struct {
+	seconds tier
+	minutes tier
+	rollups rollups
+
+	// Channel values of recent samples, see channels.go
+	channels channelRing
+
+	// Cumulative counts at the previous sample, for deltas
+	packets uint64
+	lost    uint64
+}
+
+// Recorder samples every universe once a second into downsampled history
+type Recorder struct {
+	manager *universe.Manager
+	tracker *stats.Tracker
+
+	series map[uint16]*series
+	latest time.Time // Time of the last sample
+	mu     sync.RWMutex
+
+	rollupHandlers []func(Rollup)
+	handlerMu      sync.Mutex // Calls rollup handlers one at a time
+}
+
+// NewRecorder creates a recorder sampling the given manager and tracker
+func NewRecorder(manager *universe.Manager, tracker *stats.Tracker) *Recorder {
+	return &Recorder{
+		manager: manager,
+		tracker: tracker,
+		series:  make(map[uint16]*series),
+	}
+}
+
+// Run samples periodically until the context is cancelled
+func (r *Recorder) Run(ctx context.Context) {
+	ticker := time.NewTicker(sampleInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			r.Record(now)
+		}
+	}
+}
+
+// Record samples the packets and losses since the previous sample and the
+// active channel count of every universe, and passes rollups whose period
+// ended to the OnRollup handlers
+func (r *Recorder) Record(now time.Time) {
+	universes := r.manager.GetAll()
+
+	r.mu.Lock()
+	var finished []Rollup
+	defer func() {
+		handlers := r.rollupHandlers
+		r.mu.Unlock()
+		r.notifyRollups(handlers, finished)
+	}()
+
+	r.latest = now
+	held := make(map[uint16]bool, len(universes))
+	for _, u := range universes {
+		held[u.ID] = true
+	}
+	// Universes the manager let go of, whatever removed them, take their
+	// history with them; their periods in progress end here
+	for id, s := range r.series {
+		if !held[id] {
+			finished = append(finished, s.rollups.open()...)
+			delete(r.series, id)
+		}
+	}
+
+	for _, u := range universes {
+		agg := r.tracker.Aggregate([]uint16{u.ID})
+
+		s, ok := r.series[u.ID]
+		if !ok {
+			s = &series{
+				seconds:  newTier(time.Second, secondPoints),
+				minutes:  newTier(time.Minute, minutePoints),
+				channels: newChannelRing(channelPoints),
+			}
+			r.series[u.ID] = s
+		}
+
+		// The packet count going down means the stats were reset; count
+		// from zero. Loss alone also goes down when late packets fill
+		// earlier gaps.
+		if agg.PacketCount < s.packets {
+			s.packets, s.lost = 0, 0
+		}
+		packets := agg.PacketCount - s.packets
+		lost := agg.LostPackets - min(s.lost, agg.LostPackets)
+		s.packets, s.lost = agg.PacketCount, agg.LostPackets
+
+		active := u.ActiveChannelCount()
+		s.seconds.add(now, packets, lost, active)
+		s.minutes.add(now, packets, lost, active)
+
+		var values [512]uint8
+		for i, ch := range u.GetAllChannels() {
+			values[i] = ch.Value
+		}
+		s.channels.add(now, values)
+
+		available := now.Sub(u.GetInfo().LastPacket) <= availabilityTimeout
+		var done *Rollup
+		s.rollups.hourly, done = addRollup(s.rollups.hourly, maxHourlyRollups, u.ID, Hourly, now, packets, lost, available)
+		if done != nil {
+			finished = append(finished, *done)
+		}
+		s.rollups.daily, done = addRollup(s.rollups.daily, maxDailyRollups, u.ID, Daily, now, packets, lost, available)
+		if done != nil {
+			finished = append(finished, *done)
+		}
+	}
+}
+
+// Forget drops the history of a universe, for universes the manager no
+// longer holds. Its rollups in progress go to the OnRollup handlers as
+// they are, so the last partial period isn't lost.
+func (r *Recorder) Forget(universeID uint16) {
+	r.mu.Lock()
+	var open []Rollup
+	if s, ok := r.series[universeID]; ok {
+		open = s.rollups.open()
+		delete(r.series, universeID)
+	}
+	handlers := r.rollupHandlers
+	r.mu.Unlock()
+	r.notifyRollups(handlers, open)
+}
+
+// Range returns the history of a universe between from and to, oldest
+// first. Ranges within the last hour have 1 s resolution, longer ones 1 min.
+func (r *Recorder) Range(universeID uint16, from, to time.Time) []Sample {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	s, ok := r.series[universeID]
+	if !ok {
+		return nil
+	}
+	if r.latest.Sub(from) < s.seconds.span() {
+		return s.seconds.between(from, to)
+	}
+	return s.minutes.between(from, to)
+}
+
+// Universes returns the IDs of universes with history, sorted
+func (r *Recorder) Universes() []uint16 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := make([]uint16, 0, len(r.series))
+	for id := range r.series {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
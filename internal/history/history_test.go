@@ -0,0 +1,136 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"sacn-monitor/internal/stats"
+	"sacn-monitor/internal/universe"
+)
+
+func TestRecorder_Range(t *testing.T) {
+	manager := universe.NewManager()
+	tracker := stats.NewTracker()
+	recorder := NewRecorder(manager, tracker)
+
+	cid := [16]byte{1}
+	manager.GetOrCreate(1).Update([]byte{10, 20, 0}, "console", cid, 100, 0)
+
+	start := time.Date(2026, 3, 14, 21, 0, 0, 0, time.UTC)
+	seq := uint8(0)
+	for i := 0; i < 120; i++ {
+		for j := 0; j < 44; j++ {
+			tracker.RecordPacket(1, cid, "console", seq)
+			seq++
+		}
+		if i == 90 {
+			seq += 2 // two packets lost in the second minute
+		}
+		recorder.Record(start.Add(time.Duration(i) * time.Second))
+	}
+	end := start.Add(119 * time.Second)
+
+	seconds := recorder.Range(1, start.Add(100*time.Second), end)
+	if len(seconds) != 20 {
+		t.Fatalf("len(Range(last 20 s)) = %d, want 20 points of 1 s", len(seconds))
+	}
+	last := seconds[len(seconds)-1]
+	if !last.Time.Equal(end) || last.Duration != time.Second || last.Packets != 44 || last.PacketRate != 44 || last.ActiveChannels != 3 {
+		t.Errorf("last point = %+v, want 44 packets at 44 pps with 3 active channels at %v", last, end)
+	}
+
+	// Longer than the seconds tier reaches falls back to minutes
+	minutes := recorder.Range(1, start.Add(-2*time.Hour), end)
+	if len(minutes) != 2 {
+		t.Fatalf("len(Range(2 h)) = %d, want 2 points of 1 min", len(minutes))
+	}
+	second := minutes[1]
+	if second.Duration != time.Minute || second.Packets != 60*44 || second.PacketRate != 44 || second.Lost != 2 {
+		t.Errorf("second minute = %+v, want 2640 packets at 44 pps and 2 lost", second)
+	}
+	if got := second.LossPercentage(); got < 0.07 || got > 0.08 {
+		t.Errorf("LossPercentage() = %v, want 2/2642", got)
+	}
+
+	if got := recorder.Universes(); len(got) != 1 || got[0] != 1 {
+		t.Errorf("Universes() = %v, want [1]", got)
+	}
+	if got := recorder.Range(2, start, end); got != nil {
+		t.Errorf("Range() of unknown universe = %v, want nil", got)
+	}
+}
+
+func TestRecorder_StatsReset(t *testing.T) {
+	manager := universe.NewManager()
+	tracker := stats.NewTracker()
+	recorder := NewRecorder(manager, tracker)
+
+	cid := [16]byte{1}
+	manager.GetOrCreate(1)
+	start := time.Date(2026, 3, 14, 21, 0, 0, 0, time.UTC)
+
+	for seq := uint8(0); seq < 10; seq++ {
+		tracker.RecordPacket(1, cid, "console", seq)
+	}
+	recorder.Record(start)
+
+	tracker.ResetUniverseStats(1)
+	for seq := uint8(10); seq < 13; seq++ {
+		tracker.RecordPacket(1, cid, "console", seq)
+	}
+	recorder.Record(start.Add(time.Second))
+
+	got := recorder.Range(1, start, start.Add(time.Second))
+	if len(got) != 2 || got[0].Packets != 10 || got[1].Packets != 3 {
+		t.Errorf("Range() = %+v, want 10 then 3 packets across the reset", got)
+	}
+}
+
+func TestRecorder_Forget(t *testing.T) {
+	manager := universe.NewManager()
+	tracker := stats.NewTracker()
+	recorder := NewRecorder(manager, tracker)
+	manager.GetOrCreate(1)
+	manager.GetOrCreate(2)
+	recorder.Record(time.Date(2026, 3, 14, 21, 0, 0, 0, time.UTC))
+
+	recorder.Forget(1)
+	if got := recorder.Universes(); len(got) != 1 || got[0] != 2 {
+		t.Errorf("Universes() after Forget(1) = %v, want [2]", got)
+	}
+}
+
+func TestRecorder_DropsRemovedUniverses(t *testing.T) {
+	manager := universe.NewManager()
+	tracker := stats.NewTracker()
+	recorder := NewRecorder(manager, tracker)
+	start := time.Date(2026, 3, 14, 21, 0, 0, 0, time.UTC)
+
+	for id := uint16(1); id <= 3; id++ {
+		manager.GetOrCreate(id)
+	}
+	recorder.Record(start)
+	manager.Remove(2)
+	recorder.Record(start.Add(time.Second))
+
+	if got := recorder.Universes(); len(got) != 2 || got[0] != 1 || got[1] != 3 {
+		t.Errorf("Universes() = %v, want [1 3] once universe 2 is gone", got)
+	}
+}
+
+func TestRecorder_BeforeEpoch(t *testing.T) {
+	manager := universe.NewManager()
+	tracker := stats.NewTracker()
+	recorder := NewRecorder(manager, tracker)
+	manager.GetOrCreate(1).Update([]byte{10}, "console", [16]byte{1}, 100, 0)
+	tracker.RecordPacket(1, [16]byte{1}, "console", 0)
+	recorder.Record(time.Time{})
+
+	start := time.Unix(-90, 0)
+	for i := range 3 {
+		recorder.Record(start.Add(time.Duration(i) * time.Second))
+	}
+	if got := recorder.Range(1, start, start.Add(2*time.Second)); len(got) != 3 {
+		t.Errorf("len(Range()) before 1970 = %d, want 3", len(got))
+	}
+}
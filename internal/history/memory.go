@@ -0,0 +1,28 @@
+package history
+
+import (
+	"time"
+	"unsafe"
+)
+
+// MemoryUsage is an estimate of the memory held by a history recorder
+type MemoryUsage struct {
+	Universes int
+	Bytes     int64 // Point rings, channel values and rollups
+}
+
+// MemoryUsage estimates the memory currently held by the recorder. Each
+// universe's rings are allocated in full when it is first sampled.
+func (r *Recorder) MemoryUsage() MemoryUsage {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	usage := MemoryUsage{Universes: len(r.series)}
+	for _, s := range r.series {
+		usage.Bytes += int64(unsafe.Sizeof(*s))
+		usage.Bytes += int64(cap(s.seconds.points)+cap(s.minutes.points)) * int64(unsafe.Sizeof(point{}))
+		usage.Bytes += int64(cap(s.channels.frames)) * int64(unsafe.Sizeof([512]uint8{})+unsafe.Sizeof(time.Time{}))
+		usage.Bytes += int64(cap(s.rollups.hourly)+cap(s.rollups.daily)) * int64(unsafe.Sizeof(Rollup{}))
+	}
+	return usage
+}
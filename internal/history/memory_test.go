@@ -0,0 +1,29 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"sacn-monitor/internal/stats"
+	"sacn-monitor/internal/universe"
+)
+
+func TestRecorder_MemoryUsage(t *testing.T) {
+	manager := universe.NewManager()
+	tracker := stats.NewTracker()
+	recorder := NewRecorder(manager, tracker)
+
+	if got := recorder.MemoryUsage(); got.Universes != 0 || got.Bytes != 0 {
+		t.Errorf("empty recorder usage = %+v, want zero", got)
+	}
+
+	manager.GetOrCreate(1).Update([]byte{10}, "console", [16]byte{1}, 100, 0)
+	tracker.RecordPacket(1, [16]byte{1}, "console", 0)
+	recorder.Record(time.Now())
+
+	// Both rings are allocated in full on the first sample
+	got := recorder.MemoryUsage()
+	if got.Universes != 1 || got.Bytes < (secondPoints+minutePoints)*16 {
+		t.Errorf("usage = %+v, want one universe with full rings", got)
+	}
+}
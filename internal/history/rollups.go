@@ -0,0 +1,166 @@
+package history
+
+import (
+	"sort"
+	"time"
+)
+
+// Rollup periods
+const (
+	Hourly = time.Hour
+	Daily  = 24 * time.Hour
+)
+
+// Rollup bounds and defaults
+const (
+	// maxHourlyRollups keeps a week of hourly rollups per universe
+	maxHourlyRollups = 7 * 24
+	// maxDailyRollups keeps about three months of daily rollups per universe
+	maxDailyRollups = 90
+	// availabilityTimeout is how long a universe may go without packets and
+	// still count as available (E1.31 network data loss timeout)
+	availabilityTimeout = 2500 * time.Millisecond
+)
+
+// Rollup summarizes a universe over an hour or a day
+type Rollup struct {
+	Universe uint16
+	Period   time.Duration // Hourly or Daily
+	Start    time.Time
+	Packets  uint64
+	Lost     uint64
+	PeakRate float64 // Highest packets per second of any sample
+
+	// Samples taken in the period, and how many of them found a live source
+	Samples   int
+	Available int
+}
+
+// LossPercentage returns the share of expected packets lost in the period
+func (r Rollup) LossPercentage() float64 {
+	total := r.Packets + r.Lost
+	if total == 0 {
+		return 0
+	}
+	return float64(r.Lost) / float64(total) * 100
+}
+
+// Availability returns the percentage of samples that found a live source
+func (r Rollup) Availability() float64 {
+	if r.Samples == 0 {
+		return 0
+	}
+	return float64(r.Available) / float64(r.Samples) * 100
+}
+
+// rollups are the hourly and daily rollups of one universe, oldest first.
+// The last of each is still in progress.
+type rollups struct {
+	hourly []Rollup
+	daily  []Rollup
+}
+
+// open returns the hourly and daily rollups in progress
+func (r rollups) open() []Rollup {
+	var result []Rollup
+	if n := len(r.hourly); n > 0 {
+		result = append(result, r.hourly[n-1])
+	}
+	if n := len(r.daily); n > 0 {
+		result = append(result, r.daily[n-1])
+	}
+	return result
+}
+
+// periodStart returns the start of the hour or local day containing t
+func periodStart(t time.Time, period time.Duration) time.Time {
+	if period == Daily {
+		y, m, d := t.Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	}
+	return t.Truncate(period)
+}
+
+// addRollup sums a sample into the rollup of its period, starting a new one when
+// the period changed. A finished rollup is returned for OnRollup handlers.
+func addRollup(list []Rollup, limit int, universeID uint16, period time.Duration, now time.Time, packets, lost uint64, available bool) ([]Rollup, *Rollup) {
+	var finished *Rollup
+	start := periodStart(now, period)
+	if n := len(list); n == 0 || !list[n-1].Start.Equal(start) {
+		if n > 0 {
+			done := list[n-1]
+			finished = &done
+		}
+		list = append(list, Rollup{Universe: universeID, Period: period, Start: start})
+		if len(list) > limit {
+			list = append(list[:0], list[len(list)-limit:]...)
+		}
+	}
+
+	r := &list[len(list)-1]
+	r.Packets += packets
+	r.Lost += lost
+	r.PeakRate = max(r.PeakRate, float64(packets)/sampleInterval.Seconds())
+	r.Samples++
+	if available {
+		r.Available++
+	}
+	return list, finished
+}
+
+// OnRollup registers a handler called with each hourly and daily rollup
+// once its period is over, or cut short by the universe going away or
+// Flush. Handlers are called one at a time, without the recorder's lock.
+func (r *Recorder) OnRollup(handler func(Rollup)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rollupHandlers = append(r.rollupHandlers, handler)
+}
+
+// Flush hands the rollups still in progress to the OnRollup handlers, so
+// the last, partial period is kept on exit. Call it once recording has
+// stopped: periods recorded into afterwards would be handed over again.
+func (r *Recorder) Flush() {
+	r.mu.RLock()
+	ids := make([]uint16, 0, len(r.series))
+	for id := range r.series {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	var open []Rollup
+	for _, id := range ids {
+		open = append(open, r.series[id].rollups.open()...)
+	}
+	handlers := r.rollupHandlers
+	r.mu.RUnlock()
+	r.notifyRollups(handlers, open)
+}
+
+// notifyRollups calls the handlers with each rollup. Caller must not hold
+// r.mu.
+func (r *Recorder) notifyRollups(handlers []func(Rollup), rollups []Rollup) {
+	r.handlerMu.Lock()
+	defer r.handlerMu.Unlock()
+	for _, rollup := range rollups {
+		for _, handler := range handlers {
+			handler(rollup)
+		}
+	}
+}
+
+// Rollups returns the hourly or daily rollups of a universe, oldest first.
+// The last one covers the period still in progress.
+func (r *Recorder) Rollups(universeID uint16, period time.Duration) []Rollup {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	s, ok := r.series[universeID]
+	if !ok {
+		return nil
+	}
+	list := s.rollups.hourly
+	if period == Daily {
+		list = s.rollups.daily
+	}
+	return append([]Rollup(nil), list...)
+}
@@ -0,0 +1,117 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"sacn-monitor/internal/stats"
+	"sacn-monitor/internal/universe"
+)
+
+func TestAddRollup_Periods(t *testing.T) {
+	start := time.Date(2026, 3, 14, 21, 59, 59, 0, time.Local)
+
+	list, done := addRollup(nil, 2, 1, Hourly, start, 44, 1, true)
+	if done != nil || len(list) != 1 || !list[0].Start.Equal(start.Truncate(time.Hour)) {
+		t.Fatalf("first sample: list = %+v, done = %v", list, done)
+	}
+
+	list, done = addRollup(list, 2, 1, Hourly, start.Add(time.Second), 40, 0, false)
+	if done == nil || done.Packets != 44 || done.Lost != 1 || done.PeakRate != 44 || done.Availability() != 100 {
+		t.Fatalf("finished rollup = %+v, want the 21:00 hour with 44 packets", done)
+	}
+	if len(list) != 2 || list[1].Packets != 40 || list[1].Availability() != 0 {
+		t.Errorf("current rollup = %+v, want 40 packets, unavailable", list[1])
+	}
+
+	// Older rollups drop out beyond the limit
+	list, _ = addRollup(list, 2, 1, Hourly, start.Add(time.Hour+time.Second), 1, 0, true)
+	if len(list) != 2 || list[0].Packets != 40 {
+		t.Errorf("after limit: %+v, want the last two hours", list)
+	}
+
+	// Days start at local midnight
+	day := periodStart(start, Daily)
+	if want := time.Date(2026, 3, 14, 0, 0, 0, 0, time.Local); !day.Equal(want) {
+		t.Errorf("periodStart(Daily) = %v, want %v", day, want)
+	}
+}
+
+func TestRecorder_Rollups(t *testing.T) {
+	manager := universe.NewManager()
+	tracker := stats.NewTracker()
+	recorder := NewRecorder(manager, tracker)
+
+	var finished []Rollup
+	recorder.OnRollup(func(r Rollup) { finished = append(finished, r) })
+
+	cid := [16]byte{1}
+	manager.GetOrCreate(1).Update([]byte{1}, "console", cid, 100, 0)
+	for seq := uint8(0); seq < 5; seq++ {
+		tracker.RecordPacket(1, cid, "console", seq)
+	}
+
+	// Just before and after the coming midnight: the universe's last packet
+	// is long ago by then, so neither sample finds a live source
+	midnight := periodStart(time.Now(), Daily).AddDate(0, 0, 1)
+	recorder.Record(midnight.Add(-time.Second))
+	recorder.Record(midnight)
+
+	if len(finished) != 2 || finished[0].Period != Hourly || finished[1].Period != Daily {
+		t.Fatalf("finished = %+v, want the last hour and day before midnight", finished)
+	}
+	if finished[1].Packets != 5 || finished[1].Available != 0 || finished[1].Samples != 1 {
+		t.Errorf("finished day = %+v, want 5 packets in 1 unavailable sample", finished[1])
+	}
+
+	daily := recorder.Rollups(1, Daily)
+	if len(daily) != 2 || !daily[1].Start.Equal(midnight) {
+		t.Errorf("Rollups(Daily) = %+v, want the finished day and the one in progress", daily)
+	}
+	if got := recorder.Rollups(2, Hourly); got != nil {
+		t.Errorf("Rollups() of unknown universe = %v, want nil", got)
+	}
+}
+
+func TestRecorder_RollupAvailability(t *testing.T) {
+	manager := universe.NewManager()
+	recorder := NewRecorder(manager, stats.NewTracker())
+
+	manager.GetOrCreate(1).Update([]byte{1}, "console", [16]byte{1}, 100, 0)
+	now := time.Now()
+	recorder.Record(now)
+	recorder.Record(now.Add(5 * time.Second))
+
+	var samples, available int
+	for _, r := range recorder.Rollups(1, Hourly) {
+		samples += r.Samples
+		available += r.Available
+	}
+	if samples != 2 || available != 1 {
+		t.Errorf("samples/available = %d/%d, want 2/1", samples, available)
+	}
+}
+
+func TestRecorder_FlushAndForgetHandOverOpenRollups(t *testing.T) {
+	manager := universe.NewManager()
+	tracker := stats.NewTracker()
+	recorder := NewRecorder(manager, tracker)
+
+	var handed []Rollup
+	recorder.OnRollup(func(r Rollup) { handed = append(handed, r) })
+
+	manager.GetOrCreate(1)
+	manager.GetOrCreate(2)
+	recorder.Record(time.Date(2026, 3, 14, 21, 30, 0, 0, time.UTC))
+
+	recorder.Flush()
+	if len(handed) != 4 || handed[0].Universe != 1 || handed[2].Universe != 2 {
+		t.Fatalf("Flush() handed %+v, want the hour and day in progress of universes 1 and 2", handed)
+	}
+
+	handed = nil
+	recorder.Forget(2)
+	if len(handed) != 2 || handed[0].Universe != 2 || handed[0].Period != Hourly || handed[1].Period != Daily {
+		t.Errorf("Forget() handed %+v, want universe 2's hour and day in progress", handed)
+	}
+}
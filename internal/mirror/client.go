@@ -0,0 +1,87 @@
+package mirror
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// frameMsg delivers a rendered view received from the server
+type frameMsg string
+
+// disconnectedMsg is sent when the server closes the connection
+type disconnectedMsg struct{ err error }
+
+// clientModel displays frames from a mirror server and forwards input
+type clientModel struct {
+	encoder *json.Encoder
+	frame   string
+	err     error
+}
+
+func (m clientModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m clientModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.String() == "q" || msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		_ = m.encoder.Encode(clientMessage{Type: "key", Key: msg.String()})
+	case tea.WindowSizeMsg:
+		_ = m.encoder.Encode(clientMessage{Type: "size", Width: msg.Width, Height: msg.Height})
+	case frameMsg:
+		m.frame = string(msg)
+	case disconnectedMsg:
+		m.err = msg.err
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m clientModel) View() string {
+	if m.frame == "" {
+		return "Waiting for mirrored session..."
+	}
+	return m.frame
+}
+
+// Attach connects to a running session's mirror server and displays it
+// until the user quits or the session ends
+func Attach(addr string) error {
+	network, address := splitAddr(addr)
+	conn, err := net.Dial(network, address)
+	if err != nil {
+		return fmt.Errorf("failed to attach to %s: %w", addr, err)
+	}
+	defer conn.Close()
+
+	p := tea.NewProgram(clientModel{encoder: json.NewEncoder(conn)}, tea.WithAltScreen())
+
+	go func() {
+		scanner := bufio.NewScanner(conn)
+		scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+		for scanner.Scan() {
+			var frame frameMessage
+			if err := json.Unmarshal(scanner.Bytes(), &frame); err != nil {
+				continue
+			}
+			p.Send(frameMsg(frame.View))
+		}
+		p.Send(disconnectedMsg{err: scanner.Err()})
+	}()
+
+	final, err := p.Run()
+	if err != nil {
+		return err
+	}
+	if m, ok := final.(clientModel); ok && m.err != nil {
+		return fmt.Errorf("mirror connection lost: %w", m.err)
+	}
+	return nil
+}
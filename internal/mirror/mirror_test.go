@@ -0,0 +1,90 @@
+package mirror
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"sacn-monitor/internal/stats"
+	"sacn-monitor/internal/tui"
+	"sacn-monitor/internal/universe"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestKeyFromString(t *testing.T) {
+	tests := []string{"tab", "up", "down", "shift+tab", "s", "B"}
+	for _, s := range tests {
+		if got := keyFromString(s).String(); got != s {
+			t.Errorf("keyFromString(%q).String() = %q", s, got)
+		}
+	}
+
+	if msg := keyFromString("tab"); msg.Type != tea.KeyTab {
+		t.Errorf("keyFromString(tab).Type = %v, want KeyTab", msg.Type)
+	}
+}
+
+func TestSplitAddr(t *testing.T) {
+	if network, addr := splitAddr("unix:/tmp/sock"); network != "unix" || addr != "/tmp/sock" {
+		t.Errorf("splitAddr(unix) = %q, %q", network, addr)
+	}
+	if network, addr := splitAddr("127.0.0.1:5569"); network != "tcp" || addr != "127.0.0.1:5569" {
+		t.Errorf("splitAddr(tcp) = %q, %q", network, addr)
+	}
+}
+
+func TestServer_StreamsFrames(t *testing.T) {
+	manager := universe.NewManager()
+	manager.GetOrCreate(7).Update([]byte{255}, "mirrored-source", [16]byte{}, 100, 0)
+	tracker := stats.NewTracker()
+
+	server, err := Listen("127.0.0.1:0", func() tui.Model {
+		return tui.NewModel(manager, tracker)
+	})
+	if err != nil {
+		t.Fatalf("Listen() returned error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		server.Serve(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	conn, err := net.Dial("tcp", server.Addr().String())
+	if err != nil {
+		t.Fatalf("Dial() returned error: %v", err)
+	}
+	defer conn.Close()
+
+	if err := json.NewEncoder(conn).Encode(clientMessage{Type: "size", Width: 120, Height: 40}); err != nil {
+		t.Fatal(err)
+	}
+
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var frame frameMessage
+		if err := json.Unmarshal(scanner.Bytes(), &frame); err != nil {
+			t.Fatalf("invalid frame: %v", err)
+		}
+		if strings.Contains(frame.View, "mirrored-source") {
+			if !strings.Contains(frame.View, "Read-only mirror") {
+				t.Error("mirrored view is not marked read-only")
+			}
+			return
+		}
+	}
+	t.Fatalf("no frame showing the universe received: %v", scanner.Err())
+}
@@ -0,0 +1,56 @@
+package mirror
+
+import (
+	"net"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Messages are exchanged as JSON lines in both directions
+
+// clientMessage is sent by an attached terminal to the server
+type clientMessage struct {
+	Type   string `json:"type"` // "size" or "key"
+	Width  int    `json:"width,omitempty"`
+	Height int    `json:"height,omitempty"`
+	Key    string `json:"key,omitempty"`
+}
+
+// frameMessage carries a rendered view from the server to a client
+type frameMessage struct {
+	View string `json:"view"`
+}
+
+// splitAddr turns an address into a network and address for net.Listen and
+// net.Dial. Addresses prefixed with "unix:" are Unix domain sockets,
+// anything else is TCP.
+func splitAddr(addr string) (string, string) {
+	if path, ok := strings.CutPrefix(addr, "unix:"); ok {
+		return "unix", path
+	}
+	return "tcp", addr
+}
+
+// listen opens a listener for mirror clients
+func listen(addr string) (net.Listener, error) {
+	network, address := splitAddr(addr)
+	return net.Listen(network, address)
+}
+
+// specialKeys are the non-rune keys a client may forward
+var specialKeys = []tea.KeyType{
+	tea.KeyTab, tea.KeyShiftTab, tea.KeyEnter, tea.KeyEsc, tea.KeyBackspace,
+	tea.KeyUp, tea.KeyDown, tea.KeyLeft, tea.KeyRight,
+	tea.KeyHome, tea.KeyEnd, tea.KeyPgUp, tea.KeyPgDown, tea.KeySpace,
+}
+
+// keyFromString rebuilds a key message from its String() form
+func keyFromString(s string) tea.KeyMsg {
+	for _, t := range specialKeys {
+		if t.String() == s {
+			return tea.KeyMsg{Type: t}
+		}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
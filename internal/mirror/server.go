@@ -0,0 +1,138 @@
+package mirror
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"sacn-monitor/internal/tui"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// frameInterval is how often attached clients are re-rendered
+const frameInterval = 100 * time.Millisecond
+
+// Server serves read-only mirrored views of the running session. Each
+// attached client gets its own model over the shared universe manager and
+// stats tracker, so navigation is independent but the data is the same.
+type Server struct {
+	listener net.Listener
+	newModel func() tui.Model
+	wg       sync.WaitGroup
+}
+
+// Listen starts accepting mirror clients on addr (host:port, or
+// unix:/path/to/socket). newModel creates the model for each client.
+func Listen(addr string, newModel func() tui.Model) (*Server, error) {
+	listener, err := listen(addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for mirror clients on %s: %w", addr, err)
+	}
+
+	return &Server{
+		listener: listener,
+		newModel: newModel,
+	}, nil
+}
+
+// Addr returns the address the server is listening on
+func (s *Server) Addr() net.Addr {
+	return s.listener.Addr()
+}
+
+// Serve accepts clients until the context is cancelled
+func (s *Server) Serve(ctx context.Context) {
+	go func() {
+		<-ctx.Done()
+		s.listener.Close()
+	}()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			break
+		}
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handle(ctx, conn)
+		}()
+	}
+
+	s.wg.Wait()
+}
+
+// Close stops accepting clients
+func (s *Server) Close() error {
+	return s.listener.Close()
+}
+
+// handle drives one client's model until it disconnects
+func (s *Server) handle(ctx context.Context, conn net.Conn) {
+	defer conn.Close()
+
+	// Decode client input in the background
+	incoming := make(chan clientMessage)
+	go func() {
+		defer close(incoming)
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			var msg clientMessage
+			if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+				continue
+			}
+			select {
+			case incoming <- msg:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	model := s.newModel().ReadOnly()
+	encoder := json.NewEncoder(conn)
+	ticker := time.NewTicker(frameInterval)
+	defer ticker.Stop()
+
+	var lastView string
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-incoming:
+			if !ok {
+				return
+			}
+			switch msg.Type {
+			case "size":
+				model = update(model, tea.WindowSizeMsg{Width: msg.Width, Height: msg.Height})
+			case "key":
+				model = update(model, keyFromString(msg.Key))
+			}
+		case now := <-ticker.C:
+			model = update(model, tui.TickMsg(now))
+		}
+
+		view := model.View()
+		if view == lastView {
+			continue
+		}
+		lastView = view
+		if err := encoder.Encode(frameMessage{View: view}); err != nil {
+			return
+		}
+	}
+}
+
+// update applies a message to a model, discarding any returned command
+// since the server drives ticks itself
+func update(m tui.Model, msg tea.Msg) tui.Model {
+	updated, _ := m.Update(msg)
+	return updated.(tui.Model)
+}
@@ -0,0 +1,177 @@
+// Package osc decodes Open Sound Control 1.0 messages received over UDP,
+// for driving the monitor from show-control systems
+package osc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// bundleTag starts an OSC bundle
+const bundleTag = "#bundle"
+
+// Message is a decoded OSC message
+type Message struct {
+	Address string
+	Args    []any // int32, int64, float32, float64, string, []byte, bool or nil
+}
+
+// Int returns argument i as an integer. Show-control systems often send
+// numbers as floats, so those are accepted and rounded.
+func (m Message) Int(i int) (int, bool) {
+	if i >= len(m.Args) {
+		return 0, false
+	}
+	switch v := m.Args[i].(type) {
+	case int32:
+		return int(v), true
+	case int64:
+		return int(v), true
+	case float32:
+		return int(math.Round(float64(v))), true
+	case float64:
+		return int(math.Round(v)), true
+	default:
+		return 0, false
+	}
+}
+
+// String returns argument i as a string
+func (m Message) String(i int) (string, bool) {
+	if i >= len(m.Args) {
+		return "", false
+	}
+	s, ok := m.Args[i].(string)
+	return s, ok
+}
+
+// Parse decodes a packet into its messages: one for a plain message, or
+// every message of a bundle and its nested bundles in order
+func Parse(data []byte) ([]Message, error) {
+	if !bytes.HasPrefix(data, []byte(bundleTag+"\x00")) {
+		msg, err := parseMessage(data)
+		if err != nil {
+			return nil, err
+		}
+		return []Message{msg}, nil
+	}
+
+	// Bundle: tag, 8-byte time tag, then size-prefixed elements. Time tags
+	// are ignored; everything is handled on arrival.
+	if len(data) < 16 {
+		return nil, errors.New("osc: bundle too short")
+	}
+	var messages []Message
+	for rest := data[16:]; len(rest) > 0; {
+		if len(rest) < 4 {
+			return nil, errors.New("osc: truncated bundle element size")
+		}
+		size := int(binary.BigEndian.Uint32(rest))
+		if size < 0 || size > len(rest)-4 {
+			return nil, fmt.Errorf("osc: bundle element of %d bytes exceeds packet", size)
+		}
+		nested, err := Parse(rest[4 : 4+size])
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, nested...)
+		rest = rest[4+size:]
+	}
+	return messages, nil
+}
+
+// parseMessage decodes a single message
+func parseMessage(data []byte) (Message, error) {
+	address, rest, err := readString(data)
+	if err != nil {
+		return Message{}, fmt.Errorf("osc: address: %w", err)
+	}
+	if len(address) == 0 || address[0] != '/' {
+		return Message{}, fmt.Errorf("osc: invalid address %q", address)
+	}
+	msg := Message{Address: address}
+
+	// Very old senders omit the type tag string altogether
+	if len(rest) == 0 {
+		return msg, nil
+	}
+	tags, rest, err := readString(rest)
+	if err != nil {
+		return Message{}, fmt.Errorf("osc: type tags: %w", err)
+	}
+	if len(tags) == 0 || tags[0] != ',' {
+		return Message{}, fmt.Errorf("osc: invalid type tags %q", tags)
+	}
+
+	for _, tag := range tags[1:] {
+		var arg any
+		switch tag {
+		case 'i':
+			if len(rest) < 4 {
+				return Message{}, errors.New("osc: truncated int32")
+			}
+			arg, rest = int32(binary.BigEndian.Uint32(rest)), rest[4:]
+		case 'f':
+			if len(rest) < 4 {
+				return Message{}, errors.New("osc: truncated float32")
+			}
+			arg, rest = math.Float32frombits(binary.BigEndian.Uint32(rest)), rest[4:]
+		case 'h':
+			if len(rest) < 8 {
+				return Message{}, errors.New("osc: truncated int64")
+			}
+			arg, rest = int64(binary.BigEndian.Uint64(rest)), rest[8:]
+		case 'd':
+			if len(rest) < 8 {
+				return Message{}, errors.New("osc: truncated float64")
+			}
+			arg, rest = math.Float64frombits(binary.BigEndian.Uint64(rest)), rest[8:]
+		case 's':
+			arg, rest, err = readString(rest)
+			if err != nil {
+				return Message{}, fmt.Errorf("osc: string argument: %w", err)
+			}
+		case 'b':
+			if len(rest) < 4 {
+				return Message{}, errors.New("osc: truncated blob size")
+			}
+			size := int(binary.BigEndian.Uint32(rest))
+			padded := pad4(size)
+			if size < 0 || padded > len(rest)-4 {
+				return Message{}, errors.New("osc: truncated blob")
+			}
+			arg, rest = rest[4:4+size], rest[4+padded:]
+		case 'T':
+			arg = true
+		case 'F':
+			arg = false
+		case 'N':
+			arg = nil
+		default:
+			return Message{}, fmt.Errorf("osc: unsupported type tag %q", tag)
+		}
+		msg.Args = append(msg.Args, arg)
+	}
+	return msg, nil
+}
+
+// readString reads a null-terminated string padded to 4 bytes
+func readString(data []byte) (string, []byte, error) {
+	end := bytes.IndexByte(data, 0)
+	if end < 0 {
+		return "", nil, errors.New("unterminated string")
+	}
+	padded := pad4(end + 1)
+	if padded > len(data) {
+		return "", nil, errors.New("truncated string padding")
+	}
+	return string(data[:end]), data[padded:], nil
+}
+
+// pad4 rounds n up to a multiple of 4
+func pad4(n int) int {
+	return (n + 3) &^ 3
+}
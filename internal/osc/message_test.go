@@ -0,0 +1,128 @@
+package osc
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"net"
+	"testing"
+	"time"
+)
+
+// oscString encodes s as a padded OSC string
+func oscString(s string) []byte {
+	b := append([]byte(s), 0)
+	for len(b)%4 != 0 {
+		b = append(b, 0)
+	}
+	return b
+}
+
+// oscInt encodes an int32 argument
+func oscInt(v int32) []byte {
+	return binary.BigEndian.AppendUint32(nil, uint32(v))
+}
+
+// oscFloat encodes a float32 argument
+func oscFloat(v float32) []byte {
+	return binary.BigEndian.AppendUint32(nil, math.Float32bits(v))
+}
+
+// join concatenates byte slices
+func join(parts ...[]byte) []byte {
+	var b []byte
+	for _, p := range parts {
+		b = append(b, p...)
+	}
+	return b
+}
+
+func TestParse_Message(t *testing.T) {
+	data := join(oscString("/sacn-monitor/universe"), oscString(",ifsT"), oscInt(7), oscFloat(2.6), oscString("FOH"))
+
+	messages, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse() returned error: %v", err)
+	}
+	if len(messages) != 1 {
+		t.Fatalf("got %d messages, want 1", len(messages))
+	}
+	msg := messages[0]
+	if msg.Address != "/sacn-monitor/universe" || len(msg.Args) != 4 {
+		t.Fatalf("message = %+v, want 4 arguments to /sacn-monitor/universe", msg)
+	}
+	if n, ok := msg.Int(0); !ok || n != 7 {
+		t.Errorf("Int(0) = %d, %v, want 7", n, ok)
+	}
+	if n, ok := msg.Int(1); !ok || n != 3 {
+		t.Errorf("Int(1) = %d, %v, want the float rounded to 3", n, ok)
+	}
+	if s, ok := msg.String(2); !ok || s != "FOH" {
+		t.Errorf("String(2) = %q, %v, want FOH", s, ok)
+	}
+	if msg.Args[3] != true {
+		t.Errorf("Args[3] = %v, want true", msg.Args[3])
+	}
+	if _, ok := msg.Int(4); ok {
+		t.Error("Int(4) ok past the last argument")
+	}
+}
+
+func TestParse_Bundle(t *testing.T) {
+	first := join(oscString("/a"), oscString(","))
+	second := join(oscString("/b"), oscString(",i"), oscInt(1))
+	data := join(oscString("#bundle"), make([]byte, 8),
+		oscInt(int32(len(first))), first,
+		oscInt(int32(len(second))), second)
+
+	messages, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse() returned error: %v", err)
+	}
+	if len(messages) != 2 || messages[0].Address != "/a" || messages[1].Address != "/b" {
+		t.Errorf("messages = %+v, want /a then /b", messages)
+	}
+}
+
+func TestParse_Malformed(t *testing.T) {
+	tests := map[string][]byte{
+		"no address":       join(oscString("universe"), oscString(",")),
+		"unterminated":     []byte("/abc"),
+		"truncated int":    join(oscString("/a"), oscString(",i"), []byte{0, 1}),
+		"unknown tag":      join(oscString("/a"), oscString(",x")),
+		"oversized bundle": join(oscString("#bundle"), make([]byte, 8), oscInt(100)),
+	}
+	for name, data := range tests {
+		if _, err := Parse(data); err == nil {
+			t.Errorf("%s: Parse() returned no error", name)
+		}
+	}
+}
+
+func TestServer_Serve(t *testing.T) {
+	server, err := Listen("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() returned error: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	received := make(chan Message, 1)
+	go server.Serve(ctx, func(m Message) { received <- m }, nil)
+
+	conn, err := net.Dial("udp", server.Addr().String())
+	if err != nil {
+		t.Fatalf("Dial() returned error: %v", err)
+	}
+	defer conn.Close()
+	conn.Write(join(oscString("/sacn-monitor/reset"), oscString(",")))
+
+	select {
+	case msg := <-received:
+		if msg.Address != "/sacn-monitor/reset" {
+			t.Errorf("received %q, want /sacn-monitor/reset", msg.Address)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no message received")
+	}
+}
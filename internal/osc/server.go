@@ -0,0 +1,61 @@
+package osc
+
+import (
+	"context"
+	"fmt"
+	"net"
+)
+
+// maxPacket is the largest OSC packet read, the UDP maximum
+const maxPacket = 65535
+
+// Server receives OSC packets on a UDP port
+type Server struct {
+	conn net.PacketConn
+}
+
+// Listen opens a UDP port for OSC on addr (host:port)
+func Listen(addr string) (*Server, error) {
+	conn, err := net.ListenPacket("udp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for OSC on %s: %w", addr, err)
+	}
+	return &Server{conn: conn}, nil
+}
+
+// Addr returns the address the server is listening on
+func (s *Server) Addr() net.Addr {
+	return s.conn.LocalAddr()
+}
+
+// Serve passes every received message to handle until the context is
+// cancelled. Malformed packets are passed to onError, if set, and skipped.
+func (s *Server) Serve(ctx context.Context, handle func(Message), onError func(error)) {
+	go func() {
+		<-ctx.Done()
+		s.conn.Close()
+	}()
+
+	buf := make([]byte, maxPacket)
+	for {
+		n, from, err := s.conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		messages, err := Parse(buf[:n])
+		if err != nil {
+			if onError != nil {
+				onError(fmt.Errorf("OSC packet from %s: %w", from, err))
+			}
+			continue
+		}
+		for _, msg := range messages {
+			handle(msg)
+		}
+	}
+}
+
+// Close stops receiving
+func (s *Server) Close() error {
+	return s.conn.Close()
+}
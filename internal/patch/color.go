@@ -0,0 +1,98 @@
+package patch
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Color is an RGB color computed from a fixture's channel values
+type Color struct {
+	R, G, B uint8
+}
+
+// Hex formats the color as #RRGGBB
+func (c Color) Hex() string {
+	return fmt.Sprintf("#%02X%02X%02X", c.R, c.G, c.B)
+}
+
+// CellLayout returns the per-cell channel layout of a color fixture, such
+// as "rgb" or "grbw", or "" if the fixture type isn't a color layout. A
+// layout uses each of r, g, b once and optionally w.
+func (f Fixture) CellLayout() string {
+	layout := strings.ToLower(f.Type)
+	if len(layout) < 3 || len(layout) > 4 {
+		return ""
+	}
+	for _, c := range "rgb" {
+		if strings.Count(layout, string(c)) != 1 {
+			return ""
+		}
+	}
+	if len(layout) == 4 && strings.Count(layout, "w") != 1 {
+		return ""
+	}
+	return layout
+}
+
+// Colors computes the colors shown by a fixture from its footprint's
+// channel values. Fixtures with a cell layout yield one color per cell
+// (e.g. each pixel of a tape); other fixtures yield a single color when
+// they have parameters named Red, Green and Blue, scaled by a Dim or
+// Intensity parameter if present. Fixtures without color yield nil.
+func (f Fixture) Colors(values []uint8) []Color {
+	if layout := f.CellLayout(); layout != "" {
+		cells := len(values) / len(layout)
+		colors := make([]Color, cells)
+		for i := range colors {
+			colors[i] = mixCell(layout, values[i*len(layout):(i+1)*len(layout)])
+		}
+		return colors
+	}
+
+	offsets := make(map[string]int)
+	for i, name := range f.Params {
+		offsets[strings.ToLower(name)] = i
+	}
+	r, hasR := offsets["red"]
+	g, hasG := offsets["green"]
+	b, hasB := offsets["blue"]
+	if !hasR || !hasG || !hasB || max(r, g, b) >= len(values) {
+		return nil
+	}
+
+	c := Color{R: values[r], G: values[g], B: values[b]}
+	for _, dimmer := range []string{"dim", "intensity"} {
+		if d, ok := offsets[dimmer]; ok && d < len(values) {
+			c = Color{
+				R: uint8(int(c.R) * int(values[d]) / 255),
+				G: uint8(int(c.G) * int(values[d]) / 255),
+				B: uint8(int(c.B) * int(values[d]) / 255),
+			}
+			break
+		}
+	}
+	return []Color{c}
+}
+
+// mixCell converts one cell's values in the given layout to a color, adding
+// any white component to all three primaries
+func mixCell(layout string, values []uint8) Color {
+	var r, g, b, w int
+	for i, c := range layout {
+		switch c {
+		case 'r':
+			r = int(values[i])
+		case 'g':
+			g = int(values[i])
+		case 'b':
+			b = int(values[i])
+		case 'w':
+			w = int(values[i])
+		}
+	}
+	return Color{
+		R: uint8(min(r+w, 255)),
+		G: uint8(min(g+w, 255)),
+		B: uint8(min(b+w, 255)),
+	}
+}
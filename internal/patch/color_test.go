@@ -0,0 +1,57 @@
+package patch
+
+import (
+	"testing"
+)
+
+func TestFixture_CellLayout(t *testing.T) {
+	tests := []struct {
+		fixtureType string
+		want        string
+	}{
+		{"rgb", "rgb"},
+		{"GRB", "grb"},
+		{"rgbw", "rgbw"},
+		{"rgbb", ""},
+		{"rg", ""},
+		{"dimmer", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := (Fixture{Type: tt.fixtureType}).CellLayout(); got != tt.want {
+			t.Errorf("CellLayout(%q) = %q, want %q", tt.fixtureType, got, tt.want)
+		}
+	}
+}
+
+func TestFixture_Colors_Cells(t *testing.T) {
+	tape := Fixture{Name: "Tape", Type: "grbw", Footprint: 8}
+	colors := tape.Colors([]uint8{10, 20, 30, 5, 255, 0, 0, 0})
+
+	if len(colors) != 2 {
+		t.Fatalf("len(Colors()) = %d, want 2", len(colors))
+	}
+
+	// GRBW: green 10, red 20, blue 30, plus 5 white
+	if colors[0] != (Color{R: 25, G: 15, B: 35}) {
+		t.Errorf("colors[0] = %+v, want {25 15 35}", colors[0])
+	}
+	if colors[1].Hex() != "#00FF00" {
+		t.Errorf("colors[1].Hex() = %s, want #00FF00", colors[1].Hex())
+	}
+}
+
+func TestFixture_Colors_Parameters(t *testing.T) {
+	wash := Fixture{Params: []string{"Dim", "Red", "Green", "Blue"}}
+	colors := wash.Colors([]uint8{128, 255, 0, 100})
+
+	if len(colors) != 1 || colors[0] != (Color{R: 128, G: 0, B: 50}) {
+		t.Errorf("Colors() = %+v, want [{128 0 50}]", colors)
+	}
+
+	spot := Fixture{Params: []string{"Dim", "Pan", "Tilt"}}
+	if colors := spot.Colors([]uint8{255, 0, 0}); colors != nil {
+		t.Errorf("Colors() for non-color fixture = %v, want nil", colors)
+	}
+}
@@ -0,0 +1,74 @@
+package patch
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ParseCSV reads a channel-per-row patch with the columns
+//
+//	universe,channel,fixture,parameter
+//
+// An optional header row is skipped. Consecutive channels with the same
+// fixture name on the same universe are grouped into one fixture.
+func ParseCSV(r io.Reader) ([]Fixture, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	var fixtures []Fixture
+	line := 0
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line++
+
+		if len(record) < 3 {
+			return nil, fmt.Errorf("line %d: expected universe,channel,fixture[,parameter]", line)
+		}
+
+		universe, uErr := strconv.ParseUint(strings.TrimSpace(record[0]), 10, 16)
+		channel, cErr := strconv.Atoi(strings.TrimSpace(record[1]))
+		if uErr != nil || cErr != nil {
+			if line == 1 {
+				continue // Header row
+			}
+			return nil, fmt.Errorf("line %d: invalid universe or channel", line)
+		}
+
+		name := strings.TrimSpace(record[2])
+		param := ""
+		if len(record) > 3 {
+			param = strings.TrimSpace(record[3])
+		}
+
+		// Extend the previous fixture if this row continues it
+		if n := len(fixtures); n > 0 {
+			last := &fixtures[n-1]
+			if last.Name == name && last.Universe == uint16(universe) && channel == last.LastChannel()+1 {
+				last.Footprint++
+				last.Params = append(last.Params, param)
+				continue
+			}
+		}
+
+		fixtures = append(fixtures, Fixture{
+			Name:      name,
+			Universe:  uint16(universe),
+			Address:   channel,
+			Footprint: 1,
+			Params:    []string{param},
+		})
+	}
+
+	return fixtures, nil
+}
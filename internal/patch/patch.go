@@ -0,0 +1,216 @@
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Fixture is a patched device occupying a contiguous range of channels
+type Fixture struct {
+	Name      string   `json:"name"`
+	Universe  uint16   `json:"universe"`
+	Address   int      `json:"address"`              // 1-based start channel
+	Footprint int      `json:"footprint"`            // Number of channels used
+	Params    []string `json:"parameters,omitempty"` // Parameter name per channel offset, e.g. "Dim", "Pan"
+	Type      string   `json:"type,omitempty"`       // Cell layout for color fixtures, e.g. "rgb", "rgbw", "grb"
+	Wide      []int    `json:"wide,omitempty"`       // 1-based offsets of coarse channels paired with the next one as 16-bit
+}
+
+// Label names the fixture and parameter a channel is patched to
+type Label struct {
+	Fixture   string
+	Parameter string
+}
+
+// String formats the label as "Fixture Parameter"
+func (l Label) String() string {
+	if l.Parameter == "" {
+		return l.Fixture
+	}
+	return l.Fixture + " " + l.Parameter
+}
+
+// Parameter returns the parameter name for a 1-based channel within the
+// fixture, falling back to its 1-based offset (e.g. "#3") when unnamed
+func (f Fixture) Parameter(channel int) string {
+	offset := channel - f.Address
+	if offset >= 0 && offset < len(f.Params) && f.Params[offset] != "" {
+		return f.Params[offset]
+	}
+	return fmt.Sprintf("#%d", offset+1)
+}
+
+// WideOffsets returns the 1-based offsets of the fixture's 16-bit coarse
+// channels, in order: those listed in Wide, and any parameter directly
+// followed by the same name with " fine" (e.g. "Pan", "Pan fine")
+func (f Fixture) WideOffsets() []int {
+	wide := make(map[int]bool, len(f.Wide))
+	for _, o := range f.Wide {
+		wide[o] = true
+	}
+	for i := 0; i+1 < len(f.Params); i++ {
+		coarse := strings.ToLower(strings.TrimSpace(f.Params[i]))
+		fine := strings.ToLower(strings.TrimSpace(f.Params[i+1]))
+		if coarse != "" && (fine == coarse+" fine" || fine == coarse+"fine") {
+			wide[i+1] = true
+		}
+	}
+
+	offsets := make([]int, 0, len(wide))
+	for o := range wide {
+		offsets = append(offsets, o)
+	}
+	sort.Ints(offsets)
+	return offsets
+}
+
+// LastChannel returns the 1-based last channel occupied by the fixture
+func (f Fixture) LastChannel() int {
+	return f.Address + f.Footprint - 1
+}
+
+// Contains reports whether the 1-based channel is within the fixture's footprint
+func (f Fixture) Contains(channel int) bool {
+	return channel >= f.Address && channel <= f.LastChannel()
+}
+
+// Patch maps fixtures onto universe channels
+type Patch struct {
+	fixtures []Fixture
+	coverage map[uint16]*[512]int // Index+1 into fixtures per channel, 0 = unpatched
+}
+
+// file is the on-disk JSON representation of a patch
+type file struct {
+	Fixtures []Fixture `json:"fixtures"`
+}
+
+// New builds a patch from a list of fixtures, validating their addresses.
+// Overlapping fixtures are allowed; the first one listed owns the channel.
+func New(fixtures []Fixture) (*Patch, error) {
+	p := &Patch{
+		coverage: make(map[uint16]*[512]int),
+	}
+
+	for i, f := range fixtures {
+		if f.Universe == 0 {
+			return nil, fmt.Errorf("fixture %d (%q): universe must be set", i+1, f.Name)
+		}
+		if f.Address < 1 || f.Address > 512 {
+			return nil, fmt.Errorf("fixture %d (%q): address %d out of range 1-512", i+1, f.Name, f.Address)
+		}
+		if f.Footprint < 1 || f.LastChannel() > 512 {
+			return nil, fmt.Errorf("fixture %d (%q): footprint %d at address %d exceeds the universe", i+1, f.Name, f.Footprint, f.Address)
+		}
+		if len(f.Params) > f.Footprint {
+			return nil, fmt.Errorf("fixture %d (%q): %d parameters for a footprint of %d", i+1, f.Name, len(f.Params), f.Footprint)
+		}
+		for _, o := range f.Wide {
+			if o < 1 || o >= f.Footprint {
+				return nil, fmt.Errorf("fixture %d (%q): 16-bit offset %d needs a fine channel within the footprint of %d", i+1, f.Name, o, f.Footprint)
+			}
+		}
+
+		p.fixtures = append(p.fixtures, f)
+		cov, exists := p.coverage[f.Universe]
+		if !exists {
+			cov = &[512]int{}
+			p.coverage[f.Universe] = cov
+		}
+		for ch := f.Address; ch <= f.LastChannel(); ch++ {
+			if cov[ch-1] == 0 {
+				cov[ch-1] = len(p.fixtures)
+			}
+		}
+	}
+
+	return p, nil
+}
+
+// Load reads a patch file. Files ending in .csv are read as CSV (see
+// ParseCSV), anything else as JSON.
+func Load(path string) (*Patch, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read patch file: %w", err)
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		fixtures, err := ParseCSV(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse patch file %s: %w", path, err)
+		}
+		return New(fixtures)
+	}
+
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse patch file %s: %w", path, err)
+	}
+
+	return New(f.Fixtures)
+}
+
+// Fixtures returns all fixtures, ordered by universe and address
+func (p *Patch) Fixtures() []Fixture {
+	result := make([]Fixture, len(p.fixtures))
+	copy(result, p.fixtures)
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Universe != result[j].Universe {
+			return result[i].Universe < result[j].Universe
+		}
+		return result[i].Address < result[j].Address
+	})
+	return result
+}
+
+// IsPatched reports whether a universe has any fixtures patched on it
+func (p *Patch) IsPatched(universe uint16) bool {
+	_, exists := p.coverage[universe]
+	return exists
+}
+
+// FixtureAt returns the fixture owning the 1-based channel, if any
+func (p *Patch) FixtureAt(universe uint16, channel int) (Fixture, bool) {
+	cov, exists := p.coverage[universe]
+	if !exists || channel < 1 || channel > 512 || cov[channel-1] == 0 {
+		return Fixture{}, false
+	}
+	return p.fixtures[cov[channel-1]-1], true
+}
+
+// Label returns the fixture and parameter name of a 1-based channel
+func (p *Patch) Label(universe uint16, channel int) (Label, bool) {
+	f, ok := p.FixtureAt(universe, channel)
+	if !ok {
+		return Label{}, false
+	}
+	return Label{Fixture: f.Name, Parameter: f.Parameter(channel)}, true
+}
+
+// WidePair returns the 1-based coarse channel of the 16-bit pair a
+// channel belongs to, as coarse or fine, if its fixture has one there
+func (p *Patch) WidePair(universe uint16, channel int) (int, bool) {
+	f, ok := p.FixtureAt(universe, channel)
+	if !ok {
+		return 0, false
+	}
+	for _, o := range f.WideOffsets() {
+		coarse := f.Address + o - 1
+		if channel == coarse || channel == coarse+1 {
+			return coarse, true
+		}
+	}
+	return 0, false
+}
+
+// Covers reports whether the 1-based channel is inside any fixture's footprint
+func (p *Patch) Covers(universe uint16, channel int) bool {
+	_, ok := p.FixtureAt(universe, channel)
+	return ok
+}
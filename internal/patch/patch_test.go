@@ -0,0 +1,236 @@
+package patch
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNew_Coverage(t *testing.T) {
+	p, err := New([]Fixture{
+		{Name: "Spot 1", Universe: 1, Address: 1, Footprint: 16},
+		{Name: "Wash 1", Universe: 1, Address: 101, Footprint: 8},
+	})
+	if err != nil {
+		t.Fatalf("New() returned error: %v", err)
+	}
+
+	tests := []struct {
+		universe uint16
+		channel  int
+		want     string
+	}{
+		{1, 1, "Spot 1"},
+		{1, 16, "Spot 1"},
+		{1, 17, ""},
+		{1, 108, "Wash 1"},
+		{1, 109, ""},
+		{2, 1, ""},
+		{1, 0, ""},
+		{1, 513, ""},
+	}
+
+	for _, tt := range tests {
+		f, ok := p.FixtureAt(tt.universe, tt.channel)
+		if f.Name != tt.want || ok != (tt.want != "") {
+			t.Errorf("FixtureAt(%d, %d) = %q, %v, want %q", tt.universe, tt.channel, f.Name, ok, tt.want)
+		}
+	}
+
+	if !p.IsPatched(1) || p.IsPatched(2) {
+		t.Errorf("IsPatched(1), IsPatched(2) = %v, %v, want true, false", p.IsPatched(1), p.IsPatched(2))
+	}
+}
+
+func TestNew_Overlap(t *testing.T) {
+	p, err := New([]Fixture{
+		{Name: "first", Universe: 1, Address: 1, Footprint: 4},
+		{Name: "second", Universe: 1, Address: 3, Footprint: 4},
+	})
+	if err != nil {
+		t.Fatalf("New() returned error: %v", err)
+	}
+
+	if f, _ := p.FixtureAt(1, 3); f.Name != "first" {
+		t.Errorf("FixtureAt(1, 3) = %q, want first", f.Name)
+	}
+	if f, _ := p.FixtureAt(1, 6); f.Name != "second" {
+		t.Errorf("FixtureAt(1, 6) = %q, want second", f.Name)
+	}
+}
+
+func TestNew_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture Fixture
+	}{
+		{"no universe", Fixture{Address: 1, Footprint: 1}},
+		{"address zero", Fixture{Universe: 1, Address: 0, Footprint: 1}},
+		{"address too high", Fixture{Universe: 1, Address: 513, Footprint: 1}},
+		{"no footprint", Fixture{Universe: 1, Address: 1, Footprint: 0}},
+		{"overflows universe", Fixture{Universe: 1, Address: 510, Footprint: 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New([]Fixture{tt.fixture}); err == nil {
+				t.Error("New() expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "patch.json")
+	data := `{"fixtures": [
+		{"name": "Wash 2", "universe": 2, "address": 10, "footprint": 4},
+		{"name": "Wash 1", "universe": 1, "address": 10, "footprint": 4}
+	]}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	fixtures := p.Fixtures()
+	if len(fixtures) != 2 || fixtures[0].Name != "Wash 1" {
+		t.Errorf("Fixtures() = %v, want Wash 1 first", fixtures)
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Load() of missing file expected error, got nil")
+	}
+}
+
+func TestPatch_Label(t *testing.T) {
+	p, err := New([]Fixture{
+		{Name: "Spot 12", Universe: 1, Address: 147, Footprint: 3, Params: []string{"Dim", "Pan"}},
+	})
+	if err != nil {
+		t.Fatalf("New() returned error: %v", err)
+	}
+
+	tests := []struct {
+		channel int
+		want    string
+	}{
+		{147, "Spot 12 Dim"},
+		{148, "Spot 12 Pan"},
+		{149, "Spot 12 #3"},
+	}
+
+	for _, tt := range tests {
+		label, ok := p.Label(1, tt.channel)
+		if !ok || label.String() != tt.want {
+			t.Errorf("Label(1, %d) = %q, %v, want %q", tt.channel, label, ok, tt.want)
+		}
+	}
+
+	if _, ok := p.Label(1, 150); ok {
+		t.Error("Label(1, 150) returned ok for unpatched channel")
+	}
+}
+
+func TestNew_TooManyParameters(t *testing.T) {
+	_, err := New([]Fixture{{Name: "x", Universe: 1, Address: 1, Footprint: 1, Params: []string{"a", "b"}}})
+	if err == nil {
+		t.Error("New() expected error for more parameters than footprint, got nil")
+	}
+}
+
+func TestParseCSV(t *testing.T) {
+	data := `universe,channel,fixture,parameter
+1,1,Spot 1,Dim
+1,2,Spot 1,Pan
+1,3,Spot 1,Tilt
+# house lights
+1,10,House,
+2,1,Spot 1,Dim
+`
+	fixtures, err := ParseCSV(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("ParseCSV() returned error: %v", err)
+	}
+
+	if len(fixtures) != 3 {
+		t.Fatalf("len(fixtures) = %d, want 3: %+v", len(fixtures), fixtures)
+	}
+
+	spot := fixtures[0]
+	if spot.Name != "Spot 1" || spot.Address != 1 || spot.Footprint != 3 || spot.Params[2] != "Tilt" {
+		t.Errorf("fixtures[0] = %+v, want Spot 1 at 1 with 3 channels", spot)
+	}
+
+	if fixtures[1].Name != "House" || fixtures[1].Address != 10 {
+		t.Errorf("fixtures[1] = %+v, want House at 10", fixtures[1])
+	}
+
+	if fixtures[2].Universe != 2 {
+		t.Errorf("fixtures[2].Universe = %d, want 2", fixtures[2].Universe)
+	}
+}
+
+func TestParseCSV_Invalid(t *testing.T) {
+	for _, data := range []string{"1,1\n", "1,1,a\nx,2,b\n"} {
+		if _, err := ParseCSV(strings.NewReader(data)); err == nil {
+			t.Errorf("ParseCSV(%q) expected error, got nil", data)
+		}
+	}
+}
+
+func TestLoad_CSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "patch.csv")
+	if err := os.WriteFile(path, []byte("3,5,Wash,Red\n3,6,Wash,Green\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	if label, _ := p.Label(3, 6); label.String() != "Wash Green" {
+		t.Errorf("Label(3, 6) = %q, want Wash Green", label)
+	}
+}
+
+func TestPatch_WidePair(t *testing.T) {
+	p, err := New([]Fixture{
+		{Name: "Spot 1", Universe: 1, Address: 1, Footprint: 6, Params: []string{"Dim", "Pan", "Pan fine", "Tilt", "Tilt Fine", "Gobo"}},
+		{Name: "Mover", Universe: 1, Address: 11, Footprint: 4, Wide: []int{3}},
+	})
+	if err != nil {
+		t.Fatalf("New() returned error: %v", err)
+	}
+
+	tests := []struct {
+		channel int
+		coarse  int
+		ok      bool
+	}{
+		{1, 0, false},
+		{2, 2, true},
+		{3, 2, true},
+		{4, 4, true},
+		{5, 4, true},
+		{6, 0, false},
+		{12, 0, false},
+		{13, 13, true},
+		{14, 13, true},
+		{20, 0, false},
+	}
+	for _, tt := range tests {
+		coarse, ok := p.WidePair(1, tt.channel)
+		if coarse != tt.coarse || ok != tt.ok {
+			t.Errorf("WidePair(1, %d) = %d, %v, want %d, %v", tt.channel, coarse, ok, tt.coarse, tt.ok)
+		}
+	}
+
+	if _, err := New([]Fixture{{Name: "bad", Universe: 1, Address: 1, Footprint: 2, Wide: []int{2}}}); err == nil {
+		t.Error("New() accepted a 16-bit offset without a fine channel")
+	}
+}
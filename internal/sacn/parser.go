@@ -41,26 +41,27 @@ func Parse(data []byte) (*Packet, error) {
 
 	packet := &Packet{
 		ReceivedAt: time.Now(),
+		Length:     len(data),
 	}
 
 	// Extract CID (offset 22-37)
 	copy(packet.CID[:], data[22:38])
 
 	// Extract Source Name (offset 44-107): 64 bytes, null-terminated
-	sourceNameBytes := data[44:108]
-	nullIdx := bytes.IndexByte(sourceNameBytes, 0)
-	if nullIdx > 0 {
-		packet.SourceName = string(sourceNameBytes[:nullIdx])
-	} else if nullIdx < 0 {
-		packet.SourceName = string(sourceNameBytes)
-	}
+	packet.SourceName = parseSourceName(data[44:108])
 
 	// Extract Priority (offset 108)
 	packet.Priority = data[108]
 
+	// Extract Synchronization Address (offset 109-110)
+	packet.SyncAddress = binary.BigEndian.Uint16(data[109:111])
+
 	// Extract Sequence (offset 111)
 	packet.Sequence = data[111]
 
+	// Extract Options (offset 112)
+	packet.Options = data[112]
+
 	// Extract Universe (offset 113-114)
 	packet.Universe = binary.BigEndian.Uint16(data[113:115])
 
@@ -79,3 +80,124 @@ func Parse(data []byte) (*Packet, error) {
 
 	return packet, nil
 }
+
+// IsExtended reports whether data carries the extended root vector used by
+// synchronization and universe discovery packets
+func IsExtended(data []byte) bool {
+	return len(data) >= 22 && binary.BigEndian.Uint32(data[18:22]) == E131ExtendedRootVector
+}
+
+// ParseDiscovery parses a raw E1.31 universe discovery packet
+func ParseDiscovery(data []byte) (*DiscoveryPacket, error) {
+	if len(data) < E131DiscoveryHeaderSize {
+		return nil, NewParseError("packet too short", 0)
+	}
+
+	// Validate preamble size (offset 0-1): must be 0x0010
+	if data[0] != 0x00 || data[1] != 0x10 {
+		return nil, NewParseError("invalid preamble size", 0)
+	}
+
+	// Validate ACN Packet Identifier (offset 4-15)
+	if !bytes.Equal(data[4:16], ACNPacketIdentifier) {
+		return nil, NewParseError("invalid ACN packet identifier", 4)
+	}
+
+	// Validate Root Vector (offset 18-21): must be 0x00000008
+	if binary.BigEndian.Uint32(data[18:22]) != E131ExtendedRootVector {
+		return nil, NewParseError("invalid root vector", 18)
+	}
+
+	// Validate Framing Vector (offset 40-43): must be 0x00000002
+	if binary.BigEndian.Uint32(data[40:44]) != E131DiscoveryVector {
+		return nil, NewParseError("invalid framing vector", 40)
+	}
+
+	// Validate Universe Discovery Vector (offset 114-117): must be 0x00000001
+	if binary.BigEndian.Uint32(data[114:118]) != E131DiscoveryListVector {
+		return nil, NewParseError("invalid universe discovery vector", 114)
+	}
+
+	packet := &DiscoveryPacket{
+		ReceivedAt: time.Now(),
+	}
+
+	// Extract CID (offset 22-37)
+	copy(packet.CID[:], data[22:38])
+
+	// Extract Source Name (offset 44-107)
+	packet.SourceName = parseSourceName(data[44:108])
+
+	// Extract Page and Last Page (offset 118-119)
+	packet.Page = data[118]
+	packet.LastPage = data[119]
+
+	// Extract universe list (offset 120+): 2 bytes per universe
+	count := (len(data) - E131DiscoveryHeaderSize) / 2
+	if count > E131MaxChannels {
+		count = E131MaxChannels
+	}
+	packet.Universes = make([]uint16, count)
+	for i := range packet.Universes {
+		offset := E131DiscoveryHeaderSize + i*2
+		packet.Universes[i] = binary.BigEndian.Uint16(data[offset : offset+2])
+	}
+
+	return packet, nil
+}
+
+// IsSync reports whether an extended packet is a synchronization packet
+func IsSync(data []byte) bool {
+	return IsExtended(data) && len(data) >= 44 && binary.BigEndian.Uint32(data[40:44]) == E131SyncVector
+}
+
+// ParseSync parses a raw E1.31 synchronization packet
+func ParseSync(data []byte) (*SyncPacket, error) {
+	if len(data) < E131SyncPacketSize {
+		return nil, NewParseError("packet too short", 0)
+	}
+
+	// Validate preamble size (offset 0-1): must be 0x0010
+	if data[0] != 0x00 || data[1] != 0x10 {
+		return nil, NewParseError("invalid preamble size", 0)
+	}
+
+	// Validate ACN Packet Identifier (offset 4-15)
+	if !bytes.Equal(data[4:16], ACNPacketIdentifier) {
+		return nil, NewParseError("invalid ACN packet identifier", 4)
+	}
+
+	// Validate Root Vector (offset 18-21): must be 0x00000008
+	if binary.BigEndian.Uint32(data[18:22]) != E131ExtendedRootVector {
+		return nil, NewParseError("invalid root vector", 18)
+	}
+
+	// Validate Framing Vector (offset 40-43): must be 0x00000001
+	if binary.BigEndian.Uint32(data[40:44]) != E131SyncVector {
+		return nil, NewParseError("invalid framing vector", 40)
+	}
+
+	packet := &SyncPacket{
+		ReceivedAt: time.Now(),
+	}
+
+	// Extract CID (offset 22-37)
+	copy(packet.CID[:], data[22:38])
+
+	// Extract Sequence (offset 44)
+	packet.Sequence = data[44]
+
+	// Extract Synchronization Address (offset 45-46)
+	packet.SyncAddress = binary.BigEndian.Uint16(data[45:47])
+
+	return packet, nil
+}
+
+// parseSourceName extracts a null-terminated source name field
+func parseSourceName(field []byte) string {
+	nullIdx := bytes.IndexByte(field, 0)
+	if nullIdx < 0 {
+		return string(field)
+	}
+	return string(field[:nullIdx])
+}
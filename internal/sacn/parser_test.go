@@ -1,6 +1,7 @@
 package sacn
 
 import (
+	"net"
 	"testing"
 )
 
@@ -105,6 +106,10 @@ func TestParse_ValidPacket(t *testing.T) {
 		t.Errorf("Universe = %d, want 1", result.Universe)
 	}
 
+	if result.Length != len(packet) {
+		t.Errorf("Length = %d, want %d", result.Length, len(packet))
+	}
+
 	if result.Sequence != 42 {
 		t.Errorf("Sequence = %d, want 42", result.Sequence)
 	}
@@ -326,3 +331,235 @@ func TestPacket_ChannelCount(t *testing.T) {
 		})
 	}
 }
+
+// buildDiscoveryPacket creates a valid E1.31 universe discovery packet for testing
+func buildDiscoveryPacket(sourceName string, page, lastPage uint8, universes []uint16) []byte {
+	packetSize := E131DiscoveryHeaderSize + len(universes)*2
+	packet := make([]byte, packetSize)
+
+	// === Root Layer ===
+	packet[1] = 0x10
+	copy(packet[4:16], ACNPacketIdentifier)
+	rootLength := uint16(packetSize - 16)
+	packet[16] = 0x70 | byte(rootLength>>8)
+	packet[17] = byte(rootLength)
+	packet[21] = 0x08 // Root Vector: VECTOR_ROOT_E131_EXTENDED
+	copy(packet[22:38], []byte{0xca, 0xfe})
+
+	// === Framing Layer ===
+	framingLength := uint16(packetSize - 38)
+	packet[38] = 0x70 | byte(framingLength>>8)
+	packet[39] = byte(framingLength)
+	packet[43] = 0x02 // Framing Vector: VECTOR_E131_EXTENDED_DISCOVERY
+	copy(packet[44:108], []byte(sourceName))
+
+	// === Universe Discovery Layer ===
+	discoveryLength := uint16(packetSize - 112)
+	packet[112] = 0x70 | byte(discoveryLength>>8)
+	packet[113] = byte(discoveryLength)
+	packet[117] = 0x01 // Vector: VECTOR_UNIVERSE_DISCOVERY_UNIVERSE_LIST
+	packet[118] = page
+	packet[119] = lastPage
+	for i, u := range universes {
+		packet[E131DiscoveryHeaderSize+i*2] = byte(u >> 8)
+		packet[E131DiscoveryHeaderSize+i*2+1] = byte(u)
+	}
+
+	return packet
+}
+
+// buildSyncPacket creates a valid E1.31 synchronization packet for testing
+func buildSyncPacket(sequence uint8, syncAddress uint16) []byte {
+	packet := make([]byte, E131SyncPacketSize)
+
+	// === Root Layer ===
+	packet[1] = 0x10
+	copy(packet[4:16], ACNPacketIdentifier)
+	rootLength := uint16(E131SyncPacketSize - 16)
+	packet[16] = 0x70 | byte(rootLength>>8)
+	packet[17] = byte(rootLength)
+	packet[21] = 0x08 // Root Vector: VECTOR_ROOT_E131_EXTENDED
+	copy(packet[22:38], []byte{0xbe, 0xef})
+
+	// === Framing Layer ===
+	framingLength := uint16(E131SyncPacketSize - 38)
+	packet[38] = 0x70 | byte(framingLength>>8)
+	packet[39] = byte(framingLength)
+	packet[43] = 0x01 // Framing Vector: VECTOR_E131_EXTENDED_SYNCHRONIZATION
+	packet[44] = sequence
+	packet[45] = byte(syncAddress >> 8)
+	packet[46] = byte(syncAddress)
+
+	return packet
+}
+
+func TestParse_SyncAddress(t *testing.T) {
+	packet := buildValidPacket(1, 1, "test", []byte{0})
+	packet[109] = 0x1b
+	packet[110] = 0x58 // 7000
+
+	result, err := Parse(packet)
+	if err != nil {
+		t.Fatalf("Parse() returned error: %v", err)
+	}
+
+	if result.SyncAddress != 7000 {
+		t.Errorf("SyncAddress = %d, want 7000", result.SyncAddress)
+	}
+}
+
+func TestParseSync_Valid(t *testing.T) {
+	packet := buildSyncPacket(42, 7000)
+
+	if !IsSync(packet) {
+		t.Fatal("IsSync() = false, want true")
+	}
+	if IsSync(buildDiscoveryPacket("test", 0, 0, nil)) {
+		t.Error("IsSync(discovery packet) = true, want false")
+	}
+
+	result, err := ParseSync(packet)
+	if err != nil {
+		t.Fatalf("ParseSync() returned error: %v", err)
+	}
+
+	if result.Sequence != 42 {
+		t.Errorf("Sequence = %d, want 42", result.Sequence)
+	}
+	if result.SyncAddress != 7000 {
+		t.Errorf("SyncAddress = %d, want 7000", result.SyncAddress)
+	}
+	if result.CID[0] != 0xbe || result.CID[1] != 0xef {
+		t.Errorf("CID = %x, want beef...", result.CID)
+	}
+}
+
+func TestParseSync_TooShort(t *testing.T) {
+	if _, err := ParseSync(buildSyncPacket(0, 1)[:40]); err == nil {
+		t.Error("ParseSync() expected error for short packet, got nil")
+	}
+}
+
+func TestParse_Options(t *testing.T) {
+	packet := buildValidPacket(1, 1, "test", []byte{0})
+	packet[112] = OptionPreviewData | OptionStreamTerminated
+
+	result, err := Parse(packet)
+	if err != nil {
+		t.Fatalf("Parse() returned error: %v", err)
+	}
+
+	if !result.PreviewData() {
+		t.Error("PreviewData() = false, want true")
+	}
+
+	if !result.StreamTerminated() {
+		t.Error("StreamTerminated() = false, want true")
+	}
+}
+
+func TestIsExtended(t *testing.T) {
+	if IsExtended(buildValidPacket(1, 1, "test", []byte{0})) {
+		t.Error("IsExtended(data packet) = true, want false")
+	}
+
+	if !IsExtended(buildDiscoveryPacket("test", 0, 0, nil)) {
+		t.Error("IsExtended(discovery packet) = false, want true")
+	}
+
+	if IsExtended([]byte{0x00, 0x10}) {
+		t.Error("IsExtended(short packet) = true, want false")
+	}
+}
+
+func TestParseDiscovery_Valid(t *testing.T) {
+	universes := []uint16{1, 2, 300}
+	packet := buildDiscoveryPacket("console", 1, 2, universes)
+
+	result, err := ParseDiscovery(packet)
+	if err != nil {
+		t.Fatalf("ParseDiscovery() returned error: %v", err)
+	}
+
+	if result.SourceName != "console" {
+		t.Errorf("SourceName = %q, want %q", result.SourceName, "console")
+	}
+
+	if result.Page != 1 || result.LastPage != 2 {
+		t.Errorf("Page/LastPage = %d/%d, want 1/2", result.Page, result.LastPage)
+	}
+
+	if result.CID[0] != 0xca || result.CID[1] != 0xfe {
+		t.Errorf("CID = %x, want cafe...", result.CID)
+	}
+
+	if len(result.Universes) != len(universes) {
+		t.Fatalf("len(Universes) = %d, want %d", len(result.Universes), len(universes))
+	}
+
+	for i, expected := range universes {
+		if result.Universes[i] != expected {
+			t.Errorf("Universes[%d] = %d, want %d", i, result.Universes[i], expected)
+		}
+	}
+}
+
+func TestParseDiscovery_InvalidVectors(t *testing.T) {
+	tests := []struct {
+		name    string
+		offset  int
+		message string
+	}{
+		{"root vector", 21, "invalid root vector"},
+		{"framing vector", 43, "invalid framing vector"},
+		{"discovery vector", 117, "invalid universe discovery vector"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			packet := buildDiscoveryPacket("test", 0, 0, []uint16{1})
+			packet[tt.offset] = 0x07
+
+			_, err := ParseDiscovery(packet)
+			if err == nil {
+				t.Fatal("ParseDiscovery() expected error, got nil")
+			}
+
+			if err.Error() != tt.message {
+				t.Errorf("error = %q, want %q", err.Error(), tt.message)
+			}
+		})
+	}
+}
+
+func TestPacket_Transport(t *testing.T) {
+	tests := []struct {
+		dst  net.IP
+		want string
+	}{
+		{nil, ""},
+		{net.IPv4(239, 255, 0, 1), "multicast"},
+		{net.IPv4bcast, "broadcast"},
+		{net.IPv4(10, 0, 0, 5), "unicast"},
+	}
+	for _, tt := range tests {
+		p := &Packet{Destination: tt.dst}
+		if got := p.Transport(); got != tt.want {
+			t.Errorf("Transport() with destination %v = %q, want %q", tt.dst, got, tt.want)
+		}
+	}
+}
+
+func TestStartCodeName(t *testing.T) {
+	tests := map[uint8]string{
+		0x00: "0x00 (DMX)",
+		0xDD: "0xDD (per-address priority)",
+		0x17: "0x17 (text)",
+		0x42: "0x42",
+	}
+	for code, want := range tests {
+		if got := StartCodeName(code); got != want {
+			t.Errorf("StartCodeName(0x%02X) = %q, want %q", code, got, want)
+		}
+	}
+}
@@ -5,23 +5,41 @@ import (
 	"fmt"
 	"net"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"golang.org/x/net/ipv4"
 )
 
 // Receiver listens for sACN packets on multicast, unicast, and broadcast
 type Receiver struct {
-	packets chan *Packet
-	conn    *ipv4.PacketConn
-	rawConn net.PacketConn
-	mu      sync.RWMutex
-	started bool
+	packets   chan *Packet
+	discovery chan *DiscoveryPacket
+	syncs     chan *SyncPacket
+	conn      *ipv4.PacketConn
+	rawConn   net.PacketConn
+	mu        sync.RWMutex
+	started   bool
+
+	// Sync addresses whose multicast group has been joined
+	syncGroups map[uint16]bool
+
+	// Packets dropped because a channel was full, i.e. the consumer
+	// couldn't keep up
+	dropped atomic.Uint64
+	onDrop  func(*Packet)
+
+	// Called with every datagram read, see SetCaptureHandler
+	onCapture func(data []byte, src net.Addr, dst net.IP, at time.Time)
 }
 
 // NewReceiver creates a new sACN receiver
 func NewReceiver() *Receiver {
 	return &Receiver{
-		packets: make(chan *Packet, 1000),
+		packets:    make(chan *Packet, 1000),
+		discovery:  make(chan *DiscoveryPacket, 100),
+		syncs:      make(chan *SyncPacket, 1000),
+		syncGroups: make(map[uint16]bool),
 	}
 }
 
@@ -30,6 +48,37 @@ func (r *Receiver) Packets() <-chan *Packet {
 	return r.packets
 }
 
+// Discovery returns the channel of received universe discovery packets
+func (r *Receiver) Discovery() <-chan *DiscoveryPacket {
+	return r.discovery
+}
+
+// Syncs returns the channel of received synchronization packets
+func (r *Receiver) Syncs() <-chan *SyncPacket {
+	return r.syncs
+}
+
+// Dropped returns how many data and sync packets were dropped because the
+// consumer couldn't keep up
+func (r *Receiver) Dropped() uint64 {
+	return r.dropped.Load()
+}
+
+// SetDropHandler registers a function called with each data packet dropped
+// because the packet channel was full. It runs on the receiving goroutine,
+// before any later packet is delivered. Set it before Start.
+func (r *Receiver) SetDropHandler(handler func(*Packet)) {
+	r.onDrop = handler
+}
+
+// SetCaptureHandler registers a function called with every datagram read,
+// valid or not, before it's parsed. It runs on the receiving goroutine and
+// data is reused afterwards, so it must copy what it keeps and not block.
+// Set it before Start.
+func (r *Receiver) SetCaptureHandler(handler func(data []byte, src net.Addr, dst net.IP, at time.Time)) {
+	r.onCapture = handler
+}
+
 // Start begins listening for sACN packets
 func (r *Receiver) Start(ctx context.Context) error {
 	r.mu.Lock()
@@ -60,6 +109,10 @@ func (r *Receiver) Start(ctx context.Context) error {
 	// We'll dynamically join more if we see them
 	r.joinMulticastGroups(1, 63)
 
+	// Join the universe discovery group so sources announcing their
+	// universes are seen too
+	r.joinMulticastGroups(E131DiscoveryUniverse, E131DiscoveryUniverse)
+
 	// Start packet reading goroutine
 	go r.readPackets(ctx)
 
@@ -118,7 +171,7 @@ func (r *Receiver) readPackets(ctx context.Context) {
 		default:
 		}
 
-		n, _, src, err := r.conn.ReadFrom(buf)
+		n, cm, src, err := r.conn.ReadFrom(buf)
 		if err != nil {
 			// Check if context is cancelled
 			select {
@@ -130,6 +183,20 @@ func (r *Receiver) readPackets(ctx context.Context) {
 			}
 		}
 
+		if r.onCapture != nil {
+			var dst net.IP
+			if cm != nil {
+				dst = cm.Dst
+			}
+			r.onCapture(buf[:n], src, dst, time.Now())
+		}
+
+		// Synchronization and discovery packets use the extended root vector
+		if IsExtended(buf[:n]) {
+			r.handleExtended(buf[:n], src)
+			continue
+		}
+
 		// Parse the packet
 		packet, err := Parse(buf[:n])
 		if err != nil {
@@ -138,16 +205,63 @@ func (r *Receiver) readPackets(ctx context.Context) {
 		}
 
 		packet.SourceAddr = src
+		if cm != nil {
+			packet.Destination = cm.Dst
+		}
+
+		// Sync packets go to the sync address's own multicast group
+		if packet.SyncAddress != 0 && !r.syncGroups[packet.SyncAddress] {
+			r.syncGroups[packet.SyncAddress] = true
+			r.joinMulticastGroups(packet.SyncAddress, packet.SyncAddress)
+		}
 
 		// Try to send packet, drop if channel is full
 		select {
 		case r.packets <- packet:
 		default:
 			// Channel full, drop packet
+			r.dropped.Add(1)
+			if r.onDrop != nil {
+				r.onDrop(packet)
+			}
 		}
 	}
 }
 
+// handleExtended parses an extended packet and forwards it to its channel
+func (r *Receiver) handleExtended(data []byte, src net.Addr) {
+	if IsSync(data) {
+		syncPacket, err := ParseSync(data)
+		if err != nil {
+			return
+		}
+		syncPacket.SourceAddr = src
+
+		select {
+		case r.syncs <- syncPacket:
+		default:
+			r.dropped.Add(1)
+		}
+		return
+	}
+
+	discovery, err := ParseDiscovery(data)
+	if err != nil {
+		// Silently drop invalid or unsupported extended packets
+		return
+	}
+
+	discovery.SourceAddr = src
+
+	// Try to send packet, drop if channel is full. Only conformance mode
+	// reads discovery, and sources repeat it every few seconds, so an
+	// overflow isn't counted as a receiver drop.
+	select {
+	case r.discovery <- discovery:
+	default:
+	}
+}
+
 // Stop stops the receiver
 func (r *Receiver) Stop() {
 	r.mu.Lock()
@@ -1,6 +1,7 @@
 package sacn
 
 import (
+	"fmt"
 	"net"
 	"time"
 )
@@ -14,8 +15,66 @@ const (
 	E131FramingVector = 0x00000002
 	E131DMPVector     = 0x02
 	E131MulticastBase = "239.255."
+
+	// Extended packets (synchronization and universe discovery)
+	E131ExtendedRootVector  = 0x00000008
+	E131DiscoveryVector     = 0x00000002
+	E131DiscoveryListVector = 0x00000001
+	E131DiscoveryUniverse   = 64214
+	E131DiscoveryHeaderSize = 120
+	E131DiscoveryInterval   = 10 * time.Second
+	E131SyncVector          = 0x00000001
+	E131SyncPacketSize      = 49
+
+	// Universe range usable for data, per E1.31 section 6.2.7
+	E131MinUniverse = 1
+	E131MaxUniverse = 63999
+
+	// Priority range, per E1.31 section 6.2.3
+	E131MaxPriority = 200
+
+	// E131NetworkDataLossTimeout is how long a receiver waits before
+	// considering a source lost
+	E131NetworkDataLossTimeout = 2500 * time.Millisecond
+)
+
+// Framing layer option bits (offset 112)
+const (
+	OptionPreviewData      = 0x80
+	OptionStreamTerminated = 0x40
+	OptionForceSync        = 0x20
+)
+
+// Start codes of the DMX data (offset 125)
+const (
+	StartCodeDMX                = 0x00
+	StartCodePerAddressPriority = 0xDD // Per-channel priorities instead of levels
+	StartCodeText               = 0x17 // ASCII text packet
+	StartCodeRDM                = 0xCC // Remote Device Management
+	StartCodeSystemInfo         = 0xCF // System information packet
 )
 
+// StartCodeName returns a description of a start code, e.g. "0xDD
+// (per-address priority)"
+func StartCodeName(code uint8) string {
+	var name string
+	switch code {
+	case StartCodeDMX:
+		name = "DMX"
+	case StartCodePerAddressPriority:
+		name = "per-address priority"
+	case StartCodeText:
+		name = "text"
+	case StartCodeRDM:
+		name = "RDM"
+	case StartCodeSystemInfo:
+		name = "system information"
+	default:
+		return fmt.Sprintf("0x%02X", code)
+	}
+	return fmt.Sprintf("0x%02X (%s)", code, name)
+}
+
 // ACNPacketIdentifier is the magic bytes for E1.31 packets
 var ACNPacketIdentifier = []byte{0x41, 0x53, 0x43, 0x2d, 0x45, 0x31, 0x2e, 0x31, 0x37, 0x00, 0x00, 0x00}
 
@@ -25,18 +84,22 @@ type Packet struct {
 	CID [16]byte // Component Identifier (UUID)
 
 	// Framing layer
-	SourceName string
-	Priority   uint8
-	Sequence   uint8
-	Universe   uint16
+	SourceName  string
+	Priority    uint8
+	SyncAddress uint16 // Universe carrying the sync packets that release this data, 0 = unsynchronized
+	Sequence    uint8
+	Options     uint8
+	Universe    uint16
 
 	// DMP layer
 	StartCode   uint8
 	ChannelData []byte // DMX channel values (up to 512)
 
 	// Metadata
-	SourceAddr net.Addr
-	ReceivedAt time.Time
+	SourceAddr  net.Addr
+	Destination net.IP // Address the packet was sent to, nil if unknown
+	ReceivedAt  time.Time
+	Length      int // Size of the UDP payload in bytes
 }
 
 // ChannelCount returns the number of channels in this packet
@@ -44,6 +107,64 @@ func (p *Packet) ChannelCount() int {
 	return len(p.ChannelData)
 }
 
+// SourceIP returns the IP address the packet was received from, or "" if unknown
+func (p *Packet) SourceIP() string {
+	if addr, ok := p.SourceAddr.(*net.UDPAddr); ok {
+		return addr.IP.String()
+	}
+	return ""
+}
+
+// Transport returns how the packet was addressed: "multicast", "broadcast"
+// or "unicast", or "" if the destination is unknown
+func (p *Packet) Transport() string {
+	switch {
+	case p.Destination == nil:
+		return ""
+	case p.Destination.IsMulticast():
+		return "multicast"
+	case p.Destination.Equal(net.IPv4bcast):
+		return "broadcast"
+	default:
+		return "unicast"
+	}
+}
+
+// PreviewData reports whether the packet is flagged as preview data
+func (p *Packet) PreviewData() bool {
+	return p.Options&OptionPreviewData != 0
+}
+
+// StreamTerminated reports whether the source has flagged the stream as terminated
+func (p *Packet) StreamTerminated() bool {
+	return p.Options&OptionStreamTerminated != 0
+}
+
+// DiscoveryPacket represents a parsed E1.31 universe discovery packet
+type DiscoveryPacket struct {
+	CID        [16]byte
+	SourceName string
+	Page       uint8
+	LastPage   uint8
+	Universes  []uint16 // Universes the source is transmitting on this page
+
+	// Metadata
+	SourceAddr net.Addr
+	ReceivedAt time.Time
+}
+
+// SyncPacket represents a parsed E1.31 synchronization packet, which releases
+// data held by receivers on universes using its sync address
+type SyncPacket struct {
+	CID         [16]byte
+	Sequence    uint8
+	SyncAddress uint16
+
+	// Metadata
+	SourceAddr net.Addr
+	ReceivedAt time.Time
+}
+
 // ParseError represents an error during packet parsing
 type ParseError struct {
 	Message string
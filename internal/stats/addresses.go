@@ -0,0 +1,151 @@
+package stats
+
+import (
+	"sort"
+	"time"
+)
+
+// duplicateCIDWindow is how recently two addresses must both have sent
+// with the same CID for it to count as duplicated
+const duplicateCIDWindow = 5 * time.Second
+
+// maxAddressChanges is the number of address changes kept in the log
+const maxAddressChanges = 200
+
+// AddressChange records a CID starting to arrive from a new address, e.g.
+// after a DHCP lease change, or from a spoofed or duplicated source
+type AddressChange struct {
+	Time     time.Time
+	CID      [16]byte
+	Previous string
+	Current  string
+}
+
+// sourceAddress is the current and previous address of a CID
+type sourceAddress struct {
+	current   string
+	previous  string
+	changedAt time.Time
+	transport string
+}
+
+// RecordSourceAddress records the IP address a CID's packets arrive from.
+// CIDs are meant to be unique per source, so the same CID arriving from two
+// addresses at once indicates cloned configurations. An address not seen
+// within the duplicate window is logged as an address change.
+func (t *Tracker) RecordSourceAddress(sourceCID [16]byte, addr string) {
+	if addr == "" {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.clock.Now()
+	seen, exists := t.addresses[sourceCID]
+	if !exists {
+		seen = make(map[string]time.Time)
+		t.addresses[sourceCID] = seen
+	}
+	lastSeen, known := seen[addr]
+	seen[addr] = now
+
+	current := t.sourceAddrs[sourceCID]
+	if current.current == addr {
+		return
+	}
+	// Alternating between addresses that are all live is a duplicated CID,
+	// not a change
+	if current.current != "" && (!known || now.Sub(lastSeen) > duplicateCIDWindow) {
+		current.previous = current.current
+		current.changedAt = now
+		change := AddressChange{
+			Time:     now,
+			CID:      sourceCID,
+			Previous: current.previous,
+			Current:  addr,
+		}
+		t.addressChanges = append(t.addressChanges, change)
+		t.notify(Notification{Kind: AddressChanged, Time: now, CID: sourceCID, Address: change})
+		if len(t.addressChanges) > maxAddressChanges {
+			t.addressChanges = t.addressChanges[len(t.addressChanges)-maxAddressChanges:]
+		}
+	}
+	current.current = addr
+	t.sourceAddrs[sourceCID] = current
+}
+
+// RecordSourceTransport records how a CID's latest packet was addressed,
+// e.g. "multicast" or "unicast"
+func (t *Tracker) RecordSourceTransport(sourceCID [16]byte, transport string) {
+	if transport == "" {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	current := t.sourceAddrs[sourceCID]
+	current.transport = transport
+	t.sourceAddrs[sourceCID] = current
+}
+
+// GetAddressChanges returns up to n address changes, newest first. n <= 0
+// returns all.
+func (t *Tracker) GetAddressChanges(n int) []AddressChange {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if n <= 0 || n > len(t.addressChanges) {
+		n = len(t.addressChanges)
+	}
+	result := make([]AddressChange, n)
+	for i := 0; i < n; i++ {
+		result[i] = t.addressChanges[len(t.addressChanges)-1-i]
+	}
+	return result
+}
+
+// GetSourceAddresses returns the addresses a CID was seen from within the
+// duplicate detection window, sorted
+func (t *Tracker) GetSourceAddresses(sourceCID [16]byte) []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.recentAddresses(sourceCID, t.clock.Now())
+}
+
+// IsDuplicateCID reports whether a CID is currently arriving from more than
+// one address
+func (t *Tracker) IsDuplicateCID(sourceCID [16]byte) bool {
+	return len(t.GetSourceAddresses(sourceCID)) > 1
+}
+
+// GetDuplicateCIDs returns every CID currently arriving from more than one
+// address, with those addresses
+func (t *Tracker) GetDuplicateCIDs() map[[16]byte][]string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	now := t.clock.Now()
+	result := make(map[[16]byte][]string)
+	for cid := range t.addresses {
+		if addrs := t.recentAddresses(cid, now); len(addrs) > 1 {
+			result[cid] = addrs
+		}
+	}
+	return result
+}
+
+// recentAddresses returns the addresses seen for a CID within the window.
+// Caller must hold t.mu.
+func (t *Tracker) recentAddresses(sourceCID [16]byte, now time.Time) []string {
+	cutoff := now.Add(-duplicateCIDWindow)
+	var addrs []string
+	for addr, lastSeen := range t.addresses[sourceCID] {
+		if lastSeen.After(cutoff) {
+			addrs = append(addrs, addr)
+		}
+	}
+	sort.Strings(addrs)
+	return addrs
+}
@@ -0,0 +1,125 @@
+package stats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTracker_DuplicateCID(t *testing.T) {
+	tracker := NewTracker()
+	cid := [16]byte{1, 2, 3, 4}
+
+	tracker.RecordPacket(1, cid, "console", 0)
+	tracker.RecordSourceAddress(cid, "10.0.0.1")
+	tracker.RecordSourceAddress(cid, "10.0.0.1")
+	if tracker.IsDuplicateCID(cid) {
+		t.Fatal("IsDuplicateCID() = true for a single address, want false")
+	}
+
+	tracker.RecordSourceAddress(cid, "10.0.0.2")
+	if !tracker.IsDuplicateCID(cid) {
+		t.Fatal("IsDuplicateCID() = false for two addresses, want true")
+	}
+
+	sources := tracker.GetSources(1)
+	if len(sources) != 1 || !sources[0].DuplicateCID {
+		t.Fatalf("GetSources(1) = %+v, want one source flagged as duplicate", sources)
+	}
+	if got := sources[0].Addresses; len(got) != 2 || got[0] != "10.0.0.1" || got[1] != "10.0.0.2" {
+		t.Errorf("Addresses = %v, want [10.0.0.1 10.0.0.2]", got)
+	}
+
+	dups := tracker.GetDuplicateCIDs()
+	if len(dups[cid]) != 2 {
+		t.Errorf("GetDuplicateCIDs()[cid] = %v, want two addresses", dups[cid])
+	}
+}
+
+func TestTracker_DuplicateCID_StaleAddressExpires(t *testing.T) {
+	tracker := NewTracker()
+	cid := [16]byte{1, 2, 3, 4}
+
+	tracker.RecordSourceAddress(cid, "10.0.0.1")
+	tracker.mu.Lock()
+	tracker.addresses[cid]["10.0.0.1"] = time.Now().Add(-2 * duplicateCIDWindow)
+	tracker.mu.Unlock()
+	tracker.RecordSourceAddress(cid, "10.0.0.2")
+
+	if tracker.IsDuplicateCID(cid) {
+		t.Error("IsDuplicateCID() = true after the old address went silent, want false")
+	}
+	if got := tracker.GetSourceAddresses(cid); len(got) != 1 || got[0] != "10.0.0.2" {
+		t.Errorf("GetSourceAddresses() = %v, want [10.0.0.2]", got)
+	}
+}
+
+func TestTracker_RecordSourceAddress_IgnoresEmpty(t *testing.T) {
+	tracker := NewTracker()
+	cid := [16]byte{1}
+
+	tracker.RecordSourceAddress(cid, "")
+	if got := tracker.GetSourceAddresses(cid); len(got) != 0 {
+		t.Errorf("GetSourceAddresses() = %v, want none", got)
+	}
+}
+
+func TestTracker_AddressChange(t *testing.T) {
+	tracker := NewTracker()
+	cid := [16]byte{1, 2, 3, 4}
+
+	tracker.RecordPacket(1, cid, "console", 0)
+	tracker.RecordSourceAddress(cid, "10.0.0.1")
+	tracker.RecordSourceAddress(cid, "10.0.0.1")
+	if got := tracker.GetAddressChanges(0); len(got) != 0 {
+		t.Fatalf("GetAddressChanges() = %+v for a single address, want none", got)
+	}
+
+	// The old address going silent, then a new one (e.g. a new DHCP lease)
+	tracker.mu.Lock()
+	tracker.addresses[cid]["10.0.0.1"] = time.Now().Add(-2 * duplicateCIDWindow)
+	tracker.mu.Unlock()
+	tracker.RecordSourceAddress(cid, "10.0.0.7")
+
+	changes := tracker.GetAddressChanges(0)
+	if len(changes) != 1 || changes[0].Previous != "10.0.0.1" || changes[0].Current != "10.0.0.7" || changes[0].CID != cid {
+		t.Fatalf("GetAddressChanges() = %+v, want one change from 10.0.0.1 to 10.0.0.7", changes)
+	}
+
+	src := tracker.GetSources(1)[0]
+	if src.Address != "10.0.0.7" || src.PreviousAddress != "10.0.0.1" || src.AddressChangedAt.IsZero() {
+		t.Errorf("source addresses = %q (was %q) at %v, want 10.0.0.7 (was 10.0.0.1)", src.Address, src.PreviousAddress, src.AddressChangedAt)
+	}
+}
+
+func TestTracker_AddressChange_DuplicateLoggedOnce(t *testing.T) {
+	tracker := NewTracker()
+	cid := [16]byte{1, 2, 3, 4}
+
+	// Two consoles with the same CID interleave their packets
+	for i := 0; i < 10; i++ {
+		tracker.RecordSourceAddress(cid, "10.0.0.1")
+		tracker.RecordSourceAddress(cid, "10.0.0.2")
+	}
+
+	if got := tracker.GetAddressChanges(0); len(got) != 1 {
+		t.Errorf("GetAddressChanges() = %+v, want only the first appearance of the second address", got)
+	}
+}
+
+func TestTracker_RecordSourceTransport(t *testing.T) {
+	tracker := NewTracker()
+	cid := [16]byte{1, 2, 3, 4}
+
+	tracker.RecordPacket(1, cid, "console", 0)
+	tracker.RecordSourceAddress(cid, "10.0.0.1")
+	tracker.RecordSourceTransport(cid, "multicast")
+	tracker.RecordSourceTransport(cid, "")
+
+	sources := tracker.GetSources(1)
+	if len(sources) != 1 {
+		t.Fatalf("GetSources(1) returned %d sources, want 1", len(sources))
+	}
+	if sources[0].Address != "10.0.0.1" || sources[0].Transport != "multicast" {
+		t.Errorf("Address, Transport = %q, %q, want 10.0.0.1, multicast", sources[0].Address, sources[0].Transport)
+	}
+}
@@ -0,0 +1,84 @@
+package stats
+
+// Aggregate is statistics summed over several universes
+type Aggregate struct {
+	Universes   int // Universes with any statistics
+	Sources     int // Distinct source CIDs
+	PacketRate  float64
+	ByteRate    float64
+	Bytes       uint64
+	PacketCount uint64
+	LostPackets uint64
+	OutOfOrder  uint64
+	Duplicates  uint64
+
+	// Packets dropped locally by the receiver, apart from network loss
+	ReceiverDrops uint64
+
+	recent counts // Over the loss window
+}
+
+// LossPercentage returns the cumulative loss percentage over all universes
+func (a Aggregate) LossPercentage() float64 {
+	total := a.PacketCount + a.LostPackets
+	if total == 0 {
+		return 0
+	}
+	return float64(a.LostPackets) / float64(total) * 100
+}
+
+// RecentLossPercentage returns the loss percentage over the last minute
+func (a Aggregate) RecentLossPercentage() float64 {
+	return a.recent.lossPercentage()
+}
+
+// Aggregate sums statistics over the given universes. Universes without
+// statistics are skipped.
+func (t *Tracker) Aggregate(ids []uint16) Aggregate {
+	var agg Aggregate
+	cids := make(map[[16]byte]bool)
+	now := t.clock.Now()
+
+	for _, id := range ids {
+		t.mu.RLock()
+		stats := t.universes[id]
+		t.mu.RUnlock()
+		if stats == nil {
+			continue
+		}
+
+		stats.mu.RLock()
+		agg.Universes++
+		agg.PacketCount += stats.PacketCount
+		agg.LostPackets += stats.LostPackets
+		agg.OutOfOrder += stats.OutOfOrder
+		agg.Duplicates += stats.Duplicates
+		agg.ReceiverDrops += stats.ReceiverDrops
+		agg.Bytes += stats.Bytes
+		for cid := range stats.Sources {
+			cids[cid] = true
+		}
+
+		rate := stats.rateCounts.sum(now)
+		agg.PacketRate += float64(rate.Received)
+		agg.ByteRate += float64(rate.Bytes)
+		agg.recent.add(stats.lossCounts.sum(now))
+		stats.mu.RUnlock()
+	}
+
+	agg.PacketRate /= t.rateWindow.Seconds()
+	agg.ByteRate /= t.rateWindow.Seconds()
+	agg.Sources = len(cids)
+	return agg
+}
+
+// Totals sums statistics over every tracked universe, for network-wide
+// figures
+func (t *Tracker) Totals() Aggregate {
+	return t.Aggregate(t.GetAllUniverseIDs())
+}
+
+// GetTotalPacketRate returns packets per second received over all universes
+func (t *Tracker) GetTotalPacketRate() float64 {
+	return t.Totals().PacketRate
+}
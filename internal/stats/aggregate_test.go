@@ -0,0 +1,84 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTracker_Aggregate(t *testing.T) {
+	tracker := NewTracker()
+	cidA := [16]byte{1}
+	cidB := [16]byte{2}
+
+	tracker.RecordPacket(1, cidA, "a", 0)
+	tracker.RecordPacket(1, cidA, "a", 1)
+	tracker.RecordPacket(2, cidA, "a", 0)
+	tracker.RecordPacket(2, cidB, "b", 0)
+	tracker.RecordPacket(2, cidB, "b", 3) // 2 lost
+	tracker.RecordPacket(3, cidB, "b", 0) // not in the group
+
+	agg := tracker.Aggregate([]uint16{1, 2, 99})
+
+	if agg.Universes != 2 {
+		t.Errorf("Universes = %d, want 2", agg.Universes)
+	}
+	if agg.Sources != 2 {
+		t.Errorf("Sources = %d, want 2", agg.Sources)
+	}
+	if agg.PacketCount != 5 {
+		t.Errorf("PacketCount = %d, want 5", agg.PacketCount)
+	}
+	if agg.LostPackets != 2 {
+		t.Errorf("LostPackets = %d, want 2", agg.LostPackets)
+	}
+	if agg.PacketRate != 5 {
+		t.Errorf("PacketRate = %f, want 5", agg.PacketRate)
+	}
+	if got, want := agg.LossPercentage(), 2.0/7*100; math.Abs(got-want) > 1e-9 {
+		t.Errorf("LossPercentage() = %f, want %f", got, want)
+	}
+	if got, want := agg.RecentLossPercentage(), 2.0/7*100; math.Abs(got-want) > 1e-9 {
+		t.Errorf("RecentLossPercentage() = %f, want %f", got, want)
+	}
+}
+
+func TestTracker_Aggregate_Empty(t *testing.T) {
+	agg := NewTracker().Aggregate([]uint16{1})
+	if agg.Universes != 0 || agg.LossPercentage() != 0 || agg.RecentLossPercentage() != 0 {
+		t.Errorf("Aggregate() = %+v, want empty", agg)
+	}
+}
+
+func TestTracker_Aggregate_Bytes(t *testing.T) {
+	tracker := NewTracker()
+	tracker.RecordPacket(1, [16]byte{1}, "a", 0)
+	tracker.RecordBytes(1, [16]byte{1}, 600)
+	tracker.RecordPacket(2, [16]byte{1}, "a", 0)
+	tracker.RecordBytes(2, [16]byte{1}, 400)
+
+	agg := tracker.Aggregate([]uint16{1, 2})
+	if agg.Bytes != 1000 || agg.ByteRate != 1000 {
+		t.Errorf("Bytes/ByteRate = %d/%f, want 1000/1000", agg.Bytes, agg.ByteRate)
+	}
+}
+
+func TestTracker_Totals(t *testing.T) {
+	tracker := NewTracker()
+	cidA := [16]byte{1}
+	cidB := [16]byte{2}
+
+	tracker.RecordPacket(1, cidA, "console", 0)
+	tracker.RecordPacket(1, cidA, "console", 1)
+	tracker.RecordPacket(2, cidA, "console", 0)
+	tracker.RecordPacket(3, cidB, "backup", 0)
+	tracker.RecordPacket(3, cidB, "backup", 2) // 1 lost
+	tracker.RecordBytes(3, cidB, 638)
+
+	totals := tracker.Totals()
+	if totals.Universes != 3 || totals.Sources != 2 || totals.PacketCount != 5 || totals.LostPackets != 1 || totals.Bytes != 638 {
+		t.Errorf("Totals() = %+v, want 3 universes, 2 sources, 5 packets, 1 lost, 638 bytes", totals)
+	}
+	if got := tracker.GetTotalPacketRate(); got != 5 {
+		t.Errorf("GetTotalPacketRate() = %f, want 5", got)
+	}
+}
@@ -0,0 +1,91 @@
+package stats
+
+import "time"
+
+// RecordBytes records the size of a received packet, counted per universe
+// and per source. Every packet on the wire counts, duplicates included.
+func (t *Tracker) RecordBytes(universeID uint16, sourceCID [16]byte, n int) {
+	if n <= 0 {
+		return
+	}
+	stats := t.getOrCreate(universeID)
+
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	now := t.clock.Now()
+	stats.Bytes += uint64(n)
+	if source, exists := stats.Sources[sourceCID]; exists {
+		source.Bytes += uint64(n)
+	}
+
+	stats.rateCounts.record(now, counts{Bytes: uint64(n)})
+	stats.peak.observe(now, stats.rateCounts.sum(now), t.rateWindow)
+	t.recordTotal(now, counts{Bytes: uint64(n)})
+	stats.sourceWindow(sourceCID, t.rateWindow).record(now, counts{Bytes: uint64(n)})
+}
+
+// sourceWindow returns a source's rate window, creating it on first use.
+// Caller must hold stats.mu for writing.
+func (stats *UniverseStats) sourceWindow(sourceCID [16]byte, span time.Duration) *bucketWindow {
+	window, ok := stats.sourceCounts[sourceCID]
+	if !ok {
+		window = newBucketWindow(span, rateBuckets)
+		stats.sourceCounts[sourceCID] = window
+	}
+	return window
+}
+
+// GetByteRate returns bytes per second received for a universe
+func (t *Tracker) GetByteRate(universeID uint16) float64 {
+	return t.byteRate(universeID, nil)
+}
+
+// GetSourceByteRate returns bytes per second received from one source on a universe
+func (t *Tracker) GetSourceByteRate(universeID uint16, sourceCID [16]byte) float64 {
+	return t.byteRate(universeID, &sourceCID)
+}
+
+// GetTotalByteRate returns bytes per second received over all universes
+func (t *Tracker) GetTotalByteRate() float64 {
+	var total float64
+	for _, id := range t.GetAllUniverseIDs() {
+		total += t.GetByteRate(id)
+	}
+	return total
+}
+
+// GetTotalBytes returns the bytes received over all universes
+func (t *Tracker) GetTotalBytes() uint64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var total uint64
+	for _, stats := range t.universes {
+		stats.mu.RLock()
+		total += stats.Bytes
+		stats.mu.RUnlock()
+	}
+	return total
+}
+
+// byteRate sums the bytes in the rate window of a universe, optionally for
+// one source only
+func (t *Tracker) byteRate(universeID uint16, sourceCID *[16]byte) float64 {
+	t.mu.RLock()
+	stats := t.universes[universeID]
+	t.mu.RUnlock()
+
+	if stats == nil {
+		return 0
+	}
+
+	stats.mu.RLock()
+	defer stats.mu.RUnlock()
+
+	window := stats.rateCounts
+	if sourceCID != nil {
+		window = stats.sourceCounts[*sourceCID]
+	}
+	return float64(window.sum(t.clock.Now()).Bytes) / t.rateWindow.Seconds()
+}
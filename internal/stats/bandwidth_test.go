@@ -0,0 +1,52 @@
+package stats
+
+import "testing"
+
+func TestTracker_RecordBytes(t *testing.T) {
+	tracker := NewTracker()
+	cidA := [16]byte{1}
+	cidB := [16]byte{2}
+
+	tracker.RecordPacket(1, cidA, "a", 0)
+	tracker.RecordBytes(1, cidA, 638)
+	tracker.RecordPacket(1, cidB, "b", 0)
+	tracker.RecordBytes(1, cidB, 200)
+	tracker.RecordPacket(2, cidA, "a", 0)
+	tracker.RecordBytes(2, cidA, 638)
+
+	if got := tracker.GetByteRate(1); got != 838 {
+		t.Errorf("GetByteRate(1) = %f, want 838", got)
+	}
+	if got := tracker.GetSourceByteRate(1, cidB); got != 200 {
+		t.Errorf("GetSourceByteRate(1, b) = %f, want 200", got)
+	}
+	if got := tracker.GetTotalByteRate(); got != 1476 {
+		t.Errorf("GetTotalByteRate() = %f, want 1476", got)
+	}
+	if got := tracker.GetTotalBytes(); got != 1476 {
+		t.Errorf("GetTotalBytes() = %d, want 1476", got)
+	}
+
+	for _, s := range tracker.GetSources(1) {
+		if s.CID == cidA && s.Bytes != 638 {
+			t.Errorf("source a Bytes = %d, want 638", s.Bytes)
+		}
+	}
+
+	tracker.ResetUniverseStats(1)
+	if got := tracker.GetByteRate(1); got != 0 {
+		t.Errorf("GetByteRate(1) = %f after reset, want 0", got)
+	}
+}
+
+func TestTracker_RecordBytes_UnknownUniverse(t *testing.T) {
+	tracker := NewTracker()
+	tracker.RecordBytes(5, [16]byte{1}, 100)
+
+	if got := tracker.GetByteRate(5); got != 100 {
+		t.Errorf("GetByteRate(5) = %f, want 100", got)
+	}
+	if got := tracker.GetByteRate(6); got != 0 {
+		t.Errorf("GetByteRate(6) = %f, want 0", got)
+	}
+}
@@ -0,0 +1,114 @@
+package stats
+
+import (
+	"sort"
+	"time"
+)
+
+// SourceComparison is one row of a side-by-side comparison of the sources
+// sending to a universe
+type SourceComparison struct {
+	CID                [16]byte
+	Name               string
+	Address            string
+	PacketRate         float64
+	LossPercentage     float64
+	Priority           uint8
+	PerAddressPriority bool
+	Slots              int       // Footprint: slots in the latest data packet
+	LastChange         time.Time // When the source's levels last changed
+	LastSeen           time.Time
+	Online             bool // Sent within the data loss timeout
+
+	// Active marks the online sources with the highest priority, whose
+	// data receivers use; several are active when they tie (merging)
+	Active bool
+}
+
+// RecordData records the levels of a source's data packet on a universe,
+// for its footprint and when its levels last changed. It expects the
+// packet to have been recorded already; data of unknown sources is ignored.
+func (t *Tracker) RecordData(universeID uint16, sourceCID [16]byte, data []byte) {
+	t.mu.RLock()
+	stats := t.universes[universeID]
+	t.mu.RUnlock()
+
+	if stats == nil {
+		return
+	}
+
+	// FNV-1a over the slot count and levels, so a change needs no copy
+	hash := uint64(14695981039346656037) ^ uint64(len(data))
+	for _, b := range data {
+		hash ^= uint64(b)
+		hash *= 1099511628211
+	}
+
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	source := stats.Sources[sourceCID]
+	if source == nil {
+		return
+	}
+	if source.LastChange.IsZero() || hash != source.dataHash {
+		source.LastChange = t.clock.Now()
+		source.dataHash = hash
+	}
+	source.Slots = len(data)
+}
+
+// CompareSources returns the sources of a universe side by side, active
+// sources first, then by priority and name
+func (t *Tracker) CompareSources(universeID uint16) []SourceComparison {
+	t.mu.RLock()
+	stats := t.universes[universeID]
+	t.mu.RUnlock()
+
+	if stats == nil {
+		return nil
+	}
+
+	now := t.clock.Now()
+	var rows []SourceComparison
+	var highest uint8
+	stats.mu.RLock()
+	for cid, s := range stats.Sources {
+		row := SourceComparison{
+			CID:                cid,
+			Name:               s.Name,
+			PacketRate:         float64(stats.sourceCounts[cid].sum(now).Received) / t.rateWindow.Seconds(),
+			LossPercentage:     lossPercentage(s.PacketCount, s.LostPackets),
+			Priority:           s.Priority,
+			PerAddressPriority: s.PerAddressPriority,
+			Slots:              s.Slots,
+			LastChange:         s.LastChange,
+			LastSeen:           s.LastSeen,
+			Online:             s.PacketCount > 0 && now.Sub(s.LastSeen) <= dataLossTimeout,
+		}
+		if row.Online {
+			highest = max(highest, row.Priority)
+		}
+		rows = append(rows, row)
+	}
+	stats.mu.RUnlock()
+
+	t.mu.RLock()
+	for i := range rows {
+		rows[i].Address = t.sourceAddrs[rows[i].CID].current
+		rows[i].Active = rows[i].Online && rows[i].Priority == highest
+	}
+	t.mu.RUnlock()
+
+	sort.Slice(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		if a.Active != b.Active {
+			return a.Active
+		}
+		if a.Priority != b.Priority {
+			return a.Priority > b.Priority
+		}
+		return a.Name < b.Name
+	})
+	return rows
+}
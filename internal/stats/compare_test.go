@@ -0,0 +1,68 @@
+package stats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTracker_CompareSources(t *testing.T) {
+	tracker := NewTracker()
+	primary, backup, old := [16]byte{1}, [16]byte{2}, [16]byte{3}
+
+	tracker.RecordSourceAddress(primary, "10.0.0.1")
+	for i := range 3 {
+		tracker.RecordPacket(1, primary, "primary", uint8(i))
+		tracker.RecordPriority(1, primary, 120, false)
+		tracker.RecordData(1, primary, []byte{1, 2, 3})
+	}
+	tracker.RecordPacket(1, backup, "backup", 0)
+	tracker.RecordPriority(1, backup, 100, false)
+	tracker.RecordData(1, backup, []byte{1, 2})
+	tracker.RecordPacket(1, old, "old", 0)
+	tracker.RecordPriority(1, old, 200, false)
+	ageSource(tracker, 1, old, 5*time.Second) // Offline, so not active
+
+	rows := tracker.CompareSources(1)
+	if len(rows) != 3 {
+		t.Fatalf("len(CompareSources()) = %d, want 3", len(rows))
+	}
+	first := rows[0]
+	if first.Name != "primary" || !first.Active || first.Priority != 120 || first.Slots != 3 || first.Address != "10.0.0.1" {
+		t.Errorf("first = %+v, want active primary at 120 with 3 slots", first)
+	}
+	if first.PacketRate != 3 {
+		t.Errorf("primary rate = %v, want 3", first.PacketRate)
+	}
+	if rows[1].Name != "old" || rows[1].Active || rows[1].Online {
+		t.Errorf("second = %+v, want offline old source, not active", rows[1])
+	}
+	if rows[2].Name != "backup" || rows[2].Active || rows[2].Slots != 2 {
+		t.Errorf("third = %+v, want inactive backup with 2 slots", rows[2])
+	}
+	if tracker.CompareSources(2) != nil {
+		t.Error("CompareSources() of an unknown universe should be nil")
+	}
+}
+
+func TestTracker_RecordDataLastChange(t *testing.T) {
+	tracker := NewTracker()
+	cid := [16]byte{1}
+
+	tracker.RecordPacket(1, cid, "console", 0)
+	tracker.RecordData(1, cid, []byte{10, 20})
+	src := tracker.GetUniverseStats(1).Sources[cid]
+	first := src.LastChange
+	if first.IsZero() {
+		t.Fatal("LastChange not set by the first data")
+	}
+
+	src.LastChange = first.Add(-time.Minute)
+	tracker.RecordData(1, cid, []byte{10, 20})
+	if !src.LastChange.Equal(first.Add(-time.Minute)) {
+		t.Error("LastChange moved although the levels are the same")
+	}
+	tracker.RecordData(1, cid, []byte{10, 21})
+	if !src.LastChange.After(first.Add(-time.Minute)) {
+		t.Error("LastChange not updated by changed levels")
+	}
+}
@@ -0,0 +1,35 @@
+package stats
+
+// RecordReceiverDrop records a data packet the receiver dropped because
+// processing couldn't keep up. It counts as a receiver drop rather than
+// network loss: the sequence gap it leaves is not counted as lost. It must
+// be called before any later packet of the source is recorded; packets
+// queued ahead of it may still be recorded afterwards.
+func (t *Tracker) RecordReceiverDrop(universeID uint16, sourceCID [16]byte, sequence uint8) {
+	stats := t.getOrCreate(universeID)
+
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	stats.ReceiverDrops++
+	if source, ok := stats.Sources[sourceCID]; ok {
+		source.ReceiverDrops++
+		source.dropped[sequence] = t.clock.Now()
+	}
+}
+
+// GetReceiverDrops returns how many packets of a universe the receiver
+// dropped locally
+func (t *Tracker) GetReceiverDrops(universeID uint16) uint64 {
+	t.mu.RLock()
+	stats := t.universes[universeID]
+	t.mu.RUnlock()
+
+	if stats == nil {
+		return 0
+	}
+
+	stats.mu.RLock()
+	defer stats.mu.RUnlock()
+	return stats.ReceiverDrops
+}
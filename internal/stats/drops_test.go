@@ -0,0 +1,103 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"sacn-monitor/internal/clock"
+)
+
+func TestTracker_ReceiverDropsAreNotLoss(t *testing.T) {
+	tracker := NewTracker()
+	cid := [16]byte{1}
+
+	tracker.RecordPacket(1, cid, "console", 10)
+	tracker.RecordReceiverDrop(1, cid, 11)
+	tracker.RecordReceiverDrop(1, cid, 12)
+	tracker.RecordPacket(1, cid, "console", 14) // 11-12 dropped, 13 lost
+
+	if got := tracker.GetReceiverDrops(1); got != 2 {
+		t.Errorf("GetReceiverDrops() = %d, want 2", got)
+	}
+	stats := tracker.GetUniverseStats(1)
+	if stats.LostPackets != 1 {
+		t.Errorf("LostPackets = %d, want 1", stats.LostPackets)
+	}
+	if src := stats.Sources[cid]; src.ReceiverDrops != 2 || src.LostPackets != 1 {
+		t.Errorf("source drops/lost = %d/%d, want 2/1", src.ReceiverDrops, src.LostPackets)
+	}
+
+	events := tracker.GetLossEvents(0)
+	if len(events) != 1 || events[0].Lost != 1 {
+		t.Errorf("GetLossEvents() = %+v, want one event with 1 lost", events)
+	}
+
+	// A gap made up only of drops is no loss at all
+	tracker.RecordReceiverDrop(1, cid, 15)
+	tracker.RecordPacket(1, cid, "console", 16)
+	if stats.LostPackets != 1 || len(tracker.GetLossEvents(0)) != 1 {
+		t.Errorf("LostPackets = %d after a dropped packet, want 1", stats.LostPackets)
+	}
+
+	if totals := tracker.Totals(); totals.ReceiverDrops != 3 {
+		t.Errorf("Totals().ReceiverDrops = %d, want 3", totals.ReceiverDrops)
+	}
+}
+
+func TestTracker_ReceiverDropBeforeFirstPacket(t *testing.T) {
+	tracker := NewTracker()
+	cid := [16]byte{1}
+
+	// The source isn't known yet, so only the universe counts the drop
+	tracker.RecordReceiverDrop(1, cid, 0)
+	tracker.RecordPacket(1, cid, "console", 1)
+
+	if got := tracker.GetReceiverDrops(1); got != 1 {
+		t.Errorf("GetReceiverDrops() = %d, want 1", got)
+	}
+	if got := tracker.GetUniverseStats(1).LostPackets; got != 0 {
+		t.Errorf("LostPackets = %d, want 0", got)
+	}
+}
+
+func TestTracker_ReceiverDropWithQueuedPackets(t *testing.T) {
+	tracker := NewTracker()
+	cid := [16]byte{1}
+
+	for seq := 0; seq < 12; seq++ {
+		tracker.RecordPacket(1, cid, "console", uint8(seq))
+	}
+	// Sequence 12 is dropped while a full lap, starting with the previous
+	// 12, is still queued ahead of it
+	tracker.RecordReceiverDrop(1, cid, 12)
+	for seq := 12; seq < 256+12; seq++ {
+		tracker.RecordPacket(1, cid, "console", uint8(seq))
+	}
+	tracker.RecordPacket(1, cid, "console", 13)
+
+	if got := tracker.GetUniverseStats(1).LostPackets; got != 0 {
+		t.Errorf("LostPackets = %d, want 0", got)
+	}
+}
+
+func TestTracker_StaleReceiverDropMark(t *testing.T) {
+	tracker := NewTracker()
+	c := clock.NewManual(time.Unix(0, 0))
+	tracker.SetClock(c)
+	cid := [16]byte{1}
+
+	tracker.RecordPacket(1, cid, "console", 10)
+	tracker.RecordReceiverDrop(1, cid, 11)
+	tracker.RecordPacket(1, cid, "console", 11) // Queued ahead of the drop, from a lap earlier
+
+	// The drop's gap never came; a lap later the mark excuses nothing
+	for seq := 12; seq < 256+11; seq++ {
+		c.Advance(25 * time.Millisecond)
+		tracker.RecordPacket(1, cid, "console", uint8(seq))
+	}
+	tracker.RecordPacket(1, cid, "console", 12)
+
+	if got := tracker.GetUniverseStats(1).LostPackets; got != 1 {
+		t.Errorf("LostPackets = %d, want 1", got)
+	}
+}
@@ -0,0 +1,53 @@
+package stats
+
+import "time"
+
+// Flapping detection
+const (
+	// flapWindow is the period over which recent transitions are counted
+	flapWindow = 10 * time.Minute
+	// flapThreshold is how many transitions within the window make a
+	// source flapping
+	flapThreshold = 3
+	// flapHistory is how many transition times are kept per source
+	flapHistory = 16
+)
+
+// Flapping counts a source's offline→online transitions (returns after the
+// data loss timeout). A flapping gateway is a different failure from
+// steady low-level loss.
+type Flapping struct {
+	Total    uint64    // Transitions since first seen
+	Recent   int       // Transitions within the flap window
+	Since    time.Time // Oldest transition within the window
+	Flapping bool      // Recent reached the threshold
+}
+
+// flaps is a ring of a source's most recent transition times
+type flaps struct {
+	times [flapHistory]time.Time
+	next  int
+}
+
+// record adds a transition at now
+func (f *flaps) record(now time.Time) {
+	f.times[f.next] = now
+	f.next = (f.next + 1) % flapHistory
+}
+
+// Flapping returns the source's transition counts as of now
+func (s Source) Flapping(now time.Time) Flapping {
+	result := Flapping{Total: s.Uptime.Outages}
+	cutoff := now.Add(-flapWindow)
+	for _, at := range s.flaps.times {
+		if at.IsZero() || !at.After(cutoff) {
+			continue
+		}
+		result.Recent++
+		if result.Since.IsZero() || at.Before(result.Since) {
+			result.Since = at
+		}
+	}
+	result.Flapping = result.Recent >= flapThreshold
+	return result
+}
@@ -0,0 +1,49 @@
+package stats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSource_Flapping(t *testing.T) {
+	tracker := NewTracker()
+	cid := [16]byte{1}
+
+	tracker.RecordPacket(1, cid, "gateway", 0)
+	stats := tracker.GetUniverseStats(1)
+
+	// Drop out past the data loss timeout and come back three times
+	for seq := uint8(1); seq <= 3; seq++ {
+		stats.mu.Lock()
+		src := stats.Sources[cid]
+		src.LastSeen = src.LastSeen.Add(-3 * time.Second)
+		src.Uptime.LastSeen = src.Uptime.LastSeen.Add(-3 * time.Second)
+		stats.mu.Unlock()
+		tracker.RecordPacket(1, cid, "gateway", seq)
+	}
+
+	flapping := tracker.GetSources(1)[0].Flapping(time.Now())
+	if flapping.Total != 3 || flapping.Recent != 3 || !flapping.Flapping || flapping.Since.IsZero() {
+		t.Errorf("Flapping() = %+v, want 3 recent transitions, flapping", flapping)
+	}
+
+	// Transitions age out of the window
+	later := time.Now().Add(flapWindow + time.Second)
+	if got := tracker.GetSources(1)[0].Flapping(later); got.Recent != 0 || got.Flapping || got.Total != 3 {
+		t.Errorf("Flapping() after the window = %+v, want no recent transitions, 3 in total", got)
+	}
+}
+
+func TestFlaps_RingWraps(t *testing.T) {
+	var f flaps
+	start := time.Now()
+	for i := 0; i < flapHistory+4; i++ {
+		f.record(start.Add(time.Duration(i) * time.Second))
+	}
+
+	s := Source{flaps: f}
+	got := s.Flapping(start.Add(time.Minute))
+	if got.Recent != flapHistory || !got.Since.Equal(start.Add(4*time.Second)) {
+		t.Errorf("Flapping() = %+v, want the newest %d transitions", got, flapHistory)
+	}
+}
@@ -0,0 +1,91 @@
+package stats
+
+import "time"
+
+// Constants for gap tracking
+const (
+	// defaultGapThreshold is the inter-packet gap above which a gap is logged
+	defaultGapThreshold = 500 * time.Millisecond
+	// maxGapLog is the number of gaps kept in the log
+	maxGapLog = 500
+)
+
+// Gap is a pause in a source's packets on a universe longer than the gap threshold
+type Gap struct {
+	Universe uint16
+	CID      [16]byte
+	Source   string
+	Start    time.Time // When the last packet before the gap arrived
+	Duration time.Duration
+}
+
+// SetGapThreshold sets the inter-packet gap above which gaps are logged
+func (t *Tracker) SetGapThreshold(d time.Duration) {
+	t.logMu.Lock()
+	defer t.logMu.Unlock()
+	t.gapThreshold = d
+}
+
+// GetGaps returns up to n logged gaps, newest first. n <= 0 returns all.
+func (t *Tracker) GetGaps(n int) []Gap {
+	t.logMu.Lock()
+	defer t.logMu.Unlock()
+
+	if n <= 0 || n > len(t.gaps) {
+		n = len(t.gaps)
+	}
+	result := make([]Gap, n)
+	for i := 0; i < n; i++ {
+		result[i] = t.gaps[len(t.gaps)-1-i]
+	}
+	return result
+}
+
+// GetLongestGap returns the longest gap of any source on a universe
+func (t *Tracker) GetLongestGap(universeID uint16) time.Duration {
+	var longest time.Duration
+	for _, s := range t.GetSources(universeID) {
+		longest = max(longest, s.LongestGap)
+	}
+	return longest
+}
+
+// recordGap updates the source's longest gap and logs the gap if it
+// exceeds the threshold. Caller must hold stats.mu.
+func (t *Tracker) recordGap(stats *UniverseStats, source *Source, gap time.Duration) {
+	if gap > source.LongestGap {
+		source.LongestGap = gap
+		source.LongestGapAt = source.LastSeen
+	}
+
+	t.logMu.Lock()
+	defer t.logMu.Unlock()
+
+	if gap <= t.gapThreshold {
+		return
+	}
+	t.gaps = append(t.gaps, Gap{
+		Universe: stats.UniverseID,
+		CID:      source.CID,
+		Source:   source.Name,
+		Start:    source.LastSeen,
+		Duration: gap,
+	})
+	if len(t.gaps) > maxGapLog {
+		t.gaps = t.gaps[len(t.gaps)-maxGapLog:]
+	}
+}
+
+// removeGaps drops logged gaps of a universe
+func (t *Tracker) removeGaps(universeID uint16) {
+	t.logMu.Lock()
+	defer t.logMu.Unlock()
+
+	kept := t.gaps[:0]
+	for _, g := range t.gaps {
+		if g.Universe != universeID {
+			kept = append(kept, g)
+		}
+	}
+	t.gaps = kept
+}
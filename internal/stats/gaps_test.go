@@ -0,0 +1,63 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"sacn-monitor/internal/clock"
+)
+
+func TestTracker_Gaps(t *testing.T) {
+	c := clock.NewManual(time.Unix(1000, 0))
+	tracker := NewTracker()
+	tracker.SetClock(c)
+	tracker.SetGapThreshold(5 * time.Millisecond)
+	cid := [16]byte{1}
+
+	tracker.RecordPacket(1, cid, "console", 0)
+	c.Advance(time.Millisecond)
+	tracker.RecordPacket(1, cid, "console", 1) // no gap
+	c.Advance(10 * time.Millisecond)
+	tracker.RecordPacket(1, cid, "console", 2)
+
+	gaps := tracker.GetGaps(0)
+	if len(gaps) != 1 {
+		t.Fatalf("len(GetGaps()) = %d, want 1", len(gaps))
+	}
+	g := gaps[0]
+	if g.Universe != 1 || g.CID != cid || g.Source != "console" || g.Duration != 10*time.Millisecond {
+		t.Errorf("gap = %+v, want a 10ms gap from console on universe 1", g)
+	}
+
+	if got := tracker.GetLongestGap(1); got != g.Duration {
+		t.Errorf("GetLongestGap() = %v, want %v", got, g.Duration)
+	}
+	if s := tracker.GetSources(1)[0]; !s.LongestGapAt.Equal(g.Start) {
+		t.Errorf("LongestGapAt = %v, want %v", s.LongestGapAt, g.Start)
+	}
+
+	tracker.ResetUniverseStats(1)
+	if len(tracker.GetGaps(0)) != 0 || tracker.GetLongestGap(1) != 0 {
+		t.Error("gaps survived ResetUniverseStats")
+	}
+}
+
+func TestTracker_GetGaps_NewestFirstAndBounded(t *testing.T) {
+	tracker := NewTracker()
+	tracker.gaps = make([]Gap, maxGapLog)
+	tracker.SetGapThreshold(0)
+
+	tracker.RecordPacket(1, [16]byte{1}, "a", 0)
+	tracker.RecordPacket(1, [16]byte{1}, "a", 1)
+
+	gaps := tracker.GetGaps(0)
+	if len(gaps) != maxGapLog {
+		t.Errorf("len(GetGaps()) = %d, want %d", len(gaps), maxGapLog)
+	}
+	if gaps[0].Source != "a" {
+		t.Errorf("newest gap = %+v, want the one just recorded", gaps[0])
+	}
+	if got := tracker.GetGaps(3); len(got) != 3 {
+		t.Errorf("len(GetGaps(3)) = %d, want 3", len(got))
+	}
+}
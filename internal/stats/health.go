@@ -0,0 +1,64 @@
+package stats
+
+import "math"
+
+// Health score penalty caps, out of 100 points
+const (
+	maxLossPenalty     = 50 // Reached at 2.5% recent loss
+	maxJitterPenalty   = 20 // Reached when the jitter equals the mean interval
+	maxRatePenalty     = 20 // A sustained rate deviation
+	maxFlappingPenalty = 30 // Reached at 3 reconnects in the last minute
+)
+
+// Health is a 0-100 quality score of a universe, with the points taken off
+// for each problem
+type Health struct {
+	Score    int // 100 is perfect, 0 is stale or worse
+	Stale    bool
+	Loss     float64 // Penalty for recent packet loss
+	Jitter   float64 // Penalty for irregular packet timing
+	Rate     float64 // Penalty for the rate being off its expected rate
+	Flapping float64 // Penalty for sources dropping out and returning
+}
+
+// GetHealth scores a universe from its recent loss, jitter, rate stability
+// and source flapping. A universe without packets for the data loss timeout
+// scores 0.
+func (t *Tracker) GetHealth(universeID uint16) Health {
+	t.mu.RLock()
+	stats := t.universes[universeID]
+	t.mu.RUnlock()
+
+	if stats == nil {
+		return Health{Stale: true}
+	}
+
+	rate := t.GetRateStatus(universeID)
+
+	stats.mu.RLock()
+	now := t.clock.Now()
+	if now.Sub(stats.LastPacket) > dataLossTimeout {
+		stats.mu.RUnlock()
+		return Health{Stale: true}
+	}
+	recent := stats.lossCounts.sum(now)
+	jitter := stats.intervals.jitter()
+	stats.mu.RUnlock()
+
+	var h Health
+	h.Loss = min(recent.lossPercentage()*maxLossPenalty/2.5, maxLossPenalty)
+	if jitter.Mean > 0 {
+		h.Jitter = min(float64(jitter.StdDev)/float64(jitter.Mean)*maxJitterPenalty, maxJitterPenalty)
+	}
+	if rate.Deviating {
+		h.Rate = maxRatePenalty
+	} else if rate.Expected > 0 {
+		// Off-rate but not yet sustained counts for at most half
+		off := math.Abs(rate.Current-rate.Expected) / rate.Expected
+		h.Rate = min(off/rateTolerance, 1) * maxRatePenalty / 2
+	}
+	h.Flapping = min(float64(recent.Reconnects)*maxFlappingPenalty/3, maxFlappingPenalty)
+
+	h.Score = max(0, int(math.Round(100-h.Loss-h.Jitter-h.Rate-h.Flapping)))
+	return h
+}
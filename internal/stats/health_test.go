@@ -0,0 +1,67 @@
+package stats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTracker_GetHealth(t *testing.T) {
+	tracker := NewTracker()
+	cid := [16]byte{1}
+
+	if h := tracker.GetHealth(1); !h.Stale || h.Score != 0 {
+		t.Errorf("GetHealth() of unknown universe = %+v, want stale with score 0", h)
+	}
+
+	tracker.RecordPacket(1, cid, "console", 0)
+	stats := tracker.GetUniverseStats(1)
+
+	// Steady 44 Hz timing and no loss is perfect
+	stats.mu.Lock()
+	stats.intervals = &intervalWindow{}
+	for i := 0; i < 50; i++ {
+		stats.intervals.add(22727 * time.Microsecond)
+	}
+	stats.mu.Unlock()
+	if h := tracker.GetHealth(1); h.Score != 100 || h.Stale {
+		t.Errorf("healthy universe = %+v, want score 100", h)
+	}
+
+	// 2.5% loss takes the full loss penalty, a reconnect a third of flapping's
+	stats.mu.Lock()
+	now := time.Now()
+	stats.lossCounts.record(now, counts{Received: 974, Lost: 25})
+	stats.lossCounts.record(now, counts{Reconnects: 1})
+	stats.mu.Unlock()
+	h := tracker.GetHealth(1)
+	if h.Loss != maxLossPenalty || h.Flapping != 10 || h.Score != 40 {
+		t.Errorf("lossy, flapping universe = %+v, want loss 50, flapping 10, score 40", h)
+	}
+
+	// A universe without packets for the data loss timeout scores 0
+	stats.mu.Lock()
+	stats.LastPacket = now.Add(-3 * time.Second)
+	stats.mu.Unlock()
+	if h := tracker.GetHealth(1); !h.Stale || h.Score != 0 {
+		t.Errorf("stale universe = %+v, want stale with score 0", h)
+	}
+}
+
+func TestTracker_Reconnects(t *testing.T) {
+	tracker := NewTracker()
+	cid := [16]byte{1}
+
+	tracker.RecordPacket(1, cid, "console", 0)
+	stats := tracker.GetUniverseStats(1)
+	stats.mu.Lock()
+	stats.Sources[cid].LastSeen = time.Now().Add(-3 * time.Second)
+	stats.mu.Unlock()
+
+	tracker.RecordPacket(1, cid, "console", 1)
+
+	stats.mu.RLock()
+	defer stats.mu.RUnlock()
+	if got := stats.lossCounts.sum(time.Now()).Reconnects; got != 1 {
+		t.Errorf("Reconnects = %d, want 1 after the source returned", got)
+	}
+}
@@ -0,0 +1,97 @@
+package stats
+
+import (
+	"math"
+	"time"
+)
+
+// Constants for jitter tracking
+const (
+	// jitterSamples is how many recent inter-arrival times jitter is computed over
+	jitterSamples = 128
+	// maxJitterInterval excludes gaps longer than the E1.31 data loss
+	// timeout, which are outages rather than jitter
+	maxJitterInterval = 2500 * time.Millisecond
+)
+
+// Jitter describes the spread of inter-arrival times between packets
+type Jitter struct {
+	Mean    time.Duration // Average time between packets
+	StdDev  time.Duration // Standard deviation of the time between packets
+	Samples int           // Number of intervals measured
+}
+
+// intervalWindow keeps the most recent inter-arrival times in a ring
+type intervalWindow struct {
+	samples [jitterSamples]time.Duration
+	next    int
+	count   int
+}
+
+// add records an interval, ignoring outages
+func (w *intervalWindow) add(d time.Duration) {
+	if d <= 0 || d > maxJitterInterval {
+		return
+	}
+	w.samples[w.next] = d
+	w.next = (w.next + 1) % jitterSamples
+	if w.count < jitterSamples {
+		w.count++
+	}
+}
+
+// jitter computes the mean and standard deviation of the recorded intervals
+func (w *intervalWindow) jitter() Jitter {
+	if w == nil || w.count == 0 {
+		return Jitter{}
+	}
+
+	var sum float64
+	for _, d := range w.samples[:w.count] {
+		sum += float64(d)
+	}
+	mean := sum / float64(w.count)
+
+	var sq float64
+	for _, d := range w.samples[:w.count] {
+		diff := float64(d) - mean
+		sq += diff * diff
+	}
+
+	return Jitter{
+		Mean:    time.Duration(mean),
+		StdDev:  time.Duration(math.Sqrt(sq / float64(w.count))),
+		Samples: w.count,
+	}
+}
+
+// GetJitter returns the inter-arrival jitter of all packets on a universe
+func (t *Tracker) GetJitter(universeID uint16) Jitter {
+	t.mu.RLock()
+	stats := t.universes[universeID]
+	t.mu.RUnlock()
+
+	if stats == nil {
+		return Jitter{}
+	}
+
+	stats.mu.RLock()
+	defer stats.mu.RUnlock()
+	return stats.intervals.jitter()
+}
+
+// GetSourceJitter returns the inter-arrival jitter of one source's packets
+// on a universe
+func (t *Tracker) GetSourceJitter(universeID uint16, sourceCID [16]byte) Jitter {
+	t.mu.RLock()
+	stats := t.universes[universeID]
+	t.mu.RUnlock()
+
+	if stats == nil {
+		return Jitter{}
+	}
+
+	stats.mu.RLock()
+	defer stats.mu.RUnlock()
+	return stats.sourceIntervals[sourceCID].jitter()
+}
@@ -0,0 +1,79 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"sacn-monitor/internal/clock"
+)
+
+func TestIntervalWindow_Jitter(t *testing.T) {
+	var w intervalWindow
+	for _, d := range []time.Duration{20, 30, 20, 30} {
+		w.add(d * time.Millisecond)
+	}
+
+	j := w.jitter()
+	if j.Samples != 4 {
+		t.Errorf("Samples = %d, want 4", j.Samples)
+	}
+	if j.Mean != 25*time.Millisecond {
+		t.Errorf("Mean = %v, want 25ms", j.Mean)
+	}
+	if j.StdDev != 5*time.Millisecond {
+		t.Errorf("StdDev = %v, want 5ms", j.StdDev)
+	}
+}
+
+func TestIntervalWindow_IgnoresOutages(t *testing.T) {
+	var w intervalWindow
+	w.add(25 * time.Millisecond)
+	w.add(10 * time.Second)
+	w.add(0)
+
+	if j := w.jitter(); j.Samples != 1 || j.StdDev != 0 {
+		t.Errorf("jitter() = %+v, want one sample with no spread", j)
+	}
+}
+
+func TestIntervalWindow_KeepsRecentSamples(t *testing.T) {
+	var w intervalWindow
+	for i := 0; i < jitterSamples; i++ {
+		w.add(100 * time.Millisecond)
+	}
+	for i := 0; i < jitterSamples; i++ {
+		w.add(10 * time.Millisecond)
+	}
+
+	if j := w.jitter(); j.Samples != jitterSamples || j.Mean != 10*time.Millisecond {
+		t.Errorf("jitter() = %+v, want only the newest %d samples", j, jitterSamples)
+	}
+}
+
+func TestTracker_GetJitter(t *testing.T) {
+	c := clock.NewManual(time.Unix(1000, 0))
+	tracker := NewTracker()
+	tracker.SetClock(c)
+	cid := [16]byte{1}
+
+	if j := tracker.GetJitter(1); j.Samples != 0 {
+		t.Errorf("GetJitter() = %+v for unknown universe, want zero", j)
+	}
+
+	for seq := uint8(0); seq < 3; seq++ {
+		tracker.RecordPacket(1, cid, "test", seq)
+		c.Advance(2 * time.Millisecond)
+	}
+
+	if j := tracker.GetJitter(1); j.Samples != 2 || j.Mean != 2*time.Millisecond {
+		t.Errorf("GetJitter() = %+v, want 2 samples of 2ms", j)
+	}
+	if j := tracker.GetSourceJitter(1, cid); j.Samples != 2 {
+		t.Errorf("GetSourceJitter() = %+v, want 2 samples", j)
+	}
+
+	tracker.ResetUniverseStats(1)
+	if j := tracker.GetJitter(1); j.Samples != 0 {
+		t.Errorf("GetJitter() = %+v after reset, want zero", j)
+	}
+}
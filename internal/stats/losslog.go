@@ -0,0 +1,61 @@
+package stats
+
+import "time"
+
+// maxLossLog is the number of loss events kept in the log
+const maxLossLog = 1000
+
+// LossEvent records a sequence gap detected for a source
+type LossEvent struct {
+	Time     time.Time
+	Universe uint16
+	CID      [16]byte
+	Source   string
+	Lost     int // Number of packets missing
+
+	// Sequence numbers of the missing packets and the packets either side
+	FirstMissing  uint8
+	LastMissing   uint8
+	ReceivedAfter uint8
+	ReceivedNext  uint8
+}
+
+// GetLossEvents returns up to n loss events, newest first. n <= 0 returns all.
+func (t *Tracker) GetLossEvents(n int) []LossEvent {
+	t.logMu.Lock()
+	defer t.logMu.Unlock()
+
+	if n <= 0 || n > len(t.losses) {
+		n = len(t.losses)
+	}
+	result := make([]LossEvent, n)
+	for i := 0; i < n; i++ {
+		result[i] = t.losses[len(t.losses)-1-i]
+	}
+	return result
+}
+
+// recordLoss appends to the loss log, dropping the oldest entries when full
+func (t *Tracker) recordLoss(e LossEvent) {
+	t.logMu.Lock()
+	defer t.logMu.Unlock()
+
+	t.losses = append(t.losses, e)
+	if len(t.losses) > maxLossLog {
+		t.losses = t.losses[len(t.losses)-maxLossLog:]
+	}
+}
+
+// removeLosses drops logged losses of a universe
+func (t *Tracker) removeLosses(universeID uint16) {
+	t.logMu.Lock()
+	defer t.logMu.Unlock()
+
+	kept := t.losses[:0]
+	for _, e := range t.losses {
+		if e.Universe != universeID {
+			kept = append(kept, e)
+		}
+	}
+	t.losses = kept
+}
@@ -0,0 +1,66 @@
+package stats
+
+import "testing"
+
+func TestTracker_LossEvents(t *testing.T) {
+	tracker := NewTracker()
+	cid := [16]byte{1}
+
+	tracker.RecordPacket(1, cid, "console", 10)
+	tracker.RecordPacket(1, cid, "console", 11)
+	tracker.RecordPacket(1, cid, "console", 15) // 12-14 lost
+	tracker.RecordPacket(2, cid, "console", 254)
+	tracker.RecordPacket(2, cid, "console", 1) // 255, 0 lost
+
+	events := tracker.GetLossEvents(0)
+	if len(events) != 2 {
+		t.Fatalf("len(GetLossEvents()) = %d, want 2", len(events))
+	}
+
+	newest := events[0]
+	if newest.Universe != 2 || newest.Lost != 2 || newest.FirstMissing != 255 || newest.LastMissing != 0 {
+		t.Errorf("newest = %+v, want 2 lost (255-0) on universe 2", newest)
+	}
+
+	oldest := events[1]
+	want := LossEvent{
+		Time:          oldest.Time,
+		Universe:      1,
+		CID:           cid,
+		Source:        "console",
+		Lost:          3,
+		FirstMissing:  12,
+		LastMissing:   14,
+		ReceivedAfter: 11,
+		ReceivedNext:  15,
+	}
+	if oldest != want {
+		t.Errorf("oldest = %+v, want %+v", oldest, want)
+	}
+	if oldest.Time.IsZero() {
+		t.Error("loss event has no timestamp")
+	}
+
+	tracker.ResetUniverseStats(1)
+	if got := tracker.GetLossEvents(0); len(got) != 1 || got[0].Universe != 2 {
+		t.Errorf("GetLossEvents() after reset = %+v, want only universe 2", got)
+	}
+}
+
+func TestTracker_LossEvents_Bounded(t *testing.T) {
+	tracker := NewTracker()
+	cid := [16]byte{1}
+
+	seq := uint8(0)
+	for i := 0; i < maxLossLog+10; i++ {
+		tracker.RecordPacket(1, cid, "console", seq)
+		seq += 2
+	}
+
+	if got := len(tracker.GetLossEvents(0)); got != maxLossLog {
+		t.Errorf("len(GetLossEvents()) = %d, want %d", got, maxLossLog)
+	}
+	if got := len(tracker.GetLossEvents(5)); got != 5 {
+		t.Errorf("len(GetLossEvents(5)) = %d, want 5", got)
+	}
+}
@@ -0,0 +1,81 @@
+package stats
+
+import "unsafe"
+
+// mapEntryOverhead is a rough per-entry cost of a Go map beyond the key
+// and value themselves
+const mapEntryOverhead = 16
+
+// MemoryUsage is an estimate of the memory held by the tracker. Fixed-size
+// structures are counted exactly; map and slice overheads are approximate.
+type MemoryUsage struct {
+	Universes int
+	Sources   int // Sources summed over universes
+
+	UniverseBytes int64 // Universe and source records
+	WindowBytes   int64 // Rate, loss, jitter and percentile windows
+	LogBytes      int64 // Gap, outage, loss, address change and priority logs
+	TimelineBytes int64 // Sequence timelines
+	AddressBytes  int64 // Addresses seen per CID
+}
+
+// Total returns the estimated bytes over all parts
+func (m MemoryUsage) Total() int64 {
+	return m.UniverseBytes + m.WindowBytes + m.LogBytes + m.TimelineBytes + m.AddressBytes
+}
+
+// MemoryUsage estimates the memory currently held by the tracker
+func (t *Tracker) MemoryUsage() MemoryUsage {
+	var usage MemoryUsage
+
+	t.mu.RLock()
+	universes := make([]*UniverseStats, 0, len(t.universes))
+	for _, stats := range t.universes {
+		universes = append(universes, stats)
+	}
+	for _, seen := range t.addresses {
+		usage.AddressBytes += int64(unsafe.Sizeof([16]byte{})) + mapEntryOverhead
+		for addr := range seen {
+			usage.AddressBytes += int64(len(addr)) + int64(unsafe.Sizeof(addr)+unsafe.Sizeof(t.started)) + mapEntryOverhead
+		}
+	}
+	usage.AddressBytes += int64(len(t.sourceAddrs)) * (int64(unsafe.Sizeof(sourceAddress{})) + mapEntryOverhead)
+	usage.LogBytes += int64(cap(t.addressChanges)) * int64(unsafe.Sizeof(AddressChange{}))
+	t.mu.RUnlock()
+
+	usage.Universes = len(universes)
+	for _, stats := range universes {
+		stats.mu.RLock()
+		usage.Sources += len(stats.Sources)
+		usage.UniverseBytes += int64(unsafe.Sizeof(*stats)) + mapEntryOverhead
+		usage.WindowBytes += windowBytes(stats.rateCounts) + windowBytes(stats.lossCounts)
+		usage.WindowBytes += int64(unsafe.Sizeof(intervalWindow{}) + unsafe.Sizeof(intervalHistogram{}))
+		for _, w := range stats.sourceCounts {
+			usage.WindowBytes += windowBytes(w) + mapEntryOverhead
+		}
+		usage.WindowBytes += int64(len(stats.sourceIntervals)) * (int64(unsafe.Sizeof(intervalWindow{})) + mapEntryOverhead)
+		for _, s := range stats.Sources {
+			usage.UniverseBytes += int64(unsafe.Sizeof(*s)) + mapEntryOverhead
+			if s.timeline != nil {
+				usage.TimelineBytes += int64(cap(s.timeline.samples)) * int64(unsafe.Sizeof(SequenceSample{}))
+			}
+		}
+		stats.mu.RUnlock()
+	}
+
+	t.logMu.Lock()
+	usage.LogBytes += int64(cap(t.gaps)+cap(t.outages)) * int64(unsafe.Sizeof(Gap{}))
+	usage.LogBytes += int64(cap(t.losses)) * int64(unsafe.Sizeof(LossEvent{}))
+	usage.LogBytes += int64(cap(t.priorityChanges)) * int64(unsafe.Sizeof(PriorityChange{}))
+	t.logMu.Unlock()
+
+	return usage
+}
+
+// windowBytes returns the size of a bucket window
+func windowBytes(w *bucketWindow) int64 {
+	if w == nil {
+		return 0
+	}
+	return int64(unsafe.Sizeof(*w)) + int64(cap(w.buckets))*int64(unsafe.Sizeof(bucket{}))
+}
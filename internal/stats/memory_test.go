@@ -0,0 +1,27 @@
+package stats
+
+import "testing"
+
+func TestTracker_MemoryUsage(t *testing.T) {
+	tracker := NewTracker()
+	if got := tracker.MemoryUsage(); got.Universes != 0 || got.Total() != 0 {
+		t.Errorf("empty tracker usage = %+v, want zero", got)
+	}
+
+	tracker.RecordPacket(1, [16]byte{1}, "a", 0)
+	one := tracker.MemoryUsage()
+	if one.Universes != 1 || one.Sources != 1 || one.UniverseBytes == 0 || one.WindowBytes == 0 {
+		t.Errorf("usage = %+v, want one universe and source with windows", one)
+	}
+
+	// More universes cost more, and timelines are counted once enabled
+	tracker.SetSequenceTimeline(1000)
+	tracker.RecordPacket(2, [16]byte{2}, "b", 0)
+	two := tracker.MemoryUsage()
+	if two.Universes != 2 || two.Sources != 2 || two.Total() <= one.Total() {
+		t.Errorf("usage = %+v, want more than %+v", two, one)
+	}
+	if two.TimelineBytes < 1000*16 {
+		t.Errorf("TimelineBytes = %d, want a 1000 sample timeline counted", two.TimelineBytes)
+	}
+}
@@ -0,0 +1,54 @@
+package stats
+
+import "time"
+
+// Peak is the highest packet rate and bandwidth observed, with when
+type Peak struct {
+	PacketRate   float64
+	PacketRateAt time.Time
+	ByteRate     float64
+	ByteRateAt   time.Time
+}
+
+// observe raises the peaks to the rates of the window ending at now
+func (p *Peak) observe(now time.Time, c counts, window time.Duration) {
+	if rate := float64(c.Received) / window.Seconds(); rate > p.PacketRate {
+		p.PacketRate, p.PacketRateAt = rate, now
+	}
+	if rate := float64(c.Bytes) / window.Seconds(); rate > p.ByteRate {
+		p.ByteRate, p.ByteRateAt = rate, now
+	}
+}
+
+// GetPeak returns the peak packet rate and bandwidth of a universe
+func (t *Tracker) GetPeak(universeID uint16) Peak {
+	t.mu.RLock()
+	stats := t.universes[universeID]
+	t.mu.RUnlock()
+
+	if stats == nil {
+		return Peak{}
+	}
+
+	stats.mu.RLock()
+	defer stats.mu.RUnlock()
+	return stats.peak
+}
+
+// GetGlobalPeak returns the peak packet rate and bandwidth over all
+// universes together
+func (t *Tracker) GetGlobalPeak() Peak {
+	t.totalMu.Lock()
+	defer t.totalMu.Unlock()
+	return t.totalPeak
+}
+
+// recordTotal adds to the network-wide rate window and raises the global
+// peaks
+func (t *Tracker) recordTotal(now time.Time, c counts) {
+	t.totalMu.Lock()
+	defer t.totalMu.Unlock()
+
+	t.totalCounts.record(now, c)
+	t.totalPeak.observe(now, t.totalCounts.sum(now), t.rateWindow)
+}
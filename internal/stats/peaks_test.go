@@ -0,0 +1,69 @@
+package stats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTracker_Peaks(t *testing.T) {
+	tracker := NewTracker()
+	cid := [16]byte{1}
+
+	for seq := uint8(0); seq < 3; seq++ {
+		tracker.RecordPacket(1, cid, "console", seq)
+		tracker.RecordBytes(1, cid, 100)
+	}
+	tracker.RecordPacket(2, cid, "console", 0)
+	tracker.RecordBytes(2, cid, 100)
+
+	peak := tracker.GetPeak(1)
+	if peak.PacketRate != 3 || peak.ByteRate != 300 || peak.PacketRateAt.IsZero() || peak.ByteRateAt.IsZero() {
+		t.Errorf("GetPeak(1) = %+v, want 3 pps and 300 B/s with timestamps", peak)
+	}
+
+	global := tracker.GetGlobalPeak()
+	if global.PacketRate != 4 || global.ByteRate != 400 {
+		t.Errorf("GetGlobalPeak() = %+v, want 4 pps and 400 B/s", global)
+	}
+
+	// A late packet counts towards the network totals like any other
+	tracker.RecordPacket(3, cid, "console", 0)
+	tracker.RecordPacket(3, cid, "console", 2)
+	tracker.RecordPacket(3, cid, "console", 1) // Out of order
+	if got := tracker.GetGlobalPeak().PacketRate; got != 7 {
+		t.Errorf("GetGlobalPeak() after a late packet = %v pps, want 7", got)
+	}
+
+	tracker.ResetUniverseStats(1)
+	if got := tracker.GetPeak(1); got != (Peak{}) {
+		t.Errorf("GetPeak(1) after reset = %+v, want zero", got)
+	}
+	tracker.ResetAllStats()
+	if got := tracker.GetGlobalPeak(); got != (Peak{}) {
+		t.Errorf("GetGlobalPeak() after reset = %+v, want zero", got)
+	}
+}
+
+func TestCheckRate_Burst(t *testing.T) {
+	stats := &UniverseStats{}
+	start := time.Now()
+
+	stats.checkRate(start, 44, 0)
+	stats.checkRate(start.Add(time.Second), 300, 0)
+	if !stats.rate.Bursting || stats.rate.Bursts != 1 || !stats.rate.LastBurst.Start.Equal(start.Add(time.Second)) {
+		t.Fatalf("at 300 pps: %+v, want a burst started", stats.rate)
+	}
+
+	// A sustained burst is not learned as the new rate
+	stats.checkRate(start.Add(2*time.Second), 400, 0)
+	stats.checkRate(start.Add(5*time.Second), 350, 0)
+	if stats.rate.Expected != 44 {
+		t.Errorf("learned rate = %v during a burst, want 44 kept", stats.rate.Expected)
+	}
+
+	stats.checkRate(start.Add(6*time.Second), 44, 0)
+	burst := stats.rate.LastBurst
+	if stats.rate.Bursting || burst.Duration != 5*time.Second || burst.PeakRate != 400 {
+		t.Errorf("after burst: %+v, want a finished 5 s burst peaking at 400 pps", stats.rate)
+	}
+}
@@ -0,0 +1,131 @@
+package stats
+
+import (
+	"math"
+	"time"
+)
+
+// Constants for inter-arrival percentiles. Intervals are counted in
+// log-spaced bins, so each percentile is within 5% of the true value
+// while recording stays constant time.
+const (
+	percentileSlices = 6                      // Ring of histograms over the window
+	percentileWindow = time.Minute            // Window the percentiles cover
+	percentileBins   = 128                    // Bins per histogram
+	percentileMinBin = 100 * time.Microsecond // Upper bound of the first bin
+	percentileGrowth = 1.1                    // Ratio between bin bounds
+)
+
+// Percentiles are inter-arrival time percentiles over the recent window
+type Percentiles struct {
+	P50     time.Duration
+	P95     time.Duration
+	P99     time.Duration
+	Samples int // Number of intervals measured
+}
+
+// histogramSlice counts the intervals of one time slot
+type histogramSlice struct {
+	slot  int64
+	bins  [percentileBins]uint32
+	count int
+}
+
+// intervalHistogram estimates inter-arrival percentiles over a sliding
+// window from a ring of per-slot histograms
+type intervalHistogram struct {
+	slices [percentileSlices]histogramSlice
+}
+
+// binFor returns the bin an interval falls into
+func binFor(d time.Duration) int {
+	if d <= percentileMinBin {
+		return 0
+	}
+	bin := 1 + int(math.Log(float64(d)/float64(percentileMinBin))/math.Log(percentileGrowth))
+	return min(bin, percentileBins-1)
+}
+
+// binValue returns the geometric middle of a bin
+func binValue(bin int) time.Duration {
+	if bin == 0 {
+		return percentileMinBin
+	}
+	upper := float64(percentileMinBin) * math.Pow(percentileGrowth, float64(bin))
+	return time.Duration(upper / math.Sqrt(percentileGrowth))
+}
+
+// add records an interval at now, ignoring outages
+func (h *intervalHistogram) add(now time.Time, d time.Duration) {
+	if d <= 0 || d > maxJitterInterval {
+		return
+	}
+	slot := now.UnixNano() / int64(percentileWindow/percentileSlices)
+	s := &h.slices[ringIndex(slot, percentileSlices)]
+	if s.slot != slot {
+		*s = histogramSlice{slot: slot}
+	}
+	s.bins[binFor(d)]++
+	s.count++
+}
+
+// percentiles returns the p50, p95 and p99 intervals of the window ending
+// at now
+func (h *intervalHistogram) percentiles(now time.Time) Percentiles {
+	if h == nil {
+		return Percentiles{}
+	}
+
+	var bins [percentileBins]uint32
+	var total int
+	slot := now.UnixNano() / int64(percentileWindow/percentileSlices)
+	for i := range h.slices {
+		s := &h.slices[i]
+		if s.count == 0 || s.slot <= slot-percentileSlices || s.slot > slot {
+			continue
+		}
+		for b, n := range s.bins {
+			bins[b] += n
+		}
+		total += s.count
+	}
+	if total == 0 {
+		return Percentiles{}
+	}
+
+	// Smallest bin whose cumulative count reaches each rank
+	quantile := func(q float64) time.Duration {
+		rank := uint32(math.Ceil(q * float64(total)))
+		var seen uint32
+		for b, n := range bins {
+			seen += n
+			if seen >= rank {
+				return binValue(b)
+			}
+		}
+		return binValue(percentileBins - 1)
+	}
+
+	return Percentiles{
+		P50:     quantile(0.50),
+		P95:     quantile(0.95),
+		P99:     quantile(0.99),
+		Samples: total,
+	}
+}
+
+// GetIntervalPercentiles returns the p50, p95 and p99 inter-arrival times
+// of a universe over the last minute
+func (t *Tracker) GetIntervalPercentiles(universeID uint16) Percentiles {
+	t.mu.RLock()
+	stats := t.universes[universeID]
+	t.mu.RUnlock()
+
+	if stats == nil {
+		return Percentiles{}
+	}
+
+	stats.mu.RLock()
+	defer stats.mu.RUnlock()
+	return stats.histogram.percentiles(t.clock.Now())
+}
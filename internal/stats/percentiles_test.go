@@ -0,0 +1,79 @@
+package stats
+
+import (
+	"testing"
+	"time"
+)
+
+// within reports whether got is within 5% of want
+func within(got, want time.Duration) bool {
+	diff := got - want
+	if diff < 0 {
+		diff = -diff
+	}
+	return float64(diff) <= 0.05*float64(want)
+}
+
+func TestIntervalHistogram_Percentiles(t *testing.T) {
+	var h intervalHistogram
+	now := time.Unix(1000, 0)
+
+	// 90 regular 23 ms intervals, 8 at 50 ms and 2 stutters of 200 ms
+	for range 90 {
+		h.add(now, 23*time.Millisecond)
+	}
+	for range 8 {
+		h.add(now, 50*time.Millisecond)
+	}
+	h.add(now, 200*time.Millisecond)
+	h.add(now, 200*time.Millisecond)
+
+	p := h.percentiles(now)
+	if p.Samples != 100 {
+		t.Fatalf("Samples = %d, want 100", p.Samples)
+	}
+	if !within(p.P50, 23*time.Millisecond) {
+		t.Errorf("P50 = %s, want ~23ms", p.P50)
+	}
+	if !within(p.P95, 50*time.Millisecond) {
+		t.Errorf("P95 = %s, want ~50ms", p.P95)
+	}
+	if !within(p.P99, 200*time.Millisecond) {
+		t.Errorf("P99 = %s, want ~200ms", p.P99)
+	}
+}
+
+func TestIntervalHistogram_Window(t *testing.T) {
+	var h intervalHistogram
+	start := time.Unix(1000, 0)
+
+	h.add(start, 500*time.Millisecond)
+	h.add(start, 10*time.Second) // Outage, ignored
+	later := start.Add(30 * time.Second)
+	h.add(later, 20*time.Millisecond)
+
+	if p := h.percentiles(later); p.Samples != 2 || !within(p.P99, 500*time.Millisecond) {
+		t.Errorf("percentiles = %+v, want 2 samples with p99 ~500ms", p)
+	}
+
+	// The first interval has left the window
+	end := start.Add(percentileWindow + time.Second)
+	if p := h.percentiles(end); p.Samples != 1 || !within(p.P99, 20*time.Millisecond) {
+		t.Errorf("percentiles = %+v, want 1 sample of ~20ms", p)
+	}
+}
+
+func TestTracker_GetIntervalPercentiles(t *testing.T) {
+	tracker := NewTracker()
+	if p := tracker.GetIntervalPercentiles(1); p.Samples != 0 {
+		t.Errorf("unknown universe: %+v, want zero", p)
+	}
+
+	cid := [16]byte{1}
+	for i := range 3 {
+		tracker.RecordPacket(1, cid, "console", uint8(i))
+	}
+	if p := tracker.GetIntervalPercentiles(1); p.Samples != 2 {
+		t.Errorf("Samples = %d, want 2", p.Samples)
+	}
+}
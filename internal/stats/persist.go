@@ -0,0 +1,279 @@
+package stats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// State is the persisted form of the tracker's cumulative statistics and
+// logs. Sliding windows (rates, recent loss, jitter) are not kept.
+type State struct {
+	SavedAt         time.Time        `json:"saved_at"`
+	Universes       []UniverseState  `json:"universes"`
+	Gaps            []Gap            `json:"gaps,omitempty"`
+	Outages         []Gap            `json:"outages,omitempty"`
+	Losses          []LossEvent      `json:"losses,omitempty"`
+	AddressChanges  []AddressChange  `json:"address_changes,omitempty"`
+	PriorityChanges []PriorityChange `json:"priority_changes,omitempty"`
+	GlobalPeak      Peak             `json:"global_peak"`
+}
+
+// UniverseState is the persisted statistics of one universe
+type UniverseState struct {
+	UniverseID    uint16        `json:"universe"`
+	PacketCount   uint64        `json:"packets"`
+	LostPackets   uint64        `json:"lost"`
+	OutOfOrder    uint64        `json:"out_of_order"`
+	Duplicates    uint64        `json:"duplicates"`
+	ReceiverDrops uint64        `json:"receiver_drops,omitempty"`
+	Bytes         uint64        `json:"bytes"`
+	LastPacket    time.Time     `json:"last_packet"`
+	Uptime        Uptime        `json:"uptime"`
+	Peak          Peak          `json:"peak"`
+	Sources       []SourceState `json:"sources"`
+}
+
+// SourceState is the persisted statistics of one source on a universe
+type SourceState struct {
+	CID           [16]byte      `json:"cid"`
+	Name          string        `json:"name"`
+	LastSeen      time.Time     `json:"last_seen"`
+	PacketCount   uint64        `json:"packets"`
+	LostPackets   uint64        `json:"lost"`
+	OutOfOrder    uint64        `json:"out_of_order"`
+	Duplicates    uint64        `json:"duplicates"`
+	ReceiverDrops uint64        `json:"receiver_drops,omitempty"`
+	Bytes         uint64        `json:"bytes"`
+	LongestGap    time.Duration `json:"longest_gap"`
+	LongestGapAt  time.Time     `json:"longest_gap_at"`
+	Uptime        Uptime        `json:"uptime"`
+}
+
+// State returns the tracker's cumulative statistics for persisting
+func (t *Tracker) State() State {
+	state := State{SavedAt: t.clock.Now()}
+
+	for _, id := range t.GetAllUniverseIDs() {
+		stats := t.GetUniverseStats(id)
+		if stats == nil {
+			continue
+		}
+
+		stats.mu.RLock()
+		u := UniverseState{
+			UniverseID:    id,
+			PacketCount:   stats.PacketCount,
+			LostPackets:   stats.LostPackets,
+			OutOfOrder:    stats.OutOfOrder,
+			Duplicates:    stats.Duplicates,
+			ReceiverDrops: stats.ReceiverDrops,
+			Bytes:         stats.Bytes,
+			LastPacket:    stats.LastPacket,
+			Uptime:        stats.Uptime,
+			Peak:          stats.peak,
+		}
+		for _, s := range stats.Sources {
+			u.Sources = append(u.Sources, SourceState{
+				CID:           s.CID,
+				Name:          s.Name,
+				LastSeen:      s.LastSeen,
+				PacketCount:   s.PacketCount,
+				LostPackets:   s.LostPackets,
+				OutOfOrder:    s.OutOfOrder,
+				Duplicates:    s.Duplicates,
+				ReceiverDrops: s.ReceiverDrops,
+				Bytes:         s.Bytes,
+				LongestGap:    s.LongestGap,
+				LongestGapAt:  s.LongestGapAt,
+				Uptime:        s.Uptime,
+			})
+		}
+		stats.mu.RUnlock()
+		state.Universes = append(state.Universes, u)
+	}
+
+	// Logs are kept newest last, as in the tracker
+	state.Gaps = reversed(t.GetGaps(0))
+	state.Outages = reversed(t.GetOutages(0))
+	state.Losses = reversed(t.GetLossEvents(0))
+	state.AddressChanges = reversed(t.GetAddressChanges(0))
+	state.PriorityChanges = reversed(t.GetPriorityChanges(0))
+	state.GlobalPeak = t.GetGlobalPeak()
+	return state
+}
+
+// Restore replaces the tracker's statistics with a persisted state. Last
+// seen times move forward by the time since the state was saved, so the
+// monitor being down counts neither as an outage nor towards uptime, and
+// the first packet of each source starts a fresh sequence.
+func (t *Tracker) Restore(state State) {
+	shift := max(t.clock.Now().Sub(state.SavedAt), 0)
+
+	universes := make(map[uint16]*UniverseStats, len(state.Universes))
+	for _, u := range state.Universes {
+		stats := &UniverseStats{
+			UniverseID:      u.UniverseID,
+			Sources:         make(map[[16]byte]*Source, len(u.Sources)),
+			PacketCount:     u.PacketCount,
+			LostPackets:     u.LostPackets,
+			OutOfOrder:      u.OutOfOrder,
+			Duplicates:      u.Duplicates,
+			ReceiverDrops:   u.ReceiverDrops,
+			Bytes:           u.Bytes,
+			LastPacket:      shifted(u.LastPacket, shift),
+			Uptime:          u.Uptime.shifted(shift),
+			peak:            u.Peak,
+			rateCounts:      newBucketWindow(t.rateWindow, rateBuckets),
+			lossCounts:      newBucketWindow(lossWindowDuration, lossBuckets),
+			sourceCounts:    make(map[[16]byte]*bucketWindow),
+			intervals:       &intervalWindow{},
+			histogram:       &intervalHistogram{},
+			sourceIntervals: make(map[[16]byte]*intervalWindow),
+		}
+		for _, s := range u.Sources {
+			stats.Sources[s.CID] = &Source{
+				CID:           s.CID,
+				Name:          s.Name,
+				LastSeen:      shifted(s.LastSeen, shift),
+				PacketCount:   s.PacketCount,
+				LostPackets:   s.LostPackets,
+				OutOfOrder:    s.OutOfOrder,
+				Duplicates:    s.Duplicates,
+				ReceiverDrops: s.ReceiverDrops,
+				Bytes:         s.Bytes,
+				LongestGap:    s.LongestGap,
+				LongestGapAt:  s.LongestGapAt,
+				Uptime:        s.Uptime.shifted(shift),
+				restored:      true,
+			}
+		}
+		universes[u.UniverseID] = stats
+	}
+
+	t.mu.Lock()
+	t.universes = universes
+	t.addressChanges = append([]AddressChange(nil), state.AddressChanges...)
+	t.mu.Unlock()
+
+	t.logMu.Lock()
+	t.gaps = append([]Gap(nil), state.Gaps...)
+	t.outages = append([]Gap(nil), state.Outages...)
+	t.losses = append([]LossEvent(nil), state.Losses...)
+	t.priorityChanges = append([]PriorityChange(nil), state.PriorityChanges...)
+	t.logMu.Unlock()
+
+	t.totalMu.Lock()
+	t.totalPeak = state.GlobalPeak
+	t.totalMu.Unlock()
+}
+
+// WriteStateFile saves a state as JSON. The file is replaced atomically so
+// a crash mid-write keeps the previous state.
+func WriteStateFile(path string, state State) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode stats state: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write stats state: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write stats state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write stats state: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write stats state: %w", err)
+	}
+	return nil
+}
+
+// ReadStateFile loads a state saved with WriteStateFile
+func ReadStateFile(path string) (State, error) {
+	var state State
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return state, fmt.Errorf("failed to read stats state: %w", err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, fmt.Errorf("failed to parse stats state %s: %w", path, err)
+	}
+	return state, nil
+}
+
+// Persister saves the tracker's state to a file periodically
+type Persister struct {
+	tracker  *Tracker
+	path     string
+	interval time.Duration
+	onError  func(error)
+}
+
+// NewPersister creates a persister saving to path every interval, passing
+// failed saves to onError
+func NewPersister(tracker *Tracker, path string, interval time.Duration, onError func(error)) *Persister {
+	return &Persister{
+		tracker:  tracker,
+		path:     path,
+		interval: interval,
+		onError:  onError,
+	}
+}
+
+// Save writes the current state to the file
+func (p *Persister) Save() error {
+	return WriteStateFile(p.path, p.tracker.State())
+}
+
+// Run saves periodically until the context is cancelled
+func (p *Persister) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := p.Save(); err != nil && p.onError != nil {
+				p.onError(err)
+			}
+		}
+	}
+}
+
+// shifted moves a non-zero time forward by d
+func shifted(t time.Time, d time.Duration) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	return t.Add(d)
+}
+
+// shifted returns the uptime with its first and last seen times moved
+// forward by d
+func (u Uptime) shifted(d time.Duration) Uptime {
+	u.FirstSeen = shifted(u.FirstSeen, d)
+	u.LastSeen = shifted(u.LastSeen, d)
+	return u
+}
+
+// reversed returns a newest-first log in oldest-first order
+func reversed[T any](items []T) []T {
+	result := make([]T, len(items))
+	for i, item := range items {
+		result[len(items)-1-i] = item
+	}
+	return result
+}
@@ -0,0 +1,94 @@
+package stats
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTracker_StateRoundTrip(t *testing.T) {
+	tracker := NewTracker()
+	cid := [16]byte{1}
+
+	tracker.RecordPacket(1, cid, "console", 0)
+	tracker.RecordPacket(1, cid, "console", 1)
+	tracker.RecordPacket(1, cid, "console", 4) // 2 lost
+	tracker.RecordBytes(1, cid, 638)
+	tracker.RecordSourceAddress(cid, "10.0.0.1")
+
+	path := filepath.Join(t.TempDir(), "stats.json")
+	if err := WriteStateFile(path, tracker.State()); err != nil {
+		t.Fatalf("WriteStateFile() returned error: %v", err)
+	}
+	state, err := ReadStateFile(path)
+	if err != nil {
+		t.Fatalf("ReadStateFile() returned error: %v", err)
+	}
+
+	restored := NewTracker()
+	restored.Restore(state)
+
+	agg := restored.Totals()
+	if agg.PacketCount != 3 || agg.LostPackets != 2 || agg.Bytes != 638 {
+		t.Errorf("restored totals = %+v, want 3 packets, 2 lost, 638 bytes", agg)
+	}
+	if got := restored.GetLossEvents(0); len(got) != 1 || got[0].Lost != 2 {
+		t.Errorf("restored loss log = %+v, want the one loss event", got)
+	}
+	sources := restored.GetSources(1)
+	if len(sources) != 1 || sources[0].Name != "console" || sources[0].LostPackets != 2 {
+		t.Fatalf("restored sources = %+v, want console with 2 lost", sources)
+	}
+
+	// The source carries on from a new sequence after the restart without
+	// it counting as loss or an outage
+	restored.RecordPacket(1, cid, "console", 200)
+	if got := restored.Totals().LostPackets; got != 2 {
+		t.Errorf("LostPackets after first packet = %d, want still 2", got)
+	}
+	if got := restored.GetUptime(1).Outages; got != 0 {
+		t.Errorf("Outages after restart = %d, want 0", got)
+	}
+}
+
+func TestTracker_RestoreShiftsDowntime(t *testing.T) {
+	firstSeen := time.Now().Add(-time.Hour)
+	state := State{
+		SavedAt: time.Now().Add(-10 * time.Minute),
+		Universes: []UniverseState{{
+			UniverseID: 1,
+			Uptime:     Uptime{FirstSeen: firstSeen, LastSeen: time.Now().Add(-10 * time.Minute)},
+		}},
+	}
+
+	tracker := NewTracker()
+	tracker.Restore(state)
+
+	uptime := tracker.GetUptime(1)
+	if shift := uptime.FirstSeen.Sub(firstSeen); shift < 10*time.Minute-time.Second || shift > 10*time.Minute+time.Second {
+		t.Errorf("FirstSeen moved by %s, want the 10 minutes the monitor was down", shift)
+	}
+	if !uptime.Online(time.Now()) {
+		t.Error("universe online at save time is not online after restore")
+	}
+}
+
+func TestWriteStateFile_ReplacesAtomically(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "stats.json")
+
+	for i := 0; i < 2; i++ {
+		if err := WriteStateFile(path, State{SavedAt: time.Now()}); err != nil {
+			t.Fatalf("WriteStateFile() returned error: %v", err)
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("directory has %d entries, want only the state file", len(entries))
+	}
+}
@@ -0,0 +1,123 @@
+package stats
+
+import "time"
+
+// maxPriorityChanges is the number of priority changes kept in the log
+const maxPriorityChanges = 500
+
+// PriorityChange records a source changing its declared priority, or
+// starting or stopping sending per-address priority (start code 0xDD)
+type PriorityChange struct {
+	Time     time.Time
+	Universe uint16
+	CID      [16]byte
+	Source   string
+
+	Previous uint8 // Declared priority before the change
+	Current  uint8
+
+	// Whether per-address priority was being sent before and after
+	PreviousPerAddress bool
+	PerAddress         bool
+}
+
+// RecordPriority records the priority of a source's packet on a universe,
+// logging changes. perAddress marks a per-address priority packet, whose
+// declared priority is not compared. It expects the packet to have been
+// recorded already; priorities of unknown sources are ignored.
+func (t *Tracker) RecordPriority(universeID uint16, sourceCID [16]byte, priority uint8, perAddress bool) {
+	t.mu.RLock()
+	stats := t.universes[universeID]
+	t.mu.RUnlock()
+
+	if stats == nil {
+		return
+	}
+
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	source := stats.Sources[sourceCID]
+	if source == nil {
+		return
+	}
+
+	now := t.clock.Now()
+	change := PriorityChange{
+		Time:               now,
+		Universe:           universeID,
+		CID:                sourceCID,
+		Source:             source.Name,
+		Previous:           source.Priority,
+		Current:            source.Priority,
+		PreviousPerAddress: source.PerAddressPriority,
+		PerAddress:         source.PerAddressPriority,
+	}
+
+	// Per-address priority is sent less often than levels, so it is only
+	// considered gone after the data loss timeout
+	if perAddress {
+		source.perAddressAt = now
+		change.PerAddress = true
+	} else {
+		if source.priorityKnown {
+			change.Current = priority
+		} else {
+			change.Previous, change.Current = priority, priority
+		}
+		source.Priority = priority
+		source.priorityKnown = true
+		if source.PerAddressPriority && now.Sub(source.perAddressAt) > dataLossTimeout {
+			change.PerAddress = false
+		}
+	}
+	source.PerAddressPriority = change.PerAddress
+
+	if change.Previous == change.Current && change.PreviousPerAddress == change.PerAddress {
+		return
+	}
+	t.recordPriorityChange(change)
+	t.notify(Notification{Kind: PriorityChanged, Time: now, Universe: universeID, CID: sourceCID, Source: source.Name, Priority: change})
+}
+
+// GetPriorityChanges returns up to n priority changes, newest first. n <= 0
+// returns all.
+func (t *Tracker) GetPriorityChanges(n int) []PriorityChange {
+	t.logMu.Lock()
+	defer t.logMu.Unlock()
+
+	if n <= 0 || n > len(t.priorityChanges) {
+		n = len(t.priorityChanges)
+	}
+	result := make([]PriorityChange, n)
+	for i := 0; i < n; i++ {
+		result[i] = t.priorityChanges[len(t.priorityChanges)-1-i]
+	}
+	return result
+}
+
+// recordPriorityChange appends to the priority log, dropping the oldest
+// entries when full
+func (t *Tracker) recordPriorityChange(c PriorityChange) {
+	t.logMu.Lock()
+	defer t.logMu.Unlock()
+
+	t.priorityChanges = append(t.priorityChanges, c)
+	if len(t.priorityChanges) > maxPriorityChanges {
+		t.priorityChanges = t.priorityChanges[len(t.priorityChanges)-maxPriorityChanges:]
+	}
+}
+
+// removePriorityChanges drops logged priority changes of a universe
+func (t *Tracker) removePriorityChanges(universeID uint16) {
+	t.logMu.Lock()
+	defer t.logMu.Unlock()
+
+	kept := t.priorityChanges[:0]
+	for _, c := range t.priorityChanges {
+		if c.Universe != universeID {
+			kept = append(kept, c)
+		}
+	}
+	t.priorityChanges = kept
+}
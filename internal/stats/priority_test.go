@@ -0,0 +1,56 @@
+package stats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTracker_PriorityChanges(t *testing.T) {
+	tracker := NewTracker()
+	cid := [16]byte{1}
+
+	// Unknown sources are ignored
+	tracker.RecordPriority(1, cid, 100, false)
+	if got := tracker.GetPriorityChanges(0); len(got) != 0 {
+		t.Fatalf("GetPriorityChanges() = %+v before any packet, want none", got)
+	}
+
+	tracker.RecordPacket(1, cid, "backup", 0)
+	tracker.RecordPriority(1, cid, 100, false) // First priority is not a change
+	tracker.RecordPriority(1, cid, 100, false)
+	if got := tracker.GetPriorityChanges(0); len(got) != 0 {
+		t.Fatalf("GetPriorityChanges() = %+v for a steady priority, want none", got)
+	}
+
+	tracker.RecordPriority(1, cid, 120, false)
+	tracker.RecordPriority(1, cid, 0, true) // Per-address priority starts
+	tracker.RecordPriority(1, cid, 120, false)
+
+	changes := tracker.GetPriorityChanges(0)
+	if len(changes) != 2 {
+		t.Fatalf("len(GetPriorityChanges()) = %d, want 2", len(changes))
+	}
+	if c := changes[1]; c.Previous != 100 || c.Current != 120 || c.Source != "backup" || c.PerAddress {
+		t.Errorf("oldest = %+v, want 100 → 120 without per-address", c)
+	}
+	if c := changes[0]; c.Previous != 120 || c.Current != 120 || c.PreviousPerAddress || !c.PerAddress {
+		t.Errorf("newest = %+v, want per-address starting at 120", c)
+	}
+
+	src := tracker.GetUniverseStats(1).Sources[cid]
+	if src.Priority != 120 || !src.PerAddressPriority {
+		t.Errorf("source priority = %d, per-address %v; want 120, true", src.Priority, src.PerAddressPriority)
+	}
+
+	// Per-address priority stops once it hasn't been seen for the timeout
+	src.perAddressAt = time.Now().Add(-dataLossTimeout - time.Second)
+	tracker.RecordPriority(1, cid, 120, false)
+	if c := tracker.GetPriorityChanges(1)[0]; !c.PreviousPerAddress || c.PerAddress {
+		t.Errorf("newest = %+v, want per-address stopping", c)
+	}
+
+	tracker.ResetUniverseStats(1)
+	if got := tracker.GetPriorityChanges(0); len(got) != 0 {
+		t.Errorf("GetPriorityChanges() = %+v after reset, want none", got)
+	}
+}
@@ -0,0 +1,168 @@
+package stats
+
+import (
+	"math"
+	"time"
+)
+
+// Refresh rate defaults
+const (
+	// rateTolerance is the fraction the packet rate may deviate from the
+	// expected rate before it counts as off-rate
+	rateTolerance = 0.2
+	// rateDeviationHold is how long the rate must stay off before it is
+	// flagged, so a single slow second doesn't
+	rateDeviationHold = 3 * time.Second
+	// rateLearnWeight is how much each in-tolerance sample moves a learned rate
+	rateLearnWeight = 0.05
+	// burstFactor is how many times the expected rate counts as a burst,
+	// e.g. a console spamming snapshots
+	burstFactor = 2.0
+)
+
+// Burst is a period of packet rate far above the expected rate
+type Burst struct {
+	Start    time.Time
+	Duration time.Duration // Zero while the burst is ongoing
+	PeakRate float64
+}
+
+// RateStatus describes a universe's packet rate against its expected rate
+type RateStatus struct {
+	Current   float64 // Packets per second over the rate window
+	Expected  float64 // Configured or learned rate, 0 while still unknown
+	Learned   bool    // Expected was learned from the traffic, not configured
+	Deviating bool    // Rate has been off by more than the tolerance for a sustained period
+	Since     time.Time
+
+	Bursting  bool   // Rate is currently above burstFactor times the expected rate
+	Bursts    uint64 // Bursts seen so far
+	LastBurst Burst  // The ongoing or most recent burst
+}
+
+// SetExpectedRate sets the expected packet rate of a universe. Zero falls
+// back to the default rate.
+func (t *Tracker) SetExpectedRate(universeID uint16, hz float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if hz <= 0 {
+		delete(t.expectedRates, universeID)
+		return
+	}
+	t.expectedRates[universeID] = hz
+}
+
+// SetDefaultExpectedRate sets the expected packet rate of universes without
+// their own. Zero learns each universe's rate from its traffic instead.
+func (t *Tracker) SetDefaultExpectedRate(hz float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.defaultExpectedRate = max(hz, 0)
+}
+
+// GetRateStatus returns the packet rate of a universe against its expected
+// rate
+func (t *Tracker) GetRateStatus(universeID uint16) RateStatus {
+	t.mu.RLock()
+	stats := t.universes[universeID]
+	t.mu.RUnlock()
+
+	if stats == nil {
+		return RateStatus{}
+	}
+
+	rate := t.GetPacketRate(universeID)
+
+	stats.mu.RLock()
+	defer stats.mu.RUnlock()
+
+	status := stats.rate
+	status.Current = rate
+	return status
+}
+
+// expectedRate returns the configured rate of a universe, or 0 to learn it
+func (t *Tracker) expectedRate(universeID uint16) float64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if hz, ok := t.expectedRates[universeID]; ok {
+		return hz
+	}
+	return t.defaultExpectedRate
+}
+
+// checkRate compares the rate over the window ending at now against the
+// expected rate, or learns the rate when expected is 0. A learned rate only
+// flags drops: a sustained rise becomes the new learned rate. Caller must
+// hold stats.mu.
+func (stats *UniverseStats) checkRate(now time.Time, rate, expected float64) {
+	status := &stats.rate
+	status.Learned = expected == 0
+	if status.Learned {
+		if status.Expected == 0 {
+			status.Expected = rate
+			return
+		}
+	} else {
+		status.Expected = expected
+	}
+
+	stats.checkBurst(now, rate)
+
+	off := math.Abs(rate-status.Expected) > status.Expected*rateTolerance
+	if status.Learned && rate > status.Expected {
+		// Faster than learned: adopt it once sustained, like a deviation,
+		// unless it is a burst
+		if !off {
+			status.Expected += (rate - status.Expected) * rateLearnWeight
+		} else if stats.offRateSince.IsZero() {
+			stats.offRateSince = now
+		} else if now.Sub(stats.offRateSince) >= rateDeviationHold && !status.Bursting {
+			status.Expected = rate
+			stats.offRateSince = time.Time{}
+		}
+		status.Deviating = false
+		status.Since = time.Time{}
+		return
+	}
+
+	if !off {
+		stats.offRateSince = time.Time{}
+		status.Deviating = false
+		status.Since = time.Time{}
+		if status.Learned {
+			status.Expected += (rate - status.Expected) * rateLearnWeight
+		}
+		return
+	}
+
+	if stats.offRateSince.IsZero() {
+		stats.offRateSince = now
+	}
+	if !status.Deviating && now.Sub(stats.offRateSince) >= rateDeviationHold {
+		status.Deviating = true
+		status.Since = stats.offRateSince
+	}
+}
+
+// checkBurst tracks bursts of rate above burstFactor times the expected
+// rate. Unlike deviations they are flagged at once. Caller must hold
+// stats.mu.
+func (stats *UniverseStats) checkBurst(now time.Time, rate float64) {
+	status := &stats.rate
+	if rate > status.Expected*burstFactor {
+		if !status.Bursting {
+			status.Bursting = true
+			status.Bursts++
+			status.LastBurst = Burst{Start: now}
+		}
+		status.LastBurst.PeakRate = max(status.LastBurst.PeakRate, rate)
+		return
+	}
+	if status.Bursting {
+		status.Bursting = false
+		status.LastBurst.Duration = now.Sub(status.LastBurst.Start)
+	}
+}
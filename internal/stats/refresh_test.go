@@ -0,0 +1,84 @@
+package stats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCheckRate_ConfiguredDeviation(t *testing.T) {
+	stats := &UniverseStats{}
+	start := time.Now()
+
+	stats.checkRate(start, 44, 44)
+	if stats.rate.Deviating || stats.rate.Learned || stats.rate.Expected != 44 {
+		t.Fatalf("at full rate: %+v, want not deviating with expected 44", stats.rate)
+	}
+
+	// Dropping to keep-alive rate is only flagged once sustained
+	stats.checkRate(start.Add(time.Second), 1, 44)
+	if stats.rate.Deviating {
+		t.Error("flagged after one slow second")
+	}
+	stats.checkRate(start.Add(4*time.Second), 1, 44)
+	if !stats.rate.Deviating || !stats.rate.Since.Equal(start.Add(time.Second)) {
+		t.Errorf("after 3 s at 1 pps: %+v, want deviating since the drop", stats.rate)
+	}
+
+	stats.checkRate(start.Add(5*time.Second), 43, 44)
+	if stats.rate.Deviating {
+		t.Error("still deviating after the rate returned within tolerance")
+	}
+
+	// A configured rate flags rises too
+	stats.checkRate(start.Add(6*time.Second), 88, 44)
+	stats.checkRate(start.Add(9*time.Second), 88, 44)
+	if !stats.rate.Deviating {
+		t.Error("sustained rise above the configured rate not flagged")
+	}
+}
+
+func TestCheckRate_Learned(t *testing.T) {
+	stats := &UniverseStats{}
+	start := time.Now()
+
+	stats.checkRate(start, 30, 0)
+	if !stats.rate.Learned || stats.rate.Expected != 30 {
+		t.Fatalf("first sample: %+v, want learned rate 30", stats.rate)
+	}
+
+	// A sustained rise is adopted rather than flagged
+	stats.checkRate(start.Add(time.Second), 44, 0)
+	stats.checkRate(start.Add(4*time.Second), 44, 0)
+	if stats.rate.Deviating || stats.rate.Expected != 44 {
+		t.Errorf("after sustained rise: %+v, want learned rate 44", stats.rate)
+	}
+
+	// A sustained drop is flagged, and doesn't teach the lower rate
+	stats.checkRate(start.Add(5*time.Second), 1, 0)
+	stats.checkRate(start.Add(8*time.Second), 1, 0)
+	if !stats.rate.Deviating || stats.rate.Expected != 44 {
+		t.Errorf("after sustained drop: %+v, want deviating from 44", stats.rate)
+	}
+}
+
+func TestTracker_ExpectedRate(t *testing.T) {
+	tracker := NewTracker()
+	tracker.SetDefaultExpectedRate(44)
+	tracker.SetExpectedRate(2, 30)
+
+	if got := tracker.expectedRate(1); got != 44 {
+		t.Errorf("expectedRate(1) = %v, want default 44", got)
+	}
+	if got := tracker.expectedRate(2); got != 30 {
+		t.Errorf("expectedRate(2) = %v, want 30", got)
+	}
+
+	tracker.SetExpectedRate(2, 0)
+	if got := tracker.expectedRate(2); got != 44 {
+		t.Errorf("expectedRate(2) after clearing = %v, want default 44", got)
+	}
+
+	if got := tracker.GetRateStatus(1); got != (RateStatus{}) {
+		t.Errorf("GetRateStatus() of unknown universe = %+v, want zero", got)
+	}
+}
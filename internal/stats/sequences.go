@@ -0,0 +1,94 @@
+package stats
+
+import "time"
+
+// SequenceSample is one packet in a source's sequence timeline
+type SequenceSample struct {
+	Time     time.Time
+	Sequence uint8
+	Arrival  Arrival
+	Lost     int // Packets found missing just before this one
+}
+
+// sequenceRing keeps a source's most recent sequence samples
+type sequenceRing struct {
+	samples []SequenceSample
+	next    int
+	full    bool
+}
+
+// add records a sample, overwriting the oldest when full
+func (r *sequenceRing) add(s SequenceSample) {
+	r.samples[r.next] = s
+	r.next = (r.next + 1) % len(r.samples)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// all returns the samples oldest first
+func (r *sequenceRing) all() []SequenceSample {
+	if r == nil {
+		return nil
+	}
+	if !r.full {
+		return append([]SequenceSample(nil), r.samples[:r.next]...)
+	}
+	result := make([]SequenceSample, 0, len(r.samples))
+	result = append(result, r.samples[r.next:]...)
+	return append(result, r.samples[:r.next]...)
+}
+
+// String returns the arrival's name as used in exports
+func (a Arrival) String() string {
+	switch a {
+	case InOrder:
+		return "in_order"
+	case OutOfOrder:
+		return "out_of_order"
+	case Duplicate:
+		return "duplicate"
+	default:
+		return "unknown"
+	}
+}
+
+// SetSequenceTimeline enables recording the last n packets' sequence
+// numbers per source, for visualizing gaps over time. 0 (the default)
+// disables it. Sources already seen keep their current setting.
+func (t *Tracker) SetSequenceTimeline(n int) {
+	t.timelineSize.Store(int64(max(n, 0)))
+}
+
+// recordSequence adds a packet to a source's timeline, if enabled
+func (t *Tracker) recordSequence(source *Source, s SequenceSample) {
+	if source.timeline == nil {
+		n := t.timelineSize.Load()
+		if n == 0 {
+			return
+		}
+		source.timeline = &sequenceRing{samples: make([]SequenceSample, n)}
+	}
+	source.timeline.add(s)
+}
+
+// GetSequenceTimeline returns a source's recorded sequence timeline on a
+// universe, oldest first
+func (t *Tracker) GetSequenceTimeline(universeID uint16, sourceCID [16]byte) []SequenceSample {
+	t.mu.RLock()
+	stats := t.universes[universeID]
+	t.mu.RUnlock()
+
+	if stats == nil {
+		return nil
+	}
+
+	stats.mu.RLock()
+	defer stats.mu.RUnlock()
+
+	source := stats.Sources[sourceCID]
+	if source == nil {
+		return nil
+	}
+	return source.timeline.all()
+}
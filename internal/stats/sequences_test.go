@@ -0,0 +1,46 @@
+package stats
+
+import "testing"
+
+func TestTracker_SequenceTimeline(t *testing.T) {
+	tracker := NewTracker()
+	cid := [16]byte{1}
+
+	// Disabled by default
+	tracker.RecordPacket(1, cid, "console", 0)
+	if got := tracker.GetSequenceTimeline(1, cid); len(got) != 0 {
+		t.Fatalf("GetSequenceTimeline() = %+v while disabled, want none", got)
+	}
+
+	tracker.SetSequenceTimeline(4)
+	tracker.RecordPacket(2, cid, "console", 10)
+	tracker.RecordPacket(2, cid, "console", 13) // 11-12 lost
+	tracker.RecordPacket(2, cid, "console", 12) // Late
+	tracker.RecordPacket(2, cid, "console", 14)
+	tracker.RecordPacket(2, cid, "console", 14) // Duplicate
+
+	got := tracker.GetSequenceTimeline(2, cid)
+	want := []struct {
+		seq     uint8
+		arrival Arrival
+		lost    int
+	}{
+		{13, InOrder, 2},
+		{12, OutOfOrder, 0},
+		{14, InOrder, 0},
+		{14, Duplicate, 0},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("len(GetSequenceTimeline()) = %d, want %d (the ring size)", len(got), len(want))
+	}
+	for i, w := range want {
+		if got[i].Sequence != w.seq || got[i].Arrival != w.arrival || got[i].Lost != w.lost {
+			t.Errorf("sample %d = %+v, want seq %d %s lost %d", i, got[i], w.seq, w.arrival, w.lost)
+		}
+	}
+	for i := 1; i < len(got); i++ {
+		if got[i].Time.Before(got[i-1].Time) {
+			t.Errorf("samples not oldest first: %+v", got)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package stats
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// NotificationKind identifies what a notification reports
+type NotificationKind int
+
+const (
+	// NewSource is a source sending to a universe for the first time
+	NewSource NotificationKind = iota
+	// LossDetected is a sequence gap; Loss describes it
+	LossDetected
+	// RateChange is a universe starting or stopping deviating from its
+	// expected rate, or a burst starting or ending; Rate describes it
+	RateChange
+	// AddressChanged is a source arriving from a new address; Address
+	// describes it
+	AddressChanged
+	// PriorityChanged is a source changing its priority or per-address
+	// priority; Priority describes it
+	PriorityChanged
+)
+
+func (k NotificationKind) String() string {
+	switch k {
+	case NewSource:
+		return "new_source"
+	case LossDetected:
+		return "loss_detected"
+	case RateChange:
+		return "rate_change"
+	case AddressChanged:
+		return "address_changed"
+	case PriorityChanged:
+		return "priority_changed"
+	default:
+		return "unknown"
+	}
+}
+
+// Notification is pushed to subscribers when the tracker sees a change
+type Notification struct {
+	Kind     NotificationKind
+	Time     time.Time
+	Universe uint16
+	CID      [16]byte
+	Source   string
+
+	Loss     LossEvent      // For LossDetected
+	Rate     RateStatus     // For RateChange
+	Address  AddressChange  // For AddressChanged
+	Priority PriorityChange // For PriorityChanged
+}
+
+// subscribers fan notifications out to subscription channels
+type subscribers struct {
+	chans   map[chan Notification]struct{}
+	dropped atomic.Uint64 // Notifications dropped because a subscriber was full
+	mu      sync.RWMutex
+}
+
+// Subscribe returns a channel receiving notifications, buffered to size,
+// and a function ending the subscription and closing the channel.
+// Notifications are sent without blocking packet processing: a subscriber
+// that falls more than size behind misses notifications.
+func (t *Tracker) Subscribe(size int) (<-chan Notification, func()) {
+	ch := make(chan Notification, max(size, 1))
+
+	t.subs.mu.Lock()
+	if t.subs.chans == nil {
+		t.subs.chans = make(map[chan Notification]struct{})
+	}
+	t.subs.chans[ch] = struct{}{}
+	t.subs.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			t.subs.mu.Lock()
+			delete(t.subs.chans, ch)
+			close(ch)
+			t.subs.mu.Unlock()
+		})
+	}
+}
+
+// DroppedNotifications returns how many notifications were dropped because
+// a subscriber's channel was full
+func (t *Tracker) DroppedNotifications() uint64 {
+	return t.subs.dropped.Load()
+}
+
+// notify sends a notification to every subscriber without blocking
+func (t *Tracker) notify(n Notification) {
+	t.subs.mu.RLock()
+	defer t.subs.mu.RUnlock()
+
+	for ch := range t.subs.chans {
+		select {
+		case ch <- n:
+		default:
+			t.subs.dropped.Add(1)
+		}
+	}
+}
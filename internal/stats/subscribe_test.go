@@ -0,0 +1,58 @@
+package stats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTracker_Subscribe(t *testing.T) {
+	tracker := NewTracker()
+	notifications, unsubscribe := tracker.Subscribe(10)
+	cid := [16]byte{1}
+
+	tracker.RecordPacket(1, cid, "console", 0)
+	tracker.RecordPacket(1, cid, "console", 3) // 2 lost
+	tracker.RecordSourceAddress(cid, "10.0.0.1")
+	tracker.RecordSourceAddress(cid, "10.0.0.2")
+
+	var got []Notification
+	for len(got) < 3 {
+		select {
+		case n := <-notifications:
+			got = append(got, n)
+		case <-time.After(time.Second):
+			t.Fatalf("got %d notifications, want 3", len(got))
+		}
+	}
+
+	if got[0].Kind != NewSource || got[0].Universe != 1 || got[0].Source != "console" {
+		t.Errorf("first = %+v, want new_source for console on universe 1", got[0])
+	}
+	if got[1].Kind != LossDetected || got[1].Loss.Lost != 2 {
+		t.Errorf("second = %+v, want loss_detected of 2 packets", got[1])
+	}
+	if got[2].Kind != AddressChanged || got[2].Address.Current != "10.0.0.2" {
+		t.Errorf("third = %+v, want address_changed to 10.0.0.2", got[2])
+	}
+
+	unsubscribe()
+	unsubscribe() // Safe to call twice
+	if _, open := <-notifications; open {
+		t.Error("channel still open after unsubscribe")
+	}
+	tracker.RecordPacket(2, cid, "console", 0) // Must not panic on the closed channel
+}
+
+func TestTracker_Subscribe_FullSubscriberDropped(t *testing.T) {
+	tracker := NewTracker()
+	_, unsubscribe := tracker.Subscribe(1)
+	defer unsubscribe()
+
+	for id := uint16(1); id <= 3; id++ {
+		tracker.RecordPacket(id, [16]byte{1}, "console", 0)
+	}
+
+	if got := tracker.DroppedNotifications(); got != 2 {
+		t.Errorf("DroppedNotifications() = %d, want 2", got)
+	}
+}
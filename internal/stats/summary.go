@@ -0,0 +1,174 @@
+package stats
+
+import (
+	"sort"
+	"time"
+)
+
+// Summary is an end-of-session report of every universe and source
+type Summary struct {
+	Start     time.Time         `json:"start"`
+	End       time.Time         `json:"end"`
+	Duration  time.Duration     `json:"duration"`
+	Universes []UniverseSummary `json:"universes"`
+}
+
+// UniverseSummary is the session report of one universe
+type UniverseSummary struct {
+	Universe       uint16          `json:"universe"`
+	FirstSeen      time.Time       `json:"first_seen"`
+	LastSeen       time.Time       `json:"last_seen"`
+	Monitored      time.Duration   `json:"monitored"` // Since first seen
+	Packets        uint64          `json:"packets"`
+	Lost           uint64          `json:"lost"`
+	LossPercentage float64         `json:"loss_percentage"`
+	OutOfOrder     uint64          `json:"out_of_order"`
+	Duplicates     uint64          `json:"duplicates"`
+	ReceiverDrops  uint64          `json:"receiver_drops"`
+	Bytes          uint64          `json:"bytes"`
+	WorstGap       time.Duration   `json:"worst_gap"` // Longest gap of any source
+	WorstGapAt     time.Time       `json:"worst_gap_at"`
+	Outages        uint64          `json:"outages"`
+	OfflineTime    time.Duration   `json:"offline_time"`
+	Availability   float64         `json:"availability"`
+	Sources        []SourceSummary `json:"sources"`
+}
+
+// SourceSummary is the session report of one source on a universe
+type SourceSummary struct {
+	CID            [16]byte      `json:"cid"`
+	Name           string        `json:"name"`
+	Address        string        `json:"address,omitempty"`
+	FirstSeen      time.Time     `json:"first_seen"`
+	LastSeen       time.Time     `json:"last_seen"`
+	Monitored      time.Duration `json:"monitored"`
+	Packets        uint64        `json:"packets"`
+	Lost           uint64        `json:"lost"`
+	LossPercentage float64       `json:"loss_percentage"`
+	WorstGap       time.Duration `json:"worst_gap"`
+	WorstGapAt     time.Time     `json:"worst_gap_at"`
+	Outages        uint64        `json:"outages"`
+	OfflineTime    time.Duration `json:"offline_time"`
+	LongestOutage  time.Duration `json:"longest_outage"`
+	Availability   float64       `json:"availability"`
+
+	// Offline periods still in the outage log, oldest first, including an
+	// ongoing one
+	Offline []Gap `json:"offline,omitempty"`
+}
+
+// Summary reports the session so far per universe and source, universes
+// in ID order and sources by name
+func (t *Tracker) Summary() Summary {
+	now := t.clock.Now()
+
+	t.mu.RLock()
+	start := t.started
+	t.mu.RUnlock()
+
+	summary := Summary{Start: start, End: now, Duration: now.Sub(start)}
+
+	// Outages per source, oldest first
+	type sourceKey struct {
+		universe uint16
+		cid      [16]byte
+	}
+	offline := make(map[sourceKey][]Gap)
+	for _, o := range reversed(t.GetOutages(0)) {
+		key := sourceKey{o.Universe, o.CID}
+		offline[key] = append(offline[key], o)
+	}
+
+	ids := t.GetAllUniverseIDs()
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for _, id := range ids {
+		stats := t.GetUniverseStats(id)
+		if stats == nil {
+			continue
+		}
+
+		stats.mu.RLock()
+		u := UniverseSummary{
+			Universe:       id,
+			FirstSeen:      stats.Uptime.FirstSeen,
+			LastSeen:       stats.Uptime.LastSeen,
+			Monitored:      sinceFirstSeen(stats.Uptime, now),
+			Packets:        stats.PacketCount,
+			Lost:           stats.LostPackets,
+			LossPercentage: lossPercentage(stats.PacketCount, stats.LostPackets),
+			OutOfOrder:     stats.OutOfOrder,
+			Duplicates:     stats.Duplicates,
+			ReceiverDrops:  stats.ReceiverDrops,
+			Bytes:          stats.Bytes,
+			Outages:        stats.Uptime.Outages,
+			OfflineTime:    stats.Uptime.OfflineTime(now),
+			Availability:   stats.Uptime.Availability(now),
+		}
+		for _, s := range stats.Sources {
+			src := SourceSummary{
+				CID:            s.CID,
+				Name:           s.Name,
+				FirstSeen:      s.Uptime.FirstSeen,
+				LastSeen:       s.Uptime.LastSeen,
+				Monitored:      sinceFirstSeen(s.Uptime, now),
+				Packets:        s.PacketCount,
+				Lost:           s.LostPackets,
+				LossPercentage: lossPercentage(s.PacketCount, s.LostPackets),
+				WorstGap:       s.LongestGap,
+				WorstGapAt:     s.LongestGapAt,
+				Outages:        s.Uptime.Outages,
+				OfflineTime:    s.Uptime.OfflineTime(now),
+				LongestOutage:  s.Uptime.LongestOutage,
+				Availability:   s.Uptime.Availability(now),
+				Offline:        offline[sourceKey{id, s.CID}],
+			}
+			if !s.Uptime.FirstSeen.IsZero() && !s.Uptime.Online(now) {
+				src.Offline = append(src.Offline, Gap{
+					Universe: id,
+					CID:      s.CID,
+					Source:   s.Name,
+					Start:    s.Uptime.LastSeen,
+					Duration: now.Sub(s.Uptime.LastSeen),
+				})
+			}
+			if s.LongestGap > u.WorstGap {
+				u.WorstGap = s.LongestGap
+				u.WorstGapAt = s.LongestGapAt
+			}
+			u.Sources = append(u.Sources, src)
+		}
+		stats.mu.RUnlock()
+
+		sort.Slice(u.Sources, func(i, j int) bool { return u.Sources[i].Name < u.Sources[j].Name })
+		summary.Universes = append(summary.Universes, u)
+	}
+
+	// Addresses are kept per CID
+	t.mu.RLock()
+	for i := range summary.Universes {
+		for j := range summary.Universes[i].Sources {
+			src := &summary.Universes[i].Sources[j]
+			src.Address = t.sourceAddrs[src.CID].current
+		}
+	}
+	t.mu.RUnlock()
+
+	return summary
+}
+
+// sinceFirstSeen returns how long ago something was first seen
+func sinceFirstSeen(u Uptime, now time.Time) time.Duration {
+	if u.FirstSeen.IsZero() {
+		return 0
+	}
+	return now.Sub(u.FirstSeen)
+}
+
+// lossPercentage returns lost packets as a share of all expected packets
+func lossPercentage(received, lost uint64) float64 {
+	total := received + lost
+	if total == 0 {
+		return 0
+	}
+	return float64(lost) / float64(total) * 100
+}
@@ -0,0 +1,85 @@
+package stats
+
+import (
+	"testing"
+	"time"
+)
+
+// ageSource moves a source's last packet back by d, as if it had been
+// silent since
+func ageSource(tracker *Tracker, universeID uint16, cid [16]byte, d time.Duration) {
+	stats := tracker.GetUniverseStats(universeID)
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+	src := stats.Sources[cid]
+	src.LastSeen = src.LastSeen.Add(-d)
+	src.Uptime.LastSeen = src.Uptime.LastSeen.Add(-d)
+	src.Uptime.FirstSeen = src.Uptime.FirstSeen.Add(-d)
+	stats.Uptime.LastSeen = stats.Uptime.LastSeen.Add(-d)
+	stats.Uptime.FirstSeen = stats.Uptime.FirstSeen.Add(-d)
+}
+
+func TestTracker_Summary(t *testing.T) {
+	tracker := NewTracker()
+	main, backup := [16]byte{1}, [16]byte{2}
+
+	tracker.RecordSourceAddress(main, "10.0.0.1")
+	tracker.RecordPacket(2, main, "main", 0)
+	ageSource(tracker, 2, main, 5*time.Second)
+	tracker.RecordPacket(2, main, "main", 1) // Back after an outage
+	tracker.RecordPacket(2, main, "main", 4) // 2-3 lost
+	tracker.RecordPacket(1, backup, "backup", 0)
+	ageSource(tracker, 1, backup, 10*time.Second) // Offline since
+
+	summary := tracker.Summary()
+	if summary.Duration <= 0 || summary.End.Before(summary.Start) {
+		t.Errorf("session = %s from %s, want a positive duration", summary.Duration, summary.Start)
+	}
+	if len(summary.Universes) != 2 || summary.Universes[0].Universe != 1 || summary.Universes[1].Universe != 2 {
+		t.Fatalf("universes = %+v, want 1 and 2 in order", summary.Universes)
+	}
+
+	u := summary.Universes[1]
+	if u.Packets != 3 || u.Lost != 2 || u.LossPercentage != 40 {
+		t.Errorf("universe 2 packets/lost/loss = %d/%d/%v, want 3/2/40", u.Packets, u.Lost, u.LossPercentage)
+	}
+	if u.WorstGap < 5*time.Second || u.Outages != 1 {
+		t.Errorf("universe 2 worst gap %s, outages %d; want >= 5s and 1", u.WorstGap, u.Outages)
+	}
+	src := u.Sources[0]
+	if src.Name != "main" || src.Address != "10.0.0.1" || len(src.Offline) != 1 || src.Offline[0].Duration < 5*time.Second {
+		t.Errorf("main = %+v, want one finished 5s offline period from 10.0.0.1", src)
+	}
+
+	// A source still offline reports the ongoing period
+	backupSrc := summary.Universes[0].Sources[0]
+	if len(backupSrc.Offline) != 1 || backupSrc.Offline[0].Duration < 10*time.Second {
+		t.Errorf("backup offline = %+v, want an ongoing 10s period", backupSrc.Offline)
+	}
+	if backupSrc.Monitored < 10*time.Second {
+		t.Errorf("backup monitored %s, want >= 10s", backupSrc.Monitored)
+	}
+}
+
+func TestTracker_Outages(t *testing.T) {
+	tracker := NewTracker()
+	cid := [16]byte{1}
+
+	tracker.RecordPacket(1, cid, "console", 0)
+	tracker.RecordPacket(1, cid, "console", 1)
+	if got := tracker.GetOutages(0); len(got) != 0 {
+		t.Fatalf("GetOutages() = %+v, want none", got)
+	}
+
+	ageSource(tracker, 1, cid, 3*time.Second)
+	tracker.RecordPacket(1, cid, "console", 2)
+	got := tracker.GetOutages(0)
+	if len(got) != 1 || got[0].Universe != 1 || got[0].Duration < 3*time.Second {
+		t.Errorf("GetOutages() = %+v, want one 3s outage", got)
+	}
+
+	tracker.ResetUniverseStats(1)
+	if got := tracker.GetOutages(0); len(got) != 0 {
+		t.Errorf("GetOutages() = %+v after reset, want none", got)
+	}
+}
@@ -2,7 +2,10 @@ package stats
 
 import (
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"sacn-monitor/internal/clock"
 )
 
 // Constants for loss tracking
@@ -11,23 +14,80 @@ const (
 	lossWindowDuration = time.Minute
 	// sourceRestartThreshold is the sequence gap above which we assume source restart
 	sourceRestartThreshold = 200
+	// duplicateWindow is how soon a repeat of a source's last sequence number
+	// must arrive to count as a second copy of the same packet (e.g. a gateway
+	// sending both unicast and multicast)
+	duplicateWindow = 100 * time.Millisecond
+	// outOfOrderWindow is how many sequence numbers back a packet may be and
+	// still count as a late arrival rather than a source restart
+	outOfOrderWindow = 20
+	// dataLossTimeout is the silence after which a source counts as lost
+	// (E1.31 network data loss timeout); the rate check starts over after it
+	dataLossTimeout = 2500 * time.Millisecond
+	// dropMarkTimeout is how long a receiver drop excuses its sequence
+	// number. The packets queued ahead of the drop are processed well within
+	// it, while a mark the gap check never used can't excuse real loss a
+	// sequence lap later.
+	dropMarkTimeout = 2500 * time.Millisecond
 )
 
-// PacketEvent records a packet reception event for sliding window tracking
-type PacketEvent struct {
-	Timestamp time.Time
-	Received  uint64 // packets received in this event
-	Lost      uint64 // packets lost detected in this event
-}
+// Arrival classifies a packet by its sequence number
+type Arrival int
+
+const (
+	// InOrder is a new packet: the next expected one, or one after a gap
+	InOrder Arrival = iota
+	// OutOfOrder is a late packet that fills a gap previously counted as loss
+	OutOfOrder
+	// Duplicate is a second copy of a packet already received
+	Duplicate
+)
 
 // Source represents a unique sACN source
 type Source struct {
-	CID          [16]byte
-	Name         string
-	LastSequence uint8
-	LastSeen     time.Time
-	PacketCount  uint64
-	LostPackets  uint64
+	CID           [16]byte
+	Name          string
+	LastSequence  uint8
+	LastSeen      time.Time
+	PacketCount   uint64
+	LostPackets   uint64 // Packets missing from the sequence, excluding late arrivals
+	OutOfOrder    uint64 // Packets that arrived late, after a newer one
+	Duplicates    uint64 // Extra copies of already received packets
+	ReceiverDrops uint64 // Packets dropped locally by the receiver, not counted as lost
+	Bytes         uint64 // Bytes received, including duplicates
+	LongestGap    time.Duration
+	LongestGapAt  time.Time // When the longest gap started
+	Uptime        Uptime
+
+	// Declared priority of the latest data packet, and whether per-address
+	// priority (start code 0xDD) is being sent
+	Priority           uint8
+	PerAddressPriority bool
+
+	// Slots in the latest data packet, and when its levels last changed
+	Slots      int
+	LastChange time.Time
+
+	missing  [256]bool      // Sequence numbers counted as lost that may still arrive late
+	dropped  [256]time.Time // When the receiver dropped each sequence number, zero if not
+	restored bool           // Restored from a saved state; the next packet starts a fresh sequence
+	flaps    flaps          // Recent offline→online transitions
+
+	timeline      *sequenceRing // Recent sequence numbers, if enabled
+	priorityKnown bool          // Priority has been recorded
+	perAddressAt  time.Time     // Latest per-address priority packet
+	dataHash      uint64        // Hash of the latest levels, to detect changes
+
+	// Addresses the CID was recently seen from, and whether that is more
+	// than one (a duplicated CID), the address of its latest packet and the
+	// address before the last change, and how its latest packet was
+	// addressed. Filled in by GetSources.
+	Addresses        []string
+	DuplicateCID     bool
+	Address          string
+	PreviousAddress  string
+	AddressChangedAt time.Time
+	Transport        string
 }
 
 // UniverseStats tracks statistics for a single universe
@@ -36,59 +96,96 @@ type UniverseStats struct {
 	Sources         map[[16]byte]*Source
 	PacketCount     uint64
 	LostPackets     uint64
+	OutOfOrder      uint64 // Late packets, included in PacketCount
+	Duplicates      uint64 // Extra copies of already received packets, not in PacketCount
+	ReceiverDrops   uint64 // Packets dropped locally by the receiver, not in LostPackets
+	Bytes           uint64 // Bytes received, including duplicates
 	LastPacket      time.Time
-	packetsInWindow []time.Time   // For rate calculation
-	lossWindow      []PacketEvent // For sliding window loss calculation
+	Uptime          Uptime
+	rateCounts      *bucketWindow              // Packets and bytes over the rate window
+	lossCounts      *bucketWindow              // Received, lost and recovered over the last minute
+	sourceCounts    map[[16]byte]*bucketWindow // Packets and bytes per source over the rate window
+	intervals       *intervalWindow
+	histogram       *intervalHistogram // Inter-arrival percentiles over the last minute
+	sourceIntervals map[[16]byte]*intervalWindow
+	peak            Peak
+	rate            RateStatus // Rate check state; Current is filled in on read
+	rateSince       time.Time  // Start of the current run of packets, for a full rate window
+	offRateSince    time.Time  // Start of the current off-rate period
 	mu              sync.RWMutex
 }
 
 // Tracker tracks packet statistics for all universes
 type Tracker struct {
-	universes  map[uint16]*UniverseStats
-	rateWindow time.Duration
-	mu         sync.RWMutex
+	universes      map[uint16]*UniverseStats
+	addresses      map[[16]byte]map[string]time.Time // Last time each CID was seen per address
+	sourceAddrs    map[[16]byte]sourceAddress        // Current and previous address per CID
+	addressChanges []AddressChange                   // CIDs moving to a new address, oldest first
+	rateWindow     time.Duration
+	started        time.Time // Start of the session, for the summary
+	clock          clock.Clock
+	mu             sync.RWMutex
+
+	// Expected packet rates per universe, and for the rest (0 = learn)
+	expectedRates       map[uint16]float64
+	defaultExpectedRate float64
+
+	// Gap and loss logs, under their own lock since entries are recorded
+	// while holding a universe's lock
+	gapThreshold    time.Duration
+	gaps            []Gap            // Gaps above the threshold, oldest first
+	outages         []Gap            // Source silences over the data loss timeout, oldest first
+	losses          []LossEvent      // Detected losses, oldest first
+	priorityChanges []PriorityChange // Source priority changes, oldest first
+	logMu           sync.Mutex
+
+	// Network-wide rate window and peaks, under their own lock for the
+	// same reason
+	totalCounts *bucketWindow
+	totalPeak   Peak
+	totalMu     sync.Mutex
+
+	subs subscribers
+
+	timelineSize atomic.Int64 // Packets kept in each source's sequence timeline, 0 = off
 }
 
 // NewTracker creates a new stats tracker
 func NewTracker() *Tracker {
 	return &Tracker{
-		universes:  make(map[uint16]*UniverseStats),
-		rateWindow: time.Second, // Calculate rate over 1 second window
+		universes:     make(map[uint16]*UniverseStats),
+		addresses:     make(map[[16]byte]map[string]time.Time),
+		sourceAddrs:   make(map[[16]byte]sourceAddress),
+		expectedRates: make(map[uint16]float64),
+		rateWindow:    time.Second, // Calculate rate over 1 second window
+		gapThreshold:  defaultGapThreshold,
+		totalCounts:   newBucketWindow(time.Second, rateBuckets),
+		started:       time.Now(),
+		clock:         clock.Real{},
 	}
 }
 
-// RecordPacket records a packet for statistics tracking
-func (t *Tracker) RecordPacket(universeID uint16, sourceCID [16]byte, sourceName string, sequence uint8) {
+// SetClock makes the tracker read the time from c instead of the wall
+// clock, e.g. to replay a capture with its timestamps. The session starts
+// at the clock's current time. Set it before recording packets.
+func (t *Tracker) SetClock(c clock.Clock) {
 	t.mu.Lock()
-	stats, exists := t.universes[universeID]
-	if !exists {
-		stats = &UniverseStats{
-			UniverseID: universeID,
-			Sources:    make(map[[16]byte]*Source),
-		}
-		t.universes[universeID] = stats
-	}
-	t.mu.Unlock()
+	defer t.mu.Unlock()
+	t.clock = c
+	t.started = c.Now()
+}
+
+// RecordPacket records a packet for statistics tracking and classifies it
+// by sequence number. Only InOrder packets carry new data; out-of-order and
+// duplicate packets are counted but should not be processed again.
+func (t *Tracker) RecordPacket(universeID uint16, sourceCID [16]byte, sourceName string, sequence uint8) Arrival {
+	stats := t.getOrCreate(universeID)
+	expected := t.expectedRate(universeID)
 
 	stats.mu.Lock()
 	defer stats.mu.Unlock()
 
-	now := time.Now()
-	stats.PacketCount++
-	stats.LastPacket = now
-
-	// Add to rate window
-	stats.packetsInWindow = append(stats.packetsInWindow, now)
-
-	// Clean old packets from window
-	cutoff := now.Add(-t.rateWindow)
-	newWindow := stats.packetsInWindow[:0]
-	for _, pt := range stats.packetsInWindow {
-		if pt.After(cutoff) {
-			newWindow = append(newWindow, pt)
-		}
-	}
-	stats.packetsInWindow = newWindow
+	now := t.clock.Now()
 
 	// Track source
 	source, sourceExists := stats.Sources[sourceCID]
@@ -98,53 +195,181 @@ func (t *Tracker) RecordPacket(universeID uint16, sourceCID [16]byte, sourceName
 			Name: sourceName,
 		}
 		stats.Sources[sourceCID] = source
+		t.notify(Notification{Kind: NewSource, Time: now, Universe: universeID, CID: sourceCID, Source: sourceName})
+	}
+	seen := source.PacketCount > 0 && !source.restored
+	source.restored = false
+
+	// Sequence distance from the last packet, as a signed step
+	// (E1.31 section 6.7.2)
+	step := int8(sequence - source.LastSequence)
+
+	// A repeat of the last sequence number arriving right after it is a
+	// second copy of the same packet, not a new one
+	if seen && step == 0 && now.Sub(source.LastSeen) < duplicateWindow {
+		source.Duplicates++
+		stats.Duplicates++
+		t.recordSequence(source, SequenceSample{Time: now, Sequence: sequence, Arrival: Duplicate})
+		return Duplicate
+	}
+
+	// A packet slightly behind the last one arrived late. If it filled a
+	// gap counted as loss it wasn't lost after all; otherwise it is a late
+	// copy of a packet already received.
+	if seen && step < 0 && step > -outOfOrderWindow {
+		if !source.missing[sequence] {
+			source.Duplicates++
+			stats.Duplicates++
+			t.recordSequence(source, SequenceSample{Time: now, Sequence: sequence, Arrival: Duplicate})
+			return Duplicate
+		}
+		source.missing[sequence] = false
+		source.OutOfOrder++
+		stats.OutOfOrder++
+		if source.LostPackets > 0 {
+			source.LostPackets--
+		}
+		if stats.LostPackets > 0 {
+			stats.LostPackets--
+		}
+		stats.PacketCount++
+		source.PacketCount++
+		stats.rateCounts.record(now, counts{Received: 1})
+		stats.peak.observe(now, stats.rateCounts.sum(now), t.rateWindow)
+		t.recordTotal(now, counts{Received: 1})
+		stats.sourceWindow(sourceCID, t.rateWindow).record(now, counts{Received: 1})
+		stats.lossCounts.record(now, counts{Received: 1, Recovered: 1})
+		t.recordSequence(source, SequenceSample{Time: now, Sequence: sequence, Arrival: OutOfOrder})
+		return OutOfOrder
+	}
+
+	// Inter-arrival time for jitter, on the universe and per source
+	if !stats.LastPacket.IsZero() {
+		stats.intervals.add(now.Sub(stats.LastPacket))
+		stats.histogram.add(now, now.Sub(stats.LastPacket))
+	}
+	if seen {
+		window, ok := stats.sourceIntervals[sourceCID]
+		if !ok {
+			window = &intervalWindow{}
+			stats.sourceIntervals[sourceCID] = window
+		}
+		window.add(now.Sub(source.LastSeen))
+		t.recordGap(stats, source, now.Sub(source.LastSeen))
+
+		// A source coming back after being lost is flapping
+		if now.Sub(source.LastSeen) > dataLossTimeout {
+			stats.lossCounts.record(now, counts{Reconnects: 1})
+			source.flaps.record(now)
+			t.recordOutage(Gap{
+				Universe: universeID,
+				CID:      sourceCID,
+				Source:   sourceName,
+				Start:    source.LastSeen,
+				Duration: now.Sub(source.LastSeen),
+			})
+		}
+	}
+
+	// The rate is only checked once a full window of packets is in, and
+	// restarts after the universe went silent
+	if stats.rateSince.IsZero() || now.Sub(stats.LastPacket) > dataLossTimeout {
+		stats.rateSince = now
+	}
+
+	stats.PacketCount++
+	stats.LastPacket = now
+	stats.Uptime.seen(now)
+	source.Uptime.seen(now)
+	stats.rateCounts.record(now, counts{Received: 1})
+	stats.sourceWindow(sourceCID, t.rateWindow).record(now, counts{Received: 1})
+	recent := stats.rateCounts.sum(now)
+	stats.peak.observe(now, recent, t.rateWindow)
+	t.recordTotal(now, counts{Received: 1})
+	if now.Sub(stats.rateSince) >= t.rateWindow {
+		rate := float64(recent.Received) / t.rateWindow.Seconds()
+		before := stats.rate
+		stats.checkRate(now, rate, expected)
+		if stats.rate.Deviating != before.Deviating || stats.rate.Bursting != before.Bursting {
+			status := stats.rate
+			status.Current = rate
+			t.notify(Notification{Kind: RateChange, Time: now, Universe: universeID, CID: sourceCID, Source: sourceName, Rate: status})
+		}
 	}
 
-	// Check for packet loss (sequence gap)
+	// Check for packet loss (sequence gap). Steps further back than the
+	// out-of-order window, like large forward gaps, mean the source restarted.
+	// Packets the receiver dropped itself are already counted and not lost.
 	var lostThisPacket uint64
-	if sourceExists && source.PacketCount > 0 {
-		expectedSeq := uint8((int(source.LastSequence) + 1) % 256)
-		if sequence != expectedSeq {
-			// Calculate how many packets were lost
-			var lost int
-			if sequence > expectedSeq {
-				lost = int(sequence) - int(expectedSeq)
-			} else {
-				// Wrapped around
-				lost = 256 - int(expectedSeq) + int(sequence)
+	if seen && step != 1 {
+		gap := int(uint8(sequence-source.LastSequence)) - 1
+		// If gap is too large, assume source restart rather than massive loss
+		lost := 0
+		if gap > 0 && gap < sourceRestartThreshold {
+			for i := 1; i <= gap; i++ {
+				seq := source.LastSequence + uint8(i)
+				if at := source.dropped[seq]; !at.IsZero() && now.Sub(at) <= dropMarkTimeout {
+					source.dropped[seq] = time.Time{}
+					continue
+				}
+				source.missing[seq] = true
+				lost++
 			}
-			// If gap is too large, assume source restart rather than massive loss
-			if lost < sourceRestartThreshold {
-				lostThisPacket = uint64(lost)
-				source.LostPackets += lostThisPacket
-				stats.LostPackets += lostThisPacket
+		}
+		if lost > 0 {
+			lostThisPacket = uint64(lost)
+			source.LostPackets += lostThisPacket
+			stats.LostPackets += lostThisPacket
+			loss := LossEvent{
+				Time:          now,
+				Universe:      universeID,
+				CID:           sourceCID,
+				Source:        sourceName,
+				Lost:          lost,
+				FirstMissing:  source.LastSequence + 1,
+				LastMissing:   sequence - 1,
+				ReceivedAfter: source.LastSequence,
+				ReceivedNext:  sequence,
 			}
-			// If lost >= sourceRestartThreshold, we treat it as a restart
-			// and don't count any loss
+			t.recordLoss(loss)
+			t.notify(Notification{Kind: LossDetected, Time: now, Universe: universeID, CID: sourceCID, Source: sourceName, Loss: loss})
 		}
 	}
+	// The drop mark stays: packets queued ahead of a drop can carry its
+	// sequence number from a lap earlier
+	source.missing[sequence] = false
 
 	// Record event for sliding window loss tracking
-	stats.lossWindow = append(stats.lossWindow, PacketEvent{
-		Timestamp: now,
-		Received:  1,
-		Lost:      lostThisPacket,
-	})
-
-	// Clean old events from loss window
-	lossCutoff := now.Add(-lossWindowDuration)
-	newLossWindow := stats.lossWindow[:0]
-	for _, evt := range stats.lossWindow {
-		if evt.Timestamp.After(lossCutoff) {
-			newLossWindow = append(newLossWindow, evt)
-		}
-	}
-	stats.lossWindow = newLossWindow
+	stats.lossCounts.record(now, counts{Received: 1, Lost: lostThisPacket})
 
 	source.LastSequence = sequence
 	source.LastSeen = now
 	source.PacketCount++
 	source.Name = sourceName // Update name in case it changed
+	t.recordSequence(source, SequenceSample{Time: now, Sequence: sequence, Arrival: InOrder, Lost: int(lostThisPacket)})
+	return InOrder
+}
+
+// getOrCreate returns the stats of a universe, creating them on first use
+func (t *Tracker) getOrCreate(universeID uint16) *UniverseStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats, exists := t.universes[universeID]
+	if !exists {
+		stats = &UniverseStats{
+			UniverseID:      universeID,
+			Sources:         make(map[[16]byte]*Source),
+			rateCounts:      newBucketWindow(t.rateWindow, rateBuckets),
+			lossCounts:      newBucketWindow(lossWindowDuration, lossBuckets),
+			sourceCounts:    make(map[[16]byte]*bucketWindow),
+			intervals:       &intervalWindow{},
+			histogram:       &intervalHistogram{},
+			sourceIntervals: make(map[[16]byte]*intervalWindow),
+		}
+		t.universes[universeID] = stats
+	}
+	return stats
 }
 
 // GetUniverseStats returns stats for a specific universe
@@ -167,17 +392,7 @@ func (t *Tracker) GetPacketRate(universeID uint16) float64 {
 	stats.mu.RLock()
 	defer stats.mu.RUnlock()
 
-	// Clean old packets and count
-	now := time.Now()
-	cutoff := now.Add(-t.rateWindow)
-	count := 0
-	for _, pt := range stats.packetsInWindow {
-		if pt.After(cutoff) {
-			count++
-		}
-	}
-
-	return float64(count) / t.rateWindow.Seconds()
+	return float64(stats.rateCounts.sum(t.clock.Now()).Received) / t.rateWindow.Seconds()
 }
 
 // GetLossPercentage returns cumulative packet loss percentage for a universe
@@ -214,24 +429,37 @@ func (t *Tracker) GetRecentLossPercentage(universeID uint16) float64 {
 	stats.mu.RLock()
 	defer stats.mu.RUnlock()
 
-	// Sum up received and lost from the sliding window
-	now := time.Now()
-	cutoff := now.Add(-lossWindowDuration)
+	return stats.lossCounts.sum(t.clock.Now()).lossPercentage()
+}
 
-	var totalReceived, totalLost uint64
-	for _, evt := range stats.lossWindow {
-		if evt.Timestamp.After(cutoff) {
-			totalReceived += evt.Received
-			totalLost += evt.Lost
-		}
+// GetDuplicateCount returns how many duplicate packets a universe received
+func (t *Tracker) GetDuplicateCount(universeID uint16) uint64 {
+	t.mu.RLock()
+	stats := t.universes[universeID]
+	t.mu.RUnlock()
+
+	if stats == nil {
+		return 0
 	}
 
-	totalExpected := totalReceived + totalLost
-	if totalExpected == 0 {
+	stats.mu.RLock()
+	defer stats.mu.RUnlock()
+	return stats.Duplicates
+}
+
+// GetOutOfOrderCount returns how many late packets a universe received
+func (t *Tracker) GetOutOfOrderCount(universeID uint16) uint64 {
+	t.mu.RLock()
+	stats := t.universes[universeID]
+	t.mu.RUnlock()
+
+	if stats == nil {
 		return 0
 	}
 
-	return float64(totalLost) / float64(totalExpected) * 100
+	stats.mu.RLock()
+	defer stats.mu.RUnlock()
+	return stats.OutOfOrder
 }
 
 // GetSourceLossPercentage returns packet loss percentage for a specific source
@@ -269,22 +497,112 @@ func (t *Tracker) ResetUniverseStats(universeID uint16) {
 		stats.mu.Lock()
 		stats.PacketCount = 0
 		stats.LostPackets = 0
-		stats.packetsInWindow = nil
-		stats.lossWindow = nil
+		stats.Duplicates = 0
+		stats.ReceiverDrops = 0
+		stats.OutOfOrder = 0
+		stats.Bytes = 0
+		stats.rateCounts.reset()
+		stats.lossCounts.reset()
+		stats.sourceCounts = make(map[[16]byte]*bucketWindow)
+		stats.intervals = &intervalWindow{}
+		stats.histogram = &intervalHistogram{}
+		stats.sourceIntervals = make(map[[16]byte]*intervalWindow)
+		stats.rate = RateStatus{}
+		stats.peak = Peak{}
+		stats.Uptime = Uptime{}
+		stats.rateSince = time.Time{}
+		stats.offRateSince = time.Time{}
 		for _, source := range stats.Sources {
 			source.PacketCount = 0
 			source.LostPackets = 0
+			source.Duplicates = 0
+			source.OutOfOrder = 0
+			source.Bytes = 0
+			source.LongestGap = 0
+			source.LongestGapAt = time.Time{}
+			source.Uptime = Uptime{}
+			source.flaps = flaps{}
+			source.ReceiverDrops = 0
+			source.missing = [256]bool{}
+			source.dropped = [256]time.Time{}
+			source.timeline = nil
 		}
 		stats.mu.Unlock()
+		t.removeGaps(universeID)
+		t.removeLosses(universeID)
+		t.removePriorityChanges(universeID)
+		t.removeOutages(universeID)
 	}
 }
 
+// RemoveUniverse drops everything tracked for a universe, for universes
+// the manager no longer holds. The addresses of its sources go too unless
+// they're still seen on another universe.
+func (t *Tracker) RemoveUniverse(universeID uint16) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats, exists := t.universes[universeID]
+	if !exists {
+		return
+	}
+	delete(t.universes, universeID)
+
+	stats.mu.RLock()
+	cids := make([][16]byte, 0, len(stats.Sources))
+	for cid := range stats.Sources {
+		cids = append(cids, cid)
+	}
+	stats.mu.RUnlock()
+	for _, cid := range cids {
+		if !t.sourceSeen(cid) {
+			delete(t.addresses, cid)
+			delete(t.sourceAddrs, cid)
+		}
+	}
+
+	t.removeGaps(universeID)
+	t.removeLosses(universeID)
+	t.removePriorityChanges(universeID)
+	t.removeOutages(universeID)
+}
+
+// sourceSeen reports whether a CID sends to any tracked universe.
+// Caller must hold t.mu.
+func (t *Tracker) sourceSeen(cid [16]byte) bool {
+	for _, stats := range t.universes {
+		stats.mu.RLock()
+		_, ok := stats.Sources[cid]
+		stats.mu.RUnlock()
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
 // ResetAllStats clears all tracked data
 func (t *Tracker) ResetAllStats() {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
 	t.universes = make(map[uint16]*UniverseStats)
+	t.addresses = make(map[[16]byte]map[string]time.Time)
+	t.sourceAddrs = make(map[[16]byte]sourceAddress)
+	t.addressChanges = nil
+	t.started = t.clock.Now()
+
+	t.logMu.Lock()
+	t.gaps = nil
+	t.losses = nil
+	t.priorityChanges = nil
+	t.outages = nil
+	t.logMu.Unlock()
+
+	t.totalMu.Lock()
+	t.totalCounts.reset()
+	t.totalPeak = Peak{}
+	t.totalMu.Unlock()
 }
 
 // GetSources returns all sources for a universe
@@ -298,12 +616,24 @@ func (t *Tracker) GetSources(universeID uint16) []Source {
 	}
 
 	stats.mu.RLock()
-	defer stats.mu.RUnlock()
-
 	sources := make([]Source, 0, len(stats.Sources))
 	for _, s := range stats.Sources {
 		sources = append(sources, *s)
 	}
+	stats.mu.RUnlock()
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	now := t.clock.Now()
+	for i := range sources {
+		sources[i].Addresses = t.recentAddresses(sources[i].CID, now)
+		sources[i].DuplicateCID = len(sources[i].Addresses) > 1
+		addr := t.sourceAddrs[sources[i].CID]
+		sources[i].Address = addr.current
+		sources[i].PreviousAddress = addr.previous
+		sources[i].AddressChangedAt = addr.changedAt
+		sources[i].Transport = addr.transport
+	}
 	return sources
 }
 
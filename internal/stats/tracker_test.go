@@ -3,6 +3,8 @@ package stats
 import (
 	"testing"
 	"time"
+
+	"sacn-monitor/internal/clock"
 )
 
 func TestNewTracker(t *testing.T) {
@@ -272,6 +274,112 @@ func TestTracker_SourceRestartDetection_SmallGapStillCounted(t *testing.T) {
 	}
 }
 
+func TestTracker_DuplicatePacket(t *testing.T) {
+	tracker := NewTracker()
+	cid := [16]byte{1, 2, 3, 4}
+
+	if got := tracker.RecordPacket(1, cid, "test", 5); got != InOrder {
+		t.Errorf("RecordPacket() = %v for the first packet, want InOrder", got)
+	}
+	if got := tracker.RecordPacket(1, cid, "test", 5); got != Duplicate {
+		t.Errorf("RecordPacket() = %v for an immediate repeat, want Duplicate", got)
+	}
+	if got := tracker.RecordPacket(1, cid, "test", 6); got != InOrder {
+		t.Errorf("RecordPacket() = %v for the next sequence, want InOrder", got)
+	}
+
+	stats := tracker.GetUniverseStats(1)
+	if stats.PacketCount != 2 {
+		t.Errorf("PacketCount = %d, want 2", stats.PacketCount)
+	}
+	if got := tracker.GetDuplicateCount(1); got != 1 {
+		t.Errorf("GetDuplicateCount() = %d, want 1", got)
+	}
+	if stats.LostPackets != 0 {
+		t.Errorf("LostPackets = %d, want 0", stats.LostPackets)
+	}
+	if got := tracker.GetPacketRate(1); got != 2 {
+		t.Errorf("GetPacketRate() = %f, want 2", got)
+	}
+
+	sources := tracker.GetSources(1)
+	if sources[0].Duplicates != 1 {
+		t.Errorf("Source.Duplicates = %d, want 1", sources[0].Duplicates)
+	}
+}
+
+func TestTracker_DuplicatePacket_OtherSourceNotDuplicate(t *testing.T) {
+	tracker := NewTracker()
+
+	tracker.RecordPacket(1, [16]byte{1}, "a", 5)
+	if got := tracker.RecordPacket(1, [16]byte{2}, "b", 5); got != InOrder {
+		t.Errorf("RecordPacket() = %v for another source with the same sequence, want InOrder", got)
+	}
+}
+
+func TestTracker_OutOfOrderNotLost(t *testing.T) {
+	tracker := NewTracker()
+	cid := [16]byte{1, 2, 3, 4}
+
+	tracker.RecordPacket(1, cid, "test", 0)
+	tracker.RecordPacket(1, cid, "test", 2) // 1 missing so far
+	if got := tracker.RecordPacket(1, cid, "test", 1); got != OutOfOrder {
+		t.Fatalf("RecordPacket() = %v for a late packet, want OutOfOrder", got)
+	}
+	tracker.RecordPacket(1, cid, "test", 3)
+
+	stats := tracker.GetUniverseStats(1)
+	if stats.LostPackets != 0 {
+		t.Errorf("LostPackets = %d, want 0 once the late packet arrived", stats.LostPackets)
+	}
+	if got := tracker.GetOutOfOrderCount(1); got != 1 {
+		t.Errorf("GetOutOfOrderCount() = %d, want 1", got)
+	}
+	if stats.PacketCount != 4 {
+		t.Errorf("PacketCount = %d, want 4", stats.PacketCount)
+	}
+	if got := tracker.GetRecentLossPercentage(1); got != 0 {
+		t.Errorf("GetRecentLossPercentage() = %f, want 0", got)
+	}
+
+	sources := tracker.GetSources(1)
+	if sources[0].OutOfOrder != 1 || sources[0].LostPackets != 0 || sources[0].LastSequence != 3 {
+		t.Errorf("source = %+v, want 1 out of order, no loss, last sequence 3", sources[0])
+	}
+}
+
+func TestTracker_LateCopyIsDuplicate(t *testing.T) {
+	tracker := NewTracker()
+	cid := [16]byte{1, 2, 3, 4}
+
+	for seq := uint8(0); seq < 5; seq++ {
+		tracker.RecordPacket(1, cid, "test", seq)
+	}
+	if got := tracker.RecordPacket(1, cid, "test", 2); got != Duplicate {
+		t.Errorf("RecordPacket() = %v for a late copy of a received packet, want Duplicate", got)
+	}
+
+	stats := tracker.GetUniverseStats(1)
+	if stats.OutOfOrder != 0 || stats.Duplicates != 1 || stats.LostPackets != 0 {
+		t.Errorf("OutOfOrder/Duplicates/LostPackets = %d/%d/%d, want 0/1/0", stats.OutOfOrder, stats.Duplicates, stats.LostPackets)
+	}
+}
+
+func TestTracker_LossAcrossWraparoundThenLate(t *testing.T) {
+	tracker := NewTracker()
+	cid := [16]byte{1, 2, 3, 4}
+
+	tracker.RecordPacket(1, cid, "test", 254)
+	tracker.RecordPacket(1, cid, "test", 1) // 255 and 0 missing
+	if got := tracker.RecordPacket(1, cid, "test", 255); got != OutOfOrder {
+		t.Errorf("RecordPacket() = %v, want OutOfOrder", got)
+	}
+
+	if stats := tracker.GetUniverseStats(1); stats.LostPackets != 1 {
+		t.Errorf("LostPackets = %d, want 1", stats.LostPackets)
+	}
+}
+
 func TestTracker_GetSourceLossPercentage(t *testing.T) {
 	tracker := NewTracker()
 	cid := [16]byte{1, 2, 3, 4}
@@ -336,6 +444,33 @@ func TestTracker_ResetUniverseStats(t *testing.T) {
 	}
 }
 
+func TestTracker_RemoveUniverse(t *testing.T) {
+	tracker := NewTracker()
+	shared := [16]byte{1}
+	only := [16]byte{2}
+
+	tracker.RecordPacket(1, shared, "console", 0)
+	tracker.RecordPacket(1, only, "backup", 0)
+	tracker.RecordPacket(2, shared, "console", 0)
+	tracker.RecordSourceAddress(shared, "10.0.0.1")
+	tracker.RecordSourceAddress(only, "10.0.0.2")
+
+	tracker.RemoveUniverse(1)
+
+	if tracker.GetUniverseStats(1) != nil {
+		t.Error("GetUniverseStats(1) != nil after RemoveUniverse")
+	}
+	if tracker.GetUniverseStats(2) == nil {
+		t.Error("GetUniverseStats(2) = nil, want universe 2 kept")
+	}
+	if got := tracker.GetSourceAddresses(shared); len(got) != 1 {
+		t.Errorf("addresses of a CID still on universe 2 = %v, want kept", got)
+	}
+	if got := tracker.GetSourceAddresses(only); len(got) != 0 {
+		t.Errorf("addresses of a CID only on universe 1 = %v, want dropped", got)
+	}
+}
+
 func TestTracker_ResetAllStats(t *testing.T) {
 	tracker := NewTracker()
 	cid := [16]byte{1, 2, 3, 4}
@@ -360,3 +495,31 @@ func TestTracker_ResetAllStats(t *testing.T) {
 		t.Errorf("Expected 0 universes after ResetAllStats, got %d", len(ids))
 	}
 }
+
+func TestTracker_SetClock(t *testing.T) {
+	tracker := NewTracker()
+	start := time.Date(2026, 3, 14, 20, 0, 0, 0, time.UTC)
+	c := clock.NewManual(start)
+	tracker.SetClock(c)
+	cid := [16]byte{1}
+
+	// 40 packets 25 ms apart fill exactly one rate window
+	for i := range 40 {
+		tracker.RecordPacket(1, cid, "console", uint8(i))
+		c.Advance(25 * time.Millisecond)
+	}
+	c.Advance(-25 * time.Millisecond)
+	if got := tracker.GetPacketRate(1); got != 40 {
+		t.Errorf("GetPacketRate() = %v, want 40", got)
+	}
+
+	// Silence past the data loss timeout is an outage on replay too
+	c.Advance(5 * time.Second)
+	tracker.RecordPacket(1, cid, "console", 40)
+	if got := tracker.GetUptime(1); got.Outages != 1 || !got.FirstSeen.Equal(start) {
+		t.Errorf("GetUptime() = %+v, want one outage since %v", got, start)
+	}
+	if got := tracker.Summary(); !got.Start.Equal(start) || got.Duration != 5975*time.Millisecond {
+		t.Errorf("Summary() session = %v for %s, want from %v", got.Start, got.Duration, start)
+	}
+}
@@ -0,0 +1,117 @@
+package stats
+
+import "time"
+
+// Uptime is when something was first seen, and how often and how long it
+// dropped out for longer than the data loss timeout since
+type Uptime struct {
+	FirstSeen     time.Time
+	LastSeen      time.Time
+	Outages       uint64        // Finished offline periods
+	OutageTime    time.Duration // Total length of finished offline periods
+	LongestOutage time.Duration
+}
+
+// seen records a packet at now, counting the silence since the previous
+// one as an outage if it exceeded the data loss timeout
+func (u *Uptime) seen(now time.Time) {
+	if u.FirstSeen.IsZero() {
+		u.FirstSeen = now
+	} else if gap := now.Sub(u.LastSeen); gap > dataLossTimeout {
+		u.Outages++
+		u.OutageTime += gap
+		u.LongestOutage = max(u.LongestOutage, gap)
+	}
+	u.LastSeen = now
+}
+
+// Online reports whether a packet arrived within the data loss timeout
+func (u Uptime) Online(now time.Time) bool {
+	return !u.FirstSeen.IsZero() && now.Sub(u.LastSeen) <= dataLossTimeout
+}
+
+// OfflineTime returns the total offline time, including an ongoing outage
+func (u Uptime) OfflineTime(now time.Time) time.Duration {
+	offline := u.OutageTime
+	if !u.FirstSeen.IsZero() && !u.Online(now) {
+		offline += now.Sub(u.LastSeen)
+	}
+	return offline
+}
+
+// OnlineTime returns the total time online since first seen
+func (u Uptime) OnlineTime(now time.Time) time.Duration {
+	if u.FirstSeen.IsZero() {
+		return 0
+	}
+	return max(now.Sub(u.FirstSeen)-u.OfflineTime(now), 0)
+}
+
+// Availability returns the percentage of time online since first seen
+func (u Uptime) Availability(now time.Time) float64 {
+	total := now.Sub(u.FirstSeen)
+	if u.FirstSeen.IsZero() || total <= 0 {
+		return 0
+	}
+	return float64(u.OnlineTime(now)) / float64(total) * 100
+}
+
+// GetUptime returns the uptime of a universe: when it was first seen and
+// its outages, over all sources
+func (t *Tracker) GetUptime(universeID uint16) Uptime {
+	t.mu.RLock()
+	stats := t.universes[universeID]
+	t.mu.RUnlock()
+
+	if stats == nil {
+		return Uptime{}
+	}
+
+	stats.mu.RLock()
+	defer stats.mu.RUnlock()
+	return stats.Uptime
+}
+
+// maxOutageLog is the number of source outages kept in the log
+const maxOutageLog = 1000
+
+// GetOutages returns up to n logged source outages (silences over the data
+// loss timeout), newest first. n <= 0 returns all.
+func (t *Tracker) GetOutages(n int) []Gap {
+	t.logMu.Lock()
+	defer t.logMu.Unlock()
+
+	if n <= 0 || n > len(t.outages) {
+		n = len(t.outages)
+	}
+	result := make([]Gap, n)
+	for i := 0; i < n; i++ {
+		result[i] = t.outages[len(t.outages)-1-i]
+	}
+	return result
+}
+
+// recordOutage logs a source returning after an outage
+func (t *Tracker) recordOutage(o Gap) {
+	t.logMu.Lock()
+	defer t.logMu.Unlock()
+
+	t.outages = append(t.outages, o)
+	if len(t.outages) > maxOutageLog {
+		t.outages = t.outages[len(t.outages)-maxOutageLog:]
+	}
+}
+
+// removeOutages drops logged outages of a universe
+func (t *Tracker) removeOutages(universeID uint16) {
+	t.logMu.Lock()
+	defer t.logMu.Unlock()
+
+	kept := t.outages[:0]
+	for _, o := range t.outages {
+		if o.Universe != universeID {
+			kept = append(kept, o)
+		}
+	}
+	t.outages = kept
+}
@@ -0,0 +1,63 @@
+package stats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUptime(t *testing.T) {
+	var u Uptime
+	start := time.Date(2026, 3, 14, 21, 0, 0, 0, time.UTC)
+
+	if u.Online(start) || u.Availability(start) != 0 {
+		t.Errorf("unseen uptime = %+v, want offline with no availability", u)
+	}
+
+	u.seen(start)
+	u.seen(start.Add(time.Second))
+	u.seen(start.Add(11 * time.Second)) // 10 s outage
+	u.seen(start.Add(12 * time.Second))
+	u.seen(start.Add(14 * time.Second)) // 2 s is within the data loss timeout
+
+	now := start.Add(20 * time.Second)
+	if u.Outages != 1 || u.OutageTime != 10*time.Second || u.LongestOutage != 10*time.Second {
+		t.Errorf("outages = %d, %s (longest %s), want 1 of 10 s", u.Outages, u.OutageTime, u.LongestOutage)
+	}
+
+	// Silent for 6 s at now: an ongoing outage counts as offline too
+	if u.Online(now) {
+		t.Error("Online() = true 6 s after the last packet")
+	}
+	if got := u.OfflineTime(now); got != 16*time.Second {
+		t.Errorf("OfflineTime() = %s, want 16s", got)
+	}
+	if got := u.OnlineTime(now); got != 4*time.Second {
+		t.Errorf("OnlineTime() = %s, want 4s", got)
+	}
+	if got := u.Availability(now); got != 20 {
+		t.Errorf("Availability() = %v, want 20", got)
+	}
+}
+
+func TestTracker_GetUptime(t *testing.T) {
+	tracker := NewTracker()
+	cid := [16]byte{1}
+
+	tracker.RecordPacket(1, cid, "console", 0)
+	stats := tracker.GetUniverseStats(1)
+	stats.mu.Lock()
+	stats.Uptime.LastSeen = stats.Uptime.LastSeen.Add(-5 * time.Second)
+	stats.Sources[cid].Uptime.LastSeen = stats.Sources[cid].Uptime.LastSeen.Add(-5 * time.Second)
+	stats.mu.Unlock()
+	tracker.RecordPacket(1, cid, "console", 1)
+
+	if got := tracker.GetUptime(1); got.Outages != 1 || got.FirstSeen.IsZero() {
+		t.Errorf("GetUptime(1) = %+v, want one outage", got)
+	}
+	if got := tracker.GetSources(1)[0].Uptime; got.Outages != 1 {
+		t.Errorf("source uptime = %+v, want one outage", got)
+	}
+	if got := tracker.GetUptime(2); got != (Uptime{}) {
+		t.Errorf("GetUptime() of unknown universe = %+v, want zero", got)
+	}
+}
@@ -0,0 +1,98 @@
+package stats
+
+import "time"
+
+// Bucket counts per sliding window. The newest bucket is still filling, so
+// a window covers between (n-1)/n and all of its duration.
+const (
+	rateBuckets = 20 // 50 ms each over the 1 s rate window
+	lossBuckets = 60 // 1 s each over the 1 minute loss window
+)
+
+// counts are the totals kept per bucket
+type counts struct {
+	Received   uint64 // Packets received
+	Lost       uint64 // Packets detected missing
+	Recovered  uint64 // Packets previously counted lost that arrived late
+	Bytes      uint64
+	Reconnects uint64 // Sources returning after the data loss timeout
+}
+
+// add sums c into the counts
+func (c *counts) add(o counts) {
+	c.Received += o.Received
+	c.Lost += o.Lost
+	c.Recovered += o.Recovered
+	c.Bytes += o.Bytes
+	c.Reconnects += o.Reconnects
+}
+
+// lossPercentage returns the share of expected packets that were lost,
+// excluding those that arrived late
+func (c counts) lossPercentage() float64 {
+	lost := c.Lost - min(c.Recovered, c.Lost)
+	total := c.Received + lost
+	if total == 0 {
+		return 0
+	}
+	return float64(lost) / float64(total) * 100
+}
+
+// bucket holds the counts of one time slot
+type bucket struct {
+	slot int64 // Index of the time slot since the epoch
+	counts
+}
+
+// bucketWindow sums counts over a sliding time window split into a ring of
+// fixed-width buckets, so recording is constant time and never allocates
+type bucketWindow struct {
+	width   time.Duration
+	buckets []bucket
+}
+
+// newBucketWindow creates a window of span split into n buckets
+func newBucketWindow(span time.Duration, n int) *bucketWindow {
+	return &bucketWindow{
+		width:   span / time.Duration(n),
+		buckets: make([]bucket, n),
+	}
+}
+
+// record adds c to the bucket covering now, reusing the bucket of an
+// expired slot
+func (w *bucketWindow) record(now time.Time, c counts) {
+	slot := now.UnixNano() / int64(w.width)
+	b := &w.buckets[ringIndex(slot, len(w.buckets))]
+	if b.slot != slot {
+		*b = bucket{slot: slot}
+	}
+	b.add(c)
+}
+
+// ringIndex returns the position of a time slot in a ring of n entries,
+// non-negative for slots before 1970 too
+func ringIndex(slot int64, n int) int {
+	return int((slot%int64(n) + int64(n)) % int64(n))
+}
+
+// sum returns the counts of all buckets within the window ending at now
+func (w *bucketWindow) sum(now time.Time) counts {
+	var total counts
+	if w == nil {
+		return total
+	}
+	slot := now.UnixNano() / int64(w.width)
+	oldest := slot - int64(len(w.buckets)) + 1
+	for i := range w.buckets {
+		if b := &w.buckets[i]; b.slot >= oldest && b.slot <= slot {
+			total.add(b.counts)
+		}
+	}
+	return total
+}
+
+// reset clears all buckets
+func (w *bucketWindow) reset() {
+	clear(w.buckets)
+}
@@ -0,0 +1,83 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"sacn-monitor/internal/clock"
+)
+
+func TestBucketWindow_Expiry(t *testing.T) {
+	w := newBucketWindow(time.Second, 10)
+	start := time.Unix(1000, 0)
+
+	w.record(start, counts{Received: 1, Bytes: 100})
+	w.record(start.Add(450*time.Millisecond), counts{Received: 2, Lost: 1})
+
+	if got := w.sum(start.Add(500 * time.Millisecond)); got != (counts{Received: 3, Lost: 1, Bytes: 100}) {
+		t.Errorf("sum within window = %+v, want both records", got)
+	}
+	if got := w.sum(start.Add(1200 * time.Millisecond)); got != (counts{Received: 2, Lost: 1}) {
+		t.Errorf("sum after first expired = %+v, want only the second record", got)
+	}
+
+	// A slot reused a full window later starts from zero
+	w.record(start.Add(time.Second), counts{Received: 5})
+	if got := w.sum(start.Add(time.Second)); got.Received != 7 {
+		t.Errorf("sum after slot reuse = %+v, want 2+5 received", got)
+	}
+
+	w.reset()
+	if got := w.sum(start.Add(time.Second)); got != (counts{}) {
+		t.Errorf("sum after reset = %+v, want zero", got)
+	}
+}
+
+func TestCounts_LossPercentage(t *testing.T) {
+	tests := []struct {
+		c    counts
+		want float64
+	}{
+		{counts{}, 0},
+		{counts{Received: 99, Lost: 1}, 1},
+		{counts{Received: 100, Lost: 2, Recovered: 2}, 0},
+		{counts{Received: 100, Lost: 1, Recovered: 3}, 0},
+	}
+	for _, tt := range tests {
+		if got := tt.c.lossPercentage(); got != tt.want {
+			t.Errorf("%+v.lossPercentage() = %v, want %v", tt.c, got, tt.want)
+		}
+	}
+}
+
+func TestTracker_RecordPacketDoesNotAllocate(t *testing.T) {
+	tracker := NewTracker()
+	cid := [16]byte{1}
+	tracker.RecordPacket(1, cid, "console", 0)
+	tracker.RecordBytes(1, cid, 638)
+
+	seq := uint8(1)
+	allocs := testing.AllocsPerRun(1000, func() {
+		tracker.RecordPacket(1, cid, "console", seq)
+		tracker.RecordBytes(1, cid, 638)
+		seq++
+	})
+	if allocs != 0 {
+		t.Errorf("RecordPacket allocated %v times per packet, want 0", allocs)
+	}
+}
+
+func TestBucketWindow_BeforeEpoch(t *testing.T) {
+	w := newBucketWindow(time.Second, 10)
+	for _, now := range []time.Time{time.Unix(-1, 0), time.Unix(-123, 456), {}} {
+		w.record(now, counts{Received: 1})
+		if got := w.sum(now); got.Received == 0 {
+			t.Errorf("sum(%v) = %+v, want the record", now, got)
+		}
+	}
+
+	tracker := NewTracker()
+	tracker.SetClock(clock.NewManual(time.Time{}))
+	tracker.RecordPacket(1, [16]byte{1}, "console", 0)
+	tracker.RecordPacket(1, [16]byte{1}, "console", 1)
+}
@@ -0,0 +1,320 @@
+// Package store keeps downsampled statistics, loss events and source history
+// in an embedded SQLite database, for queries after the show
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"sacn-monitor/internal/stats"
+	"sacn-monitor/internal/universe"
+
+	_ "github.com/mattn/go-sqlite3" // Registers the sqlite3 driver
+)
+
+// Store defaults
+const (
+	// DefaultInterval is the width of each stored sample
+	DefaultInterval = time.Minute
+	// DefaultRetention is how long rows are kept
+	DefaultRetention = 30 * 24 * time.Hour
+	// eventFlush is how often buffered loss and source events are written
+	eventFlush = time.Second
+	// eventBuffer is the tracker subscription's buffer size
+	eventBuffer = 256
+)
+
+// schema creates the tables on first use. Times are Unix milliseconds.
+const schema = `
+CREATE TABLE IF NOT EXISTS samples (
+	time            INTEGER NOT NULL,
+	universe        INTEGER NOT NULL,
+	name            TEXT    NOT NULL,
+	duration_ms     INTEGER NOT NULL,
+	packets         INTEGER NOT NULL,
+	lost            INTEGER NOT NULL,
+	packet_rate     REAL    NOT NULL,
+	loss_pct        REAL    NOT NULL,
+	health          INTEGER NOT NULL,
+	sources         INTEGER NOT NULL,
+	active_channels INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS samples_universe_time ON samples (universe, time);
+
+CREATE TABLE IF NOT EXISTS losses (
+	time           INTEGER NOT NULL,
+	universe       INTEGER NOT NULL,
+	source         TEXT    NOT NULL,
+	cid            TEXT    NOT NULL,
+	lost           INTEGER NOT NULL,
+	first_missing  INTEGER NOT NULL,
+	last_missing   INTEGER NOT NULL,
+	received_after INTEGER NOT NULL,
+	received_next  INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS losses_universe_time ON losses (universe, time);
+
+CREATE TABLE IF NOT EXISTS sources (
+	universe   INTEGER NOT NULL,
+	cid        TEXT    NOT NULL,
+	name       TEXT    NOT NULL,
+	first_seen INTEGER NOT NULL,
+	last_seen  INTEGER NOT NULL,
+	packets    INTEGER NOT NULL,
+	lost       INTEGER NOT NULL,
+	priority   INTEGER NOT NULL,
+	PRIMARY KEY (universe, cid)
+);
+
+CREATE TABLE IF NOT EXISTS source_events (
+	time     INTEGER NOT NULL,
+	universe INTEGER NOT NULL,
+	source   TEXT    NOT NULL,
+	cid      TEXT    NOT NULL,
+	kind     TEXT    NOT NULL,
+	detail   TEXT    NOT NULL
+);
+CREATE INDEX IF NOT EXISTS source_events_time ON source_events (time);
+`
+
+// counts is a universe's cumulative counts at the previous sample
+type counts struct {
+	packets uint64
+	lost    uint64
+}
+
+// Store writes a sample of every universe at each interval, loss events and
+// source changes as they happen, and a running summary of every source:
+//
+//	samples        one row per universe per interval
+//	losses         every sequence gap
+//	sources        every source seen per universe, with first and last seen
+//	source_events  new sources, address and priority changes
+//
+// Rows older than the retention are deleted at each sample.
+type Store struct {
+	db        *sql.DB
+	manager   *universe.Manager
+	tracker   *stats.Tracker
+	interval  time.Duration
+	retention time.Duration
+
+	previous map[uint16]counts // Only used by Sample
+	pending  []stats.Notification
+	mu       sync.Mutex // Guards pending
+}
+
+// Open opens or creates the database at path
+func Open(path string, manager *universe.Manager, tracker *stats.Tracker) (*Store, error) {
+	db, err := sql.Open("sqlite3", path+"?_journal_mode=WAL&_busy_timeout=5000")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open store: %w", err)
+	}
+	// One connection serializes writers, which SQLite does anyway
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to open store %s: %w", path, err)
+	}
+	return &Store{
+		db:        db,
+		manager:   manager,
+		tracker:   tracker,
+		interval:  DefaultInterval,
+		retention: DefaultRetention,
+		previous:  make(map[uint16]counts),
+	}, nil
+}
+
+// SetInterval sets the width of each sample
+func (s *Store) SetInterval(d time.Duration) {
+	if d > 0 {
+		s.interval = d
+	}
+}
+
+// SetRetention sets how long rows are kept; 0 keeps everything
+func (s *Store) SetRetention(d time.Duration) {
+	if d >= 0 {
+		s.retention = d
+	}
+}
+
+// DB returns the database, for queries
+func (s *Store) DB() *sql.DB {
+	return s.db
+}
+
+// Run stores samples and events until the context is cancelled, writing
+// buffered events once more on the way out. Write errors are reported to
+// onError.
+func (s *Store) Run(ctx context.Context, onError func(error)) {
+	notifications, unsubscribe := s.tracker.Subscribe(eventBuffer)
+	defer unsubscribe()
+
+	samples := time.NewTicker(s.interval)
+	defer samples.Stop()
+	flush := time.NewTicker(eventFlush)
+	defer flush.Stop()
+
+	report := func(err error) {
+		if err != nil && onError != nil {
+			onError(err)
+		}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			report(s.Flush())
+			return
+		case n := <-notifications:
+			s.Add(n)
+		case <-flush.C:
+			report(s.Flush())
+		case now := <-samples.C:
+			report(s.Sample(now))
+		}
+	}
+}
+
+// Add buffers a tracker notification until the next flush. Only losses,
+// new sources, address and priority changes are stored.
+func (s *Store) Add(n stats.Notification) {
+	switch n.Kind {
+	case stats.LossDetected, stats.NewSource, stats.AddressChanged, stats.PriorityChanged:
+	default:
+		return
+	}
+	s.mu.Lock()
+	s.pending = append(s.pending, n)
+	s.mu.Unlock()
+}
+
+// Flush writes buffered events
+func (s *Store) Flush() error {
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = nil
+	s.mu.Unlock()
+	if len(pending) == 0 {
+		return nil
+	}
+
+	return s.transaction(func(tx *sql.Tx) error {
+		for _, n := range pending {
+			cid := fmt.Sprintf("%x", n.CID)
+			var err error
+			switch n.Kind {
+			case stats.LossDetected:
+				l := n.Loss
+				_, err = tx.Exec(`INSERT INTO losses VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+					l.Time.UnixMilli(), l.Universe, l.Source, fmt.Sprintf("%x", l.CID), l.Lost,
+					l.FirstMissing, l.LastMissing, l.ReceivedAfter, l.ReceivedNext)
+			case stats.NewSource:
+				err = insertSourceEvent(tx, n, cid, "")
+			case stats.AddressChanged:
+				err = insertSourceEvent(tx, n, cid, n.Address.Previous+" -> "+n.Address.Current)
+			case stats.PriorityChanged:
+				detail := strconv.Itoa(int(n.Priority.Previous)) + " -> " + strconv.Itoa(int(n.Priority.Current))
+				if n.Priority.PreviousPerAddress != n.Priority.PerAddress {
+					detail += fmt.Sprintf(" (per-address %t -> %t)", n.Priority.PreviousPerAddress, n.Priority.PerAddress)
+				}
+				err = insertSourceEvent(tx, n, cid, detail)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// insertSourceEvent stores one source change
+func insertSourceEvent(tx *sql.Tx, n stats.Notification, cid, detail string) error {
+	_, err := tx.Exec(`INSERT INTO source_events VALUES (?, ?, ?, ?, ?, ?)`,
+		n.Time.UnixMilli(), n.Universe, n.Source, cid, n.Kind.String(), detail)
+	return err
+}
+
+// Sample stores the packets and losses of every universe since the
+// previous sample, updates the source summaries and deletes rows past the
+// retention
+func (s *Store) Sample(now time.Time) error {
+	return s.transaction(func(tx *sql.Tx) error {
+		for _, u := range s.manager.GetAll() {
+			agg := s.tracker.Aggregate([]uint16{u.ID})
+
+			// The packet count going down means the stats were reset
+			prev := s.previous[u.ID]
+			if agg.PacketCount < prev.packets {
+				prev = counts{}
+			}
+			packets := agg.PacketCount - prev.packets
+			lost := agg.LostPackets - min(prev.lost, agg.LostPackets)
+			s.previous[u.ID] = counts{agg.PacketCount, agg.LostPackets}
+
+			lossPct := 0.0
+			if packets+lost > 0 {
+				lossPct = float64(lost) / float64(packets+lost) * 100
+			}
+			if _, err := tx.Exec(`INSERT INTO samples VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				now.UnixMilli(), u.ID, s.manager.Name(u.ID), s.interval.Milliseconds(),
+				packets, lost, float64(packets)/s.interval.Seconds(), lossPct,
+				s.tracker.GetHealth(u.ID).Score, agg.Sources, u.ActiveChannelCount()); err != nil {
+				return err
+			}
+
+			for _, src := range s.tracker.GetSources(u.ID) {
+				if _, err := tx.Exec(`INSERT INTO sources VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+					ON CONFLICT (universe, cid) DO UPDATE SET
+						name = excluded.name, last_seen = excluded.last_seen, packets = excluded.packets,
+						lost = excluded.lost, priority = excluded.priority`,
+					u.ID, fmt.Sprintf("%x", src.CID), src.Name, src.LastSeen.UnixMilli(), src.LastSeen.UnixMilli(),
+					src.PacketCount, src.LostPackets, src.Priority); err != nil {
+					return err
+				}
+			}
+		}
+
+		if s.retention == 0 {
+			return nil
+		}
+		cutoff := now.Add(-s.retention).UnixMilli()
+		for _, query := range []string{
+			`DELETE FROM samples WHERE time < ?`,
+			`DELETE FROM losses WHERE time < ?`,
+			`DELETE FROM sources WHERE last_seen < ?`,
+			`DELETE FROM source_events WHERE time < ?`,
+		} {
+			if _, err := tx.Exec(query, cutoff); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Close closes the database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// transaction runs fn in a transaction, committing if it succeeds
+func (s *Store) transaction(fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("store write failed: %w", err)
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("store write failed: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("store write failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package store
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"sacn-monitor/internal/stats"
+	"sacn-monitor/internal/universe"
+)
+
+// count returns the rows in a table
+func count(t *testing.T, s *Store, table string) int {
+	t.Helper()
+	var n int
+	if err := s.DB().QueryRow("SELECT COUNT(*) FROM " + table).Scan(&n); err != nil {
+		t.Fatalf("failed to count %s: %v", table, err)
+	}
+	return n
+}
+
+func TestStore_Sample(t *testing.T) {
+	manager := universe.NewManager()
+	tracker := stats.NewTracker()
+	s, err := Open(filepath.Join(t.TempDir(), "history.db"), manager, tracker)
+	if err != nil {
+		t.Fatalf("Open() returned error: %v", err)
+	}
+	defer s.Close()
+	s.SetInterval(10 * time.Second)
+
+	cid := [16]byte{1}
+	manager.GetOrCreate(1).Update([]byte{10, 20, 0}, "console", cid, 100, 0)
+	seq := uint8(0)
+	record := func(n int) {
+		for i := 0; i < n; i++ {
+			tracker.RecordPacket(1, cid, "console", seq)
+			seq++
+		}
+	}
+
+	start := time.Now()
+	record(440)
+	if err := s.Sample(start); err != nil {
+		t.Fatalf("Sample() returned error: %v", err)
+	}
+	seq += 3 // Three packets lost
+	record(100)
+	if err := s.Sample(start.Add(10 * time.Second)); err != nil {
+		t.Fatalf("Sample() returned error: %v", err)
+	}
+
+	var packets, lost, active int
+	var rate float64
+	err = s.DB().QueryRow(`SELECT packets, lost, packet_rate, active_channels FROM samples ORDER BY time DESC LIMIT 1`).
+		Scan(&packets, &lost, &rate, &active)
+	if err != nil {
+		t.Fatalf("failed to query samples: %v", err)
+	}
+	if packets != 100 || lost != 3 || rate != 10 || active != 3 {
+		t.Errorf("second sample = %d packets, %d lost, %g pps, %d active; want 100, 3, 10, 3", packets, lost, rate, active)
+	}
+
+	var name string
+	var sourcePackets int
+	if err := s.DB().QueryRow(`SELECT name, packets FROM sources WHERE universe = 1`).Scan(&name, &sourcePackets); err != nil {
+		t.Fatalf("failed to query sources: %v", err)
+	}
+	if name != "console" || sourcePackets != 540 {
+		t.Errorf("source = %q with %d packets, want console with 540", name, sourcePackets)
+	}
+}
+
+func TestStore_Events(t *testing.T) {
+	s, err := Open(filepath.Join(t.TempDir(), "history.db"), universe.NewManager(), stats.NewTracker())
+	if err != nil {
+		t.Fatalf("Open() returned error: %v", err)
+	}
+	defer s.Close()
+
+	now := time.Now()
+	s.Add(stats.Notification{Kind: stats.NewSource, Time: now, Universe: 1, Source: "console"})
+	s.Add(stats.Notification{Kind: stats.LossDetected, Time: now, Universe: 1,
+		Loss: stats.LossEvent{Time: now, Universe: 1, Source: "console", Lost: 2}})
+	s.Add(stats.Notification{Kind: stats.RateChange, Time: now, Universe: 1}) // Not stored
+	if err := s.Flush(); err != nil {
+		t.Fatalf("Flush() returned error: %v", err)
+	}
+
+	if n := count(t, s, "losses"); n != 1 {
+		t.Errorf("%d losses stored, want 1", n)
+	}
+	if n := count(t, s, "source_events"); n != 1 {
+		t.Errorf("%d source events stored, want 1", n)
+	}
+}
+
+func TestStore_Retention(t *testing.T) {
+	manager := universe.NewManager()
+	tracker := stats.NewTracker()
+	s, err := Open(filepath.Join(t.TempDir(), "history.db"), manager, tracker)
+	if err != nil {
+		t.Fatalf("Open() returned error: %v", err)
+	}
+	defer s.Close()
+	s.SetRetention(time.Hour)
+
+	manager.GetOrCreate(1)
+	tracker.RecordPacket(1, [16]byte{1}, "console", 0)
+	now := time.Now()
+	s.Add(stats.Notification{Kind: stats.NewSource, Time: now, Universe: 1, Source: "console"})
+	if err := s.Flush(); err != nil {
+		t.Fatalf("Flush() returned error: %v", err)
+	}
+	if err := s.Sample(now); err != nil {
+		t.Fatalf("Sample() returned error: %v", err)
+	}
+
+	// Two hours later only the new sample is left
+	if err := s.Sample(now.Add(2 * time.Hour)); err != nil {
+		t.Fatalf("Sample() returned error: %v", err)
+	}
+	if n := count(t, s, "samples"); n != 1 {
+		t.Errorf("%d samples left, want 1", n)
+	}
+	if n := count(t, s, "source_events"); n != 0 {
+		t.Errorf("%d source events left, want 0", n)
+	}
+	if n := count(t, s, "sources"); n != 0 {
+		t.Errorf("%d sources left, want 0", n)
+	}
+}
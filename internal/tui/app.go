@@ -2,13 +2,19 @@ package tui
 
 import (
 	"fmt"
+	"slices"
 	"sort"
+	"strings"
 	"time"
 
+	"sacn-monitor/internal/config"
+	"sacn-monitor/internal/events"
+	"sacn-monitor/internal/history"
 	"sacn-monitor/internal/stats"
 	"sacn-monitor/internal/universe"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -16,14 +22,23 @@ import (
 // Timeout for considering a universe stale (no data)
 const staleTimeout = time.Second
 
+// How long synchronized data may wait for its sync packet before it is
+// flagged as held
+const syncHoldWarning = 500 * time.Millisecond
+
+// How long a source's address change is shown in the stats line
+const addressChangeNotice = time.Minute
+
 // Colors
 var (
 	cyanColor = lipgloss.Color("#00FFFF")
 	grayColor = lipgloss.Color("#666666")
 
-	whiteColor  = lipgloss.Color("#FFFFFF")
-	yellowColor = lipgloss.Color("#FFFF00")
-	redColor    = lipgloss.Color("#FF6666")
+	whiteColor   = lipgloss.Color("#FFFFFF")
+	yellowColor  = lipgloss.Color("#FFFF00")
+	greenColor   = lipgloss.Color("#66FF66")
+	redColor     = lipgloss.Color("#FF6666")
+	magentaColor = lipgloss.Color("#FF66FF")
 )
 
 // Styles
@@ -43,6 +58,30 @@ var (
 			BorderForeground(redColor).
 			Width(4)
 
+	deviationCardStyle = lipgloss.NewStyle().
+				Border(lipgloss.ThickBorder()).
+				BorderForeground(redColor).
+				Foreground(redColor).
+				Width(4)
+
+	unpatchedCardStyle = lipgloss.NewStyle().
+				Border(lipgloss.NormalBorder()).
+				BorderForeground(magentaColor).
+				Foreground(magentaColor).
+				Width(4)
+
+	changedCardStyle = lipgloss.NewStyle().
+				Border(lipgloss.NormalBorder()).
+				BorderForeground(yellowColor).
+				Foreground(yellowColor).
+				Width(4)
+
+	priorityCardStyle = lipgloss.NewStyle().
+				Border(lipgloss.NormalBorder()).
+				BorderForeground(greenColor).
+				Foreground(greenColor).
+				Width(4)
+
 	statsStyle = lipgloss.NewStyle().
 			Foreground(whiteColor)
 
@@ -77,23 +116,139 @@ var (
 
 // KeyMap defines keybindings
 type KeyMap struct {
-	Left  key.Binding
-	Right key.Binding
-	Up    key.Binding
-	Down  key.Binding
-	Tab   key.Binding
-	Quit  key.Binding
+	Left      key.Binding
+	Right     key.Binding
+	Up        key.Binding
+	Down      key.Binding
+	Tab       key.Binding
+	Snapshot  key.Binding
+	Diff      key.Binding
+	SaveLook  key.Binding
+	SaveSnap  key.Binding
+	Events    key.Binding
+	Losses    key.Binding
+	Sequences key.Binding
+	Summary   key.Binding
+	Sources   key.Binding
+	Diagnose  key.Binding
+	Export    key.Binding
+	Fixtures  key.Binding
+	MiniStats key.Binding
+	Rename    key.Binding
+	Pin       key.Binding
+	Order     key.Binding
+	MoveLeft  key.Binding
+	MoveRight key.Binding
+	Group     key.Binding
+	Channel   key.Binding
+	Heatmap   key.Binding
+	Rollups   key.Binding
+	Jump      key.Binding
+	Picker    key.Binding
+	Format    key.Binding
+	Filter    key.Binding
+	Threshold key.Binding
+	Raw8      key.Binding
+	Split     key.Binding
+	Unsplit   key.Binding
+	NextPane  key.Binding
+	EventPane key.Binding
+	Follow    key.Binding
+	Faster    key.Binding
+	Density   key.Binding
+	PageUp    key.Binding
+	Reset     key.Binding
+	ResetAll  key.Binding
+	Prune     key.Binding
+	Hide      key.Binding
+	Unhide    key.Binding
+	Remove    key.Binding
+	PageDown  key.Binding
+	SidePanel key.Binding
+	Priority  key.Binding
+	Focus     key.Binding
+	LossMode  key.Binding
+	Copy      key.Binding
+	Watch     key.Binding
+	Layout    key.Binding
+	Owners    key.Binding
+	Dismiss   key.Binding
+	Shrink    key.Binding
+	Grow      key.Binding
+	CopyRange key.Binding
+	Slower    key.Binding
+	Quit      key.Binding
 }
 
 var keys = KeyMap{
-	Left:  key.NewBinding(key.WithKeys("left", "h")),
-	Right: key.NewBinding(key.WithKeys("right", "l")),
-	Up:    key.NewBinding(key.WithKeys("up", "k")),
-	Down:  key.NewBinding(key.WithKeys("down", "j")),
-	Tab:   key.NewBinding(key.WithKeys("tab")),
-	Quit:  key.NewBinding(key.WithKeys("q", "ctrl+c")),
+	Left:      key.NewBinding(key.WithKeys("left", "h")),
+	Right:     key.NewBinding(key.WithKeys("right", "l")),
+	Up:        key.NewBinding(key.WithKeys("up", "k")),
+	Down:      key.NewBinding(key.WithKeys("down", "j")),
+	Tab:       key.NewBinding(key.WithKeys("tab")),
+	Snapshot:  key.NewBinding(key.WithKeys("s")),
+	Diff:      key.NewBinding(key.WithKeys("d")),
+	SaveLook:  key.NewBinding(key.WithKeys("B")),
+	SaveSnap:  key.NewBinding(key.WithKeys("O")),
+	Events:    key.NewBinding(key.WithKeys("e")),
+	Losses:    key.NewBinding(key.WithKeys("L")),
+	Sequences: key.NewBinding(key.WithKeys("S")),
+	Summary:   key.NewBinding(key.WithKeys("R")),
+	Sources:   key.NewBinding(key.WithKeys("c")),
+	Diagnose:  key.NewBinding(key.WithKeys("D")),
+	Export:    key.NewBinding(key.WithKeys("x")),
+	Fixtures:  key.NewBinding(key.WithKeys("f")),
+	MiniStats: key.NewBinding(key.WithKeys("m")),
+	Rename:    key.NewBinding(key.WithKeys("r")),
+	Pin:       key.NewBinding(key.WithKeys("p")),
+	Order:     key.NewBinding(key.WithKeys("o")),
+	MoveLeft:  key.NewBinding(key.WithKeys("<")),
+	MoveRight: key.NewBinding(key.WithKeys(">")),
+	Group:     key.NewBinding(key.WithKeys("g")),
+	Channel:   key.NewBinding(key.WithKeys("enter")),
+	Heatmap:   key.NewBinding(key.WithKeys("H")),
+	Rollups:   key.NewBinding(key.WithKeys("T")),
+	Jump:      key.NewBinding(key.WithKeys(":")),
+	Picker:    key.NewBinding(key.WithKeys("u")),
+	Format:    key.NewBinding(key.WithKeys("v")),
+	Filter:    key.NewBinding(key.WithKeys("a")),
+	Threshold: key.NewBinding(key.WithKeys("t")),
+	Raw8:      key.NewBinding(key.WithKeys("w")),
+	Split:     key.NewBinding(key.WithKeys("V")),
+	Unsplit:   key.NewBinding(key.WithKeys("X")),
+	NextPane:  key.NewBinding(key.WithKeys("]")),
+	EventPane: key.NewBinding(key.WithKeys("E")),
+	Follow:    key.NewBinding(key.WithKeys("F")),
+	Faster:    key.NewBinding(key.WithKeys("+", "=")),
+	Density:   key.NewBinding(key.WithKeys("C")),
+	PageUp:    key.NewBinding(key.WithKeys("pgup")),
+	Reset:     key.NewBinding(key.WithKeys("z")),
+	ResetAll:  key.NewBinding(key.WithKeys("Z")),
+	Prune:     key.NewBinding(key.WithKeys("P")),
+	Hide:      key.NewBinding(key.WithKeys("delete", "backspace")),
+	Unhide:    key.NewBinding(key.WithKeys("U")),
+	Remove:    key.NewBinding(key.WithKeys("ctrl+d")),
+	PageDown:  key.NewBinding(key.WithKeys("pgdown")),
+	SidePanel: key.NewBinding(key.WithKeys("i")),
+	Priority:  key.NewBinding(key.WithKeys("y")),
+	Focus:     key.NewBinding(key.WithKeys("b")),
+	LossMode:  key.NewBinding(key.WithKeys("n")),
+	Copy:      key.NewBinding(key.WithKeys("Y")),
+	Watch:     key.NewBinding(key.WithKeys("W")),
+	Layout:    key.NewBinding(key.WithKeys("K")),
+	Owners:    key.NewBinding(key.WithKeys("M")),
+	Dismiss:   key.NewBinding(key.WithKeys("esc")),
+	Shrink:    key.NewBinding(key.WithKeys("{")),
+	Grow:      key.NewBinding(key.WithKeys("}")),
+	CopyRange: key.NewBinding(key.WithKeys("ctrl+y")),
+	Slower:    key.NewBinding(key.WithKeys("-")),
+	Quit:      key.NewBinding(key.WithKeys("q", "ctrl+c")),
 }
 
+// busiestChannels is how many of the most frequently changing channels
+// the stats line lists
+const busiestChannels = 3
+
 // Model is the main TUI model
 type Model struct {
 	universeManager  *universe.Manager
@@ -104,6 +259,54 @@ type Model struct {
 	width            int
 	height           int
 	columnsPerRow    int
+	diffMode         bool   // Highlight channels changed since the latest snapshot
+	statusMsg        string // Feedback from the last action, cleared on the next key
+	readOnly         bool   // Mirrored sessions can look but not change shared state
+	eventLog         *events.Log
+	view             viewMode // What is shown below the stats line
+	eventCursor      int      // Selected entry in the events view
+	pinned           []uint16 // Universes pinned to the front of the tab bar, in pin order
+	tabOrder         tabOrder
+	manualOrder      []uint16 // Tab order for manual ordering
+	miniStats        bool     // Show change arrows and staleness dots in channel cards
+	movement         channelMovement
+	config           *config.Config
+	renaming         bool // Name input for the selected universe is open
+	renameInput      textinput.Model
+	groupFilter      string // Only show universes of this group, "" = all
+	history          *history.Recorder
+	selectedChannel  int  // 0-based channel highlighted in the grid
+	jumping          bool // Jump-to-channel input is open
+	jumpInput        textinput.Model
+	picking          bool // Universe picker is open
+	pickerInput      textinput.Model
+	pickerCursor     int
+	valueFormat      valueFormat
+	channelFilter    channelFilter
+	threshold        *threshold // Only show channels passing it, nil = all
+	editingThreshold bool
+	thresholdInput   textinput.Model
+	raw8             bool        // Show 16-bit pairs from the patch as separate 8-bit slots
+	showPriority     bool        // Show per-address priorities instead of levels, where present
+	panes            []splitPane // Universes shown side by side, nil = single grid
+	focusedPane      int
+	eventPane        bool     // Show the latest events below the grid
+	outputs          []string // Active captures and recordings, for the status bar
+	following        bool     // Select the most recently changed universe automatically
+	followSwitched   time.Time
+	refresh          time.Duration // Redraw interval, 0 = defaultRefresh
+	density          density
+	hideSidePanel    bool
+	confirm          *confirmation // Action waiting for y, nil = none
+	pruneTimeout     time.Duration
+	hidden           map[uint16]bool // Universes left out of the tab bar
+	restoreUniverse  uint16          // Saved selection to pick once it appears, 0 = none
+	lossMode         lossMode
+	watch            []config.WatchEntry // Channels shown in the watch panel on every screen
+	layout           layout
+	paneSharePct     int                   // Second pane's share of the screen in percent, 0 = default
+	ownerColors      bool                  // Color channels by the source owning them
+	dismissedCodes   map[startCodeKey]bool // Start code banners closed with esc
 }
 
 // NewModel creates a new TUI model
@@ -115,27 +318,122 @@ func NewModel(um *universe.Manager, st *stats.Tracker) Model {
 	}
 }
 
+// ReadOnly returns a copy of the model that ignores actions changing shared
+// state (snapshots, saved looks), for mirrored sessions
+func (m Model) ReadOnly() Model {
+	m.readOnly = true
+	return m
+}
+
+// viewMode selects what is rendered below the stats line
+type viewMode int
+
+const (
+	viewGrid viewMode = iota
+	viewEvents
+	viewLosses
+	viewFixtures
+	viewSequences
+	viewSources
+	viewDiagnostics
+	viewChannel
+	viewHeatmap
+	viewFocus
+	viewRollups
+	viewCount
+)
+
+// toggleView switches to a view, or back to the channel grid if it is
+// already shown
+func (m *Model) toggleView(v viewMode) {
+	if m.view == v {
+		m.view = viewGrid
+	} else {
+		m.view = v
+	}
+}
+
 // TickMsg is a message for periodic updates
 type TickMsg time.Time
 
-func tickCmd() tea.Cmd {
-	return tea.Tick(100*time.Millisecond, func(t time.Time) tea.Msg {
+func tickCmd(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
 		return TickMsg(t)
 	})
 }
 
 func (m Model) Init() tea.Cmd {
-	return tickCmd()
+	return tickCmd(m.refreshInterval())
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.renaming {
+			return m.updateRename(msg)
+		}
+		if m.jumping {
+			return m.updateJump(msg)
+		}
+		if m.picking {
+			return m.updatePicker(msg)
+		}
+		if m.editingThreshold {
+			return m.updateThreshold(msg)
+		}
+		if m.confirm != nil {
+			return m.updateConfirm(msg)
+		}
+		// Once the operator moves on, a restored selection no longer applies
+		m.restoreUniverse = 0
+		m.statusMsg = ""
 		switch {
 		case key.Matches(msg, keys.Quit):
+			if m.readOnly {
+				return m, nil
+			}
 			return m, tea.Quit
+		case m.readOnly && (key.Matches(msg, keys.Snapshot) || key.Matches(msg, keys.SaveLook) || key.Matches(msg, keys.SaveSnap) || key.Matches(msg, keys.Rename) || key.Matches(msg, keys.Summary) ||
+			key.Matches(msg, keys.Reset) || key.Matches(msg, keys.ResetAll) || key.Matches(msg, keys.Prune) || key.Matches(msg, keys.Remove) ||
+			key.Matches(msg, keys.Export) || key.Matches(msg, keys.Copy) || key.Matches(msg, keys.CopyRange) ||
+			key.Matches(msg, keys.Watch)):
+			m.statusMsg = "Read-only mirror: action not available"
+		case key.Matches(msg, keys.Density):
+			m.density = (m.density + 1) % densityCount
+			m.moveChannel(0)
+			m.statusMsg = fmt.Sprintf("Grid: %s", m.density)
+		case key.Matches(msg, keys.Reset):
+			if len(m.universeList) > 0 {
+				m.resetSelectedStats()
+			}
+		case key.Matches(msg, keys.ResetAll):
+			m.resetAllStats()
+		case key.Matches(msg, keys.Hide):
+			if len(m.universeList) > 0 {
+				m.hideSelected()
+			}
+		case key.Matches(msg, keys.Unhide):
+			m.unhideAll()
+		case key.Matches(msg, keys.Remove):
+			if len(m.universeList) > 0 {
+				m.removeSelected()
+			}
+		case key.Matches(msg, keys.Prune):
+			m.pruneStale()
+		case key.Matches(msg, keys.Faster):
+			m.stepRefresh(false)
+		case key.Matches(msg, keys.Slower):
+			m.stepRefresh(true)
+		case key.Matches(msg, keys.Follow):
+			m.following = !m.following
+			if m.following {
+				m.statusMsg = "Following the most recently changed universe"
+			} else {
+				m.statusMsg = "Stopped following"
+			}
 		case key.Matches(msg, keys.Tab):
-			// Cycle to next universe
+			// Cycle to next universe; choosing by hand stops following
+			m.following = false
 			if len(m.universeList) > 1 {
 				for i, id := range m.universeList {
 					if id == m.selectedUniverse {
@@ -144,12 +442,177 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 				}
 			}
+		case key.Matches(msg, keys.Snapshot):
+			// Snapshots taken from the UI are named after their capture time
+			name := time.Now().Format("15:04:05")
+			if _, ok := m.universeManager.CaptureSnapshot(m.selectedUniverse, name); ok {
+				m.diffMode = true
+			}
+		case key.Matches(msg, keys.Diff):
+			m.diffMode = !m.diffMode
+		case key.Matches(msg, keys.SaveLook):
+			m.saveLook()
+		case key.Matches(msg, keys.SaveSnap):
+			m.saveUniverseSnapshot()
+		case key.Matches(msg, keys.Summary):
+			m.exportSummary()
+		case key.Matches(msg, keys.Rename):
+			if len(m.universeList) > 0 {
+				m.startRename()
+				return m, textinput.Blink
+			}
+		case key.Matches(msg, keys.Pin):
+			m.togglePin(m.selectedUniverse)
+			m.updateUniverseList()
+		case key.Matches(msg, keys.Order):
+			m.cycleTabOrder()
+			m.updateUniverseList()
+			m.statusMsg = fmt.Sprintf("Tab order: %s", m.tabOrder)
+		case key.Matches(msg, keys.MoveLeft):
+			m.moveSelected(-1)
+			m.updateUniverseList()
+		case key.Matches(msg, keys.MoveRight):
+			m.moveSelected(1)
+			m.updateUniverseList()
+		case key.Matches(msg, keys.Group):
+			m.cycleGroupFilter()
+			m.updateUniverseList()
+			if m.groupFilter == "" {
+				m.statusMsg = "Showing all universes"
+			} else {
+				m.statusMsg = fmt.Sprintf("Showing group %s", m.groupFilter)
+			}
+		case key.Matches(msg, keys.Format):
+			m.valueFormat = (m.valueFormat + 1) % valueFormatCount
+			m.statusMsg = fmt.Sprintf("Values: %s", m.valueFormat)
+		case key.Matches(msg, keys.Filter):
+			m.channelFilter = (m.channelFilter + 1) % channelFilterCount
+			m.scrollOffset = 0
+			m.moveChannel(0)
+			m.statusMsg = fmt.Sprintf("Showing %s", m.channelFilter)
+		case key.Matches(msg, keys.Threshold):
+			m.startThreshold()
+			return m, textinput.Blink
+		case key.Matches(msg, keys.Split):
+			if len(m.universeList) > 0 {
+				m.splitAdd()
+			}
+		case key.Matches(msg, keys.Unsplit):
+			m.splitClose()
+		case key.Matches(msg, keys.NextPane):
+			if len(m.panes) > 0 {
+				m.focusPane((m.focusedPane + 1) % len(m.panes))
+			}
+		case key.Matches(msg, keys.Raw8):
+			m.raw8 = !m.raw8
+			if m.raw8 {
+				m.statusMsg = "16-bit pairs shown as 8-bit slots"
+			} else {
+				m.statusMsg = "16-bit pairs combined"
+			}
+		case key.Matches(msg, keys.Priority):
+			m.togglePriority()
+		case key.Matches(msg, keys.Dismiss):
+			m.dismissBanner()
+		case key.Matches(msg, keys.Owners):
+			m.ownerColors = !m.ownerColors
+			m.moveChannel(0)
+		case key.Matches(msg, keys.Layout):
+			m.layout = (m.layout + 1) % layoutCount
+			m.moveChannel(0)
+			m.statusMsg = fmt.Sprintf("Layout: %s", m.layout)
+		case key.Matches(msg, keys.Shrink):
+			m.resizePane(false)
+		case key.Matches(msg, keys.Grow):
+			m.resizePane(true)
+		case key.Matches(msg, keys.Watch):
+			if len(m.universeList) > 0 {
+				m.toggleWatch()
+			}
+		case key.Matches(msg, keys.Copy):
+			cmd := m.copySelectedChannel()
+			return m, cmd
+		case key.Matches(msg, keys.CopyRange):
+			cmd := m.copyVisibleChannels()
+			return m, cmd
+		case key.Matches(msg, keys.LossMode):
+			m.lossMode = (m.lossMode + 1) % lossModeCount
+			m.statusMsg = fmt.Sprintf("Loss: %s", m.lossMode)
+		case key.Matches(msg, keys.MiniStats):
+			m.miniStats = !m.miniStats
+			m.movement.valid = false
+		case key.Matches(msg, keys.EventPane):
+			m.eventPane = !m.eventPane
+		case key.Matches(msg, keys.Events):
+			m.toggleView(viewEvents)
+			m.eventCursor = 0
+		case key.Matches(msg, keys.Losses):
+			m.toggleView(viewLosses)
+		case m.view == viewLosses && key.Matches(msg, keys.Export):
+			m.exportLosses()
+		case key.Matches(msg, keys.Sequences):
+			m.toggleView(viewSequences)
+		case m.view == viewSequences && key.Matches(msg, keys.Export):
+			m.exportSequences()
+		case key.Matches(msg, keys.Rollups):
+			m.toggleView(viewRollups)
+		case m.view == viewRollups && key.Matches(msg, keys.Export):
+			m.exportRollups()
+		case key.Matches(msg, keys.Export):
+			m.exportUniverse()
+		case key.Matches(msg, keys.Fixtures):
+			m.toggleView(viewFixtures)
+		case key.Matches(msg, keys.Sources):
+			m.toggleView(viewSources)
+		case key.Matches(msg, keys.Diagnose):
+			m.toggleView(viewDiagnostics)
+		case key.Matches(msg, keys.Channel):
+			m.toggleView(viewChannel)
+		case key.Matches(msg, keys.Jump):
+			if len(m.universeList) > 0 {
+				m.startJump()
+				return m, textinput.Blink
+			}
+		case key.Matches(msg, keys.Picker):
+			if len(m.universeList) > 0 {
+				m.startPicker()
+				return m, textinput.Blink
+			}
+		case key.Matches(msg, keys.Heatmap):
+			m.toggleView(viewHeatmap)
+		case key.Matches(msg, keys.Focus):
+			m.toggleView(viewFocus)
+		case m.view == viewFocus && key.Matches(msg, keys.Down):
+			m.moveChannel(10)
+		case m.view == viewFocus && key.Matches(msg, keys.Up):
+			m.moveChannel(-10)
+		case m.view == viewHeatmap && key.Matches(msg, keys.Down):
+			m.selectedChannel = min(m.selectedChannel+heatmapColumns, 511)
+		case m.view == viewHeatmap && key.Matches(msg, keys.Up):
+			m.selectedChannel = max(m.selectedChannel-heatmapColumns, 0)
+		case key.Matches(msg, keys.Left):
+			m.moveChannel(-1)
+		case key.Matches(msg, keys.Right):
+			m.moveChannel(1)
+		case m.view == viewEvents && key.Matches(msg, keys.Down):
+			m.eventCursor = min(m.eventCursor+1, eventListSize-1)
+		case m.view == viewEvents && key.Matches(msg, keys.Up):
+			m.eventCursor = max(m.eventCursor-1, 0)
+		case m.view == viewFixtures && key.Matches(msg, keys.Down):
+			m.scrollOffset += m.gridColumns()
+		case m.view == viewFixtures && key.Matches(msg, keys.Up):
+			m.scrollOffset = max(0, m.scrollOffset-m.gridColumns())
 		case key.Matches(msg, keys.Down):
-			m.scrollOffset += m.columnsPerRow
+			m.moveChannel(m.gridColumns())
 		case key.Matches(msg, keys.Up):
-			if m.scrollOffset >= m.columnsPerRow {
-				m.scrollOffset -= m.columnsPerRow
-			}
+			m.moveChannel(-m.gridColumns())
+		case key.Matches(msg, keys.PageDown):
+			// Scrolling leaves the cursor where it is
+			m.scrollOffset = min(511, m.scrollOffset+m.gridColumns()*m.gridRows())
+		case key.Matches(msg, keys.PageUp):
+			m.scrollOffset = max(0, m.scrollOffset-m.gridColumns()*m.gridRows())
+		case key.Matches(msg, keys.SidePanel):
+			m.hideSidePanel = !m.hideSidePanel
 		}
 
 	case tea.WindowSizeMsg:
@@ -161,7 +624,42 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case TickMsg:
 		// Update universe list
 		m.updateUniverseList()
-		return m, tickCmd()
+		if m.following {
+			m.followMostActive(time.Time(msg))
+		}
+		if m.miniStats {
+			m.trackChannelMovement()
+		}
+		return m, tickCmd(m.refreshInterval())
+
+	case SelectUniverseMsg:
+		m.selectRemoteUniverse(uint16(msg))
+
+	case NoticeMsg:
+		m.statusMsg = string(msg)
+
+	default:
+		// Keep the name input's cursor blinking
+		if m.renaming {
+			var cmd tea.Cmd
+			m.renameInput, cmd = m.renameInput.Update(msg)
+			return m, cmd
+		}
+		if m.jumping {
+			var cmd tea.Cmd
+			m.jumpInput, cmd = m.jumpInput.Update(msg)
+			return m, cmd
+		}
+		if m.picking {
+			var cmd tea.Cmd
+			m.pickerInput, cmd = m.pickerInput.Update(msg)
+			return m, cmd
+		}
+		if m.editingThreshold {
+			var cmd tea.Cmd
+			m.thresholdInput, cmd = m.thresholdInput.Update(msg)
+			return m, cmd
+		}
 	}
 
 	return m, nil
@@ -176,6 +674,14 @@ func (m *Model) updateUniverseList() {
 	sort.Slice(m.universeList, func(i, j int) bool {
 		return m.universeList[i] < m.universeList[j]
 	})
+	m.universeList = m.orderUniverses(m.filterByGroup(m.filterHidden(m.universeList)))
+
+	// Pick the restored selection as soon as it is sending
+	if m.restoreUniverse != 0 && slices.Contains(m.universeList, m.restoreUniverse) {
+		m.selectedUniverse = m.restoreUniverse
+		m.restoreUniverse = 0
+		return
+	}
 
 	// Select first universe if none selected or selected no longer exists
 	if len(m.universeList) > 0 {
@@ -200,44 +706,113 @@ func (m Model) View() string {
 	var s string
 
 	// Title
-	s += titleStyle.Render("sACN Monitor") + "\n\n"
+	s += titleStyle.Render("sACN Monitor")
+	if m.following {
+		s += " " + lipgloss.NewStyle().Foreground(cyanColor).Render("Following the most recently changed universe (F: stop)")
+	}
+	if m.showPriority && m.hasPriorities() {
+		s += " " + lipgloss.NewStyle().Foreground(greenColor).Render("Per-address priorities (y: levels)")
+	}
+	s += "\n\n"
 
 	// Universe tabs
 	if len(m.universeList) > 0 {
-		tabs := ""
-		for _, id := range m.universeList {
-			tabText := fmt.Sprintf("Universe %d", id)
-			universe := m.universeManager.Get(id)
-			isStale := universe == nil || universe.IsStale(staleTimeout)
+		s += m.renderTabs() + "\n\n"
 
-			var style lipgloss.Style
-			if isStale {
-				style = tabStaleStyle
-			} else if id == m.selectedUniverse {
-				style = tabActiveStyle
-			} else {
-				style = tabInactiveStyle
-			}
-			tabs += style.Render(tabText) + " "
+		// Stats for the filtered group and the selected universe
+		if groupStats := m.renderGroupStats(); groupStats != "" {
+			s += groupStats + "\n"
 		}
-		s += tabs + "\n\n"
-
-		// Stats for selected universe
-		s += m.renderStats() + "\n\n"
+		s += m.renderStats() + "\n"
+		if banner := m.renderStartCodeBanner(); banner != "" {
+			s += banner + "\n"
+		}
+		s += "\n"
 
-		// Channel grid
-		s += m.renderChannelGrid() + "\n"
+		// Channel grid, or the view selected instead of it
+		switch m.view {
+		case viewEvents:
+			s += m.renderEvents() + "\n"
+		case viewLosses:
+			s += m.renderLosses() + "\n"
+		case viewFixtures:
+			s += m.renderFixtures() + "\n"
+		case viewSequences:
+			s += m.renderSequences() + "\n"
+		case viewSources:
+			s += m.renderSources() + "\n"
+		case viewDiagnostics:
+			s += m.renderDiagnostics() + "\n"
+		case viewChannel:
+			s += m.renderChannel() + "\n"
+		case viewHeatmap:
+			s += m.renderHeatmap() + "\n"
+		case viewFocus:
+			s += m.renderFocus() + "\n"
+		case viewRollups:
+			s += m.renderRollups() + "\n"
+		default:
+			s += m.renderLayout() + "\n"
+		}
+		if m.eventPane && m.view != viewEvents {
+			s += "\n" + m.renderEventPane() + "\n"
+		}
+	} else if len(m.hidden) > 0 {
+		s += helpStyle.Render(fmt.Sprintf("All %d universes with data are hidden (U: show)", len(m.hidden))) + "\n"
+	} else if m.groupFilter != "" {
+		s += helpStyle.Render(fmt.Sprintf("No data yet for group %s (g: next group)", m.groupFilter)) + "\n"
 	} else {
 		s += helpStyle.Render("Waiting for sACN data...") + "\n\n"
 		s += helpStyle.Render("Listening on UDP port 5568 for multicast/unicast/broadcast traffic.") + "\n"
 	}
 
 	// Help
-	s += "\n" + helpStyle.Render("Tab: switch universe | ↑↓: scroll | q: quit")
+	if m.renaming {
+		s += "\n" + m.renameInput.View()
+	} else if m.jumping {
+		s += "\n" + m.jumpInput.View()
+	} else if m.picking {
+		s += "\n" + m.renderPicker()
+	} else if m.editingThreshold {
+		s += "\n" + m.thresholdInput.View()
+	} else if m.confirm != nil {
+		s += "\n" + lipgloss.NewStyle().Foreground(yellowColor).Render(m.confirm.prompt)
+	} else if m.statusMsg != "" {
+		s += "\n" + statsStyle.Render(m.statusMsg)
+	}
+	if m.readOnly {
+		s += "\n" + helpStyle.Render("Read-only mirror | Tab: switch universe | arrows: move cursor | d: diff | e: events | L: losses | q: detach")
+	} else {
+		s += "\n" + helpStyle.Render("Tab: switch universe | arrows: move cursor | s: snapshot | d: diff | B: save look | e: events | L: losses | q: quit")
+	}
+	if len(m.watch) > 0 {
+		s += "\n" + m.renderWatchPanel()
+	}
+	s += "\n" + m.renderStatusBar()
 
 	return s
 }
 
+// sourceOrigin describes where the winning source's packets come from: a
+// short form of its CID, its address and whether it sends multicast or
+// unicast, e.g. " (1a2b3c4d, 10.0.0.5 multicast)"
+func (m Model) sourceOrigin(cid [16]byte) string {
+	if cid == [16]byte{} {
+		return ""
+	}
+	parts := []string{fmt.Sprintf("%x", cid[:4])}
+	for _, src := range m.statsTracker.GetSources(m.selectedUniverse) {
+		if src.CID != cid {
+			continue
+		}
+		if where := strings.TrimSpace(src.Address + " " + src.Transport); where != "" {
+			parts = append(parts, where)
+		}
+		break
+	}
+	return " (" + strings.Join(parts, ", ") + ")"
+}
+
 func (m Model) renderStats() string {
 	u := m.universeManager.Get(m.selectedUniverse)
 	if u == nil {
@@ -246,70 +821,248 @@ func (m Model) renderStats() string {
 
 	info := u.GetInfo()
 	rate := m.statsTracker.GetPacketRate(m.selectedUniverse)
-	loss := m.statsTracker.GetRecentLossPercentage(m.selectedUniverse)
+	jitter := m.statsTracker.GetJitter(m.selectedUniverse)
 	activeCount := u.ActiveChannelCount()
 
-	// Format loss with color
-	lossStr := fmt.Sprintf("%.1f%%", loss)
-	if loss > 1 {
-		lossStr = lipgloss.NewStyle().Foreground(redColor).Render(lossStr)
-	} else if loss > 0 {
-		lossStr = lipgloss.NewStyle().Foreground(yellowColor).Render(lossStr)
+	// Format the health score with color
+	health := m.statsTracker.GetHealth(m.selectedUniverse)
+	healthStr := fmt.Sprintf("%d", health.Score)
+	if health.Score < 60 {
+		healthStr = lipgloss.NewStyle().Foreground(redColor).Render(healthStr)
+	} else if health.Score < 90 {
+		healthStr = lipgloss.NewStyle().Foreground(yellowColor).Render(healthStr)
 	}
 
 	stats := fmt.Sprintf(
-		"Source: %s | Rate: %.1f pps | Loss: %s | Active: %d/512",
+		"Source: %s%s%s | %s | Rate: %.1f pps%s ±%s, %s | Loss: %s | Health: %s | Active: %d/512",
 		info.SourceName,
+		m.sourceOrigin(info.SourceCID),
+		renderFlags(u.Flags()),
+		renderCountdown(time.Since(info.LastPacket)),
 		rate,
-		lossStr,
+		m.rateSparkline(),
+		jitter.StdDev.Round(100*time.Microsecond),
+		formatBitrate(m.statsTracker.GetByteRate(m.selectedUniverse)),
+		m.renderLossFigures(),
+		healthStr,
 		activeCount,
 	)
+	if masked := u.MaskedCount(); masked > 0 {
+		stats += fmt.Sprintf(" (%d masked)", masked)
+	}
+
+	rateStatus := m.statsTracker.GetRateStatus(m.selectedUniverse)
+	if rateStatus.Deviating {
+		stats += lipgloss.NewStyle().Foreground(redColor).Render(
+			fmt.Sprintf(" | Rate off: expected %.1f pps for %s", rateStatus.Expected, time.Since(rateStatus.Since).Round(time.Second)))
+	}
+	if rateStatus.Bursting {
+		stats += lipgloss.NewStyle().Foreground(redColor).Render(
+			fmt.Sprintf(" | Burst: %.0f pps", rateStatus.LastBurst.PeakRate))
+	}
+	if uptime := m.statsTracker.GetUptime(m.selectedUniverse); uptime.Outages > 0 {
+		stats += lipgloss.NewStyle().Foreground(yellowColor).Render(
+			fmt.Sprintf(" | Up: %.1f%% (%d outages, longest %s)", uptime.Availability(time.Now()), uptime.Outages, uptime.LongestOutage.Round(time.Second)))
+	}
+	if peak := m.statsTracker.GetPeak(m.selectedUniverse); peak.PacketRate > 0 {
+		stats += fmt.Sprintf(" | Peak: %.0f pps at %s, %s", peak.PacketRate, peak.PacketRateAt.Format("15:04:05"), formatBitrate(peak.ByteRate))
+	}
+
+	if syncState := u.SyncState(); syncState.SyncAddress != 0 {
+		if held := syncState.Held(time.Now()); held > syncHoldWarning {
+			stats += lipgloss.NewStyle().Foreground(redColor).Render(
+				fmt.Sprintf(" | Sync %d: data held %s, no sync packet", syncState.SyncAddress, held.Round(100*time.Millisecond)))
+		} else {
+			stats += fmt.Sprintf(" | Sync %d: %d released", syncState.SyncAddress, syncState.Releases)
+		}
+	}
+
+	if p := m.statsTracker.GetIntervalPercentiles(m.selectedUniverse); p.Samples > 0 {
+		stats += fmt.Sprintf(" | Interval p50/p95/p99: %s/%s/%s",
+			p.P50.Round(100*time.Microsecond), p.P95.Round(100*time.Microsecond), p.P99.Round(100*time.Microsecond))
+	}
+	if gap := m.statsTracker.GetLongestGap(m.selectedUniverse); gap > 0 {
+		stats += fmt.Sprintf(" | Max gap: %s", gap.Round(time.Millisecond))
+	}
+	if late := m.statsTracker.GetOutOfOrderCount(m.selectedUniverse); late > 0 {
+		stats += fmt.Sprintf(" | Late: %d", late)
+	}
+	if dups := m.statsTracker.GetDuplicateCount(m.selectedUniverse); dups > 0 {
+		stats += fmt.Sprintf(" | Dup: %d", dups)
+	}
+	if drops := m.statsTracker.GetReceiverDrops(m.selectedUniverse); drops > 0 {
+		stats += lipgloss.NewStyle().Foreground(yellowColor).Render(
+			fmt.Sprintf(" | Rx drops: %d (local, not network)", drops))
+	}
+
+	if busiest := u.MostActiveChannels(busiestChannels); len(busiest) > 0 {
+		parts := make([]string, len(busiest))
+		for i, a := range busiest {
+			parts[i] = fmt.Sprintf("%d (%.1f/s)", a.Channel, a.PerSecond)
+		}
+		stats += " | Busiest: " + strings.Join(parts, ", ")
+	}
+
+	if outside := m.universeManager.OutOfFootprint(m.selectedUniverse); len(outside) > 0 {
+		warning := fmt.Sprintf(" | ⚠ %d ch outside patch (first: %d)", len(outside), outside[0])
+		stats += lipgloss.NewStyle().Foreground(magentaColor).Render(warning)
+	}
+
+	for _, src := range m.statsTracker.GetSources(m.selectedUniverse) {
+		if src.DuplicateCID {
+			warning := fmt.Sprintf(" | ⚠ %q CID duplicated (%s)", src.Name, strings.Join(src.Addresses, ", "))
+			stats += lipgloss.NewStyle().Foreground(redColor).Render(warning)
+		} else if flap := src.Flapping(time.Now()); flap.Flapping {
+			warning := fmt.Sprintf(" | ⚠ %q flapping (%d dropouts in %s)", src.Name, flap.Recent, time.Since(flap.Since).Round(time.Second))
+			stats += lipgloss.NewStyle().Foreground(redColor).Render(warning)
+		} else if !src.AddressChangedAt.IsZero() && time.Since(src.AddressChangedAt) < addressChangeNotice {
+			warning := fmt.Sprintf(" | ⚠ %q moved from %s to %s", src.Name, src.PreviousAddress, src.Address)
+			stats += lipgloss.NewStyle().Foreground(yellowColor).Render(warning)
+		}
+	}
+
+	// Most recent priority change on this universe, while it is news
+	for _, c := range m.statsTracker.GetPriorityChanges(0) {
+		if time.Since(c.Time) >= addressChangeNotice {
+			break
+		}
+		if c.Universe != m.selectedUniverse {
+			continue
+		}
+		change := fmt.Sprintf("priority %d → %d", c.Previous, c.Current)
+		if c.Previous == c.Current && c.PerAddress {
+			change = "per-address priority started"
+		} else if c.Previous == c.Current {
+			change = "per-address priority stopped"
+		}
+		warning := fmt.Sprintf(" | ⚠ %q %s at %s", c.Source, change, c.Time.Format("15:04:05"))
+		stats += lipgloss.NewStyle().Foreground(yellowColor).Render(warning)
+		break
+	}
+
+	if deviations, ok := m.universeManager.BaselineDeviations(m.selectedUniverse); ok {
+		tolerance := m.universeManager.BaselineTolerance()
+		if len(deviations) > 0 {
+			first := fmt.Sprintf("%d", deviations[0].Channel)
+			if label, ok := m.universeManager.ChannelLabel(m.selectedUniverse, deviations[0].Channel); ok {
+				first += " " + label.String()
+			}
+			stats += lipgloss.NewStyle().Foreground(redColor).Render(
+				fmt.Sprintf(" | Baseline: %d ch off by >%d (first: %s)", len(deviations), tolerance, first))
+		} else {
+			stats += lipgloss.NewStyle().Foreground(greenColor).Render(" | Baseline: OK")
+		}
+	}
+
+	if m.diffMode {
+		if snap, ok := m.universeManager.LatestSnapshot(m.selectedUniverse); ok {
+			changed := len(u.Diff(snap))
+			stats += fmt.Sprintf(" | Diff vs %s: %d changed", snap.Name, changed)
+		} else {
+			stats += " | Diff: no snapshot (s to capture)"
+		}
+	}
 
 	return statsStyle.Render(stats)
 }
 
 func (m Model) renderChannelGrid() string {
-	u := m.universeManager.Get(m.selectedUniverse)
+	if len(m.panes) > 0 {
+		return m.renderSplit()
+	}
+	grid := m.renderGrid(m.selectedUniverse, m.scrollOffset, m.gridColumns(), true)
+	if m.showSidePanel() {
+		return lipgloss.JoinHorizontal(lipgloss.Top, grid, " ", m.renderSidePanel())
+	}
+	return grid
+}
+
+// renderGrid renders the channel cards of a universe from a scroll
+// position, highlighting the selected channel if the grid has focus
+func (m Model) renderGrid(id uint16, scroll, channelsPerRow int, focused bool) string {
+	u := m.universeManager.Get(id)
 	if u == nil {
 		return ""
 	}
 
 	channels := u.GetAllChannels()
 	isStale := u.IsStale(staleTimeout)
+	now := time.Now()
+
+	// Channels carrying data outside the loaded patch
+	var outside [512]bool
+	for _, ch := range m.universeManager.OutOfFootprint(id) {
+		outside[ch-1] = true
+	}
+
+	// Channels deviating from the baseline look, when one is loaded
+	var deviating [512]bool
+	if deviations, ok := m.universeManager.BaselineDeviations(id); ok {
+		for _, d := range deviations {
+			deviating[d.Channel-1] = true
+		}
+	}
+
+	// Channels that differ from the latest snapshot, when diffing
+	var changed [512]bool
+	if m.diffMode {
+		if snap, ok := m.universeManager.LatestSnapshot(id); ok {
+			for _, d := range u.Diff(snap) {
+				changed[d.Channel-1] = true
+			}
+		}
+	}
 
 	var rows []string
-	channelsPerRow := m.columnsPerRow
 	if channelsPerRow < 1 {
 		channelsPerRow = 16
 	}
 
-	// Calculate visible rows based on height
-	// Reserve space for: title(2) + tabs(3) + stats(2) + help(2) = 9 lines
-	availableHeight := m.height - 9
-	if availableHeight < 4 {
-		availableHeight = 4
-	}
-	// Each card row is 4 lines tall (border + 2 content + border)
-	rowsPerScreen := availableHeight / 4
-	if rowsPerScreen < 1 {
-		rowsPerScreen = 1
-	}
+	rowsPerScreen := m.gridRows()
 
-	startChannel := m.scrollOffset
-	if startChannel >= 512 {
-		startChannel = 512 - channelsPerRow
+	// Positions in the shown channels, which the filter may thin out
+	shown := m.gridChannels(id)
+	if len(shown) == 0 {
+		if m.threshold != nil {
+			return helpStyle.Render(fmt.Sprintf("No channels %s (t: change threshold)", m.threshold))
+		}
+		return helpStyle.Render(fmt.Sprintf("No %s (a: change filter)", m.channelFilter))
 	}
-	if startChannel < 0 {
-		startChannel = 0
+	start := scroll
+	if start >= len(shown) {
+		start = max(0, len(shown)-channelsPerRow)
 	}
+	if start < 0 {
+		start = 0
+	}
+
+	end := min(len(shown), start+(rowsPerScreen*channelsPerRow))
 
-	endChannel := min(512, startChannel+(rowsPerScreen*channelsPerRow))
+	priorities, showPriority := m.gridPriorities(id)
+	var owners []sourceOwner
+	if m.ownerColors {
+		owners = m.sourceOwners(id)
+	}
 
-	for i := startChannel; i < endChannel; i += channelsPerRow {
+	d := m.gridDensity()
+	for p := start; p < end; p += channelsPerRow {
 		var cards []string
-		for j := 0; j < channelsPerRow && (i+j) < 512; j++ {
-			ch := channels[i+j]
-			channelNum := i + j + 1 // 1-based channel number
+		row := shown[p:min(end, p+channelsPerRow)]
+		if d == densityLine {
+			cards = append(cards, helpStyle.Render(fmt.Sprintf("%3d│", row[0]+1)))
+		}
+		for k := 0; k < len(row); k++ {
+			c := row[k]
+			ch := channels[c]
+
+			// A 16-bit pair takes the slots of both its channels
+			if d == densityCards && !showPriority && k+1 < len(row) && row[k+1] == c+1 && m.isWideCoarse(id, c) {
+				cards = append(cards, m.renderWideCard(id, ch, channels[c+1], c, isStale, focused && (c == m.selectedChannel || c+1 == m.selectedChannel)))
+				k++
+				continue
+			}
+
+			channelNum := c + 1 // 1-based channel number
 
 			var cardStyle lipgloss.Style
 			var valueStr string
@@ -317,15 +1070,47 @@ func (m Model) renderChannelGrid() string {
 			if isStale {
 				cardStyle = staleCardStyle
 				valueStr = " . "
+			} else if showPriority {
+				cardStyle, valueStr = priorityCell(priorities, c)
+			} else if m.ownerColors && ch.Active {
+				cardStyle = ownerStyle(owners, ch.SourceCID)
+				valueStr = m.valueFormat.format(ch.Value)
+			} else if u.IsMasked(c) {
+				cardStyle = inactiveCardStyle
+				valueStr = m.valueFormat.format(ch.Value)
+			} else if deviating[c] {
+				cardStyle = deviationCardStyle
+				valueStr = m.valueFormat.format(ch.Value)
+			} else if outside[c] {
+				cardStyle = unpatchedCardStyle
+				valueStr = m.valueFormat.format(ch.Value)
+			} else if changed[c] {
+				cardStyle = changedCardStyle
+				valueStr = m.valueFormat.format(ch.Value)
 			} else if ch.Active {
 				cardStyle = activeCardStyle
-				valueStr = fmt.Sprintf("%3d", ch.Value)
+				valueStr = m.valueFormat.format(ch.Value)
 			} else {
 				cardStyle = inactiveCardStyle
 				valueStr = " . "
 			}
 
+			if d != densityCards {
+				cards = append(cards, renderCell(d, cardStyle, channelNum, valueStr, focused && c == m.selectedChannel))
+				continue
+			}
+
 			cardContent := fmt.Sprintf("%3d\n%s", channelNum, valueStr)
+			if m.miniStats {
+				dot, arrow := m.cardMiniStats(c, ch, now)
+				cardContent = fmt.Sprintf("%3d%s\n%s%s", channelNum, dot, valueStr, arrow)
+			}
+			if m.cardLabels() {
+				cardContent += "\n" + helpStyle.Render(m.cardLabel(id, c, 4))
+			}
+			if focused && c == m.selectedChannel {
+				cardStyle = cardStyle.Border(lipgloss.ThickBorder()).BorderForeground(whiteColor)
+			}
 			cards = append(cards, cardStyle.Render(cardContent))
 		}
 		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, cards...))
@@ -334,6 +1119,44 @@ func (m Model) renderChannelGrid() string {
 	return lipgloss.JoinVertical(lipgloss.Left, rows...)
 }
 
+// gridRows returns how many rows of channel cards fit on screen
+func (m Model) gridRows() int {
+	// Reserve space for: title(2) + tabs(3) + stats(2) + help(2) + status bar(1) = 10 lines
+	availableHeight := m.height - 10
+	if availableHeight < 4 {
+		availableHeight = 4
+	}
+	if m.eventPane {
+		availableHeight -= eventPaneSize + 2
+	}
+	if len(m.watch) > 0 {
+		availableHeight--
+	}
+	if m.activeLayout() == layoutGridSources {
+		availableHeight -= m.sourcesPaneHeight() + 1
+	}
+	if m.ownerColors && m.view == viewGrid {
+		availableHeight--
+	}
+	if len(m.bannerStartCodes()) > 0 {
+		availableHeight--
+	}
+	return max(1, availableHeight/m.rowHeight())
+}
+
+// formatBitrate formats a byte rate as bits per second with a metric prefix
+func formatBitrate(bytesPerSecond float64) string {
+	bits := bytesPerSecond * 8
+	switch {
+	case bits >= 1e6:
+		return fmt.Sprintf("%.1f Mbit/s", bits/1e6)
+	case bits >= 1e3:
+		return fmt.Sprintf("%.0f kbit/s", bits/1e3)
+	default:
+		return fmt.Sprintf("%.0f bit/s", bits)
+	}
+}
+
 func max(a, b int) int {
 	if a > b {
 		return a
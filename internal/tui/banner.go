@@ -0,0 +1,74 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"sacn-monitor/internal/sacn"
+	"sacn-monitor/internal/universe"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// startCodeNotice is how recently an alternate start code must have been
+// seen on the selected universe for the banner to show
+const startCodeNotice = 10 * time.Second
+
+var bannerStyle = lipgloss.NewStyle().
+	Bold(true).
+	Foreground(lipgloss.Color("#000000")).
+	Background(yellowColor)
+
+// startCodeKey identifies a start code on a universe, for dismissing its banner
+type startCodeKey struct {
+	universe  uint16
+	startCode uint8
+}
+
+// bannerStartCodes returns the alternate start codes recently seen on the
+// selected universe whose banner hasn't been dismissed
+func (m Model) bannerStartCodes() []universe.StartCodeSeen {
+	u := m.universeManager.Get(m.selectedUniverse)
+	if u == nil {
+		return nil
+	}
+	var shown []universe.StartCodeSeen
+	for _, seen := range u.StartCodesSince(time.Now().Add(-startCodeNotice)) {
+		if !m.dismissedCodes[startCodeKey{m.selectedUniverse, seen.StartCode}] {
+			shown = append(shown, seen)
+		}
+	}
+	return shown
+}
+
+// dismissBanner hides the start code banner of the selected universe for
+// the start codes it currently names
+func (m *Model) dismissBanner() {
+	codes := m.bannerStartCodes()
+	if len(codes) == 0 {
+		return
+	}
+	if m.dismissedCodes == nil {
+		m.dismissedCodes = make(map[startCodeKey]bool)
+	}
+	for _, seen := range codes {
+		m.dismissedCodes[startCodeKey{m.selectedUniverse, seen.StartCode}] = true
+	}
+}
+
+// renderStartCodeBanner renders a warning naming the alternate start codes
+// on the selected universe and who sends them, or "" if there are none
+func (m Model) renderStartCodeBanner() string {
+	codes := m.bannerStartCodes()
+	if len(codes) == 0 {
+		return ""
+	}
+	parts := make([]string, len(codes))
+	for i, seen := range codes {
+		parts[i] = fmt.Sprintf("%s from %q (%d packets since %s)",
+			sacn.StartCodeName(seen.StartCode), seen.Source, seen.Packets, seen.FirstSeen.Format("15:04:05"))
+	}
+	text := " ⚠ Alternate start code on this universe: " + strings.Join(parts, ", ") + " | esc: dismiss "
+	return bannerStyle.MaxWidth(max(0, m.width)).Render(text)
+}
@@ -0,0 +1,136 @@
+package tui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// sparklinePoints is how many 1 s history samples the channel panel plots
+const sparklinePoints = 60
+
+// rateSparklineSpan is how far back the packet rate sparkline reaches
+const rateSparklineSpan = time.Minute
+
+// moveChannel moves the channel selection by delta shown channels,
+// scrolling the grid to keep it in view
+func (m *Model) moveChannel(delta int) {
+	channels := m.gridChannels(m.selectedUniverse)
+	if len(channels) == 0 {
+		return
+	}
+	pos := max(0, min(len(channels)-1, channelPosition(channels, m.selectedChannel)+delta))
+	m.selectedChannel = channels[pos]
+
+	perRow := m.gridColumns()
+	if pos < m.scrollOffset {
+		m.scrollOffset = pos / perRow * perRow
+	}
+	if rows := m.gridRows(); pos >= m.scrollOffset+rows*perRow {
+		m.scrollOffset = (pos/perRow - rows + 1) * perRow
+	}
+}
+
+// renderChannel renders the detail panel of the selected channel
+func (m Model) renderChannel() string {
+	u := m.universeManager.Get(m.selectedUniverse)
+	if u == nil {
+		return ""
+	}
+	ch := u.GetChannel(m.selectedChannel)
+	number := m.selectedChannel + 1
+
+	var b strings.Builder
+	b.WriteString(statsStyle.Render(fmt.Sprintf("Channel %d on %s", number, m.universeManager.Describe(m.selectedUniverse))) + "\n")
+	if label, ok := m.universeManager.ChannelLabel(m.selectedUniverse, number); ok {
+		b.WriteString(fmt.Sprintf("  Patch        %s\n", label))
+	}
+	if !ch.Active {
+		b.WriteString(helpStyle.Render("  Not received yet.") + "\n")
+		b.WriteString("\n" + helpStyle.Render("←→: previous/next channel | enter: back to grid"))
+		return b.String()
+	}
+
+	owner := "-"
+	for _, src := range m.statsTracker.GetSources(m.selectedUniverse) {
+		if src.CID == ch.SourceCID {
+			owner = src.Name
+			break
+		}
+	}
+	change := "-"
+	if !ch.LastChange.IsZero() {
+		change = fmt.Sprintf("%s (%s ago)", ch.LastChange.Format("15:04:05"), time.Since(ch.LastChange).Round(time.Second))
+	}
+
+	b.WriteString(fmt.Sprintf("  Value        %s\n", m.valueFormat.formatCompact(ch.Value)))
+	b.WriteString(fmt.Sprintf("  Min / Max    %s / %s\n", m.valueFormat.formatCompact(ch.Min), m.valueFormat.formatCompact(ch.Max)))
+	b.WriteString(fmt.Sprintf("  Last change  %s\n", change))
+	b.WriteString(fmt.Sprintf("  Source       %s\n", owner))
+	if p, ok := u.PerAddressPriorities(); ok && m.selectedChannel < p.Slots {
+		b.WriteString(fmt.Sprintf("  Priority     %d (per-address)\n", p.Values[m.selectedChannel]))
+	} else {
+		b.WriteString(fmt.Sprintf("  Priority     %d (universe)\n", u.GetInfo().Priority))
+	}
+
+	if m.history != nil {
+		points := m.history.ChannelHistory(m.selectedUniverse, m.selectedChannel, sparklinePoints)
+		if len(points) > 0 {
+			values := make([]float64, len(points))
+			for i, p := range points {
+				values[i] = float64(p.Value)
+			}
+			b.WriteString(fmt.Sprintf("\n  Last %ds     %s\n", len(points), sparkline(values, 255)))
+		}
+	}
+
+	width := m.width
+	if m.activeLayout() == layoutDashboard {
+		width = m.detailPaneWidth()
+	}
+	if timeline := m.renderTimeline(max(10, min(width-4, 150))); timeline != "" {
+		b.WriteString("\n" + statsStyle.Render(fmt.Sprintf("Universe %d, last %d minutes", m.selectedUniverse, int(timelineSpan.Minutes()))) + "\n")
+		b.WriteString(timeline + "\n")
+	}
+
+	b.WriteString("\n" + helpStyle.Render("←→: previous/next channel | enter: back to grid"))
+	return b.String()
+}
+
+// sparkline renders values from 0 to top as a row of block characters
+func sparkline(values []float64, top float64) string {
+	glyphs := []rune("▁▂▃▄▅▆▇█")
+	var b strings.Builder
+	for _, v := range values {
+		i := 0
+		if top > 0 {
+			i = max(0, min(len(glyphs)-1, int(v/top*float64(len(glyphs)-1)+0.5)))
+		}
+		b.WriteRune(glyphs[i])
+	}
+	return b.String()
+}
+
+// rateSparkline renders the selected universe's packet rate over the last
+// minute from the history, scaled to its peak, or "" without history
+func (m Model) rateSparkline() string {
+	if m.history == nil {
+		return ""
+	}
+	now := time.Now()
+	samples := m.history.Range(m.selectedUniverse, now.Add(-rateSparklineSpan), now)
+	if len(samples) < 2 {
+		return ""
+	}
+
+	rates := make([]float64, len(samples))
+	var top float64
+	for i, s := range samples {
+		rates[i] = s.PacketRate
+		top = math.Max(top, s.PacketRate)
+	}
+	return " " + lipgloss.NewStyle().Foreground(cyanColor).Render(sparkline(rates, top))
+}
@@ -0,0 +1,87 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// clipboardColumns is how many values a line of copied channels holds
+const clipboardColumns = 16
+
+// copyToClipboard returns a command setting the system clipboard through
+// the terminal with an OSC 52 sequence, which also works over SSH
+func copyToClipboard(text string) tea.Cmd {
+	return func() tea.Msg {
+		seq := osc52.New(text)
+		switch {
+		case os.Getenv("TMUX") != "":
+			seq = seq.Tmux()
+		case strings.HasPrefix(os.Getenv("TERM"), "screen"):
+			seq = seq.Screen()
+		}
+		_, _ = seq.WriteTo(os.Stdout)
+		return nil
+	}
+}
+
+// copySelectedChannel copies the selected channel's value, e.g.
+// "Universe 1 (FOH) channel 17: 255"
+func (m *Model) copySelectedChannel() tea.Cmd {
+	u := m.universeManager.Get(m.selectedUniverse)
+	if u == nil {
+		return nil
+	}
+	ch := u.GetChannel(m.selectedChannel)
+	value := "not received"
+	if ch.Active {
+		value = m.valueFormat.formatCompact(ch.Value)
+	}
+	text := fmt.Sprintf("%s channel %d: %s", m.universeManager.Describe(m.selectedUniverse), m.selectedChannel+1, value)
+	if label, ok := m.universeManager.ChannelLabel(m.selectedUniverse, m.selectedChannel+1); ok {
+		text += fmt.Sprintf(" (%s)", label)
+	}
+	m.statusMsg = "Copied " + text
+	return copyToClipboard(text)
+}
+
+// copyVisibleChannels copies the values of the channels on screen, 16 per
+// line, each line starting with its first channel number
+func (m *Model) copyVisibleChannels() tea.Cmd {
+	u := m.universeManager.Get(m.selectedUniverse)
+	if u == nil {
+		return nil
+	}
+	shown := m.gridChannels(m.selectedUniverse)
+	start := min(max(0, m.scrollOffset), len(shown))
+	end := min(len(shown), start+m.gridRows()*m.gridColumns())
+	visible := shown[start:end]
+	if len(visible) == 0 {
+		m.statusMsg = "No channels on screen to copy"
+		return nil
+	}
+
+	channels := u.GetAllChannels()
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("%s channels %d-%d:\n", m.universeManager.Describe(m.selectedUniverse), visible[0]+1, visible[len(visible)-1]+1))
+	for i, c := range visible {
+		if i%clipboardColumns == 0 {
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			b.WriteString(fmt.Sprintf("%3d:", c+1))
+		}
+		value := "  ."
+		if channels[c].Active {
+			value = m.valueFormat.format(channels[c].Value)
+		}
+		b.WriteString(" " + value)
+	}
+	b.WriteString("\n")
+
+	m.statusMsg = fmt.Sprintf("Copied %d channel values", len(visible))
+	return copyToClipboard(b.String())
+}
@@ -0,0 +1,46 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// confirmation is an action waiting for the user to press y
+type confirmation struct {
+	prompt string
+	action func(m *Model)
+}
+
+// askConfirm asks before running an action that can't be undone
+func (m *Model) askConfirm(prompt string, action func(m *Model)) {
+	m.confirm = &confirmation{prompt: prompt, action: action}
+}
+
+// updateConfirm runs the pending action on y and cancels it on any other
+// key
+func (m Model) updateConfirm(msg tea.KeyMsg) (Model, tea.Cmd) {
+	pending := m.confirm
+	m.confirm = nil
+	if msg.String() == "y" || msg.String() == "Y" {
+		pending.action(&m)
+	} else {
+		m.statusMsg = "Cancelled"
+	}
+	return m, nil
+}
+
+// resetSelectedStats asks before zeroing the selected universe's statistics
+func (m *Model) resetSelectedStats() {
+	id := m.selectedUniverse
+	m.askConfirm("Reset statistics of "+m.universeManager.Describe(id)+"? (y/n)", func(m *Model) {
+		m.statsTracker.ResetUniverseStats(id)
+		m.statusMsg = "Statistics reset for " + m.universeManager.Describe(id)
+	})
+}
+
+// resetAllStats asks before zeroing the statistics of every universe
+func (m *Model) resetAllStats() {
+	m.askConfirm("Reset statistics of ALL universes? (y/n)", func(m *Model) {
+		m.statsTracker.ResetAllStats()
+		m.statusMsg = "Statistics reset for all universes"
+	})
+}
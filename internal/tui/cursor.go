@@ -0,0 +1,68 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// sidePanelWidth is the width of the selected channel's side panel,
+// border included
+const sidePanelWidth = 28
+
+// minSidePanelWidth is the terminal width below which the side panel is
+// left out in favour of the grid
+const minSidePanelWidth = 90
+
+var sidePanelStyle = lipgloss.NewStyle().
+	Border(lipgloss.NormalBorder()).
+	BorderForeground(grayColor).
+	Padding(0, 1).
+	Width(sidePanelWidth - 2)
+
+// showSidePanel reports whether the selected channel's details are shown
+// beside the grid
+func (m Model) showSidePanel() bool {
+	return !m.hideSidePanel && m.activeLayout() == layoutGrid && len(m.panes) == 0 && m.width >= minSidePanelWidth
+}
+
+// renderSidePanel renders the selected channel's value, label, last change
+// and source beside the grid
+func (m Model) renderSidePanel() string {
+	u := m.universeManager.Get(m.selectedUniverse)
+	if u == nil {
+		return ""
+	}
+	ch := u.GetChannel(m.selectedChannel)
+
+	var b strings.Builder
+	b.WriteString(statsStyle.Bold(true).Render(fmt.Sprintf("Channel %d", m.selectedChannel+1)))
+	if label, ok := m.universeManager.ChannelLabel(m.selectedUniverse, m.selectedChannel+1); ok {
+		b.WriteString("\n" + helpStyle.Render(label.String()))
+	}
+	if !ch.Active {
+		b.WriteString("\n" + helpStyle.Render("Not received"))
+		return sidePanelStyle.Render(b.String())
+	}
+
+	owner := "-"
+	for _, src := range m.statsTracker.GetSources(m.selectedUniverse) {
+		if src.CID == ch.SourceCID {
+			owner = src.Name
+			break
+		}
+	}
+	change := "-"
+	if !ch.LastChange.IsZero() {
+		change = time.Since(ch.LastChange).Round(time.Second).String() + " ago"
+	}
+
+	b.WriteString(fmt.Sprintf("\nValue   %s", m.valueFormat.formatCompact(ch.Value)))
+	b.WriteString(fmt.Sprintf("\nRange   %s-%s", m.valueFormat.formatCompact(ch.Min), m.valueFormat.formatCompact(ch.Max)))
+	b.WriteString(fmt.Sprintf("\nChanged %s", change))
+	b.WriteString(fmt.Sprintf("\nSource  %s", owner))
+	b.WriteString("\n\n" + helpStyle.Render("enter: details | i: hide"))
+	return sidePanelStyle.Render(b.String())
+}
@@ -0,0 +1,73 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// density is how tightly the channel grid is drawn
+type density int
+
+const (
+	densityCards   density = iota // Bordered cards, 4 lines per row
+	densityCompact                // Borderless 2-line cells: number over value
+	densityLine                   // Values only, one line per row labeled with its first channel
+	densityCount
+)
+
+func (d density) String() string {
+	switch d {
+	case densityCompact:
+		return "compact"
+	case densityLine:
+		return "one line per row"
+	default:
+		return "cards"
+	}
+}
+
+// cellWidth is the width of a compact cell: a 3-character value and a space
+const cellWidth = 4
+
+// gridDensity returns the density the grid is drawn at. One-line rows
+// need consecutive channels, so a filtered grid uses 2-line cells.
+func (m Model) gridDensity() density {
+	if m.density == densityLine && (m.channelFilter != filterNone || m.threshold != nil) {
+		return densityCompact
+	}
+	return m.density
+}
+
+// lineHeight returns how many lines a row of the grid takes
+func (d density) lineHeight() int {
+	switch d {
+	case densityCompact:
+		return 2
+	case densityLine:
+		return 1
+	default:
+		return 4 // Border + 2 content + border
+	}
+}
+
+// cellWidthFor returns the width of one channel at the density
+func (d density) cellWidthFor() int {
+	if d == densityCards {
+		return 6 // 4 content + border
+	}
+	return cellWidth
+}
+
+// renderCell renders a channel as a borderless cell in the color its card
+// would have, reversed when selected
+func renderCell(d density, cardStyle lipgloss.Style, channelNum int, valueStr string, selected bool) string {
+	style := lipgloss.NewStyle().Foreground(cardStyle.GetBorderTopForeground())
+	if selected {
+		style = style.Reverse(true)
+	}
+	if d == densityLine {
+		return style.Render(valueStr) + " "
+	}
+	return helpStyle.Render(fmt.Sprintf("%3d ", channelNum)) + "\n" + style.Render(valueStr) + " "
+}
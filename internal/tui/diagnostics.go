@@ -0,0 +1,68 @@
+package tui
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+
+	"sacn-monitor/internal/history"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// memoryWarning is the estimated footprint above which the diagnostics
+// view warns, well before a small board runs out of RAM
+const memoryWarning = 256 << 20
+
+// WithHistory returns a copy of the model that reports the history
+// recorder's memory in the diagnostics view
+func (m Model) WithHistory(r *history.Recorder) Model {
+	m.history = r
+	return m
+}
+
+// renderDiagnostics renders memory estimates of the tracked state and the
+// Go runtime's heap
+func (m Model) renderDiagnostics() string {
+	st := m.statsTracker.MemoryUsage()
+	um := m.universeManager.MemoryUsage()
+	total := st.Total() + um.Total()
+
+	var b strings.Builder
+	b.WriteString(statsStyle.Render("Memory (estimated)") + "\n")
+	b.WriteString(fmt.Sprintf("  Stats      %4d universes %5d sources   %10s  (windows %s, logs %s, timelines %s, addresses %s)\n",
+		st.Universes, st.Sources, formatBytes(st.Total()),
+		formatBytes(st.WindowBytes), formatBytes(st.LogBytes), formatBytes(st.TimelineBytes), formatBytes(st.AddressBytes)))
+	b.WriteString(fmt.Sprintf("  Universes  %4d universes %5d snapshots %10s\n",
+		um.Universes, um.Snapshots, formatBytes(um.Total())))
+	if m.history != nil {
+		h := m.history.MemoryUsage()
+		total += h.Bytes
+		b.WriteString(fmt.Sprintf("  History    %4d universes                 %10s\n", h.Universes, formatBytes(h.Bytes)))
+	}
+
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	b.WriteString(fmt.Sprintf("\n  Total estimate %s | Go heap %s in use, %s from the OS\n",
+		formatBytes(total), formatBytes(int64(ms.HeapInuse)), formatBytes(int64(ms.Sys))))
+
+	if total > memoryWarning {
+		b.WriteString("\n" + lipgloss.NewStyle().Foreground(yellowColor).Render(
+			fmt.Sprintf("⚠ Tracked state is over %s; consider -max-universes or a shorter -sequence-timeline", formatBytes(memoryWarning))))
+	}
+	return b.String()
+}
+
+// formatBytes formats a byte count with a binary unit
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<30:
+		return fmt.Sprintf("%.1f GiB", float64(n)/(1<<30))
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MiB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KiB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
@@ -0,0 +1,57 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"sacn-monitor/internal/export"
+)
+
+// exportUniverse writes the selected universe's channel values, sources
+// and statistics to a timestamped JSON file
+func (m *Model) exportUniverse() {
+	u := m.universeManager.Get(m.selectedUniverse)
+	if u == nil {
+		return
+	}
+	now := time.Now()
+	info := u.GetInfo()
+
+	dump := export.UniverseDump{
+		Time:                 now,
+		Universe:             m.selectedUniverse,
+		Name:                 m.universeManager.Name(m.selectedUniverse),
+		Source:               info.SourceName,
+		Priority:             info.Priority,
+		PacketRate:           m.statsTracker.GetPacketRate(m.selectedUniverse),
+		RecentLossPercentage: m.statsTracker.GetRecentLossPercentage(m.selectedUniverse),
+		Health:               m.statsTracker.GetHealth(m.selectedUniverse).Score,
+		ActiveChannels:       u.ActiveChannelCount(),
+		Channels:             make([]int, 512),
+	}
+	for i, ch := range m.universeManager.LabeledChannels(m.selectedUniverse) {
+		dump.Channels[i] = -1
+		if ch.Active {
+			dump.Channels[i] = int(ch.Value)
+		}
+		if ch.Patched {
+			if dump.Labels == nil {
+				dump.Labels = make(map[int]string)
+			}
+			dump.Labels[ch.Number] = ch.Label.String()
+		}
+	}
+	for _, us := range m.statsTracker.Summary().Universes {
+		if us.Universe == m.selectedUniverse {
+			dump.Stats = &us
+			break
+		}
+	}
+
+	path := fmt.Sprintf("universe-%d-%s.json", m.selectedUniverse, now.Format("20060102-150405"))
+	if err := export.WriteUniverseDumpFile(path, dump); err != nil {
+		m.statusMsg = fmt.Sprintf("Export failed: %v", err)
+	} else {
+		m.statusMsg = fmt.Sprintf("Exported universe %d to %s", m.selectedUniverse, path)
+	}
+}
@@ -0,0 +1,132 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"sacn-monitor/internal/events"
+	"sacn-monitor/internal/universe"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// eventListSize is the number of recent events shown in the events view
+const eventListSize = 10
+
+// eventPaneSize is the number of recent events shown in the pane below
+// the grid
+const eventPaneSize = 5
+
+// WithEventLog returns a copy of the model displaying events from log
+func (m Model) WithEventLog(log *events.Log) Model {
+	m.eventLog = log
+	return m
+}
+
+// renderEvents renders the recent event list and the channel snapshot
+// captured with the selected event
+func (m Model) renderEvents() string {
+	if m.eventLog == nil || m.eventLog.Len() == 0 {
+		return helpStyle.Render("No events recorded yet.")
+	}
+
+	recent := m.eventLog.Recent(eventListSize)
+	cursor := min(m.eventCursor, len(recent)-1)
+
+	var b strings.Builder
+	b.WriteString(statsStyle.Render("Events (newest first)") + "\n")
+	for i, e := range recent {
+		marker := "  "
+		if i == cursor {
+			marker = "> "
+		}
+		line := fmt.Sprintf("%s%s  %-13s %s", marker, e.Time.Format("15:04:05"), e.Kind, e.Message)
+		if e.Snapshot != nil {
+			line += " [snapshot]"
+		}
+
+		style := eventStyle(e.Kind)
+		if i == cursor {
+			style = style.Bold(true)
+		}
+		b.WriteString(style.Render(line) + "\n")
+	}
+
+	selected := recent[cursor]
+	b.WriteString("\n")
+	if selected.Snapshot == nil {
+		b.WriteString(helpStyle.Render("No channel snapshot for this event."))
+	} else {
+		b.WriteString(m.renderEventSnapshot(selected.Snapshot))
+	}
+	return b.String()
+}
+
+// eventStyle colors an event by kind: red for trouble, yellow for
+// changes worth a look, green for recoveries
+func eventStyle(kind events.Kind) lipgloss.Style {
+	switch kind {
+	case events.SourceLost, events.LossSpike, events.DuplicateCID, events.Blackout, events.RateDeviation, events.ExportError, events.AddressChange, events.Burst, events.Flapping, events.PriorityConflict:
+		return lipgloss.NewStyle().Foreground(redColor)
+	case events.PriorityChange:
+		return lipgloss.NewStyle().Foreground(yellowColor)
+	case events.SourceOnline, events.BlackoutEnd, events.RateRestored:
+		return lipgloss.NewStyle().Foreground(greenColor)
+	}
+	return statsStyle
+}
+
+// renderEventPane renders the latest events as a short list below the
+// grid, without snapshots
+func (m Model) renderEventPane() string {
+	var b strings.Builder
+	b.WriteString(helpStyle.Render("── Events (E: hide, e: details) ──") + "\n")
+	if m.eventLog == nil || m.eventLog.Len() == 0 {
+		b.WriteString(helpStyle.Render("No events recorded yet."))
+		return b.String()
+	}
+	recent := m.eventLog.Recent(eventPaneSize)
+	for i, e := range recent {
+		line := fmt.Sprintf("%s  %-17s %s", e.Time.Format("15:04:05"), e.Kind, e.Message)
+		if r := []rune(line); m.width > 3 && len(r) > m.width-2 {
+			line = string(r[:m.width-3]) + "…"
+		}
+		b.WriteString(eventStyle(e.Kind).Render(line))
+		if i < len(recent)-1 {
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// renderEventSnapshot lists the non-zero channels of a captured snapshot as
+// "channel:value" pairs wrapped to the terminal width
+func (m Model) renderEventSnapshot(s *universe.Snapshot) string {
+	header := fmt.Sprintf("Universe %d at %s:", s.UniverseID, s.TakenAt.Format("15:04:05.000"))
+
+	const cellWidth = 9 // "512:255" plus spacing
+	perRow := max(1, (m.width-2)/cellWidth)
+
+	var rows []string
+	var row strings.Builder
+	count := 0
+	for i, v := range s.Values {
+		if !s.Active[i] || v == 0 {
+			continue
+		}
+		row.WriteString(fmt.Sprintf("%-*s", cellWidth, fmt.Sprintf("%d:%s", i+1, m.valueFormat.formatCompact(v))))
+		count++
+		if count%perRow == 0 {
+			rows = append(rows, row.String())
+			row.Reset()
+		}
+	}
+	if row.Len() > 0 {
+		rows = append(rows, row.String())
+	}
+
+	if count == 0 {
+		return statsStyle.Render(header) + "\n" + helpStyle.Render("All channels at zero (blackout).")
+	}
+	return statsStyle.Render(header) + "\n" + strings.Join(rows, "\n")
+}
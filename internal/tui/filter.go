@@ -0,0 +1,177 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// channelFilter selects which channels the grid shows
+type channelFilter int
+
+const (
+	filterNone    channelFilter = iota
+	filterActive                // Channels received in packets
+	filterNonZero               // Received channels above zero
+	channelFilterCount
+)
+
+func (f channelFilter) String() string {
+	switch f {
+	case filterActive:
+		return "active channels"
+	case filterNonZero:
+		return "non-zero channels"
+	default:
+		return "all channels"
+	}
+}
+
+// gridChannels returns the 0-based channels the grid shows for a
+// universe, in order, after the channel filter and threshold.
+// The heatmap always shows all of them.
+func (m Model) gridChannels(id uint16) []int {
+	channels := make([]int, 0, 512)
+	u := m.universeManager.Get(id)
+	if u == nil || (m.channelFilter == filterNone && m.threshold == nil) || m.view == viewHeatmap {
+		for i := range 512 {
+			channels = append(channels, i)
+		}
+		return channels
+	}
+
+	for i, ch := range u.GetAllChannels() {
+		if m.channelFilter != filterNone && !ch.Active {
+			continue
+		}
+		if m.channelFilter == filterNonZero && ch.Value == 0 {
+			continue
+		}
+		if m.threshold != nil && (!ch.Active || !m.threshold.matches(ch.Value)) {
+			continue
+		}
+		channels = append(channels, i)
+	}
+	return channels
+}
+
+// channelPosition returns the position of a channel in a grid's channels,
+// or of the next shown channel after it
+func channelPosition(channels []int, channel int) int {
+	for pos, c := range channels {
+		if c >= channel {
+			return pos
+		}
+	}
+	return max(0, len(channels)-1)
+}
+
+// threshold limits the grid to channels compared against a value
+type threshold struct {
+	op    string // ">", ">=", "<", "<=" or "="
+	value int    // DMX value, 0-255
+}
+
+// matches reports whether a channel value passes the threshold
+func (t threshold) matches(v uint8) bool {
+	switch t.op {
+	case ">":
+		return int(v) > t.value
+	case ">=":
+		return int(v) >= t.value
+	case "<":
+		return int(v) < t.value
+	case "<=":
+		return int(v) <= t.value
+	default:
+		return int(v) == t.value
+	}
+}
+
+func (t threshold) String() string {
+	return fmt.Sprintf("%s%d", t.op, t.value)
+}
+
+// parseThreshold parses a comparison such as ">200", "<=10" or ">80%"
+// (percent of full)
+func parseThreshold(s string) (threshold, error) {
+	s = strings.ReplaceAll(s, " ", "")
+	var t threshold
+	for _, op := range []string{">=", "<=", ">", "<", "="} {
+		if strings.HasPrefix(s, op) {
+			t.op, s = op, s[len(op):]
+			break
+		}
+	}
+	if t.op == "" {
+		return t, fmt.Errorf("%q needs a comparison (>, >=, <, <=, =)", s)
+	}
+
+	percent := strings.HasSuffix(s, "%")
+	n, err := strconv.Atoi(strings.TrimSuffix(s, "%"))
+	switch {
+	case err != nil:
+		return t, fmt.Errorf("invalid value %q", s)
+	case percent && (n < 0 || n > 100):
+		return t, fmt.Errorf("percentage %d out of range (0-100)", n)
+	case percent:
+		n = (n*255 + 50) / 100
+	case n < 0 || n > 255:
+		return t, fmt.Errorf("value %d out of range (0-255)", n)
+	}
+	t.value = n
+	return t, nil
+}
+
+// startThreshold opens the threshold input
+func (m *Model) startThreshold() {
+	input := textinput.New()
+	input.Prompt = "Show channels: "
+	input.Placeholder = ">200, <=10, >80% (empty shows all)"
+	input.CharLimit = 8
+	if m.threshold != nil {
+		input.SetValue(m.threshold.String())
+	}
+	input.Focus()
+
+	m.thresholdInput = input
+	m.editingThreshold = true
+}
+
+// updateThreshold handles keys while the threshold input is open
+func (m Model) updateThreshold(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.editingThreshold = false
+		m.commitThreshold(m.thresholdInput.Value())
+		return m, nil
+	case tea.KeyEsc:
+		m.editingThreshold = false
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.thresholdInput, cmd = m.thresholdInput.Update(msg)
+	return m, cmd
+}
+
+// commitThreshold applies a threshold, or clears it if empty
+func (m *Model) commitThreshold(s string) {
+	if strings.TrimSpace(s) == "" {
+		m.threshold = nil
+		m.statusMsg = "Threshold cleared"
+	} else {
+		t, err := parseThreshold(s)
+		if err != nil {
+			m.statusMsg = fmt.Sprintf("Threshold not set: %v", err)
+			return
+		}
+		m.threshold = &t
+		m.statusMsg = fmt.Sprintf("Showing channels %s", t)
+	}
+	m.scrollOffset = 0
+	m.moveChannel(0)
+}
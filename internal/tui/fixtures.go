@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"sacn-monitor/internal/universe"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// fixtureNameWidth is the column width for fixture names in the fixture view
+const fixtureNameWidth = 16
+
+// renderFixtures renders the selected universe as one row per patched
+// fixture: color fixtures as swatches per cell, others as parameter values
+func (m Model) renderFixtures() string {
+	if m.universeManager.Patch() == nil {
+		return helpStyle.Render("No patch loaded. Start with -patch to see fixtures.")
+	}
+
+	fixtures := m.universeManager.FixtureValues(m.selectedUniverse)
+	if len(fixtures) == 0 {
+		return helpStyle.Render(fmt.Sprintf("No fixtures patched on universe %d.", m.selectedUniverse))
+	}
+
+	// Reserve space for: title(2) + tabs(3) + stats(2) + help(2) = 9 lines
+	visible := max(1, m.height-9)
+	start := min(m.scrollOffset/max(1, m.columnsPerRow), max(0, len(fixtures)-visible))
+
+	var rows []string
+	for _, fv := range fixtures[start:min(len(fixtures), start+visible)] {
+		rows = append(rows, m.renderFixtureRow(fv))
+	}
+	return strings.Join(rows, "\n")
+}
+
+// renderFixtureRow renders one fixture's name, address and values
+func (m Model) renderFixtureRow(fv universe.FixtureValue) string {
+	f := fv.Fixture
+	name := f.Name
+	if len(name) > fixtureNameWidth {
+		name = name[:fixtureNameWidth-1] + "…"
+	}
+	prefix := fmt.Sprintf("%-*s %3d-%-3d ", fixtureNameWidth, name, f.Address, f.LastChannel())
+
+	if !fv.Active {
+		return helpStyle.Render(prefix + "no data")
+	}
+
+	if len(fv.Colors) > 0 {
+		// One two-character swatch per cell, wrapped to the terminal width
+		perRow := max(1, (m.width-len(prefix)-2)/2)
+		var b strings.Builder
+		for i, c := range fv.Colors {
+			if i > 0 && i%perRow == 0 {
+				b.WriteString("\n" + strings.Repeat(" ", len(prefix)))
+			}
+			b.WriteString(lipgloss.NewStyle().Background(lipgloss.Color(c.Hex())).Render("  "))
+		}
+		if len(fv.Colors) == 1 {
+			b.WriteString(" " + fv.Colors[0].Hex())
+		}
+		return statsStyle.Render(prefix) + b.String()
+	}
+
+	parts := make([]string, len(fv.Values))
+	for i, v := range fv.Values {
+		parts[i] = fmt.Sprintf("%s:%s", f.Parameter(f.Address+i), m.valueFormat.formatCompact(v))
+	}
+	return statsStyle.Render(prefix + strings.Join(parts, " "))
+}
@@ -0,0 +1,32 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"sacn-monitor/internal/universe"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// renderFlags renders compact indicators for a universe's option flags:
+// preview data, a recent Stream_Terminated and the sync address
+func renderFlags(flags universe.StreamFlags) string {
+	var badges []string
+	if flags.Preview {
+		badges = append(badges, lipgloss.NewStyle().Foreground(magentaColor).Render("PREVIEW"))
+	}
+	if flags.Terminated {
+		badges = append(badges, lipgloss.NewStyle().Foreground(redColor).Render(
+			fmt.Sprintf("TERM %s ago", time.Since(flags.TerminatedAt).Round(time.Second))))
+	}
+	if flags.SyncAddress != 0 {
+		badges = append(badges, lipgloss.NewStyle().Foreground(cyanColor).Render(
+			fmt.Sprintf("SYNC %d", flags.SyncAddress)))
+	}
+	if len(badges) == 0 {
+		return ""
+	}
+	return " [" + strings.Join(badges, " ") + "]"
+}
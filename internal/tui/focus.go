@@ -0,0 +1,101 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// bigGlyphs are 5-line block characters for the focus view's value
+var bigGlyphs = map[rune][5]string{
+	'0': {"█████", "█   █", "█   █", "█   █", "█████"},
+	'1': {"  █  ", " ██  ", "  █  ", "  █  ", " ███ "},
+	'2': {"█████", "    █", "█████", "█    ", "█████"},
+	'3': {"█████", "    █", " ████", "    █", "█████"},
+	'4': {"█   █", "█   █", "█████", "    █", "    █"},
+	'5': {"█████", "█    ", "█████", "    █", "█████"},
+	'6': {"█████", "█    ", "█████", "█   █", "█████"},
+	'7': {"█████", "    █", "   █ ", "  █  ", "  █  "},
+	'8': {"█████", "█   █", "█████", "█   █", "█████"},
+	'9': {"█████", "█   █", "█████", "    █", "█████"},
+	'A': {" ███ ", "█   █", "█████", "█   █", "█   █"},
+	'B': {"████ ", "█   █", "████ ", "█   █", "████ "},
+	'C': {"█████", "█    ", "█    ", "█    ", "█████"},
+	'D': {"████ ", "█   █", "█   █", "█   █", "████ "},
+	'E': {"█████", "█    ", "████ ", "█    ", "█████"},
+	'F': {"█████", "█    ", "████ ", "█    ", "█    "},
+	'%': {"██  █", "██ █ ", "  █  ", " █ ██", "█  ██"},
+	' ': {"     ", "     ", "     ", "     ", "     "},
+}
+
+// bigText renders text in 5-line block characters, one space between them.
+// Characters without a glyph are left out.
+func bigText(s string) string {
+	var rows [5]strings.Builder
+	for _, r := range s {
+		glyph, ok := bigGlyphs[r]
+		if !ok {
+			continue
+		}
+		for i, line := range glyph {
+			rows[i].WriteString(line + " ")
+		}
+	}
+	lines := make([]string, len(rows))
+	for i := range rows {
+		lines[i] = rows[i].String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// meter renders a value as a horizontal bar of the given width
+func meter(v uint8, width int) string {
+	filled := int(v) * width / 255
+	return strings.Repeat("█", filled) + helpStyle.Render(strings.Repeat("░", width-filled))
+}
+
+// renderFocus renders the selected channel's value in large digits with a
+// meter, to read from across a room
+func (m Model) renderFocus() string {
+	u := m.universeManager.Get(m.selectedUniverse)
+	if u == nil {
+		return ""
+	}
+	ch := u.GetChannel(m.selectedChannel)
+	number := m.selectedChannel + 1
+
+	label := fmt.Sprintf("Channel %d on %s", number, m.universeManager.Describe(m.selectedUniverse))
+	if patched, ok := m.universeManager.ChannelLabel(m.selectedUniverse, number); ok {
+		label += " · " + patched.String()
+	}
+
+	var b strings.Builder
+	b.WriteString(statsStyle.Render(label) + "\n\n")
+
+	style := lipgloss.NewStyle().Bold(true).Foreground(cyanColor)
+	var value string
+	switch {
+	case u.IsStale(staleTimeout):
+		style = style.Foreground(redColor)
+		value = "   "
+	case !ch.Active:
+		style = style.Foreground(grayColor)
+		value = "   "
+	default:
+		value = m.valueFormat.format(ch.Value)
+		if m.valueFormat == formatPercent {
+			value += "%"
+		}
+	}
+	b.WriteString(style.Render(bigText(value)) + "\n\n")
+
+	width := max(10, min(m.width-4, 100))
+	b.WriteString(style.Render(meter(ch.Value, width)) + "\n")
+	if !ch.Active {
+		b.WriteString(helpStyle.Render("Not received yet.") + "\n")
+	}
+
+	b.WriteString("\n" + helpStyle.Render("←→: previous/next channel | ↑↓: ±10 | b: back to grid"))
+	return b.String()
+}
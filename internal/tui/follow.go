@@ -0,0 +1,35 @@
+package tui
+
+import "time"
+
+// followHold is how long follow mode stays on a universe before it may
+// switch again, so alternating changes don't make the tabs flicker
+const followHold = 2 * time.Second
+
+// followMostActive selects the shown universe whose channels changed most
+// recently, at most once per followHold
+func (m *Model) followMostActive(now time.Time) {
+	if now.Sub(m.followSwitched) < followHold {
+		return
+	}
+
+	best := m.selectedUniverse
+	var bestChange time.Time
+	if u := m.universeManager.Get(best); u != nil {
+		bestChange = u.LastChanged()
+	}
+	for _, id := range m.universeList {
+		u := m.universeManager.Get(id)
+		if u == nil {
+			continue
+		}
+		if changed := u.LastChanged(); changed.After(bestChange) {
+			best, bestChange = id, changed
+		}
+	}
+
+	if best != m.selectedUniverse {
+		m.selectedUniverse = best
+		m.followSwitched = now
+	}
+}
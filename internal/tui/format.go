@@ -0,0 +1,61 @@
+package tui
+
+import "fmt"
+
+// valueFormat is how channel values are shown
+type valueFormat int
+
+const (
+	formatDecimal valueFormat = iota // 0-255
+	formatPercent                    // 0-100
+	formatHex                        // 00-FF
+	valueFormatCount
+)
+
+func (f valueFormat) String() string {
+	switch f {
+	case formatPercent:
+		return "percent"
+	case formatHex:
+		return "hex"
+	default:
+		return "decimal"
+	}
+}
+
+// format renders a DMX value in the format, three characters wide
+func (f valueFormat) format(v uint8) string {
+	switch f {
+	case formatPercent:
+		return fmt.Sprintf("%3d", (int(v)*100+127)/255)
+	case formatHex:
+		return fmt.Sprintf(" %02X", v)
+	default:
+		return fmt.Sprintf("%3d", v)
+	}
+}
+
+// formatCompact renders a DMX value in the format without padding, with
+// a % or 0x marker so values read unambiguously in lists
+func (f valueFormat) formatCompact(v uint8) string {
+	switch f {
+	case formatPercent:
+		return fmt.Sprintf("%d%%", (int(v)*100+127)/255)
+	case formatHex:
+		return fmt.Sprintf("0x%02X", v)
+	default:
+		return fmt.Sprintf("%d", v)
+	}
+}
+
+// format16 renders a 16-bit value in the format, five characters wide
+func (f valueFormat) format16(v uint16) string {
+	switch f {
+	case formatPercent:
+		return fmt.Sprintf("%5.1f", float64(v)*100/65535)
+	case formatHex:
+		return fmt.Sprintf(" %04X", v)
+	default:
+		return fmt.Sprintf("%5d", v)
+	}
+}
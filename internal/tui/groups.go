@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// cycleGroupFilter steps the tab bar filter through all universes and each
+// configured group in turn
+func (m *Model) cycleGroupFilter() {
+	groups := m.universeManager.Groups()
+	if len(groups) == 0 {
+		m.groupFilter = ""
+		return
+	}
+
+	next := 0
+	for i, g := range groups {
+		if g.Name == m.groupFilter {
+			next = i + 1
+			break
+		}
+	}
+	if next >= len(groups) {
+		m.groupFilter = ""
+	} else {
+		m.groupFilter = groups[next].Name
+	}
+}
+
+// filterByGroup keeps the universes of the filtered group, or all of them
+// when no group is selected
+func (m Model) filterByGroup(ids []uint16) []uint16 {
+	if m.groupFilter == "" {
+		return ids
+	}
+	group, ok := m.universeManager.Group(m.groupFilter)
+	if !ok {
+		return ids
+	}
+
+	result := ids[:0]
+	for _, id := range ids {
+		if group.Contains(id) {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// sortByGroup orders universes by the group they belong to, in configured
+// group order, with ungrouped universes last and ID as tie-breaker
+func (m *Model) sortByGroup(ids []uint16) {
+	index := make(map[uint16]int, len(ids))
+	for _, id := range ids {
+		i := m.universeManager.GroupIndex(id)
+		if i < 0 {
+			i = len(ids) + 1 // after every group
+		}
+		index[id] = i
+	}
+
+	sort.SliceStable(ids, func(i, j int) bool {
+		a, b := ids[i], ids[j]
+		if index[a] != index[b] {
+			return index[a] < index[b]
+		}
+		return a < b
+	})
+}
+
+// renderGroupStats summarizes the filtered group, or returns "" when no
+// group is selected
+func (m Model) renderGroupStats() string {
+	if m.groupFilter == "" {
+		return ""
+	}
+	group, ok := m.universeManager.Group(m.groupFilter)
+	if !ok {
+		return ""
+	}
+
+	agg := m.statsTracker.Aggregate(group.Universes)
+	loss := agg.RecentLossPercentage()
+	lossStr := fmt.Sprintf("%.1f%%", loss)
+	if loss > 1 {
+		lossStr = lipgloss.NewStyle().Foreground(redColor).Render(lossStr)
+	} else if loss > 0 {
+		lossStr = lipgloss.NewStyle().Foreground(yellowColor).Render(lossStr)
+	}
+
+	return statsStyle.Render(fmt.Sprintf(
+		"Group %s: %d/%d universes | Rate: %.1f pps, %s | Loss: %s | Sources: %d",
+		group.Name,
+		agg.Universes,
+		len(group.Universes),
+		agg.PacketRate,
+		formatBitrate(agg.ByteRate),
+		lossStr,
+		agg.Sources,
+	))
+}
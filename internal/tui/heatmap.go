@@ -0,0 +1,66 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// heatmapColumns is how many channels a heatmap row holds; 32 × 16 rows
+// fits a universe on an 80×24 terminal
+const heatmapColumns = 32
+
+// heatColor maps a DMX value to a black → amber → white scale
+func heatColor(v uint8) lipgloss.Color {
+	r := min(255, int(v)*2)
+	g := min(255, max(0, int(v)*2-64))
+	b := max(0, int(v)*2-255)
+	return lipgloss.Color(fmt.Sprintf("#%02X%02X%02X", r, g, b))
+}
+
+// renderHeatmap renders the whole selected universe as one colored cell
+// per channel, brighter for higher values
+func (m Model) renderHeatmap() string {
+	u := m.universeManager.Get(m.selectedUniverse)
+	if u == nil {
+		return ""
+	}
+
+	channels := u.GetAllChannels()
+	isStale := u.IsStale(staleTimeout)
+	inactive := lipgloss.NewStyle().Foreground(grayColor)
+	stale := lipgloss.NewStyle().Foreground(redColor)
+
+	var b strings.Builder
+	for row := 0; row < 512; row += heatmapColumns {
+		b.WriteString(helpStyle.Render(fmt.Sprintf("%3d ", row+1)))
+		for i := row; i < row+heatmapColumns; i++ {
+			ch := channels[i]
+			cell := "  "
+			if i == m.selectedChannel {
+				cell = "[]"
+			}
+			switch {
+			case isStale:
+				b.WriteString(stale.Render(" ."))
+			case !ch.Active || u.IsMasked(i):
+				if i != m.selectedChannel {
+					cell = " ."
+				}
+				b.WriteString(inactive.Render(cell))
+			default:
+				style := lipgloss.NewStyle().Background(heatColor(ch.Value))
+				if ch.Value > 160 {
+					style = style.Foreground(lipgloss.Color("#000000"))
+				}
+				b.WriteString(style.Render(cell))
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	ch := channels[m.selectedChannel]
+	b.WriteString(helpStyle.Render(fmt.Sprintf("Channel %d: %s | ←→: select | enter: details | H: back to grid", m.selectedChannel+1, m.valueFormat.formatCompact(ch.Value))))
+	return b.String()
+}
@@ -0,0 +1,50 @@
+package tui
+
+import "fmt"
+
+// hideSelected hides the selected universe from the tab bar for this
+// session. It is still tracked, so unhiding shows it up to date.
+func (m *Model) hideSelected() {
+	if m.hidden == nil {
+		m.hidden = make(map[uint16]bool)
+	}
+	id := m.selectedUniverse
+	m.hidden[id] = true
+	m.updateUniverseList()
+	m.statusMsg = fmt.Sprintf("Hid %s (U: show hidden universes)", m.universeManager.Describe(id))
+}
+
+// unhideAll shows every hidden universe again
+func (m *Model) unhideAll() {
+	if len(m.hidden) == 0 {
+		m.statusMsg = "No hidden universes"
+		return
+	}
+	m.statusMsg = fmt.Sprintf("Showing %d hidden universes", len(m.hidden))
+	m.hidden = nil
+	m.updateUniverseList()
+}
+
+// removeSelected stops tracking the selected universe, along with its
+// statistics and history. It comes back if its source keeps sending.
+func (m *Model) removeSelected() {
+	id := m.selectedUniverse
+	name := m.universeManager.Describe(id)
+	m.universeManager.Remove(id)
+	m.updateUniverseList()
+	m.statusMsg = fmt.Sprintf("Removed %s", name)
+}
+
+// filterHidden drops hidden universes from ids
+func (m Model) filterHidden(ids []uint16) []uint16 {
+	if len(m.hidden) == 0 {
+		return ids
+	}
+	result := ids[:0]
+	for _, id := range ids {
+		if !m.hidden[id] {
+			result = append(result, id)
+		}
+	}
+	return result
+}
@@ -0,0 +1,76 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// startJump opens the jump-to-channel input
+func (m *Model) startJump() {
+	input := textinput.New()
+	input.Prompt = "Go to channel: "
+	input.Placeholder = "channel or universe:channel"
+	input.CharLimit = 12
+	input.Focus()
+
+	m.jumpInput = input
+	m.jumping = true
+}
+
+// updateJump handles keys while the jump input is open
+func (m Model) updateJump(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.jumping = false
+		m.commitJump(m.jumpInput.Value())
+		return m, nil
+	case tea.KeyEsc:
+		m.jumping = false
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.jumpInput, cmd = m.jumpInput.Update(msg)
+	return m, cmd
+}
+
+// commitJump selects the channel (and universe) of a jump target and
+// scrolls the grid to it
+func (m *Model) commitJump(target string) {
+	universeID, hasUniverse, channel, err := parseJumpTarget(target)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Jump failed: %v", err)
+		return
+	}
+	if hasUniverse {
+		if m.universeManager.Get(universeID) == nil {
+			m.statusMsg = fmt.Sprintf("No data for universe %d", universeID)
+			return
+		}
+		m.selectUniverse(universeID)
+	}
+	m.selectedChannel = channel - 1
+	m.moveChannel(0)
+}
+
+// parseJumpTarget parses "channel" or "universe:channel" with a 1-based
+// channel number
+func parseJumpTarget(s string) (universeID uint16, hasUniverse bool, channel int, err error) {
+	s = strings.TrimSpace(s)
+	if u, c, ok := strings.Cut(s, ":"); ok {
+		id, err := strconv.ParseUint(strings.TrimSpace(u), 10, 16)
+		if err != nil || id == 0 {
+			return 0, false, 0, fmt.Errorf("invalid universe %q", u)
+		}
+		universeID, hasUniverse, s = uint16(id), true, strings.TrimSpace(c)
+	}
+	channel, err = strconv.Atoi(s)
+	if err != nil || channel < 1 || channel > 512 {
+		return 0, false, 0, fmt.Errorf("invalid channel %q (1-512)", s)
+	}
+	return universeID, hasUniverse, channel, nil
+}
@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"strings"
+	"unicode"
+
+	"sacn-monitor/internal/patch"
+)
+
+// cardLabels reports whether channel cards carry a third line with their
+// patch label. All cards get it while a patch is loaded, so rows line up.
+func (m Model) cardLabels() bool {
+	return m.gridDensity() == densityCards && m.universeManager.Patch() != nil
+}
+
+// rowHeight returns how many lines a row of the grid takes
+func (m Model) rowHeight() int {
+	if m.cardLabels() {
+		return m.gridDensity().lineHeight() + 1
+	}
+	return m.gridDensity().lineHeight()
+}
+
+// cardLabel returns the abbreviated patch label of a 0-based channel, or
+// "" if it isn't patched
+func (m Model) cardLabel(id uint16, channel, width int) string {
+	label, ok := m.universeManager.ChannelLabel(id, channel+1)
+	if !ok {
+		return ""
+	}
+	return abbreviateLabel(label, width)
+}
+
+// abbreviateLabel shortens a label to width characters, keeping what
+// tells fixtures apart: "Spot 12 Dim" becomes "S12D" in four
+func abbreviateLabel(l patch.Label, width int) string {
+	if full := l.String(); len([]rune(full)) <= width {
+		return full
+	}
+
+	// The fixture's initial and number, or its first two letters
+	words := strings.Fields(l.Fixture)
+	fixture := l.Fixture
+	if len(words) > 0 {
+		initial := string([]rune(words[0])[:1])
+		last := words[len(words)-1]
+		if len(words) > 1 && strings.IndexFunc(last, func(r rune) bool { return !unicode.IsDigit(r) }) == -1 {
+			fixture = strings.ToUpper(initial) + last
+		} else {
+			fixture = string([]rune(words[0])[:min(2, len([]rune(words[0])))])
+		}
+	}
+
+	param := strings.ReplaceAll(l.Parameter, " ", "")
+	sep := ""
+	if len([]rune(fixture))+1+len([]rune(param)) <= width {
+		sep = " "
+	}
+	abbr := []rune(fixture + sep + param)
+	return string(abbr[:min(width, len(abbr))])
+}
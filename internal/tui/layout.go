@@ -0,0 +1,98 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// layout is how the grid screen is arranged
+type layout int
+
+const (
+	layoutGrid        layout = iota // Grid with the compact channel side panel
+	layoutGridSources               // Grid above the source comparison
+	layoutDashboard                 // Grid beside the full channel detail panel
+	layoutCount
+)
+
+func (l layout) String() string {
+	switch l {
+	case layoutGridSources:
+		return "grid+sources"
+	case layoutDashboard:
+		return "dashboard+detail"
+	default:
+		return "grid only"
+	}
+}
+
+// Share of the screen the second pane of a layout takes, in percent
+const (
+	defaultPaneShare = 40
+	minPaneShare     = 20
+	maxPaneShare     = 70
+	paneShareStep    = 10
+)
+
+// activeLayout returns the layout in effect. Other views and the split
+// view use the whole screen.
+func (m Model) activeLayout() layout {
+	if m.view != viewGrid || len(m.panes) > 0 {
+		return layoutGrid
+	}
+	return m.layout
+}
+
+// paneShare returns the second pane's share of the screen in percent
+func (m Model) paneShare() int {
+	if m.paneSharePct == 0 {
+		return defaultPaneShare
+	}
+	return m.paneSharePct
+}
+
+// resizePane grows or shrinks the second pane of the layout
+func (m *Model) resizePane(grow bool) {
+	if m.activeLayout() == layoutGrid {
+		m.statusMsg = "Nothing to resize in this layout (K: change layout)"
+		return
+	}
+	share := m.paneShare()
+	if grow {
+		share = min(maxPaneShare, share+paneShareStep)
+	} else {
+		share = max(minPaneShare, share-paneShareStep)
+	}
+	m.paneSharePct = share
+	m.moveChannel(0)
+	m.statusMsg = fmt.Sprintf("%s: %d%% of the screen", m.layout, share)
+}
+
+// sourcesPaneHeight returns the lines the sources pane takes below the grid
+func (m Model) sourcesPaneHeight() int {
+	return max(4, (m.height-10)*m.paneShare()/100)
+}
+
+// detailPaneWidth returns the width of the detail pane beside the grid
+func (m Model) detailPaneWidth() int {
+	return max(30, (m.width-2)*m.paneShare()/100)
+}
+
+// renderLayout renders the grid screen in the selected layout
+func (m Model) renderLayout() string {
+	grid := m.renderChannelGrid()
+	if m.ownerColors {
+		grid = lipgloss.JoinVertical(lipgloss.Left, grid, m.renderOwnerLegend())
+	}
+	switch m.activeLayout() {
+	case layoutGridSources:
+		sources := lipgloss.NewStyle().MaxHeight(m.sourcesPaneHeight()).Render(m.renderSources())
+		return lipgloss.JoinVertical(lipgloss.Left, grid, "", sources)
+	case layoutDashboard:
+		detail := lipgloss.NewStyle().Width(m.detailPaneWidth()).MaxWidth(m.detailPaneWidth()).Render(m.renderChannel())
+		return lipgloss.JoinHorizontal(lipgloss.Top, grid, " ", detail)
+	default:
+		return grid
+	}
+}
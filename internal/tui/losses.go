@@ -0,0 +1,97 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"sacn-monitor/internal/export"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// lossListSize is the number of recent loss events shown in the losses view
+const lossListSize = 15
+
+// renderLosses renders the most recent loss events, newest first
+func (m Model) renderLosses() string {
+	recent := m.statsTracker.GetLossEvents(lossListSize)
+	if len(recent) == 0 {
+		return helpStyle.Render("No packet loss recorded.")
+	}
+
+	lossStyle := lipgloss.NewStyle().Foreground(redColor)
+
+	var b strings.Builder
+	b.WriteString(statsStyle.Render("Packet loss (newest first)") + "\n")
+	for _, e := range recent {
+		missing := fmt.Sprintf("seq %d", e.FirstMissing)
+		if e.Lost > 1 {
+			missing = fmt.Sprintf("seq %d-%d", e.FirstMissing, e.LastMissing)
+		}
+		line := fmt.Sprintf("  %s  U%-5d %-20.20s lost %-3d %s (after %d, next %d)",
+			e.Time.Format("15:04:05.000"), e.Universe, e.Source,
+			e.Lost, missing, e.ReceivedAfter, e.ReceivedNext)
+		b.WriteString(lossStyle.Render(line) + "\n")
+	}
+	if !m.readOnly {
+		b.WriteString("\n" + helpStyle.Render("x: export the full loss log to CSV"))
+	}
+	return b.String()
+}
+
+// exportLosses writes the whole loss log to a timestamped CSV file
+func (m *Model) exportLosses() {
+	path := "losses-" + time.Now().Format("20060102-150405") + ".csv"
+	if err := export.WriteLossLogFile(path, m.statsTracker.GetLossEvents(0)); err != nil {
+		m.statusMsg = fmt.Sprintf("Export failed: %v", err)
+	} else {
+		m.statusMsg = fmt.Sprintf("Exported loss log to %s", path)
+	}
+}
+
+// lossMode is which loss figures the stats line shows
+type lossMode int
+
+const (
+	lossBoth       lossMode = iota // Last minute, with the session total beside it
+	lossRecent                     // Last minute only
+	lossCumulative                 // Since monitoring started or the last reset
+	lossModeCount
+)
+
+func (l lossMode) String() string {
+	switch l {
+	case lossRecent:
+		return "last minute"
+	case lossCumulative:
+		return "since start"
+	default:
+		return "last minute and since start"
+	}
+}
+
+// colorLoss renders a loss percentage, yellow for any loss and red above 1%
+func colorLoss(loss float64) string {
+	s := fmt.Sprintf("%.1f%%", loss)
+	if loss > 1 {
+		return lipgloss.NewStyle().Foreground(redColor).Render(s)
+	} else if loss > 0 {
+		return lipgloss.NewStyle().Foreground(yellowColor).Render(s)
+	}
+	return s
+}
+
+// renderLossFigures renders the selected universe's loss for the stats line
+func (m Model) renderLossFigures() string {
+	recent := m.statsTracker.GetRecentLossPercentage(m.selectedUniverse)
+	total := m.statsTracker.GetLossPercentage(m.selectedUniverse)
+	switch m.lossMode {
+	case lossRecent:
+		return colorLoss(recent) + " (1m)"
+	case lossCumulative:
+		return colorLoss(total) + " (total)"
+	default:
+		return colorLoss(recent) + " 1m, " + colorLoss(total) + " total"
+	}
+}
@@ -0,0 +1,79 @@
+package tui
+
+import (
+	"time"
+
+	"sacn-monitor/internal/universe"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Channel freshness thresholds for the staleness dot
+const (
+	freshChannelAge = time.Second
+	lostChannelAge  = 2500 * time.Millisecond // E1.31 network data loss timeout
+)
+
+// channelMovement records which way each channel of the selected universe
+// moved between the two most recent frames
+type channelMovement struct {
+	universe  uint16
+	valid     bool
+	previous  [512]uint8
+	direction [512]int8
+}
+
+// trackChannelMovement compares the selected universe's values against the
+// previous frame. Switching universe resets the comparison.
+func (m *Model) trackChannelMovement() {
+	u := m.universeManager.Get(m.selectedUniverse)
+	if u == nil {
+		m.movement.valid = false
+		return
+	}
+
+	channels := u.GetAllChannels()
+	sameUniverse := m.movement.valid && m.movement.universe == m.selectedUniverse
+	for i, ch := range channels {
+		switch {
+		case !sameUniverse || ch.Value == m.movement.previous[i]:
+			m.movement.direction[i] = 0
+		case ch.Value > m.movement.previous[i]:
+			m.movement.direction[i] = 1
+		default:
+			m.movement.direction[i] = -1
+		}
+		m.movement.previous[i] = ch.Value
+	}
+	m.movement.universe = m.selectedUniverse
+	m.movement.valid = true
+}
+
+// cardMiniStats returns the staleness dot and change arrow shown in a channel
+// card's spare column. Both are blank for inactive channels.
+func (m Model) cardMiniStats(index int, ch universe.Channel, now time.Time) (dot, arrow string) {
+	if !ch.Active {
+		return " ", " "
+	}
+
+	age := now.Sub(ch.LastUpdate)
+	switch {
+	case age < freshChannelAge:
+		dot = lipgloss.NewStyle().Foreground(greenColor).Render("•")
+	case age < lostChannelAge:
+		dot = lipgloss.NewStyle().Foreground(yellowColor).Render("•")
+	default:
+		dot = lipgloss.NewStyle().Foreground(redColor).Render("•")
+	}
+
+	arrow = " "
+	if m.movement.valid && m.movement.universe == m.selectedUniverse {
+		switch m.movement.direction[index] {
+		case 1:
+			arrow = lipgloss.NewStyle().Foreground(greenColor).Render("↑")
+		case -1:
+			arrow = lipgloss.NewStyle().Foreground(redColor).Render("↓")
+		}
+	}
+	return dot, arrow
+}
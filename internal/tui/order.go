@@ -0,0 +1,220 @@
+package tui
+
+import (
+	"sort"
+)
+
+// tabOrder is how universe tabs are ordered after pinned universes
+type tabOrder int
+
+const (
+	orderByID tabOrder = iota
+	orderByHealth
+	orderByGroup
+	orderByActivity
+	orderByLoss
+	orderBySources
+	orderManual
+	tabOrderCount
+)
+
+func (o tabOrder) String() string {
+	switch o {
+	case orderByHealth:
+		return "health"
+	case orderByGroup:
+		return "group"
+	case orderByActivity:
+		return "activity"
+	case orderByLoss:
+		return "loss"
+	case orderBySources:
+		return "sources"
+	case orderManual:
+		return "manual"
+	default:
+		return "id"
+	}
+}
+
+// orderUniverses arranges universe IDs (given in ascending order) for the
+// tab bar: pinned universes first in the order they were pinned, then the
+// rest by the selected ordering
+func (m *Model) orderUniverses(ids []uint16) []uint16 {
+	present := make(map[uint16]bool, len(ids))
+	for _, id := range ids {
+		present[id] = true
+	}
+
+	result := make([]uint16, 0, len(ids))
+	isPinned := make(map[uint16]bool, len(m.pinned))
+	for _, id := range m.pinned {
+		isPinned[id] = true
+		if present[id] {
+			result = append(result, id)
+		}
+	}
+
+	rest := make([]uint16, 0, len(ids))
+	for _, id := range ids {
+		if !isPinned[id] {
+			rest = append(rest, id)
+		}
+	}
+
+	switch m.tabOrder {
+	case orderByHealth:
+		m.sortByHealth(rest)
+	case orderByGroup:
+		m.sortByGroup(rest)
+	case orderByActivity, orderByLoss, orderBySources:
+		m.sortByMetric(rest)
+	case orderManual:
+		rest = m.applyManualOrder(rest)
+	}
+
+	return append(result, rest...)
+}
+
+// sortByHealth puts the least healthy universes first: stale universes,
+// then by health score, with ID as tie-breaker
+func (m *Model) sortByHealth(ids []uint16) {
+	stale := make(map[uint16]bool, len(ids))
+	score := make(map[uint16]int, len(ids))
+	for _, id := range ids {
+		u := m.universeManager.Get(id)
+		stale[id] = u == nil || u.IsStale(staleTimeout)
+		score[id] = m.statsTracker.GetHealth(id).Score
+	}
+
+	sort.SliceStable(ids, func(i, j int) bool {
+		a, b := ids[i], ids[j]
+		if stale[a] != stale[b] {
+			return stale[a]
+		}
+		if score[a] != score[b] {
+			return score[a] < score[b]
+		}
+		return a < b
+	})
+}
+
+// tabMetric returns the figure a metric ordering sorts by, highest first:
+// channel value changes over the last minute, recent loss percentage or
+// source count
+func (m *Model) tabMetric(id uint16) float64 {
+	switch m.tabOrder {
+	case orderByActivity:
+		u := m.universeManager.Get(id)
+		if u == nil {
+			return 0
+		}
+		total := 0
+		for _, n := range u.ChangeCounts() {
+			total += n
+		}
+		return float64(total)
+	case orderByLoss:
+		return m.statsTracker.GetRecentLossPercentage(id)
+	case orderBySources:
+		return float64(len(m.statsTracker.GetSources(id)))
+	}
+	return 0
+}
+
+// sortByMetric puts the universes with the highest tabMetric first, with
+// ID as tie-breaker
+func (m *Model) sortByMetric(ids []uint16) {
+	metric := make(map[uint16]float64, len(ids))
+	for _, id := range ids {
+		metric[id] = m.tabMetric(id)
+	}
+
+	sort.SliceStable(ids, func(i, j int) bool {
+		a, b := ids[i], ids[j]
+		if metric[a] != metric[b] {
+			return metric[a] > metric[b]
+		}
+		return a < b
+	})
+}
+
+// applyManualOrder orders ids by the manual order, appending universes not
+// yet placed (in ID order) to both the result and the manual order
+func (m *Model) applyManualOrder(ids []uint16) []uint16 {
+	present := make(map[uint16]bool, len(ids))
+	for _, id := range ids {
+		present[id] = true
+	}
+
+	placed := make(map[uint16]bool, len(m.manualOrder))
+	result := make([]uint16, 0, len(ids))
+	for _, id := range m.manualOrder {
+		placed[id] = true
+		if present[id] {
+			result = append(result, id)
+		}
+	}
+	for _, id := range ids {
+		if !placed[id] {
+			m.manualOrder = append(m.manualOrder, id)
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// togglePin pins or unpins a universe
+func (m *Model) togglePin(id uint16) {
+	for i, pinned := range m.pinned {
+		if pinned == id {
+			m.pinned = append(m.pinned[:i], m.pinned[i+1:]...)
+			return
+		}
+	}
+	m.pinned = append(m.pinned, id)
+}
+
+// isPinned reports whether a universe is pinned to the front of the tab bar
+func (m Model) isPinned(id uint16) bool {
+	for _, pinned := range m.pinned {
+		if pinned == id {
+			return true
+		}
+	}
+	return false
+}
+
+// cycleTabOrder switches to the next ordering. Entering manual ordering
+// starts from the order currently shown.
+func (m *Model) cycleTabOrder() {
+	m.tabOrder = (m.tabOrder + 1) % tabOrderCount
+	if m.tabOrder == orderManual {
+		m.manualOrder = append([]uint16(nil), m.universeList...)
+	}
+}
+
+// moveSelected moves the selected universe by delta positions in the
+// manual order (or in the pin order when it is pinned). Any other
+// ordering switches to manual first.
+func (m *Model) moveSelected(delta int) {
+	list := &m.manualOrder
+	if m.isPinned(m.selectedUniverse) {
+		list = &m.pinned
+	} else if m.tabOrder != orderManual {
+		m.tabOrder = orderManual
+		m.manualOrder = append([]uint16(nil), m.universeList...)
+	}
+
+	for i, id := range *list {
+		if id != m.selectedUniverse {
+			continue
+		}
+		j := i + delta
+		if j < 0 || j >= len(*list) {
+			return
+		}
+		(*list)[i], (*list)[j] = (*list)[j], (*list)[i]
+		return
+	}
+}
@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ownerPalette colors channels by owning source, in source order
+var ownerPalette = []lipgloss.Color{
+	cyanColor,
+	magentaColor,
+	yellowColor,
+	greenColor,
+	lipgloss.Color("#FF9933"),
+	lipgloss.Color("#6699FF"),
+	redColor,
+	whiteColor,
+}
+
+// sourceOwner is a source on a universe and its color in the merge view
+type sourceOwner struct {
+	cid   [16]byte
+	name  string
+	color lipgloss.Color
+}
+
+// sourceOwners returns the sources of a universe in a stable order, each
+// with its color. Sources beyond the palette share its last color.
+func (m Model) sourceOwners(id uint16) []sourceOwner {
+	sources := m.statsTracker.GetSources(id)
+	sort.Slice(sources, func(i, j int) bool {
+		return bytes.Compare(sources[i].CID[:], sources[j].CID[:]) < 0
+	})
+	owners := make([]sourceOwner, len(sources))
+	for i, s := range sources {
+		owners[i] = sourceOwner{cid: s.CID, name: s.Name, color: ownerPalette[min(i, len(ownerPalette)-1)]}
+	}
+	return owners
+}
+
+// ownerStyle returns a card style colored by the source owning a channel
+func ownerStyle(owners []sourceOwner, cid [16]byte) lipgloss.Style {
+	for _, o := range owners {
+		if o.cid == cid {
+			return lipgloss.NewStyle().
+				Border(lipgloss.NormalBorder()).
+				BorderForeground(o.color).
+				Foreground(o.color).
+				Width(4)
+		}
+	}
+	return inactiveCardStyle
+}
+
+// renderOwnerLegend renders each source of the selected universe in its
+// color with how many channels it currently owns
+func (m Model) renderOwnerLegend() string {
+	u := m.universeManager.Get(m.selectedUniverse)
+	if u == nil {
+		return ""
+	}
+	owners := m.sourceOwners(m.selectedUniverse)
+	counts := make(map[[16]byte]int)
+	for _, ch := range u.GetAllChannels() {
+		if ch.Active {
+			counts[ch.SourceCID]++
+		}
+	}
+
+	parts := make([]string, 0, len(owners))
+	for _, o := range owners {
+		parts = append(parts, lipgloss.NewStyle().Foreground(o.color).Render("■ "+o.name)+
+			helpStyle.Render(fmt.Sprintf(" (%d ch)", counts[o.cid])))
+	}
+	return "Owners: " + strings.Join(parts, "  ") + helpStyle.Render("  | M: back to values")
+}
@@ -0,0 +1,153 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// pickerRows is how many matches the universe picker lists
+const pickerRows = 8
+
+// startPicker opens the universe picker
+func (m *Model) startPicker() {
+	input := textinput.New()
+	input.Prompt = "Universe: "
+	input.Placeholder = "number or name"
+	input.CharLimit = 40
+	input.Focus()
+
+	m.pickerInput = input
+	m.pickerCursor = 0
+	m.picking = true
+}
+
+// updatePicker handles keys while the universe picker is open
+func (m Model) updatePicker(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.picking = false
+		if matches := m.pickerMatches(); len(matches) > 0 {
+			m.selectUniverse(matches[min(m.pickerCursor, len(matches)-1)])
+		}
+		return m, nil
+	case tea.KeyEsc:
+		m.picking = false
+		return m, nil
+	case tea.KeyDown:
+		m.pickerCursor = min(m.pickerCursor+1, pickerRows-1)
+		return m, nil
+	case tea.KeyUp:
+		m.pickerCursor = max(m.pickerCursor-1, 0)
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.pickerInput, cmd = m.pickerInput.Update(msg)
+	m.pickerCursor = 0
+	return m, cmd
+}
+
+// selectUniverse selects a universe by hand, unhiding it, dropping a group
+// filter that hides it and stopping follow mode
+func (m *Model) selectUniverse(id uint16) {
+	m.following = false
+	delete(m.hidden, id)
+	if m.groupFilter != "" {
+		if group, ok := m.universeManager.Group(m.groupFilter); !ok || !group.Contains(id) {
+			m.groupFilter = ""
+			m.statusMsg = "Showing all universes"
+		}
+	}
+	m.selectedUniverse = id
+	m.updateUniverseList()
+}
+
+// pickerMatches returns the universes matching the picker input, best
+// first: an exact universe number, then the closest fuzzy matches of the
+// tab label
+func (m Model) pickerMatches() []uint16 {
+	query := strings.ToLower(strings.TrimSpace(m.pickerInput.Value()))
+	exact, _ := strconv.ParseUint(query, 10, 16)
+
+	type match struct {
+		id    uint16
+		score int
+	}
+	var matches []match
+	for _, u := range m.universeManager.GetAll() {
+		if query != "" && uint64(u.ID) == exact {
+			matches = append(matches, match{u.ID, -1})
+			continue
+		}
+		if score, ok := fuzzyScore(query, strings.ToLower(m.tabLabel(u.ID))); ok {
+			matches = append(matches, match{u.ID, score})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score < matches[j].score
+		}
+		return matches[i].id < matches[j].id
+	})
+
+	ids := make([]uint16, 0, min(len(matches), pickerRows))
+	for _, mt := range matches[:min(len(matches), pickerRows)] {
+		ids = append(ids, mt.id)
+	}
+	return ids
+}
+
+// fuzzyScore reports whether the query's characters appear in order in
+// text, scoring lower for tighter matches that start earlier
+func fuzzyScore(query, text string) (int, bool) {
+	score, last := 0, -1
+	for _, r := range query {
+		i := strings.IndexRune(text[last+1:], r)
+		if i < 0 {
+			return 0, false
+		}
+		if last < 0 {
+			score += i
+		} else {
+			score += i * 2
+		}
+		last += 1 + i
+	}
+	return score, true
+}
+
+// renderPicker renders the picker input and its matches
+func (m Model) renderPicker() string {
+	var b strings.Builder
+	b.WriteString(m.pickerInput.View() + "\n")
+
+	matches := m.pickerMatches()
+	if len(matches) == 0 {
+		b.WriteString(helpStyle.Render("  No matching universe"))
+		return b.String()
+	}
+	cursor := min(m.pickerCursor, len(matches)-1)
+	selected := lipgloss.NewStyle().Foreground(cyanColor).Bold(true)
+	for i, id := range matches {
+		line := m.tabLabel(id)
+		if u := m.universeManager.Get(id); u != nil && u.IsStale(staleTimeout) {
+			line += " (stale)"
+		}
+		if m.hidden[id] {
+			line += " (hidden)"
+		}
+		if i == cursor {
+			b.WriteString(selected.Render("  ▶ "+line) + "\n")
+		} else {
+			b.WriteString(fmt.Sprintf("    %s\n", line))
+		}
+	}
+	b.WriteString(helpStyle.Render("↑↓: choose | enter: select | esc: cancel"))
+	return b.String()
+}
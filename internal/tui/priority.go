@@ -0,0 +1,55 @@
+package tui
+
+import (
+	"fmt"
+
+	"sacn-monitor/internal/universe"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// gridPriorities returns the per-address priorities to show in place of a
+// universe's levels, when toggled on and the universe has them
+func (m Model) gridPriorities(id uint16) (universe.PerAddressPriorities, bool) {
+	if !m.showPriority {
+		return universe.PerAddressPriorities{}, false
+	}
+	u := m.universeManager.Get(id)
+	if u == nil {
+		return universe.PerAddressPriorities{}, false
+	}
+	return u.PerAddressPriorities()
+}
+
+// priorityCell returns how a 0-based channel shows in the priority grid.
+// Priority 0 means the source doesn't drive the channel.
+func priorityCell(p universe.PerAddressPriorities, channel int) (lipgloss.Style, string) {
+	if channel >= p.Slots || p.Values[channel] == 0 {
+		return inactiveCardStyle, " - "
+	}
+	return priorityCardStyle, fmt.Sprintf("%3d", p.Values[channel])
+}
+
+// togglePriority switches the grid between levels and per-address priorities
+func (m *Model) togglePriority() {
+	m.showPriority = !m.showPriority
+	switch {
+	case !m.showPriority:
+		m.statusMsg = "Showing levels"
+	case !m.hasPriorities():
+		m.statusMsg = fmt.Sprintf("Universe %d has no per-address priorities (0xDD); showing levels until it does", m.selectedUniverse)
+	default:
+		m.statusMsg = "Showing per-address priorities"
+	}
+}
+
+// hasPriorities reports whether the selected universe has current
+// per-address priorities
+func (m Model) hasPriorities() bool {
+	u := m.universeManager.Get(m.selectedUniverse)
+	if u == nil {
+		return false
+	}
+	_, ok := u.PerAddressPriorities()
+	return ok
+}
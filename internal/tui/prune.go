@@ -0,0 +1,33 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+)
+
+// defaultPruneTimeout is how long a universe must be silent before the
+// prune key removes it, unless automatic pruning sets another timeout
+const defaultPruneTimeout = 10 * time.Second
+
+// WithPruneTimeout returns a copy of the model whose prune key removes
+// universes silent for longer than timeout; 0 keeps the default
+func (m Model) WithPruneTimeout(timeout time.Duration) Model {
+	m.pruneTimeout = timeout
+	return m
+}
+
+// pruneStale removes universes that stopped sending, along with their
+// statistics and history
+func (m *Model) pruneStale() {
+	timeout := m.pruneTimeout
+	if timeout <= 0 {
+		timeout = defaultPruneTimeout
+	}
+	pruned := m.universeManager.PruneStale(timeout)
+	m.updateUniverseList()
+	if pruned == 0 {
+		m.statusMsg = fmt.Sprintf("No universes silent for over %s", timeout)
+		return
+	}
+	m.statusMsg = fmt.Sprintf("Pruned %d universes silent for over %s", pruned, timeout)
+}
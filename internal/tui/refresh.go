@@ -0,0 +1,58 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+)
+
+// defaultRefresh is how often the UI redraws unless configured otherwise
+const defaultRefresh = 100 * time.Millisecond
+
+// refreshSteps are the intervals the +/- keys step through
+var refreshSteps = []time.Duration{
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2 * time.Second,
+}
+
+// WithRefresh returns a copy of the model redrawing every interval;
+// 0 keeps the default
+func (m Model) WithRefresh(interval time.Duration) Model {
+	m.refresh = interval
+	return m
+}
+
+// refreshInterval returns the redraw interval
+func (m Model) refreshInterval() time.Duration {
+	if m.refresh <= 0 {
+		return defaultRefresh
+	}
+	return m.refresh
+}
+
+// stepRefresh moves the redraw interval to the next shorter (faster) or
+// longer (slower) step
+func (m *Model) stepRefresh(slower bool) {
+	current := m.refreshInterval()
+	next := current // Already beyond the last step
+	if slower {
+		for _, step := range refreshSteps {
+			if step > current {
+				next = step
+				break
+			}
+		}
+	} else {
+		for _, step := range refreshSteps {
+			if step < current {
+				next = step
+			}
+		}
+	}
+	m.refresh = next
+	m.statusMsg = fmt.Sprintf("Refresh every %s (%.0f Hz)", m.refresh, float64(time.Second)/float64(m.refresh))
+}
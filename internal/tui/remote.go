@@ -0,0 +1,22 @@
+package tui
+
+import "fmt"
+
+// SelectUniverseMsg selects a universe from outside the UI, e.g. by OSC
+// remote control
+type SelectUniverseMsg uint16
+
+// NoticeMsg shows a message in the status bar, e.g. the outcome of a
+// remote command
+type NoticeMsg string
+
+// selectRemoteUniverse selects a universe asked for remotely, if it has
+// been seen
+func (m *Model) selectRemoteUniverse(id uint16) {
+	if m.universeManager.Get(id) == nil {
+		m.statusMsg = fmt.Sprintf("Remote: no data for universe %d", id)
+		return
+	}
+	m.selectUniverse(id)
+	m.statusMsg = "Remote: selected " + m.universeManager.Describe(id)
+}
@@ -0,0 +1,75 @@
+package tui
+
+import (
+	"fmt"
+
+	"sacn-monitor/internal/config"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// WithConfig returns a copy of the model that persists changes such as
+// universe names to cfg
+func (m Model) WithConfig(cfg *config.Config) Model {
+	m.config = cfg
+	if cfg != nil {
+		m.watch = cfg.WatchList()
+	}
+	return m
+}
+
+// startRename opens the name input for the selected universe
+func (m *Model) startRename() {
+	input := textinput.New()
+	input.Prompt = fmt.Sprintf("Name for universe %d: ", m.selectedUniverse)
+	input.Placeholder = "empty to clear"
+	input.CharLimit = 40
+	input.SetValue(m.universeManager.Name(m.selectedUniverse))
+	input.Focus()
+
+	m.renameInput = input
+	m.renaming = true
+}
+
+// updateRename handles keys while the name input is open
+func (m Model) updateRename(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.renaming = false
+		m.commitRename(m.renameInput.Value())
+		return m, nil
+	case tea.KeyEsc:
+		m.renaming = false
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.renameInput, cmd = m.renameInput.Update(msg)
+	return m, cmd
+}
+
+// commitRename applies a new name to the selected universe and persists it
+func (m *Model) commitRename(name string) {
+	m.universeManager.SetName(m.selectedUniverse, name)
+
+	if m.config == nil || m.config.Path() == "" {
+		m.statusMsg = "Name set for this session (no config file)"
+		return
+	}
+
+	m.config.SetUniverseName(m.selectedUniverse, name)
+	if err := m.config.Save(); err != nil {
+		m.statusMsg = fmt.Sprintf("Name set, but saving config failed: %v", err)
+		return
+	}
+	m.statusMsg = fmt.Sprintf("Saved name to %s", m.config.Path())
+}
+
+// tabLabel returns the tab text for a universe, including its name
+func (m Model) tabLabel(id uint16) string {
+	if name := m.universeManager.Name(id); name != "" {
+		return fmt.Sprintf("%d: %s", id, name)
+	}
+	return fmt.Sprintf("Universe %d", id)
+}
@@ -0,0 +1,75 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"sacn-monitor/internal/export"
+	"sacn-monitor/internal/history"
+)
+
+// Rollups shown in the rollup view, newest first
+const (
+	hourlyRollupRows = 24
+	dailyRollupRows  = 7
+)
+
+// renderRollups renders the selected universe's recent hourly and daily
+// rollups, newest first, the first of each still in progress
+func (m Model) renderRollups() string {
+	if m.history == nil {
+		return helpStyle.Render("No history recorded.")
+	}
+	hourly := m.history.Rollups(m.selectedUniverse, history.Hourly)
+	daily := m.history.Rollups(m.selectedUniverse, history.Daily)
+	if len(hourly) == 0 {
+		return helpStyle.Render("No rollups yet for this universe.")
+	}
+
+	var b strings.Builder
+	b.WriteString(renderRollupTable("Hourly", hourly, hourlyRollupRows, "2006-01-02 15:04"))
+	b.WriteString("\n")
+	b.WriteString(renderRollupTable("Daily", daily, dailyRollupRows, "2006-01-02"))
+	if !m.readOnly {
+		b.WriteString("\n" + helpStyle.Render("x: export every universe's rollups to CSV"))
+	}
+	return b.String()
+}
+
+// renderRollupTable renders up to rows rollups of one period, newest first
+func renderRollupTable(title string, rollups []history.Rollup, rows int, layout string) string {
+	var b strings.Builder
+	b.WriteString(statsStyle.Render(title+" (newest first)") + "\n")
+	b.WriteString(helpStyle.Render(fmt.Sprintf("  %-16s %10s %8s %7s %9s %9s", "Start", "Packets", "Lost", "Loss", "Peak pps", "Available")) + "\n")
+	for i := len(rollups) - 1; i >= 0 && i >= len(rollups)-rows; i-- {
+		r := rollups[i]
+		line := fmt.Sprintf("  %-16s %10d %8d %6.2f%% %9.1f %8.1f%%",
+			r.Start.Format(layout), r.Packets, r.Lost, r.LossPercentage(), r.PeakRate, r.Availability())
+		if i == len(rollups)-1 {
+			line += helpStyle.Render("  in progress")
+		}
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
+
+// exportRollups writes every universe's hourly and daily rollups, the
+// ones in progress included, to a timestamped CSV file
+func (m *Model) exportRollups() {
+	if m.history == nil {
+		return
+	}
+	var rollups []history.Rollup
+	for _, id := range m.history.Universes() {
+		rollups = append(rollups, m.history.Rollups(id, history.Hourly)...)
+		rollups = append(rollups, m.history.Rollups(id, history.Daily)...)
+	}
+
+	path := "rollups-" + time.Now().Format("20060102-150405") + ".csv"
+	if err := export.WriteRollupsFile(path, rollups); err != nil {
+		m.statusMsg = fmt.Sprintf("Export failed: %v", err)
+	} else {
+		m.statusMsg = fmt.Sprintf("Exported %d rollups to %s", len(rollups), path)
+	}
+}
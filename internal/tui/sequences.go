@@ -0,0 +1,110 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"sacn-monitor/internal/export"
+	"sacn-monitor/internal/stats"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// sequenceLabelWidth is the width of the source name before each strip
+const sequenceLabelWidth = 20
+
+// renderSequences renders a strip chart of each source's sequence timeline
+// on the selected universe: one column per time slice, red where packets
+// went missing, yellow for late or duplicate packets
+func (m Model) renderSequences() string {
+	var timelines []export.SourceTimeline
+	for _, src := range m.statsTracker.GetSources(m.selectedUniverse) {
+		samples := m.statsTracker.GetSequenceTimeline(m.selectedUniverse, src.CID)
+		if len(samples) > 0 {
+			timelines = append(timelines, export.SourceTimeline{Universe: m.selectedUniverse, CID: src.CID, Source: src.Name, Samples: samples})
+		}
+	}
+	if len(timelines) == 0 {
+		return helpStyle.Render("No sequence timeline recorded. Start with -sequence-timeline N to keep each source's last N packets.")
+	}
+
+	// All strips share one time axis, from the oldest sample to now
+	now := time.Now()
+	oldest := now
+	for _, tl := range timelines {
+		if tl.Samples[0].Time.Before(oldest) {
+			oldest = tl.Samples[0].Time
+		}
+	}
+	columns := max(10, m.width-sequenceLabelWidth-4)
+	span := now.Sub(oldest)
+	if span < time.Second {
+		span = time.Second
+	}
+
+	var b strings.Builder
+	b.WriteString(statsStyle.Render(fmt.Sprintf("Sequence timeline (last %s)", span.Round(time.Second))) + "\n")
+	for _, tl := range timelines {
+		b.WriteString(fmt.Sprintf("  %-*.*s ", sequenceLabelWidth, sequenceLabelWidth, tl.Source))
+		b.WriteString(renderSequenceStrip(tl.Samples, oldest, span, columns) + "\n")
+	}
+	b.WriteString("\n" + helpStyle.Render("▁ received  ") +
+		lipgloss.NewStyle().Foreground(yellowColor).Render("▄ late/duplicate  ") +
+		lipgloss.NewStyle().Foreground(redColor).Render("█ gap"))
+	if !m.readOnly {
+		b.WriteString(helpStyle.Render(" | x: export the timelines to CSV"))
+	}
+	return b.String()
+}
+
+// renderSequenceStrip draws samples over columns time slices from start
+func renderSequenceStrip(samples []stats.SequenceSample, start time.Time, span time.Duration, columns int) string {
+	// Worst thing seen per column: 0 nothing, 1 received, 2 late/dup, 3 gap
+	marks := make([]int, columns)
+	for _, s := range samples {
+		col := min(int(float64(s.Time.Sub(start))/float64(span)*float64(columns)), columns-1)
+		mark := 1
+		if s.Lost > 0 {
+			mark = 3
+		} else if s.Arrival != stats.InOrder {
+			mark = 2
+		}
+		marks[col] = max(marks[col], mark)
+	}
+
+	styles := []lipgloss.Style{
+		helpStyle,
+		lipgloss.NewStyle().Foreground(greenColor),
+		lipgloss.NewStyle().Foreground(yellowColor),
+		lipgloss.NewStyle().Foreground(redColor),
+	}
+	glyphs := []string{" ", "▁", "▄", "█"}
+
+	var b strings.Builder
+	for _, mark := range marks {
+		b.WriteString(styles[mark].Render(glyphs[mark]))
+	}
+	return b.String()
+}
+
+// exportSequences writes the selected universe's sequence timelines to a
+// timestamped CSV file
+func (m *Model) exportSequences() {
+	var timelines []export.SourceTimeline
+	for _, src := range m.statsTracker.GetSources(m.selectedUniverse) {
+		timelines = append(timelines, export.SourceTimeline{
+			Universe: m.selectedUniverse,
+			CID:      src.CID,
+			Source:   src.Name,
+			Samples:  m.statsTracker.GetSequenceTimeline(m.selectedUniverse, src.CID),
+		})
+	}
+
+	path := fmt.Sprintf("sequences-%d-%s.csv", m.selectedUniverse, time.Now().Format("20060102-150405"))
+	if err := export.WriteSequenceTimelinesFile(path, timelines); err != nil {
+		m.statusMsg = fmt.Sprintf("Export failed: %v", err)
+	} else {
+		m.statusMsg = fmt.Sprintf("Exported sequence timelines to %s", path)
+	}
+}
@@ -0,0 +1,37 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"sacn-monitor/internal/export"
+	"sacn-monitor/internal/universe"
+)
+
+// saveLook saves the current look of every universe, with sources and
+// priorities, for use with -baseline or -load-snapshot
+func (m *Model) saveLook() {
+	name := "look-" + time.Now().Format("20060102-150405")
+	path := name + ".json"
+	if err := universe.WriteSnapshotFile(path, export.CaptureSnapshots(m.universeManager, m.statsTracker, name)); err != nil {
+		m.statusMsg = fmt.Sprintf("Save failed: %v", err)
+	} else {
+		m.statusMsg = fmt.Sprintf("Saved look to %s", path)
+	}
+}
+
+// saveUniverseSnapshot saves a snapshot of the selected universe to a file
+// to share or load back with -load-snapshot
+func (m *Model) saveUniverseSnapshot() {
+	snapshots := export.CaptureSnapshots(m.universeManager, m.statsTracker, time.Now().Format("15:04:05"), m.selectedUniverse)
+	if len(snapshots) == 0 {
+		m.statusMsg = "No universe selected"
+		return
+	}
+	path := fmt.Sprintf("universe-%d-%s.json", m.selectedUniverse, time.Now().Format("20060102-150405"))
+	if err := universe.WriteSnapshotFile(path, snapshots); err != nil {
+		m.statusMsg = fmt.Sprintf("Save failed: %v", err)
+	} else {
+		m.statusMsg = fmt.Sprintf("Saved %s to %s", m.universeManager.Describe(m.selectedUniverse), path)
+	}
+}
@@ -0,0 +1,47 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// renderSources renders the sources of the selected universe side by
+// side, highlighting the active ones
+func (m Model) renderSources() string {
+	rows := m.statsTracker.CompareSources(m.selectedUniverse)
+	if len(rows) == 0 {
+		return helpStyle.Render("No sources on this universe.")
+	}
+
+	activeStyle := lipgloss.NewStyle().Foreground(greenColor).Bold(true)
+
+	var b strings.Builder
+	b.WriteString(statsStyle.Render(fmt.Sprintf("Sources on %s", m.universeManager.Describe(m.selectedUniverse))) + "\n")
+	b.WriteString(helpStyle.Render(fmt.Sprintf("    %-20s %-15s %5s %9s %6s %5s %12s", "Name", "Address", "Prio", "Rate", "Loss", "Slots", "Last change")) + "\n")
+	for _, r := range rows {
+		prio := fmt.Sprintf("%d", r.Priority)
+		if r.PerAddressPriority {
+			prio += "*"
+		}
+		change := "-"
+		if !r.LastChange.IsZero() {
+			change = time.Since(r.LastChange).Round(time.Second).String() + " ago"
+		}
+		line := fmt.Sprintf("%-20.20s %-15.15s %5s %5.1f pps %5.1f%% %5d %12s",
+			r.Name, r.Address, prio, r.PacketRate, r.LossPercentage, r.Slots, change)
+
+		switch {
+		case r.Active:
+			b.WriteString(activeStyle.Render("  ▶ "+line) + "\n")
+		case !r.Online:
+			b.WriteString(helpStyle.Render("    "+line+" (offline)") + "\n")
+		default:
+			b.WriteString("    " + line + "\n")
+		}
+	}
+	b.WriteString("\n" + helpStyle.Render("▶ active: highest priority online | * per-address priority"))
+	return b.String()
+}
@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxPanes is how many universes the split view shows side by side
+const maxPanes = 4
+
+// splitPane is a universe shown in the split view with its own scroll
+// position. The focused pane's state lives in selectedUniverse and
+// scrollOffset while it has focus.
+type splitPane struct {
+	universe uint16
+	scroll   int
+}
+
+// gridColumns returns how many channels fit in a row of one grid
+func (m Model) gridColumns() int {
+	d := m.gridDensity()
+	width := m.width - 2
+	if len(m.panes) > 0 {
+		// Panes are separated by a space
+		width = (width - (len(m.panes) - 1)) / len(m.panes)
+	} else if m.activeLayout() == layoutDashboard {
+		width -= m.detailPaneWidth() + 1
+	} else if m.showSidePanel() {
+		width -= sidePanelWidth + 1
+	} else if d == densityCards {
+		return max(1, m.columnsPerRow)
+	}
+	if d == densityLine {
+		width -= cellWidth // Row labels
+	}
+	return max(1, width/d.cellWidthFor())
+}
+
+// splitAdd adds a pane showing the universe after the selected one, and
+// focuses it
+func (m *Model) splitAdd() {
+	if len(m.panes) >= maxPanes {
+		m.statusMsg = "Split view is full"
+		return
+	}
+	if len(m.panes) == 0 {
+		m.panes = []splitPane{{}}
+		m.focusedPane = 0
+	}
+	m.panes[m.focusedPane] = splitPane{m.selectedUniverse, m.scrollOffset}
+
+	next := m.selectedUniverse
+	for i, id := range m.universeList {
+		if id == m.selectedUniverse {
+			next = m.universeList[(i+1)%len(m.universeList)]
+			break
+		}
+	}
+	m.panes = append(m.panes, splitPane{universe: next})
+	m.focusPane(len(m.panes) - 1)
+}
+
+// splitClose closes the focused pane, leaving the split view when one
+// pane is left
+func (m *Model) splitClose() {
+	if len(m.panes) == 0 {
+		return
+	}
+	m.panes = append(m.panes[:m.focusedPane], m.panes[m.focusedPane+1:]...)
+	focus := min(m.focusedPane, len(m.panes)-1)
+	m.focusedPane = -1 // The closed pane's state isn't kept
+	m.focusPane(focus)
+	if len(m.panes) == 1 {
+		m.panes = nil
+	}
+}
+
+// focusPane moves focus to a pane, keeping the state of the one leaving
+func (m *Model) focusPane(i int) {
+	if m.focusedPane >= 0 && m.focusedPane < len(m.panes) {
+		m.panes[m.focusedPane] = splitPane{m.selectedUniverse, m.scrollOffset}
+	}
+	m.focusedPane = i
+	m.selectedUniverse = m.panes[i].universe
+	m.scrollOffset = m.panes[i].scroll
+}
+
+// renderSplit renders the grids of all panes side by side, each under
+// its universe's label
+func (m Model) renderSplit() string {
+	columns := m.gridColumns()
+	paneWidth := (m.width - 2 - (len(m.panes) - 1)) / len(m.panes)
+	focusedStyle := lipgloss.NewStyle().Bold(true).Foreground(cyanColor)
+
+	panes := make([]string, 0, len(m.panes)*2)
+	for i, pane := range m.panes {
+		focused := i == m.focusedPane
+		if focused {
+			pane = splitPane{m.selectedUniverse, m.scrollOffset}
+		}
+
+		label := helpStyle.Render(m.tabLabel(pane.universe))
+		if focused {
+			label = focusedStyle.Render("▶ " + m.tabLabel(pane.universe))
+		}
+		grid := m.renderGrid(pane.universe, pane.scroll, columns, focused)
+		if m.universeManager.Get(pane.universe) == nil {
+			grid = helpStyle.Render("No data")
+		}
+
+		if i > 0 {
+			panes = append(panes, " ")
+		}
+		panes = append(panes, lipgloss.NewStyle().Width(paneWidth).Render(label+"\n"+grid))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, panes...)
+}
@@ -0,0 +1,39 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// dataLossTimeout is how long E1.31 receivers wait for a packet before
+// treating a source as lost
+const dataLossTimeout = 2500 * time.Millisecond
+
+// countdownWidth is the width of the staleness countdown bar
+const countdownWidth = 10
+
+// renderCountdown renders the time since a universe's last packet and a
+// bar filling toward the data loss timeout, green while packets keep
+// coming, then yellow and red as the timeout approaches
+func renderCountdown(since time.Duration) string {
+	if since >= dataLossTimeout {
+		return lipgloss.NewStyle().Foreground(redColor).Bold(true).Render(
+			fmt.Sprintf("Last: %s ago, past the %s timeout", since.Round(100*time.Millisecond), dataLossTimeout))
+	}
+
+	fraction := float64(since) / float64(dataLossTimeout)
+	color := greenColor
+	switch {
+	case fraction >= 0.8:
+		color = redColor
+	case fraction >= 0.4:
+		color = yellowColor
+	}
+	filled := int(fraction * countdownWidth)
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", countdownWidth-filled)
+	return lipgloss.NewStyle().Foreground(color).Render(
+		fmt.Sprintf("Last: %s %s", since.Round(10*time.Millisecond), bar))
+}
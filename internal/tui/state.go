@@ -0,0 +1,137 @@
+package tui
+
+import (
+	"sacn-monitor/internal/config"
+)
+
+func (v viewMode) String() string {
+	switch v {
+	case viewEvents:
+		return "events"
+	case viewLosses:
+		return "losses"
+	case viewFixtures:
+		return "fixtures"
+	case viewSequences:
+		return "sequences"
+	case viewSources:
+		return "sources"
+	case viewDiagnostics:
+		return "diagnostics"
+	case viewChannel:
+		return "channel"
+	case viewHeatmap:
+		return "heatmap"
+	case viewFocus:
+		return "focus"
+	case viewRollups:
+		return "rollups"
+	default:
+		return "grid"
+	}
+}
+
+// parseEnum returns the value of an enum below count whose String is name
+func parseEnum[T interface {
+	~int
+	String() string
+}](name string, count T) (T, bool) {
+	for v := T(0); v < count; v++ {
+		if v.String() == name {
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+// WithState returns a copy of the model restored to a saved UI state.
+// Names that are no longer valid are ignored; the selected universe is
+// picked once it starts sending.
+func (m Model) WithState(state config.UIState) Model {
+	m.restoreUniverse = state.SelectedUniverse
+	m.selectedUniverse = state.SelectedUniverse
+	m.selectedChannel = min(511, max(0, state.SelectedChannel))
+	m.scrollOffset = min(511, max(0, state.Scroll))
+	if v, ok := parseEnum(state.View, viewCount); ok {
+		m.view = v
+	}
+	if f, ok := parseEnum(state.ChannelFilter, channelFilterCount); ok {
+		m.channelFilter = f
+	}
+	if t, err := parseThreshold(state.Threshold); state.Threshold != "" && err == nil {
+		m.threshold = &t
+	}
+	if o, ok := parseEnum(state.TabOrder, tabOrderCount); ok {
+		m.tabOrder = o
+	}
+	m.manualOrder = state.ManualOrder
+	m.pinned = state.Pinned
+	if _, ok := m.universeManager.Group(state.Group); ok {
+		m.groupFilter = state.Group
+	}
+	if f, ok := parseEnum(state.ValueFormat, valueFormatCount); ok {
+		m.valueFormat = f
+	}
+	if d, ok := parseEnum(state.Density, densityCount); ok {
+		m.density = d
+	}
+	if l, ok := parseEnum(state.LossMode, lossModeCount); ok {
+		m.lossMode = l
+	}
+	if l, ok := parseEnum(state.Layout, layoutCount); ok {
+		m.layout = l
+	}
+	if state.PaneShare >= minPaneShare && state.PaneShare <= maxPaneShare {
+		m.paneSharePct = state.PaneShare
+	}
+	m.panes = nil
+	for _, p := range state.Panes {
+		if len(m.panes) == maxPanes {
+			break
+		}
+		m.panes = append(m.panes, splitPane{universe: p.Universe, scroll: min(511, max(0, p.Scroll))})
+	}
+	if len(m.panes) > 0 {
+		m.focusedPane = 0
+		for i, p := range m.panes {
+			if p.universe == m.selectedUniverse {
+				m.focusedPane = i
+			}
+		}
+		focused := m.panes[m.focusedPane]
+		m.selectedUniverse, m.restoreUniverse = focused.universe, focused.universe
+		m.scrollOffset = focused.scroll
+	}
+	return m
+}
+
+// State returns the model's UI state for saving
+func (m Model) State() config.UIState {
+	state := config.UIState{
+		SelectedUniverse: m.selectedUniverse,
+		SelectedChannel:  m.selectedChannel,
+		Scroll:           m.scrollOffset,
+		View:             m.view.String(),
+		ChannelFilter:    m.channelFilter.String(),
+		TabOrder:         m.tabOrder.String(),
+		ManualOrder:      m.manualOrder,
+		Pinned:           m.pinned,
+		Group:            m.groupFilter,
+		ValueFormat:      m.valueFormat.String(),
+		Density:          m.density.String(),
+		LossMode:         m.lossMode.String(),
+		Layout:           m.layout.String(),
+		PaneShare:        m.paneSharePct,
+	}
+	if m.threshold != nil {
+		state.Threshold = m.threshold.String()
+	}
+	for i, p := range m.panes {
+		// The focused pane's state lives in the model
+		if i == m.focusedPane {
+			p = splitPane{m.selectedUniverse, m.scrollOffset}
+		}
+		state.Panes = append(state.Panes, config.PaneState{Universe: p.universe, Scroll: p.scroll})
+	}
+	return state
+}
@@ -0,0 +1,53 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var statusBarStyle = lipgloss.NewStyle().
+	Foreground(whiteColor).
+	Background(lipgloss.Color("#1a1a2e"))
+
+// WithOutputs returns a copy of the model that lists where the session is
+// being captured or recorded (e.g. "stats → stats.json") in the status bar
+func (m Model) WithOutputs(outputs ...string) Model {
+	m.outputs = outputs
+	return m
+}
+
+// renderStatusBar renders the global figures over all universes, whichever
+// universe is selected
+func (m Model) renderStatusBar() string {
+	totals := m.statsTracker.Totals()
+	warn := lipgloss.NewStyle().Inherit(statusBarStyle).Foreground(yellowColor)
+
+	plain := []string{
+		fmt.Sprintf("%d universes", totals.Universes),
+		fmt.Sprintf("%d sources", totals.Sources),
+		fmt.Sprintf("%.0f pps (peak %.0f)", totals.PacketRate, m.statsTracker.GetGlobalPeak().PacketRate),
+		formatBitrate(totals.ByteRate),
+		fmt.Sprintf("loss %.1f%%", totals.RecentLossPercentage()),
+	}
+	var parts []string
+	for _, p := range plain {
+		parts = append(parts, statusBarStyle.Render(p))
+	}
+	if totals.ReceiverDrops > 0 {
+		parts = append(parts, warn.Render(fmt.Sprintf("⚠ %d dropped locally", totals.ReceiverDrops)))
+	} else {
+		parts = append(parts, statusBarStyle.Render("no drops"))
+	}
+	if evicted := m.universeManager.Evictions(); evicted > 0 {
+		parts = append(parts, warn.Render(fmt.Sprintf("⚠ %d evicted (limit %d)", evicted, m.universeManager.MaxUniverses())))
+	}
+	if len(m.outputs) > 0 {
+		rec := lipgloss.NewStyle().Inherit(statusBarStyle).Foreground(redColor).Render("●")
+		parts = append(parts, rec+statusBarStyle.Render(" "+strings.Join(m.outputs, ", ")))
+	}
+
+	sep := statusBarStyle.Render(" │ ")
+	return statusBarStyle.Width(max(0, m.width)).Render(statusBarStyle.Render(" ") + strings.Join(parts, sep))
+}
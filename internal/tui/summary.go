@@ -0,0 +1,18 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"sacn-monitor/internal/export"
+)
+
+// exportSummary writes the session summary to a timestamped JSON file
+func (m *Model) exportSummary() {
+	path := "summary-" + time.Now().Format("20060102-150405") + ".json"
+	if err := export.WriteSummaryFile(path, m.statsTracker.Summary()); err != nil {
+		m.statusMsg = fmt.Sprintf("Summary failed: %v", err)
+	} else {
+		m.statusMsg = fmt.Sprintf("Wrote session summary to %s", path)
+	}
+}
@@ -0,0 +1,109 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// tabMoreWidth is the room kept for a "(+N more)" marker on each side of
+// the tab strip
+const tabMoreWidth = 12
+
+// renderTab renders one universe's tab
+func (m Model) renderTab(id uint16) string {
+	tabText := m.tabLabel(id)
+	if m.isPinned(id) {
+		tabText = "* " + tabText
+	}
+	switch m.tabOrder {
+	case orderByHealth:
+		tabText += fmt.Sprintf(" (%d)", m.statsTracker.GetHealth(id).Score)
+	case orderByActivity:
+		tabText += fmt.Sprintf(" (%.0f/min)", m.tabMetric(id))
+	case orderByLoss:
+		tabText += fmt.Sprintf(" (%.1f%%)", m.tabMetric(id))
+	case orderBySources:
+		tabText += fmt.Sprintf(" (%.0f src)", m.tabMetric(id))
+	}
+	universe := m.universeManager.Get(id)
+	isStale := universe == nil || universe.IsStale(staleTimeout)
+
+	var style lipgloss.Style
+	if isStale {
+		style = tabStaleStyle
+	} else if id == m.selectedUniverse {
+		style = tabActiveStyle
+	} else {
+		style = tabInactiveStyle
+	}
+	return style.Render(tabText)
+}
+
+// renderTabs renders the tab strip. When the tabs don't fit the terminal
+// only those around the selected universe are shown, with a count of the
+// ones scrolled off each side.
+func (m Model) renderTabs() string {
+	tabs := make([]string, len(m.universeList))
+	selected := 0
+	for i, id := range m.universeList {
+		tabs[i] = m.renderTab(id)
+		if id == m.selectedUniverse {
+			selected = i
+		}
+	}
+
+	var note string
+	if len(m.hidden) > 0 {
+		note = helpStyle.Render(fmt.Sprintf("(%d hidden, U: show)", len(m.hidden)))
+	}
+
+	// Grow the window from the selected tab, alternating sides, while it fits
+	budget := m.width - lipgloss.Width(note)
+	if total := stripWidth(tabs); total > budget {
+		budget -= 2 * tabMoreWidth
+	}
+	first, last := selected, selected
+	used := lipgloss.Width(tabs[selected]) + 1
+	for grew := true; grew; {
+		grew = false
+		if last+1 < len(tabs) {
+			if w := lipgloss.Width(tabs[last+1]) + 1; used+w <= budget {
+				last++
+				used += w
+				grew = true
+			}
+		}
+		if first > 0 {
+			if w := lipgloss.Width(tabs[first-1]) + 1; used+w <= budget {
+				first--
+				used += w
+				grew = true
+			}
+		}
+	}
+
+	var parts []string
+	if first > 0 {
+		parts = append(parts, helpStyle.Render(fmt.Sprintf("(+%d more) ", first)))
+	}
+	for _, tab := range tabs[first : last+1] {
+		parts = append(parts, tab, " ")
+	}
+	if last < len(tabs)-1 {
+		parts = append(parts, helpStyle.Render(fmt.Sprintf("(+%d more) ", len(tabs)-1-last)))
+	}
+	if note != "" {
+		parts = append(parts, note)
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Center, parts...)
+}
+
+// stripWidth returns the width of all tabs side by side
+func stripWidth(tabs []string) int {
+	total := 0
+	for _, tab := range tabs {
+		total += lipgloss.Width(tab) + 1
+	}
+	return total
+}
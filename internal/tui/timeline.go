@@ -0,0 +1,102 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"sacn-monitor/internal/events"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// timelineSpan is how far back the universe timeline reaches
+const timelineSpan = 5 * time.Minute
+
+// timelineMark is what happened in one slot of the timeline, in rising
+// order of importance
+type timelineMark int
+
+const (
+	markNone   timelineMark = iota // No history for the slot
+	markGap                        // Sampled, but no packets arrived
+	markData                       // Data received
+	markLoss                       // Packets lost
+	markSource                     // A source appeared, left, moved or changed priority
+)
+
+// sourceEventKinds are the events the timeline shows as source changes
+var sourceEventKinds = map[events.Kind]bool{
+	events.SourceOnline:     true,
+	events.SourceLost:       true,
+	events.AddressChange:    true,
+	events.PriorityChange:   true,
+	events.PriorityConflict: true,
+}
+
+// renderTimeline renders the selected universe's last few minutes as a
+// track of slots marking received data, gaps, loss and source changes
+func (m Model) renderTimeline(width int) string {
+	if m.history == nil || width < 10 {
+		return ""
+	}
+	now := time.Now()
+	from := now.Add(-timelineSpan)
+	slot := timelineSpan / time.Duration(width)
+	marks := make([]timelineMark, width)
+	mark := func(t time.Time, kind timelineMark) {
+		if t.Before(from) || t.After(now) {
+			return
+		}
+		i := min(width-1, int(t.Sub(from)/slot))
+		marks[i] = timelineMark(max(int(marks[i]), int(kind)))
+	}
+
+	for _, s := range m.history.Range(m.selectedUniverse, from, now) {
+		switch {
+		case s.Lost > 0:
+			mark(s.Time, markLoss)
+		case s.Packets > 0:
+			mark(s.Time, markData)
+		default:
+			mark(s.Time, markGap)
+		}
+	}
+	for _, e := range m.statsTracker.GetLossEvents(0) {
+		if e.Universe == m.selectedUniverse {
+			mark(e.Time, markLoss)
+		}
+	}
+	if m.eventLog != nil {
+		for _, e := range m.eventLog.Recent(0) {
+			if e.Universe == m.selectedUniverse && sourceEventKinds[e.Kind] {
+				mark(e.Time, markSource)
+			}
+		}
+	}
+
+	styles := map[timelineMark]lipgloss.Style{
+		markNone:   helpStyle,
+		markGap:    lipgloss.NewStyle().Foreground(redColor),
+		markData:   lipgloss.NewStyle().Foreground(greenColor),
+		markLoss:   lipgloss.NewStyle().Foreground(yellowColor),
+		markSource: lipgloss.NewStyle().Foreground(magentaColor),
+	}
+	glyphs := map[timelineMark]string{
+		markNone:   "·",
+		markGap:    "_",
+		markData:   "▆",
+		markLoss:   "✕",
+		markSource: "◆",
+	}
+	var b strings.Builder
+	for _, mk := range marks {
+		b.WriteString(styles[mk].Render(glyphs[mk]))
+	}
+
+	axis := fmt.Sprintf("-%dm", int(timelineSpan.Minutes()))
+	axis += strings.Repeat(" ", max(1, width-len(axis)-3)) + "now"
+	legend := styles[markData].Render("▆") + " data  " + styles[markGap].Render("_") + " gap  " +
+		styles[markLoss].Render("✕") + " loss  " + styles[markSource].Render("◆") + " source change"
+	return b.String() + "\n" + helpStyle.Render(axis) + "\n" + legend
+}
@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"sacn-monitor/internal/config"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// toggleWatch adds the selected channel to the watch panel, or removes it,
+// persisting the list in the config when there is one
+func (m *Model) toggleWatch() {
+	channel := m.selectedChannel + 1
+	entry := config.WatchEntry{Universe: m.selectedUniverse, Channel: channel}
+
+	watched := true
+	for i, w := range m.watch {
+		if w == entry {
+			m.watch = append(m.watch[:i:i], m.watch[i+1:]...)
+			watched = false
+			break
+		}
+	}
+	if watched {
+		m.watch = append(m.watch, entry)
+	}
+
+	action := "Removed"
+	if watched {
+		action = "Watching"
+	}
+	if m.config != nil {
+		m.config.ToggleWatch(entry.Universe, channel)
+	}
+	if m.config == nil || m.config.Path() == "" {
+		m.statusMsg = fmt.Sprintf("%s %d/%d for this session (no config file)", action, entry.Universe, channel)
+		return
+	}
+	if err := m.config.Save(); err != nil {
+		m.statusMsg = fmt.Sprintf("%s %d/%d, but saving config failed: %v", action, entry.Universe, channel, err)
+		return
+	}
+	m.statusMsg = fmt.Sprintf("%s %d/%d", action, entry.Universe, channel)
+}
+
+// renderWatchPanel renders the watched channels on one line, whichever
+// universe is selected: stale universes red, unreceived channels gray
+func (m Model) renderWatchPanel() string {
+	parts := make([]string, 0, len(m.watch))
+	for _, w := range m.watch {
+		label := fmt.Sprintf("%d/%d", w.Universe, w.Channel)
+		if patched, ok := m.universeManager.ChannelLabel(w.Universe, w.Channel); ok {
+			label += " " + patched.String()
+		}
+
+		u := m.universeManager.Get(w.Universe)
+		var value string
+		style := lipgloss.NewStyle().Foreground(cyanColor)
+		switch {
+		case u == nil || w.Channel < 1 || w.Channel > 512:
+			style = style.Foreground(grayColor)
+			value = "-"
+		case u.IsStale(staleTimeout):
+			ch := u.GetChannel(w.Channel - 1)
+			style = style.Foreground(redColor)
+			value = m.valueFormat.formatCompact(ch.Value) + " stale"
+		default:
+			ch := u.GetChannel(w.Channel - 1)
+			if !ch.Active {
+				style = style.Foreground(grayColor)
+				value = "-"
+			} else {
+				value = m.valueFormat.formatCompact(ch.Value)
+			}
+		}
+		parts = append(parts, helpStyle.Render(label+" ")+style.Bold(true).Render(value))
+	}
+	line := statsStyle.Render("Watch: ") + strings.Join(parts, helpStyle.Render(" │ "))
+	return lipgloss.NewStyle().MaxWidth(max(0, m.width)).Render(line)
+}
@@ -0,0 +1,47 @@
+package tui
+
+import (
+	"fmt"
+
+	"sacn-monitor/internal/universe"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// isWideCoarse reports whether a 0-based channel of a universe is the
+// coarse half of a 16-bit pair in the patch, and pairs are shown combined
+func (m Model) isWideCoarse(id uint16, channel int) bool {
+	if m.raw8 {
+		return false
+	}
+	p := m.universeManager.Patch()
+	if p == nil {
+		return false
+	}
+	coarse, ok := p.WidePair(id, channel+1)
+	return ok && coarse == channel+1
+}
+
+// renderWideCard renders a 16-bit pair starting at a 0-based channel as
+// one double-width card with the combined value
+func (m Model) renderWideCard(id uint16, coarse, fine universe.Channel, channel int, isStale, selected bool) string {
+	style := activeCardStyle
+	value := m.valueFormat.format16(uint16(coarse.Value)<<8 | uint16(fine.Value))
+	switch {
+	case isStale:
+		style = staleCardStyle
+		value = "    ."
+	case !coarse.Active && !fine.Active:
+		style = inactiveCardStyle
+		value = "    ."
+	}
+	if selected {
+		style = style.Border(lipgloss.ThickBorder()).BorderForeground(whiteColor)
+	}
+	content := fmt.Sprintf("%3d-%-3d 16\n%s", channel+1, channel+2, value)
+	if m.cardLabels() {
+		content += "\n" + helpStyle.Render(m.cardLabel(id, channel, 10))
+	}
+	// Two cards' width: the content of both plus the border between them
+	return style.Width(10).Render(content)
+}
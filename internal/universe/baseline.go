@@ -0,0 +1,186 @@
+package universe
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// snapshotFile is the on-disk JSON representation of a set of snapshots
+type snapshotFile struct {
+	Snapshots []snapshotRecord `json:"snapshots"`
+}
+
+// snapshotRecord is a single snapshot as stored on disk. Files written
+// before sources and priorities were captured load without them.
+type snapshotRecord struct {
+	Name       string         `json:"name"`
+	Universe   uint16         `json:"universe"`
+	Label      string         `json:"label,omitempty"`
+	TakenAt    time.Time      `json:"taken_at"`
+	Values     [512]uint8     `json:"values"`
+	Active     [512]bool      `json:"active"`
+	Sources    []sourceRecord `json:"sources,omitempty"`
+	Priorities []int          `json:"priorities,omitempty"` // Numbers rather than base64
+}
+
+// sourceRecord is a snapshot source as stored on disk
+type sourceRecord struct {
+	Name       string `json:"name"`
+	CID        string `json:"cid"` // Hex
+	Priority   uint8  `json:"priority"`
+	PerAddress bool   `json:"per_address,omitempty"`
+}
+
+// WriteSnapshotFile saves snapshots to a JSON file
+func WriteSnapshotFile(path string, snapshots []Snapshot) error {
+	f := snapshotFile{Snapshots: make([]snapshotRecord, len(snapshots))}
+	for i, s := range snapshots {
+		r := snapshotRecord{
+			Name:     s.Name,
+			Universe: s.UniverseID,
+			Label:    s.Label,
+			TakenAt:  s.TakenAt,
+			Values:   s.Values,
+			Active:   s.Active,
+		}
+		for _, src := range s.Sources {
+			r.Sources = append(r.Sources, sourceRecord{
+				Name:       src.Name,
+				CID:        hex.EncodeToString(src.CID[:]),
+				Priority:   src.Priority,
+				PerAddress: src.PerAddress,
+			})
+		}
+		for _, p := range s.Priorities {
+			r.Priorities = append(r.Priorities, int(p))
+		}
+		f.Snapshots[i] = r
+	}
+
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshots: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write snapshot file: %w", err)
+	}
+	return nil
+}
+
+// ReadSnapshotFile loads snapshots from a JSON file
+func ReadSnapshotFile(path string) ([]Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot file: %w", err)
+	}
+
+	var f snapshotFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot file %s: %w", path, err)
+	}
+
+	snapshots := make([]Snapshot, len(f.Snapshots))
+	for i, r := range f.Snapshots {
+		s := Snapshot{
+			Name:       r.Name,
+			UniverseID: r.Universe,
+			Label:      r.Label,
+			TakenAt:    r.TakenAt,
+			Values:     r.Values,
+			Active:     r.Active,
+		}
+		for _, src := range r.Sources {
+			cid, err := hex.DecodeString(src.CID)
+			if err != nil || len(cid) != 16 {
+				return nil, fmt.Errorf("failed to parse snapshot file %s: invalid CID %q", path, src.CID)
+			}
+			ss := SnapshotSource{Name: src.Name, Priority: src.Priority, PerAddress: src.PerAddress}
+			copy(ss.CID[:], cid)
+			s.Sources = append(s.Sources, ss)
+		}
+		for _, p := range r.Priorities {
+			if p < 0 || p > 255 {
+				return nil, fmt.Errorf("failed to parse snapshot file %s: invalid priority %d", path, p)
+			}
+			s.Priorities = append(s.Priorities, uint8(p))
+		}
+		snapshots[i] = s
+	}
+	return snapshots, nil
+}
+
+// SnapshotAll captures every known universe under the given name
+func (m *Manager) SnapshotAll(name string) []Snapshot {
+	universes := m.GetAll()
+	snapshots := make([]Snapshot, len(universes))
+	for i, u := range universes {
+		snapshots[i] = u.Snapshot(name)
+		snapshots[i].Label = m.Name(u.ID)
+	}
+	return snapshots
+}
+
+// SetBaseline installs a reference look that live output is compared
+// against. Deviations of up to tolerance levels are accepted. Passing no
+// snapshots clears the baseline.
+func (m *Manager) SetBaseline(snapshots []Snapshot, tolerance uint8) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(snapshots) == 0 {
+		m.baseline = nil
+		return
+	}
+
+	m.baseline = make(map[uint16]Snapshot, len(snapshots))
+	for _, s := range snapshots {
+		m.baseline[s.UniverseID] = s
+	}
+	m.baselineTolerance = tolerance
+}
+
+// HasBaseline reports whether a reference look is loaded
+func (m *Manager) HasBaseline() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.baseline != nil
+}
+
+// BaselineTolerance returns the accepted deviation from the baseline
+func (m *Manager) BaselineTolerance() uint8 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.baselineTolerance
+}
+
+// BaselineDeviations returns the channels of a universe that deviate from
+// the reference look by more than the tolerance. A universe missing from
+// the baseline is compared against an all-zero look. It returns false if no
+// baseline is loaded or the universe doesn't exist.
+func (m *Manager) BaselineDeviations(id uint16) ([]ChannelDelta, bool) {
+	m.mu.RLock()
+	loaded := m.baseline != nil
+	reference := m.baseline[id]
+	tolerance := m.baselineTolerance
+	m.mu.RUnlock()
+
+	u := m.Get(id)
+	if !loaded || u == nil {
+		return nil, false
+	}
+
+	var deviations []ChannelDelta
+	for _, d := range u.Diff(reference) {
+		delta := d.Delta()
+		if delta < 0 {
+			delta = -delta
+		}
+		if delta > int(tolerance) {
+			deviations = append(deviations, d)
+		}
+	}
+	return deviations, true
+}
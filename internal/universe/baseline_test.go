@@ -0,0 +1,122 @@
+package universe
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSnapshotFile_RoundTrip(t *testing.T) {
+	m := NewManager()
+	m.GetOrCreate(1).Update([]byte{1, 2, 3}, "test", [16]byte{}, 100, 0)
+	m.GetOrCreate(2).Update([]byte{255}, "test", [16]byte{}, 100, 0)
+
+	path := filepath.Join(t.TempDir(), "look.json")
+	if err := WriteSnapshotFile(path, m.SnapshotAll("look")); err != nil {
+		t.Fatalf("WriteSnapshotFile() returned error: %v", err)
+	}
+
+	snapshots, err := ReadSnapshotFile(path)
+	if err != nil {
+		t.Fatalf("ReadSnapshotFile() returned error: %v", err)
+	}
+
+	if len(snapshots) != 2 {
+		t.Fatalf("len(snapshots) = %d, want 2", len(snapshots))
+	}
+
+	if snapshots[0].UniverseID != 1 || snapshots[0].Name != "look" || snapshots[0].Values[2] != 3 || !snapshots[0].Active[2] {
+		t.Errorf("snapshots[0] = %d/%q values[2]=%d, want 1/look 3", snapshots[0].UniverseID, snapshots[0].Name, snapshots[0].Values[2])
+	}
+
+	if snapshots[1].Values[0] != 255 {
+		t.Errorf("snapshots[1].Values[0] = %d, want 255", snapshots[1].Values[0])
+	}
+}
+
+func TestReadSnapshotFile_Missing(t *testing.T) {
+	if _, err := ReadSnapshotFile(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("ReadSnapshotFile() expected error, got nil")
+	}
+}
+
+func TestManager_BaselineDeviations(t *testing.T) {
+	m := NewManager()
+	u := m.GetOrCreate(1)
+	u.Update([]byte{100, 100, 100}, "test", [16]byte{}, 100, 0)
+
+	if _, ok := m.BaselineDeviations(1); ok {
+		t.Error("BaselineDeviations() without baseline returned ok")
+	}
+
+	m.SetBaseline(m.SnapshotAll("look"), 2)
+	if !m.HasBaseline() || m.BaselineTolerance() != 2 {
+		t.Fatalf("HasBaseline(), BaselineTolerance() = %v, %d, want true, 2", m.HasBaseline(), m.BaselineTolerance())
+	}
+
+	// Channel 1 drifts within tolerance, channel 2 beyond it
+	u.Update([]byte{102, 90, 100}, "test", [16]byte{}, 100, 1)
+
+	deviations, ok := m.BaselineDeviations(1)
+	if !ok || len(deviations) != 1 || deviations[0].Channel != 2 {
+		t.Errorf("BaselineDeviations() = %v, %v, want channel 2 only", deviations, ok)
+	}
+
+	// Universes missing from the baseline are expected to be dark
+	m.GetOrCreate(2).Update([]byte{0, 50}, "test", [16]byte{}, 100, 0)
+	deviations, _ = m.BaselineDeviations(2)
+	if len(deviations) != 1 || deviations[0].Channel != 2 {
+		t.Errorf("BaselineDeviations(2) = %v, want channel 2 only", deviations)
+	}
+
+	m.SetBaseline(nil, 0)
+	if m.HasBaseline() {
+		t.Error("HasBaseline() = true after clearing")
+	}
+}
+
+func TestSnapshotFile_SourcesAndPriorities(t *testing.T) {
+	m := NewManager()
+	m.SetName(1, "FOH")
+	u := m.GetOrCreate(1)
+	u.Update([]byte{1, 2, 3}, "console", [16]byte{0xab}, 120, 0)
+	u.UpdatePriorities([]byte{100, 0, 200}, [16]byte{0xab})
+
+	path := filepath.Join(t.TempDir(), "universe.json")
+	if err := WriteSnapshotFile(path, m.SnapshotAll("look")); err != nil {
+		t.Fatalf("WriteSnapshotFile() returned error: %v", err)
+	}
+	snapshots, err := ReadSnapshotFile(path)
+	if err != nil {
+		t.Fatalf("ReadSnapshotFile() returned error: %v", err)
+	}
+
+	s := snapshots[0]
+	if s.Label != "FOH" {
+		t.Errorf("Label = %q, want FOH", s.Label)
+	}
+	want := SnapshotSource{Name: "console", CID: [16]byte{0xab}, Priority: 120, PerAddress: true}
+	if len(s.Sources) != 1 || s.Sources[0] != want {
+		t.Errorf("Sources = %+v, want [%+v]", s.Sources, want)
+	}
+	if len(s.Priorities) != 3 || s.Priorities[2] != 200 {
+		t.Errorf("Priorities = %v, want [100 0 200]", s.Priorities)
+	}
+}
+
+func TestManager_ImportSnapshots(t *testing.T) {
+	m := NewManager()
+	m.GetOrCreate(1).Update([]byte{10, 20}, "console", [16]byte{}, 100, 0)
+
+	var look Snapshot
+	look.Name, look.UniverseID = "shared", 1
+	look.Values[0], look.Active[0], look.Active[1] = 10, true, true
+	m.ImportSnapshots([]Snapshot{look})
+
+	deltas, ok := m.DiffSnapshot(1, "shared")
+	if !ok {
+		t.Fatal("DiffSnapshot() after import returned false")
+	}
+	if len(deltas) != 1 || deltas[0].Channel != 2 || deltas[0].New != 20 {
+		t.Errorf("deltas = %+v, want channel 2 changed to 20", deltas)
+	}
+}
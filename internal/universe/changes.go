@@ -0,0 +1,41 @@
+package universe
+
+import "time"
+
+// ChannelsChangedSince returns the 1-based unmasked channels whose value
+// changed after t, in channel order
+func (u *Universe) ChannelsChangedSince(t time.Time) []int {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	if !u.LastChange.After(t) {
+		return nil
+	}
+
+	var channels []int
+	for i, ch := range u.Channels {
+		if ch.LastChange.After(t) && !u.masked[i] {
+			channels = append(channels, i+1)
+		}
+	}
+	return channels
+}
+
+// LastChanged returns when any channel of the universe last changed value
+func (u *Universe) LastChanged() time.Time {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	return u.LastChange
+}
+
+// ChannelsChangedSince returns the 1-based channels that changed after t,
+// keyed by universe. Universes without changes are omitted.
+func (m *Manager) ChannelsChangedSince(t time.Time) map[uint16][]int {
+	result := make(map[uint16][]int)
+	for _, u := range m.GetAll() {
+		if channels := u.ChannelsChangedSince(t); len(channels) > 0 {
+			result[u.ID] = channels
+		}
+	}
+	return result
+}
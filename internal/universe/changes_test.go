@@ -0,0 +1,72 @@
+package universe
+
+import (
+	"testing"
+	"time"
+
+	"sacn-monitor/internal/clock"
+)
+
+func TestUniverse_LastChangeVsLastUpdate(t *testing.T) {
+	c := clock.NewManual(time.Unix(1000, 0))
+	u := NewUniverse(1)
+	u.clock = c
+	u.Update([]byte{10, 20}, "src", [16]byte{}, 100, 0)
+	first := u.GetChannel(0)
+
+	c.Advance(time.Millisecond)
+	u.Update([]byte{10, 30}, "src", [16]byte{}, 100, 1)
+
+	unchanged := u.GetChannel(0)
+	if !unchanged.LastUpdate.After(first.LastUpdate) {
+		t.Error("LastUpdate did not advance on an unchanged channel")
+	}
+	if !unchanged.LastChange.Equal(first.LastChange) {
+		t.Error("LastChange advanced although the value stayed the same")
+	}
+
+	changed := u.GetChannel(1)
+	if !changed.LastChange.Equal(changed.LastUpdate) {
+		t.Error("LastChange not set when the value changed")
+	}
+	if !u.LastChanged().Equal(changed.LastChange) {
+		t.Errorf("LastChanged() = %v, want %v", u.LastChanged(), changed.LastChange)
+	}
+}
+
+func TestUniverse_ChannelsChangedSince(t *testing.T) {
+	c := clock.NewManual(time.Unix(1000, 0))
+	u := NewUniverse(1)
+	u.clock = c
+	u.Update([]byte{1, 2, 3, 4}, "src", [16]byte{}, 100, 0)
+
+	mark := c.Now()
+	if got := u.ChannelsChangedSince(mark); len(got) != 0 {
+		t.Errorf("ChannelsChangedSince() = %v before any change, want none", got)
+	}
+
+	c.Advance(time.Millisecond)
+	u.Update([]byte{1, 9, 3, 8}, "src", [16]byte{}, 100, 1)
+
+	got := u.ChannelsChangedSince(mark)
+	if len(got) != 2 || got[0] != 2 || got[1] != 4 {
+		t.Errorf("ChannelsChangedSince() = %v, want [2 4]", got)
+	}
+}
+
+func TestManager_ChannelsChangedSince(t *testing.T) {
+	c := clock.NewManual(time.Unix(1000, 0))
+	m := NewManager()
+	m.SetClock(c)
+	m.GetOrCreate(1).Update([]byte{1}, "src", [16]byte{}, 100, 0)
+	m.GetOrCreate(2).Update([]byte{1}, "src", [16]byte{}, 100, 0)
+
+	mark := c.Now()
+	c.Advance(time.Millisecond)
+	m.Get(2).Update([]byte{5}, "src", [16]byte{}, 100, 1)
+
+	got := m.ChannelsChangedSince(mark)
+	if len(got) != 1 || len(got[2]) != 1 || got[2][0] != 1 {
+		t.Errorf("ChannelsChangedSince() = %v, want map[2:[1]]", got)
+	}
+}
@@ -0,0 +1,39 @@
+package universe
+
+import "time"
+
+// terminationNotice is how long a Stream_Terminated packet keeps being
+// reported after it arrived
+const terminationNotice = 10 * time.Second
+
+// StreamFlags is the option flags of a universe's latest data
+type StreamFlags struct {
+	Preview      bool      // Latest data is flagged Preview_Data
+	Terminated   bool      // A Stream_Terminated packet arrived recently
+	TerminatedAt time.Time // Latest Stream_Terminated packet, zero if none
+	SyncAddress  uint16    // Sync address of the latest data, 0 if unsynchronized
+}
+
+// RecordFlags stores the option flags of a data packet
+func (u *Universe) RecordFlags(preview, terminated bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.preview = preview
+	if terminated {
+		u.terminatedAt = u.clock.Now()
+	}
+}
+
+// Flags returns the option flags of the universe's latest data
+func (u *Universe) Flags() StreamFlags {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	return StreamFlags{
+		Preview:      u.preview,
+		Terminated:   !u.terminatedAt.IsZero() && u.clock.Now().Sub(u.terminatedAt) < terminationNotice,
+		TerminatedAt: u.terminatedAt,
+		SyncAddress:  u.syncAddress,
+	}
+}
@@ -0,0 +1,43 @@
+package universe
+
+import (
+	"testing"
+	"time"
+
+	"sacn-monitor/internal/clock"
+)
+
+func TestUniverse_Flags(t *testing.T) {
+	c := clock.NewManual(time.Unix(1000, 0))
+	u := NewUniverse(1)
+	u.clock = c
+
+	if got := u.Flags(); got.Preview || got.Terminated || got.SyncAddress != 0 {
+		t.Errorf("Flags() = %+v before any packet, want none set", got)
+	}
+
+	u.RecordFlags(true, false)
+	if !u.Flags().Preview {
+		t.Error("Flags().Preview = false after a preview packet")
+	}
+	u.RecordFlags(false, true)
+	got := u.Flags()
+	if got.Preview || !got.Terminated {
+		t.Errorf("Flags() = %+v after a terminated packet, want Terminated only", got)
+	}
+
+	// A later normal packet doesn't hide the termination while it is news
+	u.RecordFlags(false, false)
+	if !u.Flags().Terminated {
+		t.Error("Flags().Terminated cleared by the next packet")
+	}
+	c.Advance(terminationNotice)
+	if u.Flags().Terminated {
+		t.Error("Flags().Terminated still set after the notice period")
+	}
+
+	u.Hold([]byte{1}, "console", [16]byte{1}, 100, 0, 7)
+	if got := u.Flags().SyncAddress; got != 7 {
+		t.Errorf("Flags().SyncAddress = %d, want 7", got)
+	}
+}
@@ -0,0 +1,97 @@
+package universe
+
+import (
+	"sort"
+	"time"
+)
+
+// Change frequency is counted per channel in a ring of time slices, so a
+// packet costs one slot check plus an increment per changed channel
+const (
+	changeWindow = time.Minute
+	changeSlices = 10 // 6 s each
+)
+
+// ChannelActivity is how often a channel changed value over the window
+type ChannelActivity struct {
+	Channel   int // 1-based
+	Changes   int
+	PerSecond float64
+}
+
+// changeSlice holds the per-channel change counts of one time slot
+type changeSlice struct {
+	slot   int64
+	counts [512]uint16
+}
+
+// changeCounter counts value changes per channel over the change window
+type changeCounter struct {
+	slices [changeSlices]changeSlice
+}
+
+// current returns the slice covering now, clearing it if it held an
+// expired slot
+func (c *changeCounter) current(now time.Time) *[512]uint16 {
+	slot := now.UnixNano() / int64(changeWindow/changeSlices)
+	s := &c.slices[slot%changeSlices]
+	if s.slot != slot {
+		*s = changeSlice{slot: slot}
+	}
+	return &s.counts
+}
+
+// sum returns the change counts per channel over the window ending at now
+func (c *changeCounter) sum(now time.Time) [512]int {
+	var total [512]int
+	slot := now.UnixNano() / int64(changeWindow/changeSlices)
+	for i := range c.slices {
+		s := &c.slices[i]
+		if s.slot <= slot-changeSlices || s.slot > slot {
+			continue
+		}
+		for ch, n := range s.counts {
+			total[ch] += int(n)
+		}
+	}
+	return total
+}
+
+// ChangeCounts returns how often each channel changed value over the last
+// minute, indexed 0-511. Masked channels count as 0.
+func (u *Universe) ChangeCounts() [512]int {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	counts := u.changes.sum(u.clock.Now())
+	for i := range counts {
+		if u.masked[i] {
+			counts[i] = 0
+		}
+	}
+	return counts
+}
+
+// MostActiveChannels returns up to n unmasked channels that changed value
+// most often over the last minute, busiest first. Channels that didn't
+// change are left out. n <= 0 returns all that changed.
+func (u *Universe) MostActiveChannels(n int) []ChannelActivity {
+	var active []ChannelActivity
+	for i, changes := range u.ChangeCounts() {
+		if changes > 0 {
+			active = append(active, ChannelActivity{
+				Channel:   i + 1,
+				Changes:   changes,
+				PerSecond: float64(changes) / changeWindow.Seconds(),
+			})
+		}
+	}
+
+	sort.SliceStable(active, func(i, j int) bool {
+		return active[i].Changes > active[j].Changes
+	})
+	if n > 0 && len(active) > n {
+		active = active[:n]
+	}
+	return active
+}
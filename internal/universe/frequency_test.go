@@ -0,0 +1,51 @@
+package universe
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUniverse_MostActiveChannels(t *testing.T) {
+	u := NewUniverse(1)
+
+	// Channel 1 chases every packet, channel 3 every other, channel 2 holds
+	for i := range 10 {
+		u.Update([]byte{byte(i), 50, byte(i / 2), byte(i)}, "src", [16]byte{}, 100, uint8(i))
+	}
+	var mask [512]bool
+	mask[3] = true // Channel 4 is masked
+	u.setMask(mask)
+
+	got := u.MostActiveChannels(0)
+	if len(got) != 2 {
+		t.Fatalf("MostActiveChannels() = %+v, want channels 1 and 3", got)
+	}
+	if got[0].Channel != 1 || got[0].Changes != 9 {
+		t.Errorf("busiest = %+v, want channel 1 with 9 changes (activation not counted)", got[0])
+	}
+	if got[1].Channel != 3 || got[1].Changes != 4 {
+		t.Errorf("second = %+v, want channel 3 with 4 changes", got[1])
+	}
+	if want := 9 / changeWindow.Seconds(); got[0].PerSecond != want {
+		t.Errorf("PerSecond = %v, want %v", got[0].PerSecond, want)
+	}
+
+	if top := u.MostActiveChannels(1); len(top) != 1 || top[0].Channel != 1 {
+		t.Errorf("MostActiveChannels(1) = %+v, want channel 1 only", top)
+	}
+}
+
+func TestChangeCounter_Window(t *testing.T) {
+	var c changeCounter
+	start := time.Unix(1000, 0)
+
+	c.current(start)[0] += 3
+	c.current(start.Add(30 * time.Second))[0] += 2
+
+	if got := c.sum(start.Add(30 * time.Second))[0]; got != 5 {
+		t.Errorf("sum within window = %d, want 5", got)
+	}
+	if got := c.sum(start.Add(changeWindow + time.Second))[0]; got != 2 {
+		t.Errorf("sum after the first slice expired = %d, want 2", got)
+	}
+}
@@ -0,0 +1,64 @@
+package universe
+
+// Group is a named set of universes, e.g. "Stage" or "Pixel tape"
+type Group struct {
+	Name      string
+	Universes []uint16
+}
+
+// Contains reports whether the group includes a universe
+func (g Group) Contains(id uint16) bool {
+	for _, u := range g.Universes {
+		if u == id {
+			return true
+		}
+	}
+	return false
+}
+
+// SetGroups replaces the universe groups. Groups keep the order given.
+func (m *Manager) SetGroups(groups []Group) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.groups = make([]Group, len(groups))
+	for i, g := range groups {
+		m.groups[i] = Group{Name: g.Name, Universes: append([]uint16(nil), g.Universes...)}
+	}
+}
+
+// Groups returns all universe groups in their configured order
+func (m *Manager) Groups() []Group {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make([]Group, len(m.groups))
+	for i, g := range m.groups {
+		result[i] = Group{Name: g.Name, Universes: append([]uint16(nil), g.Universes...)}
+	}
+	return result
+}
+
+// Group returns the group with the given name
+func (m *Manager) Group(name string) (Group, bool) {
+	for _, g := range m.Groups() {
+		if g.Name == name {
+			return g, true
+		}
+	}
+	return Group{}, false
+}
+
+// GroupIndex returns the position of the first group containing a universe,
+// or -1 if it isn't in any group
+func (m *Manager) GroupIndex(id uint16) int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for i, g := range m.groups {
+		if g.Contains(id) {
+			return i
+		}
+	}
+	return -1
+}
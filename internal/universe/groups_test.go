@@ -0,0 +1,35 @@
+package universe
+
+import "testing"
+
+func TestManager_Groups(t *testing.T) {
+	m := NewManager()
+	m.SetGroups([]Group{
+		{Name: "Stage", Universes: []uint16{1, 2}},
+		{Name: "House", Universes: []uint16{10}},
+	})
+
+	g, ok := m.Group("House")
+	if !ok || !g.Contains(10) || g.Contains(1) {
+		t.Errorf("Group(House) = %+v, %v, want universe 10 only", g, ok)
+	}
+	if _, ok := m.Group("Pixels"); ok {
+		t.Error("Group(Pixels) found, want not found")
+	}
+
+	if got := m.GroupIndex(2); got != 0 {
+		t.Errorf("GroupIndex(2) = %d, want 0", got)
+	}
+	if got := m.GroupIndex(10); got != 1 {
+		t.Errorf("GroupIndex(10) = %d, want 1", got)
+	}
+	if got := m.GroupIndex(99); got != -1 {
+		t.Errorf("GroupIndex(99) = %d, want -1", got)
+	}
+
+	// Returned groups are copies
+	m.Groups()[0].Universes[0] = 99
+	if g, _ := m.Group("Stage"); g.Universes[0] != 1 {
+		t.Error("modifying Groups() result changed the manager's groups")
+	}
+}
@@ -1,38 +1,160 @@
 package universe
 
 import (
+	"context"
 	"sort"
 	"sync"
 	"time"
+
+	"sacn-monitor/internal/clock"
+	"sacn-monitor/internal/patch"
 )
 
 // Manager manages all discovered universes
 type Manager struct {
 	universes map[uint16]*Universe
+	snapshots map[uint16]map[string]Snapshot
+	names     map[uint16]string
+	patch     *patch.Patch
+	masks     map[uint16][512]bool
+	groups    []Group
 	mu        sync.RWMutex
+
+	// Upper bound on tracked universes (0 = unlimited) and how many were
+	// evicted to stay under it
+	maxUniverses int
+	evictions    uint64
+
+	// Reference look for baseline comparison, keyed by universe
+	baseline          map[uint16]Snapshot
+	baselineTolerance uint8
+
+	// Called with each universe evicted, pruned or removed
+	removeHandlers []func(id uint16)
+
+	clock clock.Clock
 }
 
 // NewManager creates a new universe manager
 func NewManager() *Manager {
 	return &Manager{
 		universes: make(map[uint16]*Universe),
+		snapshots: make(map[uint16]map[string]Snapshot),
+		names:     make(map[uint16]string),
+		masks:     make(map[uint16][512]bool),
+		clock:     clock.Real{},
 	}
 }
 
-// GetOrCreate returns the universe with the given ID, creating it if it doesn't exist
-func (m *Manager) GetOrCreate(id uint16) *Universe {
+// SetClock makes the manager's universes read the time from c instead of
+// the wall clock, e.g. to replay a capture with its timestamps
+func (m *Manager) SetClock(c clock.Clock) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	m.clock = c
+	for _, u := range m.universes {
+		u.mu.Lock()
+		u.clock = c
+		u.mu.Unlock()
+	}
+}
+
+// GetOrCreate returns the universe with the given ID, creating it if it doesn't exist
+func (m *Manager) GetOrCreate(id uint16) *Universe {
+	m.mu.Lock()
 	if u, exists := m.universes[id]; exists {
+		m.mu.Unlock()
 		return u
 	}
 
+	var evicted []uint16
+	if m.maxUniverses > 0 {
+		for len(m.universes) >= m.maxUniverses {
+			evicted = append(evicted, m.evictLeastRecentlyActive())
+		}
+	}
+
 	u := NewUniverse(id)
+	u.masked = m.masks[id]
+	u.clock = m.clock
 	m.universes[id] = u
+	m.unlockAndNotify(evicted)
 	return u
 }
 
+// SetMaxUniverses bounds the number of tracked universes. When a new
+// universe would exceed the limit, the least recently active one is evicted.
+// Zero means unlimited.
+func (m *Manager) SetMaxUniverses(n int) {
+	m.mu.Lock()
+	var evicted []uint16
+	m.maxUniverses = n
+	if n > 0 {
+		for len(m.universes) > n {
+			evicted = append(evicted, m.evictLeastRecentlyActive())
+		}
+	}
+	m.unlockAndNotify(evicted)
+}
+
+// MaxUniverses returns the universe limit, or 0 if unlimited
+func (m *Manager) MaxUniverses() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.maxUniverses
+}
+
+// Evictions returns how many universes were evicted to stay under the limit
+func (m *Manager) Evictions() uint64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.evictions
+}
+
+// OnRemove registers a handler called with the ID of each universe evicted,
+// pruned or removed, so state kept per universe elsewhere can be dropped
+// with it. Handlers run outside the manager's lock.
+func (m *Manager) OnRemove(handler func(id uint16)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.removeHandlers = append(m.removeHandlers, handler)
+}
+
+// unlockAndNotify releases m.mu, then tells the remove handlers about
+// removed universes
+func (m *Manager) unlockAndNotify(removed []uint16) {
+	handlers := m.removeHandlers
+	m.mu.Unlock()
+	for _, id := range removed {
+		for _, handler := range handlers {
+			handler(id)
+		}
+	}
+}
+
+// evictLeastRecentlyActive removes the universe that received a packet
+// longest ago and returns its ID. Caller must hold m.mu for writing and
+// have at least one universe.
+func (m *Manager) evictLeastRecentlyActive() uint16 {
+	var (
+		oldestID   uint16
+		oldestTime time.Time
+		found      bool
+	)
+	for id, u := range m.universes {
+		last := u.GetInfo().LastPacket
+		if !found || last.Before(oldestTime) || (last.Equal(oldestTime) && id < oldestID) {
+			oldestID, oldestTime, found = id, last, true
+		}
+	}
+	if found {
+		delete(m.universes, oldestID)
+		m.evictions++
+	}
+	return oldestID
+}
+
 // Get returns the universe with the given ID, or nil if it doesn't exist
 func (m *Manager) Get(id uint16) *Universe {
 	m.mu.RLock()
@@ -87,21 +209,40 @@ func (m *Manager) Count() int {
 // Remove removes a universe by ID
 func (m *Manager) Remove(id uint16) {
 	m.mu.Lock()
-	defer m.mu.Unlock()
-	delete(m.universes, id)
+	var removed []uint16
+	if _, exists := m.universes[id]; exists {
+		delete(m.universes, id)
+		removed = append(removed, id)
+	}
+	m.unlockAndNotify(removed)
 }
 
 // PruneStale removes all universes that haven't received data within the timeout
 func (m *Manager) PruneStale(timeout time.Duration) int {
 	m.mu.Lock()
-	defer m.mu.Unlock()
-
-	pruned := 0
+	var pruned []uint16
 	for id, u := range m.universes {
 		if u.IsStale(timeout) {
 			delete(m.universes, id)
-			pruned++
+			pruned = append(pruned, id)
+		}
+	}
+	m.unlockAndNotify(pruned)
+	return len(pruned)
+}
+
+// RunPruning prunes universes silent for longer than timeout until the
+// context is cancelled, checking a few times per timeout
+func (m *Manager) RunPruning(ctx context.Context, timeout time.Duration) {
+	ticker := time.NewTicker(max(timeout/4, time.Second))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.PruneStale(timeout)
 		}
 	}
-	return pruned
 }
@@ -0,0 +1,97 @@
+package universe
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ChannelRange is an inclusive range of 1-based channels
+type ChannelRange struct {
+	First int
+	Last  int
+}
+
+// ParseChannelRange parses "N" or "N-M" into a channel range
+func ParseChannelRange(s string) (ChannelRange, error) {
+	first, last, isRange := strings.Cut(strings.TrimSpace(s), "-")
+	if !isRange {
+		last = first
+	}
+
+	a, err := strconv.Atoi(strings.TrimSpace(first))
+	if err != nil {
+		return ChannelRange{}, fmt.Errorf("invalid channel range %q", s)
+	}
+	b, err := strconv.Atoi(strings.TrimSpace(last))
+	if err != nil {
+		return ChannelRange{}, fmt.Errorf("invalid channel range %q", s)
+	}
+
+	r := ChannelRange{First: a, Last: b}
+	if r.First < 1 || r.Last > 512 || r.First > r.Last {
+		return ChannelRange{}, fmt.Errorf("channel range %q out of 1-512", s)
+	}
+	return r, nil
+}
+
+// MaskChannels marks a channel range of a universe as ignored. Masked
+// channels are left out of active counts, change detection and alerts but
+// still keep their values.
+func (m *Manager) MaskChannels(id uint16, r ChannelRange) {
+	m.mu.Lock()
+	mask := m.masks[id]
+	for ch := r.First; ch <= r.Last; ch++ {
+		mask[ch-1] = true
+	}
+	m.masks[id] = mask
+	u := m.universes[id]
+	m.mu.Unlock()
+
+	if u != nil {
+		u.setMask(mask)
+	}
+}
+
+// ClearMasks removes all channel masks
+func (m *Manager) ClearMasks() {
+	m.mu.Lock()
+	m.masks = make(map[uint16][512]bool)
+	m.mu.Unlock()
+
+	for _, u := range m.GetAll() {
+		u.setMask([512]bool{})
+	}
+}
+
+// setMask replaces the universe's masked channels
+func (u *Universe) setMask(mask [512]bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.masked = mask
+}
+
+// IsMasked reports whether the channel at the given index (0-511) is masked
+func (u *Universe) IsMasked(index int) bool {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	if index < 0 || index >= 512 {
+		return false
+	}
+	return u.masked[index]
+}
+
+// MaskedCount returns the number of masked channels
+func (u *Universe) MaskedCount() int {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	count := 0
+	for _, masked := range u.masked {
+		if masked {
+			count++
+		}
+	}
+	return count
+}
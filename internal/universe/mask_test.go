@@ -0,0 +1,76 @@
+package universe
+
+import (
+	"testing"
+	"time"
+
+	"sacn-monitor/internal/clock"
+)
+
+func TestParseChannelRange(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    ChannelRange
+		wantErr bool
+	}{
+		{"5", ChannelRange{5, 5}, false},
+		{"100-120", ChannelRange{100, 120}, false},
+		{" 1 - 512 ", ChannelRange{1, 512}, false},
+		{"0", ChannelRange{}, true},
+		{"10-5", ChannelRange{}, true},
+		{"500-513", ChannelRange{}, true},
+		{"abc", ChannelRange{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseChannelRange(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseChannelRange(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseChannelRange(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestManager_MaskChannels(t *testing.T) {
+	c := clock.NewManual(time.Unix(1000, 0))
+	m := NewManager()
+	m.SetClock(c)
+	m.MaskChannels(1, ChannelRange{First: 3, Last: 4})
+
+	// The mask applies to universes created after it was set
+	u := m.GetOrCreate(1)
+	u.Update([]byte{0, 0, 50, 60}, "src", [16]byte{}, 100, 0)
+
+	if got := u.ActiveChannelCount(); got != 2 {
+		t.Errorf("ActiveChannelCount() = %d, want 2", got)
+	}
+	if got := u.MaskedCount(); got != 2 {
+		t.Errorf("MaskedCount() = %d, want 2", got)
+	}
+	if !u.IsBlackout() {
+		t.Error("IsBlackout() = false with only masked channels up, want true")
+	}
+
+	mark := c.Now()
+	snap := u.Snapshot("before")
+	c.Advance(time.Millisecond)
+	u.Update([]byte{0, 0, 99, 99}, "src", [16]byte{}, 100, 1)
+
+	if got := u.ChannelsChangedSince(mark); len(got) != 0 {
+		t.Errorf("ChannelsChangedSince() = %v, want masked changes ignored", got)
+	}
+	if got := u.Diff(snap); len(got) != 0 {
+		t.Errorf("Diff() = %v, want masked changes ignored", got)
+	}
+	if u.GetChannel(2).Value != 99 {
+		t.Error("masked channel did not keep its value")
+	}
+
+	m.ClearMasks()
+	if got := u.ActiveChannelCount(); got != 4 {
+		t.Errorf("ActiveChannelCount() = %d after ClearMasks, want 4", got)
+	}
+}
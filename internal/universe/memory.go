@@ -0,0 +1,44 @@
+package universe
+
+import "unsafe"
+
+// MemoryUsage is an estimate of the memory held by a universe manager
+type MemoryUsage struct {
+	Universes int
+	Snapshots int // Snapshots summed over universes, baseline included
+
+	UniverseBytes int64 // Channel state, change counters and pending frames
+	SnapshotBytes int64
+}
+
+// Total returns the estimated bytes over all parts
+func (m MemoryUsage) Total() int64 {
+	return m.UniverseBytes + m.SnapshotBytes
+}
+
+// MemoryUsage estimates the memory currently held by the manager's
+// universes and snapshots
+func (m *Manager) MemoryUsage() MemoryUsage {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	usage := MemoryUsage{Universes: len(m.universes)}
+	for _, u := range m.universes {
+		usage.UniverseBytes += int64(unsafe.Sizeof(*u))
+		u.mu.RLock()
+		if u.pending != nil {
+			usage.UniverseBytes += int64(unsafe.Sizeof(*u.pending)) + int64(cap(u.pending.Values))
+		}
+		u.mu.RUnlock()
+	}
+	for _, snaps := range m.snapshots {
+		usage.Snapshots += len(snaps)
+		for name, s := range snaps {
+			usage.SnapshotBytes += int64(unsafe.Sizeof(Snapshot{})) + int64(len(name)) +
+				int64(len(s.Sources))*int64(unsafe.Sizeof(SnapshotSource{})) + int64(len(s.Priorities))
+		}
+	}
+	usage.Snapshots += len(m.baseline)
+	usage.SnapshotBytes += int64(len(m.baseline)) * int64(unsafe.Sizeof(Snapshot{}))
+	return usage
+}
@@ -0,0 +1,19 @@
+package universe
+
+import "testing"
+
+func TestManager_MemoryUsage(t *testing.T) {
+	m := NewManager()
+	m.GetOrCreate(1).Update([]byte{1, 2, 3}, "src", [16]byte{}, 100, 0)
+
+	before := m.MemoryUsage()
+	if before.Universes != 1 || before.UniverseBytes < 512 {
+		t.Errorf("usage = %+v, want one universe of at least its channels", before)
+	}
+
+	m.CaptureSnapshot(1, "cue 1")
+	after := m.MemoryUsage()
+	if after.Snapshots != 1 || after.SnapshotBytes < 512 || after.Total() <= before.Total() {
+		t.Errorf("usage after a snapshot = %+v, want one snapshot counted", after)
+	}
+}
@@ -0,0 +1,39 @@
+package universe
+
+import "fmt"
+
+// SetName assigns a human-readable name to a universe, or removes it when
+// name is empty. Universes can be named before any data is received.
+func (m *Manager) SetName(id uint16, name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if name == "" {
+		delete(m.names, id)
+	} else {
+		m.names[id] = name
+	}
+}
+
+// SetNames assigns names to several universes at once
+func (m *Manager) SetNames(names map[uint16]string) {
+	for id, name := range names {
+		m.SetName(id, name)
+	}
+}
+
+// Name returns a universe's name, or "" if it hasn't been named
+func (m *Manager) Name(id uint16) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.names[id]
+}
+
+// Describe returns "universe N" followed by the universe's name in
+// parentheses if it has one, for use in messages
+func (m *Manager) Describe(id uint16) string {
+	if name := m.Name(id); name != "" {
+		return fmt.Sprintf("universe %d (%s)", id, name)
+	}
+	return fmt.Sprintf("universe %d", id)
+}
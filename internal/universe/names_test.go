@@ -0,0 +1,27 @@
+package universe
+
+import "testing"
+
+func TestManager_Names(t *testing.T) {
+	m := NewManager()
+
+	if m.Name(1) != "" {
+		t.Errorf("Name(1) = %q, want empty", m.Name(1))
+	}
+	if m.Describe(1) != "universe 1" {
+		t.Errorf("Describe(1) = %q, want %q", m.Describe(1), "universe 1")
+	}
+
+	m.SetNames(map[uint16]string{1: "FOH rig", 2: "LED wall left"})
+	if m.Name(2) != "LED wall left" {
+		t.Errorf("Name(2) = %q, want %q", m.Name(2), "LED wall left")
+	}
+	if m.Describe(1) != "universe 1 (FOH rig)" {
+		t.Errorf("Describe(1) = %q, want %q", m.Describe(1), "universe 1 (FOH rig)")
+	}
+
+	m.SetName(1, "")
+	if m.Name(1) != "" {
+		t.Errorf("Name(1) = %q after clearing, want empty", m.Name(1))
+	}
+}
@@ -0,0 +1,116 @@
+package universe
+
+import "sacn-monitor/internal/patch"
+
+// SetPatch installs the fixture patch used for footprint checks and labels.
+// Passing nil removes it.
+func (m *Manager) SetPatch(p *patch.Patch) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.patch = p
+}
+
+// Patch returns the loaded patch, or nil if none is loaded
+func (m *Manager) Patch() *patch.Patch {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.patch
+}
+
+// OutOfFootprint returns the 1-based channels of a universe that are
+// receiving non-zero data outside every patched fixture's footprint.
+// Zero levels are ignored since consoles commonly send full 512-slot
+// frames, as are masked channels. It returns nil when no patch is loaded.
+func (m *Manager) OutOfFootprint(id uint16) []int {
+	p := m.Patch()
+	u := m.Get(id)
+	if p == nil || u == nil {
+		return nil
+	}
+
+	var channels []int
+	for i, ch := range u.GetAllChannels() {
+		if ch.Active && ch.Value != 0 && !p.Covers(id, i+1) && !u.IsMasked(i) {
+			channels = append(channels, i+1)
+		}
+	}
+	return channels
+}
+
+// LabeledChannel is a channel's state together with its patch label
+type LabeledChannel struct {
+	Number int // 1-based channel number
+	Channel
+	Label   patch.Label
+	Patched bool
+}
+
+// ChannelLabel returns the patch label of a 1-based channel
+func (m *Manager) ChannelLabel(id uint16, channel int) (patch.Label, bool) {
+	p := m.Patch()
+	if p == nil {
+		return patch.Label{}, false
+	}
+	return p.Label(id, channel)
+}
+
+// LabeledChannels returns every channel of a universe with its patch label.
+// Channels are unlabeled when no patch is loaded. It returns nil if the
+// universe doesn't exist.
+func (m *Manager) LabeledChannels(id uint16) []LabeledChannel {
+	u := m.Get(id)
+	if u == nil {
+		return nil
+	}
+	p := m.Patch()
+
+	channels := u.GetAllChannels()
+	result := make([]LabeledChannel, len(channels))
+	for i, ch := range channels {
+		result[i] = LabeledChannel{Number: i + 1, Channel: ch}
+		if p != nil {
+			result[i].Label, result[i].Patched = p.Label(id, i+1)
+		}
+	}
+	return result
+}
+
+// FixtureValue is a patched fixture's current state
+type FixtureValue struct {
+	Fixture patch.Fixture
+	Values  []uint8       // Channel values across the fixture's footprint
+	Active  bool          // True if any of the fixture's channels is receiving data
+	Colors  []patch.Color // Colors shown by the fixture, per cell; nil if not a color fixture
+}
+
+// FixtureValues returns the state of every fixture patched on a universe,
+// in address order. It returns nil when no patch is loaded or the universe
+// doesn't exist.
+func (m *Manager) FixtureValues(id uint16) []FixtureValue {
+	p := m.Patch()
+	u := m.Get(id)
+	if p == nil || u == nil {
+		return nil
+	}
+
+	channels := u.GetAllChannels()
+	var result []FixtureValue
+	for _, f := range p.Fixtures() {
+		if f.Universe != id {
+			continue
+		}
+
+		fv := FixtureValue{
+			Fixture: f,
+			Values:  make([]uint8, f.Footprint),
+		}
+		for i := range fv.Values {
+			ch := channels[f.Address-1+i]
+			fv.Values[i] = ch.Value
+			fv.Active = fv.Active || ch.Active
+		}
+		fv.Colors = f.Colors(fv.Values)
+		result = append(result, fv)
+	}
+	return result
+}
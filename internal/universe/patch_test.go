@@ -0,0 +1,100 @@
+package universe
+
+import (
+	"testing"
+
+	"sacn-monitor/internal/patch"
+)
+
+func TestManager_OutOfFootprint(t *testing.T) {
+	m := NewManager()
+	u := m.GetOrCreate(1)
+	u.Update([]byte{255, 255, 0, 10}, "test", [16]byte{}, 100, 0)
+
+	if got := m.OutOfFootprint(1); got != nil {
+		t.Errorf("OutOfFootprint() without patch = %v, want nil", got)
+	}
+
+	p, err := patch.New([]patch.Fixture{{Name: "Dimmer", Universe: 1, Address: 1, Footprint: 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.SetPatch(p)
+
+	// Channel 3 is zero so it isn't flagged, channel 4 carries data outside the patch
+	got := m.OutOfFootprint(1)
+	if len(got) != 1 || got[0] != 4 {
+		t.Errorf("OutOfFootprint() = %v, want [4]", got)
+	}
+
+	// A universe with nothing patched is entirely outside the patch
+	m.GetOrCreate(2).Update([]byte{1}, "test", [16]byte{}, 100, 0)
+	if got := m.OutOfFootprint(2); len(got) != 1 || got[0] != 1 {
+		t.Errorf("OutOfFootprint(2) = %v, want [1]", got)
+	}
+}
+
+func TestManager_LabeledChannels(t *testing.T) {
+	m := NewManager()
+	m.GetOrCreate(1).Update([]byte{10, 20}, "test", [16]byte{}, 100, 0)
+
+	if m.LabeledChannels(2) != nil {
+		t.Error("LabeledChannels() for unknown universe returned non-nil")
+	}
+
+	channels := m.LabeledChannels(1)
+	if len(channels) != 512 || channels[0].Patched {
+		t.Fatalf("LabeledChannels() without patch: len %d, patched %v", len(channels), channels[0].Patched)
+	}
+
+	p, err := patch.New([]patch.Fixture{{Name: "Wash", Universe: 1, Address: 2, Footprint: 1, Params: []string{"Dim"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.SetPatch(p)
+
+	channels = m.LabeledChannels(1)
+	if !channels[1].Patched || channels[1].Label.String() != "Wash Dim" || channels[1].Value != 20 || channels[1].Number != 2 {
+		t.Errorf("channels[1] = %+v, want channel 2 Wash Dim at 20", channels[1])
+	}
+
+	if label, ok := m.ChannelLabel(1, 2); !ok || label.Fixture != "Wash" {
+		t.Errorf("ChannelLabel(1, 2) = %v, %v, want Wash", label, ok)
+	}
+}
+
+func TestManager_FixtureValues(t *testing.T) {
+	m := NewManager()
+	m.GetOrCreate(1).Update([]byte{255, 0, 0, 0, 255, 0, 200}, "test", [16]byte{}, 100, 0)
+
+	if m.FixtureValues(1) != nil {
+		t.Error("FixtureValues() without patch returned non-nil")
+	}
+
+	p, err := patch.New([]patch.Fixture{
+		{Name: "Dimmer", Universe: 1, Address: 7, Footprint: 1},
+		{Name: "Tape", Universe: 1, Address: 1, Footprint: 6, Type: "rgb"},
+		{Name: "Other", Universe: 2, Address: 1, Footprint: 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.SetPatch(p)
+
+	fixtures := m.FixtureValues(1)
+	if len(fixtures) != 2 {
+		t.Fatalf("len(FixtureValues()) = %d, want 2", len(fixtures))
+	}
+
+	tape := fixtures[0]
+	if tape.Fixture.Name != "Tape" || !tape.Active || len(tape.Colors) != 2 {
+		t.Fatalf("fixtures[0] = %+v, want active Tape with 2 cells", tape)
+	}
+	if tape.Colors[0].Hex() != "#FF0000" || tape.Colors[1].Hex() != "#00FF00" {
+		t.Errorf("tape colors = %s %s, want #FF0000 #00FF00", tape.Colors[0].Hex(), tape.Colors[1].Hex())
+	}
+
+	if dimmer := fixtures[1]; dimmer.Values[0] != 200 || dimmer.Colors != nil {
+		t.Errorf("fixtures[1] = %+v, want Dimmer at 200 without colors", dimmer)
+	}
+}
@@ -0,0 +1,44 @@
+package universe
+
+import "time"
+
+// perAddressTimeout is how long per-address priorities stay valid without
+// a new packet (E1.31 network data loss timeout)
+const perAddressTimeout = 2500 * time.Millisecond
+
+// PerAddressPriorities is the per-channel priorities a source sent with
+// start code 0xDD
+type PerAddressPriorities struct {
+	Values     [512]uint8 // 0 means the source doesn't drive the channel
+	Slots      int
+	SourceCID  [16]byte
+	ReceivedAt time.Time
+}
+
+// UpdatePriorities stores the per-address priorities of a start code 0xDD
+// packet. They don't change any channel's value.
+func (u *Universe) UpdatePriorities(priorities []byte, sourceCID [16]byte) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	p := PerAddressPriorities{
+		Slots:      min(len(priorities), 512),
+		SourceCID:  sourceCID,
+		ReceivedAt: u.clock.Now(),
+	}
+	copy(p.Values[:], priorities)
+	u.priorities = p
+}
+
+// PerAddressPriorities returns the latest per-address priorities, if they
+// arrived within the data loss timeout
+func (u *Universe) PerAddressPriorities() (PerAddressPriorities, bool) {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	p := u.priorities
+	if p.ReceivedAt.IsZero() || u.clock.Now().Sub(p.ReceivedAt) > perAddressTimeout {
+		return PerAddressPriorities{}, false
+	}
+	return p, true
+}
@@ -0,0 +1,59 @@
+package universe
+
+import (
+	"testing"
+	"time"
+
+	"sacn-monitor/internal/clock"
+)
+
+func TestUniverse_PerAddressPriorities(t *testing.T) {
+	c := clock.NewManual(time.Unix(1000, 0))
+	u := NewUniverse(1)
+	u.clock = c
+
+	if _, ok := u.PerAddressPriorities(); ok {
+		t.Error("PerAddressPriorities() ok before any 0xDD packet")
+	}
+
+	cid := [16]byte{7}
+	u.UpdatePriorities([]byte{100, 0, 200}, cid)
+
+	// Priorities must not touch the levels
+	if u.GetChannel(0).Active || u.GetChannel(2).Value != 0 {
+		t.Error("UpdatePriorities changed channel levels")
+	}
+
+	got, ok := u.PerAddressPriorities()
+	if !ok {
+		t.Fatal("PerAddressPriorities() not ok after 0xDD packet")
+	}
+	if got.Slots != 3 || got.Values[0] != 100 || got.Values[1] != 0 || got.Values[2] != 200 || got.SourceCID != cid {
+		t.Errorf("PerAddressPriorities() = slots %d values %v cid %v", got.Slots, got.Values[:3], got.SourceCID)
+	}
+
+	c.Advance(perAddressTimeout + time.Millisecond)
+	if _, ok := u.PerAddressPriorities(); ok {
+		t.Error("PerAddressPriorities() still ok after the data loss timeout")
+	}
+}
+
+func TestUniverse_ChannelMinMaxAndOwner(t *testing.T) {
+	u := NewUniverse(1)
+	a, b := [16]byte{1}, [16]byte{2}
+
+	u.Update([]byte{50}, "a", a, 100, 0)
+	u.Update([]byte{20}, "a", a, 100, 1)
+	u.Update([]byte{90}, "b", b, 100, 0)
+
+	ch := u.GetChannel(0)
+	if ch.Min != 20 || ch.Max != 90 {
+		t.Errorf("Min/Max = %d/%d, want 20/90", ch.Min, ch.Max)
+	}
+	if ch.SourceCID != b {
+		t.Errorf("SourceCID = %v, want the last source %v", ch.SourceCID, b)
+	}
+	if inactive := u.GetChannel(1); inactive.Min != 0 || inactive.Max != 0 {
+		t.Errorf("inactive channel Min/Max = %d/%d, want 0/0", inactive.Min, inactive.Max)
+	}
+}
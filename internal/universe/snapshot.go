@@ -0,0 +1,182 @@
+package universe
+
+import (
+	"sort"
+	"time"
+)
+
+// Snapshot is a named capture of a universe's channel values
+type Snapshot struct {
+	Name       string
+	UniverseID uint16
+	Label      string // Universe name at capture, if it had one
+	TakenAt    time.Time
+	Values     [512]uint8
+	Active     [512]bool
+
+	// Sources sending to the universe, the source of the latest data first
+	Sources []SnapshotSource
+	// Per-address priorities of the latest 0xDD packet, nil if none were
+	// being sent
+	Priorities []uint8
+}
+
+// SnapshotSource is a source and its priority at capture time
+type SnapshotSource struct {
+	Name       string
+	CID        [16]byte
+	Priority   uint8
+	PerAddress bool // Sending per-address priority
+}
+
+// ChannelDelta describes a channel whose value differs from a snapshot
+type ChannelDelta struct {
+	Channel int // 1-based channel number
+	Old     uint8
+	New     uint8
+}
+
+// Delta returns the signed change from the snapshot value
+func (d ChannelDelta) Delta() int {
+	return int(d.New) - int(d.Old)
+}
+
+// Snapshot captures the current channel values under the given name
+func (u *Universe) Snapshot(name string) Snapshot {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	s := Snapshot{
+		Name:       name,
+		UniverseID: u.ID,
+		TakenAt:    u.clock.Now(),
+	}
+	for i, ch := range u.Channels {
+		s.Values[i] = ch.Value
+		s.Active[i] = ch.Active
+	}
+	if !u.LastPacket.IsZero() {
+		s.Sources = []SnapshotSource{{Name: u.SourceName, CID: u.SourceCID, Priority: u.Priority}}
+	}
+	if p := u.priorities; !p.ReceivedAt.IsZero() && s.TakenAt.Sub(p.ReceivedAt) <= perAddressTimeout {
+		s.Priorities = append([]uint8(nil), p.Values[:p.Slots]...)
+		if len(s.Sources) > 0 && s.Sources[0].CID == p.SourceCID {
+			s.Sources[0].PerAddress = true
+		}
+	}
+	return s
+}
+
+// Diff compares the live channel values against a snapshot and returns the
+// channels that changed, in channel order. A channel that became active or
+// inactive since the snapshot counts as changed. Masked channels are skipped.
+func (u *Universe) Diff(s Snapshot) []ChannelDelta {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	var deltas []ChannelDelta
+	for i, ch := range u.Channels {
+		if u.masked[i] || (ch.Value == s.Values[i] && ch.Active == s.Active[i]) {
+			continue
+		}
+		deltas = append(deltas, ChannelDelta{
+			Channel: i + 1,
+			Old:     s.Values[i],
+			New:     ch.Value,
+		})
+	}
+	return deltas
+}
+
+// CaptureSnapshot captures and stores a named snapshot of a universe,
+// replacing any previous snapshot with the same name. It returns false if
+// the universe doesn't exist.
+func (m *Manager) CaptureSnapshot(id uint16, name string) (Snapshot, bool) {
+	u := m.Get(id)
+	if u == nil {
+		return Snapshot{}, false
+	}
+
+	s := u.Snapshot(name)
+	s.Label = m.Name(id)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.snapshots[id] == nil {
+		m.snapshots[id] = make(map[string]Snapshot)
+	}
+	m.snapshots[id][name] = s
+	return s, true
+}
+
+// GetSnapshot returns a stored snapshot by universe and name
+func (m *Manager) GetSnapshot(id uint16, name string) (Snapshot, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	s, exists := m.snapshots[id][name]
+	return s, exists
+}
+
+// LatestSnapshot returns the most recently captured snapshot for a universe
+func (m *Manager) LatestSnapshot(id uint16) (Snapshot, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var latest Snapshot
+	found := false
+	for _, s := range m.snapshots[id] {
+		if !found || s.TakenAt.After(latest.TakenAt) {
+			latest = s
+			found = true
+		}
+	}
+	return latest, found
+}
+
+// ListSnapshots returns all snapshots for a universe, oldest first
+func (m *Manager) ListSnapshots(id uint16) []Snapshot {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make([]Snapshot, 0, len(m.snapshots[id]))
+	for _, s := range m.snapshots[id] {
+		result = append(result, s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].TakenAt.Before(result[j].TakenAt)
+	})
+	return result
+}
+
+// ImportSnapshots stores snapshots loaded from a file, replacing any with
+// the same universe and name, so live output can be diffed against them
+func (m *Manager) ImportSnapshots(snapshots []Snapshot) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, s := range snapshots {
+		if m.snapshots[s.UniverseID] == nil {
+			m.snapshots[s.UniverseID] = make(map[string]Snapshot)
+		}
+		m.snapshots[s.UniverseID][s.Name] = s
+	}
+}
+
+// DeleteSnapshot removes a stored snapshot
+func (m *Manager) DeleteSnapshot(id uint16, name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.snapshots[id], name)
+}
+
+// DiffSnapshot compares a universe's live state against a stored snapshot.
+// It returns false if the universe or snapshot doesn't exist.
+func (m *Manager) DiffSnapshot(id uint16, name string) ([]ChannelDelta, bool) {
+	u := m.Get(id)
+	s, exists := m.GetSnapshot(id, name)
+	if u == nil || !exists {
+		return nil, false
+	}
+	return u.Diff(s), true
+}
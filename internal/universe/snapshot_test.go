@@ -0,0 +1,78 @@
+package universe
+
+import (
+	"testing"
+	"time"
+
+	"sacn-monitor/internal/clock"
+)
+
+func TestUniverse_SnapshotDiff(t *testing.T) {
+	u := NewUniverse(1)
+	u.Update([]byte{100, 50, 0}, "test", [16]byte{}, 100, 0)
+
+	s := u.Snapshot("focus")
+	if s.Name != "focus" || s.UniverseID != 1 {
+		t.Errorf("Snapshot() = %q/%d, want focus/1", s.Name, s.UniverseID)
+	}
+
+	if deltas := u.Diff(s); len(deltas) != 0 {
+		t.Errorf("Diff() right after Snapshot() = %v, want empty", deltas)
+	}
+
+	u.Update([]byte{100, 70, 0, 255}, "test", [16]byte{}, 100, 1)
+
+	deltas := u.Diff(s)
+	if len(deltas) != 2 {
+		t.Fatalf("len(Diff()) = %d, want 2", len(deltas))
+	}
+
+	if deltas[0].Channel != 2 || deltas[0].Old != 50 || deltas[0].New != 70 || deltas[0].Delta() != 20 {
+		t.Errorf("deltas[0] = %+v, want channel 2 50->70", deltas[0])
+	}
+
+	// Channel 4 became active since the snapshot
+	if deltas[1].Channel != 4 || deltas[1].Delta() != 255 {
+		t.Errorf("deltas[1] = %+v, want channel 4 0->255", deltas[1])
+	}
+}
+
+func TestManager_CaptureSnapshot(t *testing.T) {
+	c := clock.NewManual(time.Unix(1000, 0))
+	m := NewManager()
+	m.SetClock(c)
+
+	if _, ok := m.CaptureSnapshot(1, "missing"); ok {
+		t.Error("CaptureSnapshot() on unknown universe returned ok")
+	}
+
+	u := m.GetOrCreate(1)
+	u.Update([]byte{10}, "test", [16]byte{}, 100, 0)
+
+	if _, ok := m.CaptureSnapshot(1, "first"); !ok {
+		t.Fatal("CaptureSnapshot() returned !ok")
+	}
+	c.Advance(time.Millisecond)
+	m.CaptureSnapshot(1, "second")
+
+	latest, ok := m.LatestSnapshot(1)
+	if !ok || latest.Name != "second" {
+		t.Errorf("LatestSnapshot() = %q, want second", latest.Name)
+	}
+
+	if list := m.ListSnapshots(1); len(list) != 2 || list[0].Name != "first" {
+		t.Errorf("ListSnapshots() = %v, want [first second]", list)
+	}
+
+	u.Update([]byte{20}, "test", [16]byte{}, 100, 1)
+
+	deltas, ok := m.DiffSnapshot(1, "first")
+	if !ok || len(deltas) != 1 || deltas[0].New != 20 {
+		t.Errorf("DiffSnapshot() = %v, %v, want one delta to 20", deltas, ok)
+	}
+
+	m.DeleteSnapshot(1, "first")
+	if _, ok := m.GetSnapshot(1, "first"); ok {
+		t.Error("GetSnapshot() found deleted snapshot")
+	}
+}
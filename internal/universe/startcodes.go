@@ -0,0 +1,55 @@
+package universe
+
+import (
+	"sort"
+	"time"
+)
+
+// StartCodeSeen is an alternate (non-DMX) start code received on a universe
+type StartCodeSeen struct {
+	StartCode uint8
+	Source    string // Source of the latest packet with it
+	Packets   uint64
+	FirstSeen time.Time
+	LastSeen  time.Time
+}
+
+// RecordStartCode counts a packet with an alternate start code. DMX data
+// (start code 0) isn't recorded.
+func (u *Universe) RecordStartCode(startCode uint8, sourceName string) {
+	if startCode == 0 {
+		return
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	now := u.clock.Now()
+	if u.startCodes == nil {
+		u.startCodes = make(map[uint8]*StartCodeSeen)
+	}
+	seen, ok := u.startCodes[startCode]
+	if !ok {
+		seen = &StartCodeSeen{StartCode: startCode, FirstSeen: now}
+		u.startCodes[startCode] = seen
+	}
+	seen.Source = sourceName
+	seen.Packets++
+	seen.LastSeen = now
+}
+
+// StartCodesSince returns the alternate start codes received since a time,
+// in start code order
+func (u *Universe) StartCodesSince(since time.Time) []StartCodeSeen {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	var result []StartCodeSeen
+	for _, seen := range u.startCodes {
+		if !seen.LastSeen.Before(since) {
+			result = append(result, *seen)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].StartCode < result[j].StartCode })
+	return result
+}
@@ -0,0 +1,33 @@
+package universe
+
+import (
+	"testing"
+	"time"
+
+	"sacn-monitor/internal/clock"
+)
+
+func TestUniverse_StartCodesSince(t *testing.T) {
+	c := clock.NewManual(time.Unix(1000, 0))
+	u := NewUniverse(1)
+	u.clock = c
+
+	u.RecordStartCode(0x00, "console")
+	u.RecordStartCode(0xDD, "console")
+	c.Advance(5 * time.Second)
+	u.RecordStartCode(0x17, "node")
+	u.RecordStartCode(0xDD, "backup")
+
+	got := u.StartCodesSince(time.Unix(1000, 0))
+	if len(got) != 2 || got[0].StartCode != 0x17 || got[1].StartCode != 0xDD {
+		t.Fatalf("StartCodesSince() = %+v, want 0x17 and 0xDD", got)
+	}
+	if got[1].Packets != 2 || got[1].Source != "backup" || !got[1].FirstSeen.Equal(time.Unix(1000, 0)) {
+		t.Errorf("0xDD = %+v, want 2 packets, latest from backup, first seen at start", got[1])
+	}
+
+	c.Advance(10 * time.Second)
+	if got := u.StartCodesSince(c.Now().Add(-5 * time.Second)); len(got) != 0 {
+		t.Errorf("StartCodesSince() = %+v after they stopped, want none", got)
+	}
+}
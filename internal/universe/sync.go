@@ -0,0 +1,117 @@
+package universe
+
+import "time"
+
+// PendingFrame is channel data received for a synchronized universe that
+// has not yet been released by a sync packet
+type PendingFrame struct {
+	Values      []byte
+	SourceName  string
+	SourceCID   [16]byte
+	Priority    uint8
+	Sequence    uint8
+	SyncAddress uint16
+	ReceivedAt  time.Time
+}
+
+// SyncState describes a universe's synchronization status. The released
+// frame is the universe's channel values.
+type SyncState struct {
+	SyncAddress  uint16        // 0 if the source isn't synchronizing this universe
+	Pending      *PendingFrame // Latest held frame, nil if nothing is waiting
+	PendingSince time.Time     // When data started waiting for the current release
+	LastRelease  time.Time
+	Releases     uint64
+}
+
+// Held reports how long data has been waiting for a sync packet, or 0 if
+// nothing is pending
+func (s SyncState) Held(now time.Time) time.Duration {
+	if s.Pending == nil {
+		return 0
+	}
+	return now.Sub(s.PendingSince)
+}
+
+// Hold stores channel data from a packet carrying a sync address. It only
+// takes effect on the channels once a sync packet for that address releases
+// it; a newer frame replaces an older one still waiting.
+func (u *Universe) Hold(channelData []byte, sourceName string, sourceCID [16]byte, priority uint8, sequence uint8, syncAddress uint16) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	now := u.clock.Now()
+	if u.pending == nil {
+		u.pendingSince = now
+	}
+
+	values := make([]byte, len(channelData))
+	copy(values, channelData)
+	u.pending = &PendingFrame{
+		Values:      values,
+		SourceName:  sourceName,
+		SourceCID:   sourceCID,
+		Priority:    priority,
+		Sequence:    sequence,
+		SyncAddress: syncAddress,
+		ReceivedAt:  now,
+	}
+	u.syncAddress = syncAddress
+
+	// Data is arriving even while held, so the universe isn't stale
+	u.LastPacket = now
+}
+
+// Release applies the pending frame to the channels. It returns false if
+// nothing was pending.
+func (u *Universe) Release() bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.pending == nil {
+		return false
+	}
+
+	now := u.clock.Now()
+	p := u.pending
+	u.apply(now, p.Values, p.SourceName, p.SourceCID, p.Priority, p.Sequence)
+	u.pending = nil
+	u.lastRelease = now
+	u.releases++
+	return true
+}
+
+// SyncState returns a copy of the universe's synchronization status
+func (u *Universe) SyncState() SyncState {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	s := SyncState{
+		SyncAddress:  u.syncAddress,
+		PendingSince: u.pendingSince,
+		LastRelease:  u.lastRelease,
+		Releases:     u.releases,
+	}
+	if u.pending != nil {
+		p := *u.pending
+		p.Values = append([]byte(nil), u.pending.Values...)
+		s.Pending = &p
+	}
+	return s
+}
+
+// ReleaseSync releases the pending frames of every universe waiting on the
+// given sync address and returns how many were released
+func (m *Manager) ReleaseSync(syncAddress uint16) int {
+	released := 0
+	for _, u := range m.GetAll() {
+		u.mu.RLock()
+		waiting := u.pending != nil && u.pending.SyncAddress == syncAddress
+		u.mu.RUnlock()
+
+		if waiting && u.Release() {
+			released++
+		}
+	}
+	return released
+}
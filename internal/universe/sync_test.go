@@ -0,0 +1,98 @@
+package universe
+
+import (
+	"testing"
+	"time"
+
+	"sacn-monitor/internal/clock"
+)
+
+func TestUniverse_HoldUntilRelease(t *testing.T) {
+	c := clock.NewManual(time.Unix(1000, 0))
+	u := NewUniverse(1)
+	u.clock = c
+	u.Update([]byte{10, 20}, "console", [16]byte{1}, 100, 0)
+
+	u.Hold([]byte{50, 60}, "console", [16]byte{1}, 100, 1, 7000)
+
+	if got := u.GetChannel(0).Value; got != 10 {
+		t.Errorf("channel 1 = %d before release, want 10", got)
+	}
+
+	state := u.SyncState()
+	if state.SyncAddress != 7000 {
+		t.Errorf("SyncAddress = %d, want 7000", state.SyncAddress)
+	}
+	if state.Pending == nil || state.Pending.Values[0] != 50 {
+		t.Fatalf("Pending = %+v, want the held frame", state.Pending)
+	}
+	c.Advance(10 * time.Millisecond)
+	if got := state.Held(c.Now()); got != 10*time.Millisecond {
+		t.Errorf("Held() = %v, want 10ms", got)
+	}
+
+	if !u.Release() {
+		t.Fatal("Release() = false with a pending frame")
+	}
+	if got := u.GetChannel(0).Value; got != 50 {
+		t.Errorf("channel 1 = %d after release, want 50", got)
+	}
+
+	state = u.SyncState()
+	if state.Pending != nil || state.Releases != 1 || state.Held(c.Now()) != 0 {
+		t.Errorf("state after release = %+v, want nothing pending and 1 release", state)
+	}
+	if u.Release() {
+		t.Error("Release() = true with nothing pending")
+	}
+}
+
+func TestUniverse_HoldKeepsFirstPendingTime(t *testing.T) {
+	c := clock.NewManual(time.Unix(1000, 0))
+	u := NewUniverse(1)
+	u.clock = c
+	u.Hold([]byte{1}, "console", [16]byte{1}, 100, 0, 7000)
+	since := u.SyncState().PendingSince
+
+	c.Advance(time.Millisecond)
+	u.Hold([]byte{2}, "console", [16]byte{1}, 100, 1, 7000)
+
+	state := u.SyncState()
+	if !state.PendingSince.Equal(since) {
+		t.Error("PendingSince moved when a newer frame replaced a held one")
+	}
+	if state.Pending.Values[0] != 2 {
+		t.Errorf("pending value = %d, want the newest frame", state.Pending.Values[0])
+	}
+	if u.IsStale(time.Second) {
+		t.Error("IsStale() = true while held data is arriving")
+	}
+}
+
+func TestUniverse_UnsynchronizedUpdateClearsSync(t *testing.T) {
+	u := NewUniverse(1)
+	u.Hold([]byte{1}, "console", [16]byte{1}, 100, 0, 7000)
+	u.Update([]byte{3}, "console", [16]byte{1}, 100, 1)
+
+	state := u.SyncState()
+	if state.SyncAddress != 0 || state.Pending != nil {
+		t.Errorf("state = %+v, want sync cleared", state)
+	}
+}
+
+func TestManager_ReleaseSync(t *testing.T) {
+	m := NewManager()
+	m.GetOrCreate(1).Hold([]byte{1}, "console", [16]byte{1}, 100, 0, 7000)
+	m.GetOrCreate(2).Hold([]byte{2}, "console", [16]byte{1}, 100, 0, 7000)
+	m.GetOrCreate(3).Hold([]byte{3}, "console", [16]byte{1}, 100, 0, 8000)
+
+	if got := m.ReleaseSync(7000); got != 2 {
+		t.Errorf("ReleaseSync(7000) = %d, want 2", got)
+	}
+	if got := m.Get(2).GetChannel(0).Value; got != 2 {
+		t.Errorf("universe 2 channel 1 = %d, want 2", got)
+	}
+	if m.Get(3).SyncState().Pending == nil {
+		t.Error("universe 3 released by another sync address")
+	}
+}
@@ -3,6 +3,8 @@ package universe
 import (
 	"sync"
 	"time"
+
+	"sacn-monitor/internal/clock"
 )
 
 // Channel represents the state of a single DMX channel
@@ -10,6 +12,10 @@ type Channel struct {
 	Value      uint8     // Current value (0-255)
 	Active     bool      // True if channel is included in received packets
 	LastUpdate time.Time // When the channel was last updated
+	LastChange time.Time // When the channel's value last differed from the previous packet
+	Min        uint8     // Lowest value since the channel was first received
+	Max        uint8     // Highest value since the channel was first received
+	SourceCID  [16]byte  // Source of the current value
 }
 
 // Universe represents the state of a single sACN universe
@@ -21,14 +27,40 @@ type Universe struct {
 	Priority     uint8
 	LastSequence uint8
 	LastPacket   time.Time
+	LastChange   time.Time // When any channel last changed
 	PacketCount  uint64
+	clock        clock.Clock
 	mu           sync.RWMutex
+
+	// Channels excluded from counts, change detection and alerts, see mask.go
+	masked [512]bool
+
+	// Value changes per channel over the last minute, see frequency.go
+	changes changeCounter
+
+	// Latest per-address priorities, see priority.go
+	priorities PerAddressPriorities
+
+	// Alternate start codes received, see startcodes.go
+	startCodes map[uint8]*StartCodeSeen
+
+	// Option flags of the latest data, see flags.go
+	preview      bool
+	terminatedAt time.Time
+
+	// Synchronization state, see sync.go
+	syncAddress  uint16
+	pending      *PendingFrame
+	pendingSince time.Time
+	lastRelease  time.Time
+	releases     uint64
 }
 
 // NewUniverse creates a new universe with the given ID
 func NewUniverse(id uint16) *Universe {
 	return &Universe{
-		ID: id,
+		ID:    id,
+		clock: clock.Real{},
 	}
 }
 
@@ -37,8 +69,15 @@ func (u *Universe) Update(channelData []byte, sourceName string, sourceCID [16]b
 	u.mu.Lock()
 	defer u.mu.Unlock()
 
-	now := time.Now()
+	// Unsynchronized data means the source stopped using a sync address
+	u.syncAddress = 0
+	u.pending = nil
 
+	u.apply(u.clock.Now(), channelData, sourceName, sourceCID, priority, sequence)
+}
+
+// apply writes channel data and metadata. Caller must hold u.mu for writing.
+func (u *Universe) apply(now time.Time, channelData []byte, sourceName string, sourceCID [16]byte, priority uint8, sequence uint8) {
 	// Update metadata
 	u.SourceName = sourceName
 	u.SourceCID = sourceCID
@@ -48,10 +87,28 @@ func (u *Universe) Update(channelData []byte, sourceName string, sourceCID [16]b
 	u.PacketCount++
 
 	// Update channels that are in the packet
+	changes := u.changes.current(now)
 	for i := 0; i < len(channelData) && i < 512; i++ {
-		u.Channels[i].Value = channelData[i]
-		u.Channels[i].Active = true
-		u.Channels[i].LastUpdate = now
+		if u.Channels[i].Active && u.Channels[i].Value != channelData[i] && changes[i] < ^uint16(0) {
+			changes[i]++
+		}
+		ch := &u.Channels[i]
+		// A channel becoming active counts as a change too
+		if !ch.Active || ch.Value != channelData[i] {
+			ch.LastChange = now
+			if !u.masked[i] {
+				u.LastChange = now
+			}
+		}
+		if !ch.Active {
+			ch.Min, ch.Max = channelData[i], channelData[i]
+		}
+		ch.Min = min(ch.Min, channelData[i])
+		ch.Max = max(ch.Max, channelData[i])
+		ch.Value = channelData[i]
+		ch.Active = true
+		ch.LastUpdate = now
+		ch.SourceCID = sourceCID
 	}
 }
 
@@ -73,20 +130,40 @@ func (u *Universe) GetAllChannels() [512]Channel {
 	return u.Channels
 }
 
-// ActiveChannelCount returns the number of channels that are receiving data
+// ActiveChannelCount returns the number of unmasked channels that are
+// receiving data
 func (u *Universe) ActiveChannelCount() int {
 	u.mu.RLock()
 	defer u.mu.RUnlock()
 
 	count := 0
-	for _, ch := range u.Channels {
-		if ch.Active {
+	for i, ch := range u.Channels {
+		if ch.Active && !u.masked[i] {
 			count++
 		}
 	}
 	return count
 }
 
+// IsBlackout reports whether the universe is receiving data and every
+// received, unmasked channel is at zero
+func (u *Universe) IsBlackout() bool {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	active := false
+	for i, ch := range u.Channels {
+		if !ch.Active || u.masked[i] {
+			continue
+		}
+		if ch.Value != 0 {
+			return false
+		}
+		active = true
+	}
+	return active
+}
+
 // IsStale returns true if the universe hasn't received data for the given duration
 func (u *Universe) IsStale(timeout time.Duration) bool {
 	u.mu.RLock()
@@ -95,7 +172,7 @@ func (u *Universe) IsStale(timeout time.Duration) bool {
 	if u.LastPacket.IsZero() {
 		return true
 	}
-	return time.Since(u.LastPacket) > timeout
+	return u.clock.Now().Sub(u.LastPacket) > timeout
 }
 
 // GetInfo returns a snapshot of the universe metadata
@@ -3,6 +3,8 @@ package universe
 import (
 	"testing"
 	"time"
+
+	"sacn-monitor/internal/clock"
 )
 
 func TestNewUniverse(t *testing.T) {
@@ -310,3 +312,115 @@ func TestManager_PruneStale(t *testing.T) {
 		t.Errorf("Count() = %d, want 1 after prune", m.Count())
 	}
 }
+
+func TestManager_OnRemove(t *testing.T) {
+	m := NewManager()
+	var removed []uint16
+	m.OnRemove(func(id uint16) { removed = append(removed, id) })
+
+	m.GetOrCreate(1).Update([]byte{1}, "src", [16]byte{}, 100, 0)
+	m.GetOrCreate(2)
+	m.PruneStale(time.Hour)
+	if len(removed) != 1 || removed[0] != 2 {
+		t.Fatalf("removed after prune = %v, want [2]", removed)
+	}
+
+	m.SetMaxUniverses(1)
+	m.GetOrCreate(3)
+	if len(removed) != 2 || removed[1] != 1 {
+		t.Fatalf("removed after eviction = %v, want [2 1]", removed)
+	}
+
+	m.Remove(3)
+	m.Remove(3) // Already gone, not reported again
+	if len(removed) != 3 || removed[2] != 3 {
+		t.Errorf("removed after Remove = %v, want [2 1 3]", removed)
+	}
+}
+
+func TestUniverse_IsBlackout(t *testing.T) {
+	u := NewUniverse(1)
+	if u.IsBlackout() {
+		t.Error("IsBlackout() = true before any data, want false")
+	}
+
+	u.Update([]byte{0, 0, 0}, "src", [16]byte{}, 100, 0)
+	if !u.IsBlackout() {
+		t.Error("IsBlackout() = false for all-zero data, want true")
+	}
+
+	u.Update([]byte{0, 0, 1}, "src", [16]byte{}, 100, 1)
+	if u.IsBlackout() {
+		t.Error("IsBlackout() = true with a channel up, want false")
+	}
+}
+
+func TestManager_MaxUniversesEvictsLeastRecentlyActive(t *testing.T) {
+	c := clock.NewManual(time.Unix(1000, 0))
+	m := NewManager()
+	m.SetClock(c)
+	m.SetMaxUniverses(2)
+
+	m.GetOrCreate(1).Update([]byte{1}, "src", [16]byte{}, 100, 0)
+	c.Advance(time.Millisecond)
+	m.GetOrCreate(2).Update([]byte{1}, "src", [16]byte{}, 100, 0)
+	c.Advance(time.Millisecond)
+	m.Get(1).Update([]byte{2}, "src", [16]byte{}, 100, 1) // 2 is now least recently active
+
+	m.GetOrCreate(3)
+
+	if m.Count() != 2 {
+		t.Errorf("Count() = %d, want 2", m.Count())
+	}
+	if m.Get(2) != nil {
+		t.Error("universe 2 still present, want it evicted")
+	}
+	if m.Get(1) == nil || m.Get(3) == nil {
+		t.Error("universes 1 and 3 should remain")
+	}
+	if m.Evictions() != 1 {
+		t.Errorf("Evictions() = %d, want 1", m.Evictions())
+	}
+}
+
+func TestManager_SetMaxUniversesShrinks(t *testing.T) {
+	m := NewManager()
+	for id := uint16(1); id <= 5; id++ {
+		m.GetOrCreate(id)
+	}
+
+	m.SetMaxUniverses(3)
+	if m.Count() != 3 || m.Evictions() != 2 {
+		t.Errorf("Count() = %d, Evictions() = %d, want 3 and 2", m.Count(), m.Evictions())
+	}
+
+	m.SetMaxUniverses(0)
+	for id := uint16(10); id < 20; id++ {
+		m.GetOrCreate(id)
+	}
+	if m.Count() != 13 {
+		t.Errorf("Count() = %d with no limit, want 13", m.Count())
+	}
+}
+
+func TestManager_SetClock(t *testing.T) {
+	m := NewManager()
+	start := time.Date(2026, 3, 14, 20, 0, 0, 0, time.UTC)
+	c := clock.NewManual(start)
+	m.SetClock(c)
+
+	u := m.GetOrCreate(1)
+	u.Update([]byte{10}, "src", [16]byte{}, 100, 0)
+	if got := u.GetInfo().LastPacket; !got.Equal(start) {
+		t.Errorf("LastPacket = %v, want the clock's %v", got, start)
+	}
+
+	c.Advance(500 * time.Millisecond)
+	if u.IsStale(time.Second) {
+		t.Error("IsStale() after 500ms, want fresh")
+	}
+	c.Advance(time.Second)
+	if !u.IsStale(time.Second) {
+		t.Error("IsStale() after 1.5s, want stale")
+	}
+}